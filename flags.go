@@ -0,0 +1,144 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/shubh-io/dockmate/internal/config"
+	"github.com/shubh-io/dockmate/internal/docker"
+	"github.com/shubh-io/dockmate/internal/tui"
+)
+
+// options are the global flags, which go before the command:
+// `dockmate --profile homelab --read-only`, `dockmate --config ci.yml stats`.
+// They only last for the run, nothing here is written to the config except
+// a --runtime with no command after it.
+type options struct {
+	configPath string
+	profile    string
+	refresh    int
+	runtime    string
+	readOnly   bool
+	noPrecheck bool
+	debug      bool
+	logLevel   string
+	web        string
+	version    bool
+}
+
+const commandsUsage = `
+Commands:
+  runtime [docker|podman|nerdctl|auto]  pick the runtime and save it
+  stats [--summary] [--json]            print container stats
+  start|stop|restart NAME...            act on containers, then exit
+  doctor                                check the setup
+  config show|path|edit|validate        look at the config file
+  serve                                 serve the read-only JSON API, no TUI
+  report                                containers, images and volumes by owner
+  update                                update DockMate
+  version                               print the version
+`
+
+// parseOptions parses the global flags, the rest of args is the command
+func parseOptions(args []string, output io.Writer) (options, []string, error) {
+	// `dockmate --runtime` alone used to open the runtime selector, and
+	// still does
+	if len(args) == 1 && args[0] == "--runtime" {
+		return options{}, []string{"runtime"}, nil
+	}
+
+	var o options
+	fs := flag.NewFlagSet("dockmate", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.StringVar(&o.configPath, "config", "", "use this config file instead of ~/.config/dockmate/config.yml")
+	fs.StringVar(&o.profile, "profile", "", "use this profile from the config")
+	fs.IntVar(&o.refresh, "refresh", 0, "refresh every `seconds` instead of performance.poll_rate")
+	fs.StringVar(&o.runtime, "runtime", "", "use docker, podman, nerdctl or auto for the command, saved when there's none")
+	fs.BoolVar(&o.readOnly, "read-only", false, "only watch, refuse every key that changes something")
+	fs.BoolVar(&o.noPrecheck, "no-precheck", false, "skip the startup checks")
+	fs.BoolVar(&o.debug, "debug", false, "log at debug level, same as --log-level debug")
+	fs.StringVar(&o.logLevel, "log-level", "", "log at this level instead of logging.level")
+	fs.StringVar(&o.web, "web", "", "serve the read-only web view on this `address`, e.g. :8080")
+	fs.BoolVar(&o.version, "version", false, "print the version")
+	fs.BoolVar(&o.version, "v", false, "print the version")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: dockmate [flags] [command]\n\nFlags:\n")
+		fs.PrintDefaults()
+		fmt.Fprint(fs.Output(), commandsUsage)
+	}
+	if err := fs.Parse(args); err != nil {
+		return o, nil, err
+	}
+
+	// 0 is left for "not given", so poll_rate applies, an explicit
+	// --refresh 0 is as wrong as a negative one
+	var refreshErr error
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "refresh" && o.refresh <= 0 {
+			refreshErr = fmt.Errorf("--refresh must be at least 1 second, got %d", o.refresh)
+		}
+	})
+	if refreshErr != nil {
+		return o, nil, refreshErr
+	}
+	if o.runtime != "" {
+		o.runtime = strings.TrimSpace(strings.ToLower(o.runtime))
+		if o.runtime != "auto" && !slices.Contains(docker.KnownRuntimes, o.runtime) {
+			return o, nil, fmt.Errorf("unknown runtime %q, use one of: %s, auto", o.runtime, strings.Join(docker.KnownRuntimes, ", "))
+		}
+		// with no command it saves the runtime, like `dockmate runtime
+		// podman`, as it did before the other flags came along. Scripts
+		// rely on that.
+		if fs.NArg() == 0 {
+			rt := o.runtime
+			o.runtime = ""
+			return o, []string{"runtime", rt}, nil
+		}
+	}
+	return o, fs.Args(), nil
+}
+
+// apply puts the options where the rest of DockMate reads them: the
+// environment for the config file and profile, the config's overrides for
+// the runtime and refresh, and the TUI for read-only mode
+func (o options) apply() error {
+	if o.configPath != "" {
+		os.Setenv(config.ConfigEnv, o.configPath)
+	}
+	if o.profile != "" {
+		if err := checkProfile(o.profile); err != nil {
+			return err
+		}
+		os.Setenv(config.ProfileEnv, o.profile)
+	}
+	config.SetOverrides(config.Overrides{Runtime: o.runtime, PollRate: o.refresh})
+	tui.SetReadOnly(o.readOnly)
+	return nil
+}
+
+// checkProfile makes an unknown profile an error rather than a silent
+// fallback to the top of the file
+func checkProfile(name string) error {
+	cfg, _ := config.Load()
+	if slices.Contains(cfg.ProfileNames(), name) {
+		return nil
+	}
+	msg := fmt.Sprintf("there's no profile %q in %s", name, configPathHint())
+	if names := cfg.ProfileNames(); len(names) > 0 {
+		msg += ", pick one of: " + strings.Join(names, ", ")
+	}
+	return errors.New(msg)
+}
+
+// level is the log level the flags ask for, "" to use logging.level
+func (o options) level() string {
+	if o.debug {
+		return "debug"
+	}
+	return o.logLevel
+}
@@ -0,0 +1,75 @@
+package check
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// compose isn't needed to start, only for the compose view's actions, so it
+// isn't part of RunPreChecks. The TUI runs CheckCompose the first time the
+// compose view opens.
+
+// composeTimeout bounds `compose version`, podman-compose in particular can
+// be slow to start
+const composeTimeout = 10 * time.Second
+
+// composeVersion runs `compose version` with the compose command DockMate
+// would use for the current runtime, and returns its name and first line
+func composeVersion() (name, version string, err error) {
+	cc := docker.GetComposeCommand()
+	var args []string
+	if cc.SubCommand != "" {
+		args = append(args, cc.SubCommand)
+	}
+	args = append(args, "version")
+
+	ctx, cancel := context.WithTimeout(context.Background(), composeTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, cc.Binary, args...).CombinedOutput()
+	return strings.TrimSpace(cc.Binary + " " + cc.SubCommand), firstLine(string(out)), err
+}
+
+// CheckCompose checks that compose is there for the current runtime
+func CheckCompose() PreCheckResult {
+	name, output, err := composeVersion()
+	if err == nil {
+		return PreCheckResult{Passed: true}
+	}
+	msg := name + " isn't available, so compose projects can be listed but not started, stopped or restarted"
+	if output != "" {
+		msg += ":\n" + output
+	}
+	return PreCheckResult{
+		Passed:          false,
+		ErrorType:       ComposeNotInstalled,
+		ErrorMessage:    msg,
+		SuggestedAction: composeInstallHint(docker.RuntimeBinary()),
+	}
+}
+
+// composeInstallHint says how to get compose for rt on this OS
+func composeInstallHint(rt string) string {
+	switch rt {
+	case "podman":
+		return "Install podman-compose:\n" +
+			"  sudo apt install podman-compose   (Debian/Ubuntu)\n" +
+			"  sudo dnf install podman-compose   (Fedora/RHEL)\n" +
+			"  pip install --user podman-compose (anywhere else)"
+	case "nerdctl":
+		return "nerdctl compose ships with nerdctl itself, update to a release that has it:\n" +
+			"  https://github.com/containerd/nerdctl/releases"
+	}
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		return "Docker Desktop comes with compose, update it to a recent version.\n" +
+			"Without Docker Desktop: brew install docker-compose"
+	}
+	return "Install the compose plugin:\n" +
+		"  sudo apt install docker-compose-plugin   (Debian/Ubuntu, from Docker's repository)\n" +
+		"  sudo dnf install docker-compose-plugin   (Fedora/RHEL)\n" +
+		"Or see https://docs.docker.com/compose/install/linux/"
+}
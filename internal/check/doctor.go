@@ -0,0 +1,271 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/config"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+type DoctorStatus int
+
+const (
+	DoctorPass DoctorStatus = iota
+	DoctorWarn
+	DoctorFail
+	DoctorSkip
+)
+
+// DoctorResult is one line of the `dockmate doctor` report
+type DoctorResult struct {
+	Name   string
+	Status DoctorStatus
+	Detail string
+	Fix    string // what to do about a warning or failure
+}
+
+// RunDoctor runs every check RunPreChecks does, plus a few it skips, and
+// reports all of them instead of stopping at the first failure. Unlike
+// RunPreChecks it never prompts and never writes the config.
+func RunDoctor() []DoctorResult {
+	var results []DoctorResult
+	add := func(r DoctorResult) { results = append(results, r) }
+
+	add(doctorConfig())
+
+	cfg, _ := config.Load()
+	rt, rtResult := doctorRuntime(cfg)
+	add(rtResult)
+	if rt == "" {
+		return results
+	}
+
+	if _, err := exec.LookPath(rt); err != nil {
+		add(DoctorResult{Name: "Installed", Status: DoctorFail, Detail: rt + " not found in PATH", Fix: installHint(rt)})
+		return results
+	}
+	add(DoctorResult{Name: "Installed", Status: DoctorPass, Detail: rt + " " + runtimeVersion(rt)})
+
+	if cfg.Runtime.Remote != "" {
+		add(doctorRemote(cfg))
+	} else {
+		add(doctorSocket(cfg.Runtime.Socket))
+	}
+	if cfg.Runtime.TLS.Enabled() {
+		add(doctorTLS(cfg.Runtime.TLS))
+	}
+	add(doctorDaemon(rt))
+	add(doctorGroups(rt))
+	add(doctorCompose())
+
+	return results
+}
+
+func doctorConfig() DoctorResult {
+	r := DoctorResult{Name: "Config"}
+	path, err := config.GetConfigPath()
+	if err != nil {
+		r.Status, r.Detail = DoctorWarn, fmt.Sprintf("can't work out the config path: %v", err)
+		return r
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		r.Status, r.Detail = DoctorWarn, path+" doesn't exist, using defaults"
+		r.Fix = "Run dockmate once, or dockmate runtime docker|podman|nerdctl|auto, to create it"
+		return r
+	}
+
+	problems, err := config.Validate()
+	switch {
+	case err != nil:
+		r.Status, r.Detail = DoctorFail, err.Error()
+	case len(problems) > 0:
+		r.Status, r.Detail = DoctorFail, path+":\n"+strings.Join(problems, "\n")
+		r.Fix = "Fix the file, or delete it to start over with the defaults"
+	default:
+		r.Status, r.Detail = DoctorPass, path
+	}
+	return r
+}
+
+// doctorRuntime returns the runtime binary to check, "" when there is none
+func doctorRuntime(cfg *config.Config) (string, DoctorResult) {
+	r := DoctorResult{Name: "Runtime"}
+	rt := strings.TrimSpace(strings.ToLower(cfg.Runtime.Type))
+	switch rt {
+	case "docker", "podman", "nerdctl":
+		r.Status, r.Detail = DoctorPass, rt
+		return rt, r
+	case "auto":
+		detected, err := docker.DetectRuntime(cfg.Runtime.AutoOrder)
+		if err != nil {
+			r.Status, r.Detail = DoctorFail, fmt.Sprintf("auto: %v", err)
+			r.Fix = "Start Docker, Podman or containerd, or pick one with dockmate runtime"
+			return "", r
+		}
+		r.Status, r.Detail = DoctorPass, fmt.Sprintf("auto (%s, order %s)", detected, strings.Join(cfg.Runtime.AutoOrder, ", "))
+		return detected, r
+	}
+	r.Status, r.Detail = DoctorFail, fmt.Sprintf("unsupported runtime %q", cfg.Runtime.Type)
+	r.Fix = "Pick one with dockmate runtime"
+	return "", r
+}
+
+func doctorSocket(socket string) DoctorResult {
+	r := DoctorResult{Name: "Socket"}
+	if strings.TrimSpace(socket) == "" {
+		r.Status, r.Detail = DoctorSkip, "runtime.socket not set, using the runtime's default"
+		if host := os.Getenv("DOCKER_HOST"); host != "" {
+			r.Detail = "DOCKER_HOST=" + host
+		}
+		return r
+	}
+	if res := checkConfiguredSocket(socket); !res.Passed {
+		r.Status, r.Detail, r.Fix = DoctorFail, res.ErrorMessage, res.SuggestedAction
+		return r
+	}
+	r.Status, r.Detail = DoctorPass, docker.SocketURL(socket)
+	return r
+}
+
+func doctorTLS(t config.TLSConfig) DoctorResult {
+	r := DoctorResult{Name: "TLS"}
+	if res := checkConfiguredTLS(t); !res.Passed {
+		r.Status, r.Detail, r.Fix = DoctorFail, res.ErrorMessage, res.SuggestedAction
+		return r
+	}
+	r.Status, r.Detail = DoctorPass, "client certificate "+t.Cert
+	if t.Cert == "" {
+		r.Detail = "no client certificate"
+	}
+	if t.CACert == "" {
+		r.Status = DoctorWarn
+		r.Detail += ", the daemon's certificate isn't verified"
+		r.Fix = "Set runtime.tls.ca_cert to the CA the daemon's certificate was issued by"
+	} else {
+		r.Detail += ", verified against " + t.CACert
+	}
+	return r
+}
+
+// doctorRemote opens the tunnel to runtime.remote, main leaves that to the
+// doctor so a broken remote is a failed check rather than an exit
+func doctorRemote(cfg *config.Config) DoctorResult {
+	r := DoctorResult{Name: "Remote"}
+	if err := docker.UseRemote(cfg); err != nil {
+		r.Status, r.Detail = DoctorFail, fmt.Sprintf("no tunnel to %s: %v", cfg.Runtime.Remote, err)
+		r.Fix = "Check that `ssh` to the host works without a password prompt, e.g. with ssh-agent, or fix it under remotes: in the config"
+		return r
+	}
+	t := docker.ActiveRemote()
+	switch {
+	case t == nil:
+		r.Status, r.Detail = DoctorFail, fmt.Sprintf("no tunnel to %s", cfg.Runtime.Remote)
+		r.Fix = "Check that `ssh` to the host works without a password prompt, e.g. with ssh-agent"
+	case t.Err() != nil:
+		r.Status, r.Detail = DoctorFail, t.Err().Error()
+		r.Fix = "Check that `ssh` to the host works without a password prompt, e.g. with ssh-agent"
+	default:
+		r.Status, r.Detail = DoctorPass, fmt.Sprintf("%s over SSH (%s)", cfg.Runtime.Remote, t.Target())
+	}
+	return r
+}
+
+func doctorDaemon(rt string) DoctorResult {
+	r := DoctorResult{Name: "Daemon"}
+
+	var res PreCheckResult
+	switch rt {
+	case "podman":
+		res = checkPodmanService()
+	case "nerdctl":
+		res = checkNerdctlService()
+	default:
+		res = checkDockerDaemon()
+	}
+	if !res.Passed {
+		r.Status, r.Detail, r.Fix = DoctorFail, res.ErrorMessage, res.SuggestedAction
+		return r
+	}
+
+	r.Status, r.Detail = DoctorPass, "reachable"
+	if rt == "docker" && runtime.GOOS == "darwin" {
+		r.Detail = "reachable (" + detectMacProvider().Name + ")"
+	}
+	return r
+}
+
+// doctorGroups only matters for docker on Linux, where the socket is guarded by the docker group
+func doctorGroups(rt string) DoctorResult {
+	r := DoctorResult{Name: "Docker group"}
+	if rt != "docker" || runtime.GOOS != "linux" {
+		r.Status, r.Detail = DoctorSkip, "not needed for "+rt+" on "+runtime.GOOS
+		return r
+	}
+	if u, err := user.Current(); err == nil && u.Uid == "0" {
+		r.Status, r.Detail = DoctorSkip, "running as root"
+		return r
+	}
+
+	inFile, _ := isUserInDockerGroup()
+	active, _ := isDockerInActiveGroups()
+	switch {
+	case active:
+		r.Status, r.Detail = DoctorPass, "docker group is active for this session"
+	case inFile:
+		r.Status, r.Detail = DoctorWarn, "in the docker group, but this session doesn't have it yet"
+		r.Fix = "Log out and back in (or run: newgrp docker)"
+	case !doesDockerGroupExist():
+		r.Status, r.Detail = DoctorWarn, "there is no docker group (fine for rootless docker)"
+	default:
+		r.Status, r.Detail = DoctorWarn, "not in the docker group, docker may need sudo"
+		r.Fix = "sudo usermod -aG docker $USER, then log out and back in"
+	}
+	return r
+}
+
+func doctorCompose() DoctorResult {
+	r := DoctorResult{Name: "Compose"}
+	name, version, err := composeVersion()
+	if err != nil {
+		r.Status, r.Detail = DoctorWarn, fmt.Sprintf("%s not available, compose actions won't work", name)
+		r.Fix = composeInstallHint(docker.RuntimeBinary())
+		return r
+	}
+	r.Status, r.Detail = DoctorPass, version
+	return r
+}
+
+func runtimeVersion(rt string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, rt, "--version").Output()
+	if err != nil {
+		return ""
+	}
+	return "(" + firstLine(string(out)) + ")"
+}
+
+func installHint(rt string) string {
+	switch rt {
+	case "podman":
+		return checkPodmanInstalled().SuggestedAction
+	case "nerdctl":
+		return checkNerdctlInstalled().SuggestedAction
+	}
+	return checkDockerInstalled().SuggestedAction
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
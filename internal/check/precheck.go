@@ -8,9 +8,12 @@ import (
 	"os/user"
 	"runtime"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/shubh-io/dockmate/internal/config"
+	"github.com/shubh-io/dockmate/internal/docker"
+	"github.com/shubh-io/dockmate/internal/logging"
 	"github.com/shubh-io/dockmate/internal/tui"
 )
 
@@ -35,14 +38,33 @@ const (
 	DockerGroupNotRefreshed
 	PodmanNotInstalled
 	PodmanServiceNotRunning
+	NerdctlNotInstalled
+	NerdctlServiceNotRunning
+	NoRuntimeDetected
+	SocketNotFound
+	TLSFilesInvalid
+	ComposeNotInstalled
 )
 
-func isPrecheckEnabled() bool {
-	cfg, err := config.Load()
-	if err != nil {
-		return true // default to true if error loading config
+// Docker Desktop on Windows listens on a named pipe instead of a unix socket
+const windowsDockerPipe = `\\.\pipe\docker_engine`
+
+// precheckSetup names the runtime and how it's reached, the full checks run
+// again when it changes
+func precheckSetup(rt config.RuntimeConfig, runtimeType string) string {
+	return strings.Join([]string{runtimeType, rt.Socket, rt.Remote}, " ")
+}
+
+// recordPrecheck remembers the outcome in the cache. A start that only ran
+// the quick checks and passed leaves the last full run's time alone.
+func recordPrecheck(setup string, full, passed bool) {
+	if passed && !full {
+		return
+	}
+	state := config.PrecheckState{Setup: setup, CheckedAt: time.Now(), Failed: !passed}
+	if err := state.Save(); err != nil {
+		logging.Errorf("saving the precheck state: %v", err)
 	}
-	return cfg.Runtime.RunPreChecks
 }
 
 // Runtime Selection
@@ -67,7 +89,22 @@ func isRuntimeConfigured() bool {
 		return false
 	}
 	runtimeType := strings.TrimSpace(strings.ToLower(cfg.Runtime.Type))
-	return (runtimeType == "docker" || runtimeType == "podman") && runtimeType != "" && runtimeType != "auto"
+	switch runtimeType {
+	case "docker", "podman", "nerdctl", "auto":
+		return true
+	}
+	return false
+}
+
+// useDetectedRuntime saves runtime "auto" on first run when a runtime can be
+// detected, so the selector only shows up when nothing usable is installed
+func useDetectedRuntime() bool {
+	cfg, _ := config.Load()
+	if _, err := docker.DetectRuntime(cfg.Runtime.AutoOrder); err != nil {
+		return false
+	}
+	cfg.Runtime.Type = "auto"
+	return cfg.Save() == nil
 }
 
 // promptRuntimeSelection shows the runtime selector TUI and saves selection
@@ -117,6 +154,9 @@ func commandExists(cmd string) bool {
 // getDockerStartCommand detects the init system and returns the appropriate command
 func getDockerStartCommand() string {
 	if runtime.GOOS == "darwin" {
+		return detectMacProvider().StartCmd
+	}
+	if runtime.GOOS == "windows" {
 		return "Start Docker Desktop application"
 	}
 
@@ -138,6 +178,9 @@ func getDockerStartCommand() string {
 // getDockerRestartCommand detects the init system and returns the restart command
 func getDockerRestartCommand() string {
 	if runtime.GOOS == "darwin" {
+		return detectMacProvider().RestartCmd
+	}
+	if runtime.GOOS == "windows" {
 		return "Restart Docker Desktop application"
 	}
 
@@ -159,7 +202,7 @@ func getDockerRestartCommand() string {
 // getPodmanStartCommand returns their start command per platform (peak user case handling lol)
 
 func getPodmanStartCommand() string {
-	if runtime.GOOS == "darwin" {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
 		return "podman machine start"
 	}
 
@@ -179,7 +222,7 @@ func getPodmanErrorMessage() string {
 	cmd := getPodmanStartCommand()
 
 	switch runtime.GOOS {
-	case "darwin":
+	case "darwin", "windows":
 		return fmt.Sprintf("Podman machine not running.\n\nQuick fix:\n  %s\n\nIf machine doesn't exist:\n  podman machine init\n  podman machine start\n\nHelp: https://docs.podman.io/", cmd)
 
 	case "linux":
@@ -191,9 +234,9 @@ func getPodmanErrorMessage() string {
 }
 
 // checks if the 'docker' group exists on the system and anchor before docker to help find group that 'starts with' docker
-// On macOS, Docker Desktop doesn't use groups, so this always returns false
+// On macOS and Windows, Docker Desktop doesn't use a docker group, so this always returns false
 func doesDockerGroupExist() bool {
-	if runtime.GOOS == "darwin" {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
 		return false
 	}
 
@@ -213,9 +256,9 @@ func doesDockerGroupExist() bool {
 }
 
 // checks if the current user is listed in the 'docker' group in /etc/group
-// On mac-os, Docker Desktop doesn't use groups, so this always returns false
+// On mac-os and Windows there is no /etc/group, so this always returns false
 func isUserInDockerGroup() (bool, error) {
-	if runtime.GOOS == "darwin" {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
 		return false, nil
 	}
 
@@ -278,9 +321,9 @@ func isUserInDockerGroup() (bool, error) {
 }
 
 // checks if the 'docker' group is in the user's active groups (id -nG)
-// On macOS, Docker Desktop doesn't use groups, so this always returns false
+// On macOS and Windows, Docker Desktop doesn't use a docker group, so this always returns false
 func isDockerInActiveGroups() (bool, error) {
-	if runtime.GOOS == "darwin" {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
 		return false, nil
 	}
 
@@ -304,18 +347,71 @@ func isDockerInActiveGroups() (bool, error) {
 	return false, nil
 }
 
+// dockerSocketPath is the unix socket docker talks to: DOCKER_HOST (set from
+// runtime.socket at startup) when it's a unix:// URL, the default otherwise
+func dockerSocketPath() string {
+	if path := docker.SocketPath(os.Getenv("DOCKER_HOST")); path != "" {
+		return path
+	}
+	return "/var/run/docker.sock"
+}
+
+// checkConfiguredSocket makes sure runtime.socket exists when it points at a local unix socket
+func checkConfiguredSocket(socket string) PreCheckResult {
+	path := docker.SocketPath(socket)
+	if path == "" {
+		// remote endpoints (tcp://, ssh://) are checked by the daemon checks
+		return PreCheckResult{Passed: true}
+	}
+	if _, err := os.Stat(path); err != nil {
+		return PreCheckResult{
+			Passed:       false,
+			ErrorType:    SocketNotFound,
+			ErrorMessage: fmt.Sprintf("The socket configured in runtime.socket does not exist:\n  %s", path),
+			SuggestedAction: "Start the runtime that should listen there, or fix or clear runtime.socket in\n" +
+				"  ~/.config/dockmate/config.yml",
+		}
+	}
+	return PreCheckResult{Passed: true}
+}
+
+// checkConfiguredTLS makes sure the files in runtime.tls are there and are
+// certificates and keys that go together
+func checkConfiguredTLS(t config.TLSConfig) PreCheckResult {
+	if !t.Enabled() {
+		return PreCheckResult{Passed: true}
+	}
+	if err := docker.CheckTLS(t); err != nil {
+		return PreCheckResult{
+			Passed:       false,
+			ErrorType:    TLSFilesInvalid,
+			ErrorMessage: fmt.Sprintf("The TLS files in runtime.tls can't be used:\n  %v", err),
+			SuggestedAction: "Fix the paths under runtime.tls (ca_cert, cert, key) in\n" +
+				"  ~/.config/dockmate/config.yml, or clear them for a daemon without TLS",
+		}
+	}
+	return PreCheckResult{Passed: true}
+}
+
 func checkDockerSocketPermissions() (hasAccess bool, errorMsg string) {
 	if runtime.GOOS == "darwin" {
 		// permissions are managed by Docker Desktop, so skip this check
 		return true, ""
 	}
+	if runtime.GOOS == "windows" {
+		// no socket file, only check that Docker Desktop's pipe is there
+		if !isDockerPipePresent() {
+			return false, "Docker named pipe not found at " + windowsDockerPipe
+		}
+		return true, ""
+	}
 
-	socketPath := "/var/run/docker.sock"
+	socketPath := dockerSocketPath()
 
 	// check if socket exists
 	_, err := os.Stat(socketPath)
 	if err != nil {
-		return false, "Docker socket not found at /var/run/docker.sock"
+		return false, "Docker socket not found at " + socketPath
 	}
 
 	// try to access the socket with read and write flags(os.O_RDWR)
@@ -376,8 +472,10 @@ func checkDockerDaemon() PreCheckResult {
 	stderrOutput := stderr.String()
 
 	// Check daemon status FIRST
+	// (on Windows a missing pipe means Docker Desktop isn't started)
 	if strings.Contains(stderrOutput, "Is the docker daemon running") ||
 		strings.Contains(stderrOutput, "cannot connect to the Docker daemon") ||
+		(runtime.GOOS == "windows" && !isDockerPipePresent()) ||
 		!isDaemonRunning() {
 		return PreCheckResult{
 			Passed:       false,
@@ -391,10 +489,24 @@ func checkDockerDaemon() PreCheckResult {
 
 	// Check for permission/connection issues
 	if strings.Contains(stderrOutput, "permission denied") ||
-		strings.Contains(stderrOutput, "dial unix") {
+		strings.Contains(stderrOutput, "dial unix") ||
+		strings.Contains(stderrOutput, "Access is denied") {
 
-		// macOS Docker Desktop handles permissions differently
+		// macOS daemons (Docker Desktop, Colima, Rancher Desktop...) handle permissions themselves
 		if runtime.GOOS == "darwin" {
+			provider := detectMacProvider()
+			if provider.Name != dockerDesktop.Name {
+				return PreCheckResult{
+					Passed:       false,
+					ErrorType:    DockerPermissionDenied,
+					ErrorMessage: fmt.Sprintf("Cannot connect to %s.\n\nDocker error:\n%s", provider.Name, stderrOutput),
+					SuggestedAction: fmt.Sprintf("Make sure %s is running:\n\n"+
+						"  %s\n\n"+
+						"If issues persist, restart it:\n\n"+
+						"  %s\n\n"+
+						"Guide: %s", provider.Name, provider.StartCmd, provider.RestartCmd, provider.Docs),
+				}
+			}
 			return PreCheckResult{
 				Passed:       false,
 				ErrorType:    DockerPermissionDenied,
@@ -408,6 +520,21 @@ func checkDockerDaemon() PreCheckResult {
 			}
 		}
 
+		// Docker Desktop on Windows grants access through the docker-users group
+		if runtime.GOOS == "windows" {
+			return PreCheckResult{
+				Passed:       false,
+				ErrorType:    DockerPermissionDenied,
+				ErrorMessage: fmt.Sprintf("Cannot connect to Docker Desktop.\n\nDocker error:\n%s", stderrOutput),
+				SuggestedAction: "Make sure Docker Desktop is running and your user may use it:\n\n" +
+					"1. Open Docker Desktop and wait for it to start completely\n" +
+					"2. Add your user to the docker-users group (from an admin PowerShell):\n" +
+					"     net localgroup docker-users $env:USERNAME /add\n" +
+					"3. Sign out and back in\n\n" +
+					"Docker Desktop guide: https://docs.docker.com/desktop/install/windows-install/",
+			}
+		}
+
 		// Linux/Unix permission handling
 		inGroupFile, _ := isUserInDockerGroup()
 		inActiveGroups, _ := isDockerInActiveGroups()
@@ -424,11 +551,11 @@ func checkDockerDaemon() PreCheckResult {
 					"Socket error: %s\n\n"+
 					"Docker error:\n%s", socketError, stderrOutput),
 				SuggestedAction: fmt.Sprintf("Fix the Docker socket permissions:\n\n"+
-					"  sudo chown root:docker /var/run/docker.sock\n"+
-					"  sudo chmod 660 /var/run/docker.sock\n\n"+
+					"  sudo chown root:docker %s\n"+
+					"  sudo chmod 660 %s\n\n"+
 					"Or restart Docker to recreate the socket:\n\n"+
 					"  %s\n\n"+
-					"Guide: https://docs.docker.com/engine/install/linux-postinstall/", getDockerRestartCommand()),
+					"Guide: https://docs.docker.com/engine/install/linux-postinstall/", dockerSocketPath(), dockerSocketPath(), getDockerRestartCommand()),
 			}
 		}
 
@@ -479,6 +606,41 @@ func checkDockerDaemon() PreCheckResult {
 	}
 }
 
+// check if nerdctl is installed in PATH
+func checkNerdctlInstalled() PreCheckResult {
+	_, err := exec.LookPath("nerdctl")
+	if err != nil {
+		return PreCheckResult{
+			Passed:       false,
+			ErrorType:    NerdctlNotInstalled,
+			ErrorMessage: "nerdctl is not installed or not found in PATH",
+			SuggestedAction: "Please install nerdctl to use this runtime.\n\n" +
+				"Installation guide: https://github.com/containerd/nerdctl#install",
+		}
+	}
+	return PreCheckResult{Passed: true}
+}
+
+func checkNerdctlService() PreCheckResult {
+	cmd := exec.Command("nerdctl", "info")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err == nil {
+		return PreCheckResult{Passed: true}
+	}
+
+	return PreCheckResult{
+		Passed:       false,
+		ErrorType:    NerdctlServiceNotRunning,
+		ErrorMessage: fmt.Sprintf("containerd is not running or not reachable.\n\nnerdctl error:\n%s", stderr.String()),
+		SuggestedAction: "Start containerd:\n\n" +
+			"  sudo systemctl start containerd\n\n" +
+			"For rootless nerdctl:\n\n" +
+			"  containerd-rootless-setuptool.sh install",
+	}
+}
+
 func checkPodmanService() PreCheckResult {
 	cmd := exec.Command("podman", "info")
 	var stderr bytes.Buffer
@@ -499,6 +661,12 @@ func checkPodmanService() PreCheckResult {
 	}
 }
 
+// isDockerPipePresent checks for Docker Desktop's named pipe on Windows
+func isDockerPipePresent() bool {
+	_, err := os.Stat(windowsDockerPipe)
+	return err == nil
+}
+
 // Helper function to check if daemon is actually running
 func isDaemonRunning() bool {
 	cmd := exec.Command("docker", "info")
@@ -506,20 +674,34 @@ func isDaemonRunning() bool {
 	return err == nil
 }
 
-func RunPreChecks() PreCheckResult {
-
-	// Check - Is runtime configured? If not, prompt user
-	if !isRuntimeConfigured() {
+// EnsureRuntime makes sure there's a runtime to check on the very first
+// start: auto-detected, or picked in the selector when nothing is found. It
+// is the one step that needs the terminal, so it runs before the TUI and
+// RunPreChecks can run inside it.
+func EnsureRuntime() PreCheckResult {
+	// Check - Is runtime configured? If not, try auto-detection and only prompt when that finds nothing
+	if !isRuntimeConfigured() && !useDetectedRuntime() {
 		err := promptRuntimeSelection()
 		if err != nil {
 			return PreCheckResult{
 				Passed:          false,
 				ErrorType:       NoError,
 				ErrorMessage:    fmt.Sprintf("Failed to select runtime: %v", err),
-				SuggestedAction: "If you want to Change the runtime, run: \n dockmate --runtime \n",
+				SuggestedAction: "If you want to Change the runtime, run: \n dockmate runtime \n",
 			}
 		}
 	}
+	return PreCheckResult{Passed: true}
+}
+
+// RunPreChecks checks the runtime, after EnsureRuntime. The daemon is always
+// checked; the slower install checks only run when the precheck state says
+// they're due, see config.PrecheckState.Due. It never prompts and never
+// writes the config, so it's safe to run while the TUI is up.
+func RunPreChecks() (result PreCheckResult) {
+	var setup string
+	var full bool
+	defer func() { recordPrecheck(setup, full, result.Passed) }()
 
 	cfg, err := config.Load()
 	if err != nil {
@@ -527,24 +709,50 @@ func RunPreChecks() PreCheckResult {
 			Passed:          false,
 			ErrorType:       NoError,
 			ErrorMessage:    fmt.Sprintf("Failed to load config: %v", err),
-			SuggestedAction: "Try running:\n  dockmate --runtime\n\nOr delete your config file and try again:\n  rm ~/.config/dockmate/config.yml",
+			SuggestedAction: "Try running:\n  dockmate runtime\n\nOr delete your config file and try again:\n  rm ~/.config/dockmate/config.yml",
 		}
 	}
 
+	// a remote's socket is the tunnel main opened, runtime.socket isn't used
+	if cfg.Runtime.Remote == "" {
+		if result := checkConfiguredSocket(cfg.Runtime.Socket); !result.Passed {
+			return result
+		}
+	}
+	if result := checkConfiguredTLS(cfg.Runtime.TLS); !result.Passed {
+		return result
+	}
+
 	runtimeType := strings.TrimSpace(strings.ToLower(cfg.Runtime.Type))
 	if runtimeType == "" {
 		runtimeType = "docker"
 	}
 
+	if runtimeType == "auto" {
+		detected, err := docker.DetectRuntime(cfg.Runtime.AutoOrder)
+		if err != nil {
+			return PreCheckResult{
+				Passed:       false,
+				ErrorType:    NoRuntimeDetected,
+				ErrorMessage: fmt.Sprintf("Runtime auto-detection failed: %v", err),
+				SuggestedAction: "Start Docker, Podman or containerd, or pick a runtime explicitly:\n dockmate runtime \n\n" +
+					"The order tried is runtime.auto_order in ~/.config/dockmate/config.yml",
+			}
+		}
+		runtimeType = detected
+	}
+	setup = precheckSetup(cfg.Runtime, runtimeType)
+	full = config.LoadPrecheckState().Due(setup, cfg.Runtime.PrecheckTTLDays, time.Now())
+
 	errorChangeRuntimeSuggestion := func(str string) string {
-		changeRuntimeSuggestion := "\n\nOr If you want to Change the runtime to " + str + ", run: \n dockmate --runtime \n"
+		changeRuntimeSuggestion := "\n\nOr If you want to Change the runtime to " + str + ", run: \n dockmate runtime \n"
 		return changeRuntimeSuggestion
 	}
 
 	switch runtimeType {
 	case "podman":
 		// 1. Check if installed first
-		if cfg.Runtime.RunPreChecks {
+		if full {
 			result := checkPodmanInstalled()
 			if !result.Passed {
 				result.SuggestedAction += errorChangeRuntimeSuggestion("docker")
@@ -559,9 +767,24 @@ func RunPreChecks() PreCheckResult {
 			return result
 		}
 
-	case "docker", "auto":
+	case "nerdctl":
+		if full {
+			result := checkNerdctlInstalled()
+			if !result.Passed {
+				result.SuggestedAction += errorChangeRuntimeSuggestion("docker")
+				return result
+			}
+		}
+
+		result := checkNerdctlService()
+		if !result.Passed {
+			result.SuggestedAction += errorChangeRuntimeSuggestion("docker")
+			return result
+		}
+
+	case "docker":
 		// 1. Check if installed first
-		if cfg.Runtime.RunPreChecks {
+		if full {
 			result := checkDockerInstalled()
 			if !result.Passed {
 				result.SuggestedAction += errorChangeRuntimeSuggestion("podman")
@@ -581,15 +804,9 @@ func RunPreChecks() PreCheckResult {
 			Passed:          false,
 			ErrorType:       NoError,
 			ErrorMessage:    fmt.Sprintf("Unsupported runtime type: %s", runtimeType),
-			SuggestedAction: "Please choose docker or podman using: \n dockmate --runtime \n",
+			SuggestedAction: "Please choose docker, podman, nerdctl or auto using: \n dockmate runtime \n",
 		}
 	}
 
-	// save to config that prechecks have passed (if needed in future)
-	cfg.Runtime.RunPreChecks = false
-	if err := cfg.Save(); err != nil {
-		// log but don't fail prechecks
-		fmt.Fprintf(os.Stderr, "Warning: failed to save config after prechecks: %v\n", err)
-	}
 	return PreCheckResult{Passed: true}
 }
@@ -0,0 +1,130 @@
+package check
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// daemonProvider is whatever runs the docker daemon on macOS
+type daemonProvider struct {
+	Name       string
+	StartCmd   string
+	RestartCmd string
+	Docs       string
+}
+
+var (
+	dockerDesktop = daemonProvider{
+		Name:       "Docker Desktop",
+		StartCmd:   "open -a Docker",
+		RestartCmd: "Restart Docker Desktop application",
+		Docs:       "https://docs.docker.com/desktop/install/mac-install/",
+	}
+	colima = daemonProvider{
+		Name:       "Colima",
+		StartCmd:   "colima start",
+		RestartCmd: "colima restart",
+		Docs:       "https://github.com/abiosoft/colima#usage",
+	}
+	rancherDesktop = daemonProvider{
+		Name:       "Rancher Desktop",
+		StartCmd:   "rdctl start",
+		RestartCmd: "rdctl shutdown && rdctl start",
+		Docs:       "https://docs.rancherdesktop.io/",
+	}
+	orbStack = daemonProvider{
+		Name:       "OrbStack",
+		StartCmd:   "orb start",
+		RestartCmd: "orb restart",
+		Docs:       "https://docs.orbstack.dev/",
+	}
+	lima = daemonProvider{
+		Name:       "Lima",
+		StartCmd:   "limactl start docker",
+		RestartCmd: "limactl stop docker && limactl start docker",
+		Docs:       "https://lima-vm.io/docs/examples/#docker",
+	}
+)
+
+// detectMacProvider works out which daemon the docker CLI is meant to use on
+// macOS. The socket in DOCKER_HOST (or the docker context) says the most,
+// otherwise whichever alternative is installed wins over Docker Desktop.
+func detectMacProvider() daemonProvider {
+	home, _ := os.UserHomeDir()
+	exists := func(p string) bool {
+		_, err := os.Stat(p)
+		return err == nil
+	}
+	return macProviderFor(currentDockerEndpoint(home), home, commandExists, exists)
+}
+
+// macProviderFor is detectMacProvider with its lookups passed in
+func macProviderFor(endpoint, home string, hasCmd, exists func(string) bool) daemonProvider {
+	switch {
+	case strings.Contains(endpoint, "/.colima/"):
+		return colima
+	case strings.Contains(endpoint, "/.rd/"):
+		return rancherDesktop
+	case strings.Contains(endpoint, "/.orbstack/"):
+		return orbStack
+	case strings.Contains(endpoint, "/.lima/"):
+		return lima
+	case strings.Contains(endpoint, "/.docker/run/"), strings.Contains(endpoint, "/.docker/desktop/"):
+		return dockerDesktop
+	}
+
+	switch {
+	case hasCmd("colima") || exists(filepath.Join(home, ".colima")):
+		return colima
+	case hasCmd("rdctl") || exists("/Applications/Rancher Desktop.app"):
+		return rancherDesktop
+	case hasCmd("orb") || exists("/Applications/OrbStack.app"):
+		return orbStack
+	case exists("/Applications/Docker.app"):
+		return dockerDesktop
+	case hasCmd("limactl"):
+		return lima
+	}
+	return dockerDesktop
+}
+
+// currentDockerEndpoint returns DOCKER_HOST, or the host of the current docker
+// context read straight from ~/.docker (running `docker context` is slow)
+func currentDockerEndpoint(home string) string {
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		return host
+	}
+
+	var cfg struct {
+		CurrentContext string `json:"currentContext"`
+	}
+	data, err := os.ReadFile(filepath.Join(home, ".docker", "config.json"))
+	if err != nil || json.Unmarshal(data, &cfg) != nil {
+		return ""
+	}
+	if cfg.CurrentContext == "" || cfg.CurrentContext == "default" {
+		return ""
+	}
+
+	// context metadata lives in ~/.docker/contexts/meta/<sha256 of name>/meta.json,
+	// just look through all of them for the one with this name
+	metas, _ := filepath.Glob(filepath.Join(home, ".docker", "contexts", "meta", "*", "meta.json"))
+	for _, m := range metas {
+		var meta struct {
+			Name      string `json:"Name"`
+			Endpoints struct {
+				Docker struct {
+					Host string `json:"Host"`
+				} `json:"docker"`
+			} `json:"Endpoints"`
+		}
+		data, err := os.ReadFile(m)
+		if err != nil || json.Unmarshal(data, &meta) != nil || meta.Name != cfg.CurrentContext {
+			continue
+		}
+		return meta.Endpoints.Docker.Host
+	}
+	return ""
+}
@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/shubh-io/dockmate/internal/config"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// ActionCommand implements `dockmate start|stop|restart <name>...`.
+// Names are resolved with docker.ResolveContainers, so exact names, compose
+// "project/service", globs and ID prefixes all work. The same guardrails as
+// the TUI apply: pinned containers are only stopped or restarted with
+// --force, and anything touching more than one container asks first (or
+// needs --yes when there's no terminal to ask on).
+// It reports whether the TUI should open afterwards (--tui, default true).
+func ActionCommand(action string, args []string) (bool, error) {
+	fs := flag.NewFlagSet(action, flag.ContinueOnError)
+	openTUI := fs.Bool("tui", true, "open the TUI after the action, --tui=false to exit")
+	yes := fs.Bool("yes", false, "don't ask before acting on several containers")
+	force := fs.Bool("force", false, "also stop/restart pinned containers")
+	names, err := parseInterspersed(fs, args)
+	if err != nil {
+		return false, err
+	}
+	if len(names) == 0 {
+		return false, fmt.Errorf("usage: dockmate %s <name|project/service|glob|id>... [--yes] [--force] [--tui=false]", action)
+	}
+
+	// names are all it needs, the stats would only slow it down
+	containers, err := docker.ListContainersWithoutStats()
+	if err != nil {
+		return false, fmt.Errorf("listing containers: %w", err)
+	}
+
+	targets, err := resolveTargets(containers, names)
+	if err != nil {
+		return false, err
+	}
+	targets = actionable(action, targets)
+	if len(targets) == 0 {
+		fmt.Printf("Nothing to %s\n", action)
+		return *openTUI, nil
+	}
+
+	if action != "start" {
+		cwd, _ := os.Getwd()
+		cfg, overlay, _ := config.LoadWithProject(cwd)
+		pinned := append(slices.Clone(cfg.Pinned), overlay.Pinned...)
+
+		var blocked []string
+		for _, c := range targets {
+			if slices.Contains(pinned, docker.ContainerName(c)) {
+				blocked = append(blocked, docker.ContainerName(c))
+			}
+		}
+		if len(blocked) > 0 && !*force {
+			return false, fmt.Errorf("refusing to %s pinned container(s) %s, pass --force to do it anyway", action, strings.Join(blocked, ", "))
+		}
+	}
+
+	if len(targets) > 1 && !*yes {
+		ok, err := confirmTargets(action, targets)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return false, fmt.Errorf("cancelled")
+		}
+	}
+
+	// stop dependents first; start and restart bring dependencies up first
+	stages := docker.StopStages(targets)
+	if action != "stop" {
+		slices.Reverse(stages)
+	}
+
+	var failed []string
+	for _, stage := range stages {
+		for _, c := range stage {
+			name := docker.ContainerName(c)
+			if err := docker.DoAction(action, c.ID); err != nil {
+				fmt.Fprintf(os.Stderr, "%s %s: %v\n", action, name, err)
+				failed = append(failed, name)
+				continue
+			}
+			fmt.Printf("%s %s: done\n", action, name)
+		}
+	}
+	if len(failed) > 0 {
+		return false, fmt.Errorf("%s failed for %s", action, strings.Join(failed, ", "))
+	}
+	return *openTUI, nil
+}
+
+// resolveTargets resolves every name and drops duplicates; a name matching nothing is an error
+func resolveTargets(containers []docker.Container, names []string) ([]docker.Container, error) {
+	var out []docker.Container
+	seen := make(map[string]bool)
+	for _, name := range names {
+		matches := docker.ResolveContainers(containers, name)
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no container matches %q", name)
+		}
+		for _, c := range matches {
+			if !seen[c.ID] {
+				seen[c.ID] = true
+				out = append(out, c)
+			}
+		}
+	}
+	return out, nil
+}
+
+// actionable drops containers the action wouldn't change (starting a running one, stopping a stopped one)
+func actionable(action string, cs []docker.Container) []docker.Container {
+	var out []docker.Container
+	for _, c := range cs {
+		running := false
+		switch strings.ToLower(c.State) {
+		case "running", "paused", "restarting":
+			running = true
+		}
+		if (action == "start" && running) || (action == "stop" && !running) {
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// confirmTargets asks y/N on the terminal; without one it refuses instead of guessing
+func confirmTargets(action string, cs []docker.Container) (bool, error) {
+	names := make([]string, len(cs))
+	for i, c := range cs {
+		names[i] = docker.ContainerName(c)
+	}
+
+	if info, err := os.Stdin.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false, fmt.Errorf("%s would touch %d containers (%s), pass --yes to confirm", action, len(cs), strings.Join(names, ", "))
+	}
+
+	fmt.Printf("%s %d containers (%s)? [y/N] ", action, len(cs), strings.Join(names, ", "))
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+// parseInterspersed lets flags come after the names (`dockmate restart web --tui=false`),
+// the flag package stops at the first non-flag argument otherwise
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
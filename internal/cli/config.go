@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/shubh-io/dockmate/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+const configUsage = "usage: dockmate config show|path|edit|validate"
+
+// ConfigCommand implements `dockmate config show|path|edit|validate`
+func ConfigCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf(configUsage)
+	}
+
+	switch args[0] {
+	case "show":
+		return configShow()
+	case "path":
+		path, err := config.GetConfigPath()
+		if err != nil {
+			return err
+		}
+		fmt.Println(path)
+		return nil
+	case "edit":
+		return configEdit()
+	case "validate":
+		return configValidate()
+	}
+	return fmt.Errorf("unknown config command %q, %s", args[0], configUsage)
+}
+
+// configShow prints the config dockmate actually runs with: defaults filled
+// in and the working directory's .dockmate.yml merged on top
+func configShow() error {
+	cwd, _ := os.Getwd()
+	cfg, overlay, err := config.LoadWithProject(cwd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ignoring project config: %v\n", err)
+	}
+	if overlay.Path != "" {
+		fmt.Printf("# merged with %s\n", overlay.Path)
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(data))
+	return nil
+}
+
+// configEdit opens the config in $VISUAL/$EDITOR, creating it with the
+// defaults first if needed, and validates it once the editor exits
+func configEdit() error {
+	path, err := config.GetConfigPath()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := config.DefaultConfig().Save(); err != nil {
+			return fmt.Errorf("creating %s: %w", path, err)
+		}
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	// $EDITOR may carry arguments, e.g. "code --wait"
+	fields := strings.Fields(editor)
+	cmd := exec.Command(fields[0], append(fields[1:], path)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("running %s: %w", editor, err)
+	}
+
+	return configValidate()
+}
+
+func configValidate() error {
+	problems, err := config.Validate()
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		fmt.Println("Config OK")
+		return nil
+	}
+
+	path, _ := config.GetConfigPath()
+	fmt.Printf("%s:\n", path)
+	for _, p := range problems {
+		fmt.Printf("  %s\n", p)
+	}
+	return fmt.Errorf("%d problem(s) found", len(problems))
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/shubh-io/dockmate/internal/check"
+)
+
+var doctorMarks = map[check.DoctorStatus]string{
+	check.DoctorPass: "✓",
+	check.DoctorWarn: "!",
+	check.DoctorFail: "✗",
+	check.DoctorSkip: "-",
+}
+
+// DoctorCommand implements `dockmate doctor`: runs all the startup checks and
+// prints pass/fail for each. Fails when any check fails, warnings don't count.
+func DoctorCommand(args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range check.RunDoctor() {
+		lines := strings.Split(r.Detail, "\n")
+		fmt.Printf("%s %-13s %s\n", doctorMarks[r.Status], r.Name, lines[0])
+		for _, l := range lines[1:] {
+			fmt.Printf("  %-13s %s\n", "", l)
+		}
+		if r.Fix != "" {
+			for _, l := range strings.Split(r.Fix, "\n") {
+				fmt.Printf("  %-13s %s\n", "", l)
+			}
+		}
+		if r.Status == check.DoctorFail {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	return nil
+}
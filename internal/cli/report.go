@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/config"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// ReportCommand implements `dockmate report`: containers, images and volumes
+// grouped by owner label with age and size, as a table or as CSV
+func ReportCommand(args []string) error {
+	cfg, _ := config.Load()
+
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	ownerLabel := fs.String("owner-label", cfg.Report.OwnerLabel, "label that holds the owner of a resource")
+	csvPath := fs.String("csv", "", "write the report as CSV to this file (\"-\" for stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	resources, err := docker.CollectResources(*ownerLabel)
+	if err != nil {
+		return err
+	}
+
+	docker.SortByOwner(resources)
+
+	if *csvPath == "" {
+		writeReportTable(os.Stdout, resources, *ownerLabel)
+		return nil
+	}
+
+	if *csvPath == "-" {
+		return docker.WriteReportCSV(os.Stdout, resources, time.Now())
+	}
+
+	f, err := os.Create(*csvPath)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", *csvPath, err)
+	}
+	defer f.Close()
+
+	if err := docker.WriteReportCSV(f, resources, time.Now()); err != nil {
+		return err
+	}
+	fmt.Printf("Report written to %s (%d resources)\n", *csvPath, len(resources))
+	return nil
+}
+
+func resourceAge(r docker.OwnedResource) string {
+	if r.CreatedAt.IsZero() {
+		return "─"
+	}
+	return docker.FormatAge(time.Since(r.CreatedAt))
+}
+
+func writeReportTable(w io.Writer, resources []docker.OwnedResource, ownerLabel string) {
+	if len(resources) == 0 {
+		fmt.Fprintln(w, "Nothing to report.")
+		return
+	}
+
+	fmt.Fprintf(w, "Resources grouped by label %q\n", ownerLabel)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	current := ""
+	for i, r := range resources {
+		owner := docker.OwnerOf(r)
+		if owner != current || i == 0 {
+			current = owner
+			count, total := docker.OwnerTotals(resources, owner)
+			fmt.Fprintf(tw, "\n%s: %d resources, %s\n", owner, count, docker.FormatBytes(total))
+			fmt.Fprintln(tw, "  KIND\tNAME\tAGE\tSIZE")
+		}
+		name := r.Name
+		if r.State != "" {
+			name += " (" + r.State + ")"
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", r.Kind, name, resourceAge(r), docker.FormatBytes(r.SizeBytes))
+	}
+	tw.Flush()
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/shubh-io/dockmate/internal/web"
+)
+
+// ServeCommand implements `dockmate serve`: the read-only JSON API, without
+// the TUI, until interrupted. The token comes from DOCKMATE_WEB_TOKEN like
+// the web view's, or a random one is printed.
+func ServeCommand(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	api, err := web.NewAPI(os.Getenv("DOCKMATE_WEB_TOKEN"))
+	if err != nil {
+		return err
+	}
+
+	host, port, err := net.SplitHostPort(*addr)
+	if err != nil {
+		return fmt.Errorf("bad address %q: %w", *addr, err)
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	base := "http://" + net.JoinHostPort(host, port)
+	fmt.Printf("Serving the DockMate API at %s\n", base)
+	fmt.Printf("In a browser: %s/?token=%s\n", base, api.Token())
+	fmt.Printf("Endpoints: /containers /compose /stats /logs/{id}\n")
+	fmt.Printf("Try: curl -H 'Authorization: Bearer %s' %s/containers\n", api.Token(), base)
+	return api.ListenAndServe(*addr)
+}
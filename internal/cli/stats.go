@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// StatsCommand implements `dockmate stats`: a one-shot stats table, or with
+// --summary the host-wide totals and per compose project breakdown
+func StatsCommand(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	summary := fs.Bool("summary", false, "print totals for the host and each compose project")
+	asJSON := fs.Bool("json", false, "print JSON instead of a table")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	containers, err := docker.ListContainers()
+	if err != nil {
+		return fmt.Errorf("listing containers: %w", err)
+	}
+
+	if *summary {
+		sum := docker.Summarize(containers)
+		if *asJSON {
+			return printJSON(sum)
+		}
+		printSummary(sum)
+		return nil
+	}
+
+	if *asJSON {
+		return printJSON(containers)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tSTATE\tCPU\tMEM %\tMEM USAGE\tPROJECT")
+	for _, c := range containers {
+		name := ""
+		if len(c.Names) > 0 {
+			name = c.Names[0]
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", name, c.State, dash(c.CPU), dash(c.Memory), dash(c.MemUsage), dash(c.ComposeProject))
+	}
+	return tw.Flush()
+}
+
+func printSummary(sum docker.StatsSummary) {
+	fmt.Printf("Containers: %d running / %d total\n", sum.Running, sum.Total)
+	fmt.Printf("CPU:        %.1f%%\n", sum.CPUPercent)
+	fmt.Printf("Memory:     %s (%.1f%%)\n", docker.FormatBytesBinary(sum.MemBytes), sum.MemPercent)
+
+	if len(sum.Projects) == 0 {
+		return
+	}
+	fmt.Println()
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PROJECT\tRUNNING\tCPU\tMEM\tMEM %")
+	for _, p := range sum.Projects {
+		name := p.Name
+		if name == "" {
+			name = "(standalone)"
+		}
+		fmt.Fprintf(tw, "%s\t%d/%d\t%.1f%%\t%s\t%.1f%%\n", name, p.Running, p.Total, p.CPUPercent, docker.FormatBytesBinary(p.MemBytes), p.MemPercent)
+	}
+	tw.Flush()
+}
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func dash(s string) string {
+	if s == "" {
+		return "─"
+	}
+	return s
+}
@@ -0,0 +1,30 @@
+package config
+
+import "sync"
+
+// the global config as last read from disk, shared by the hot paths that
+// would otherwise parse the file on every container refresh
+var cached struct {
+	mu  sync.Mutex
+	cfg *Config
+}
+
+// Cached returns the global config, reading it from disk only on first use
+// or after Invalidate. The result is shared: read it, don't modify it; use
+// Load for a copy to change and Save.
+func Cached() *Config {
+	cached.mu.Lock()
+	defer cached.mu.Unlock()
+	if cached.cfg == nil {
+		cached.cfg, _ = Load()
+	}
+	return cached.cfg
+}
+
+// Invalidate makes the next Cached call read the file again. Save calls it,
+// and so does the TUI when config.yml changes on disk.
+func Invalidate() {
+	cached.mu.Lock()
+	cached.cfg = nil
+	cached.mu.Unlock()
+}
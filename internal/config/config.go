@@ -14,6 +14,82 @@ type Config struct {
 	Performance PerformanceConfig `yaml:"performance"`
 	Runtime     RuntimeConfig     `yaml:"runtime"`
 	Exec        ExecConfig        `yaml:"exec"`
+	TTL         TTLConfig         `yaml:"ttl"`
+	Pinned      []string          `yaml:"pinned"` // container names kept at the top of the list
+	Report      ReportConfig      `yaml:"report"`
+	Session     SessionConfig     `yaml:"session"`
+	Logs        LogsConfig        `yaml:"logs"`
+	Compose     ComposeConfig     `yaml:"compose"`
+	// display names for compose projects, keyed by the real project name
+	ProjectAliases map[string]string `yaml:"project_aliases"`
+	// short notes shown next to a container's name, keyed by container name
+	ContainerNotes map[string]string `yaml:"container_notes"`
+	Commands       []CustomCommand   `yaml:"commands"`  // external commands bound to keys
+	Templates      []RunTemplate     `yaml:"templates"` // containers to run from Ctrl+N or a key
+	Logging        LoggingConfig     `yaml:"logging"`
+	Registries     []RegistryAuth    `yaml:"registries"` // logins for the registry browser
+	Remotes        []RemoteProfile   `yaml:"remotes"`    // remote daemons over SSH, runtime.remote picks one
+	Alerts         AlertsConfig      `yaml:"alerts"`
+	Record         RecordConfig      `yaml:"record"`
+	Metrics        MetricsConfig     `yaml:"metrics"`
+	// the profile laid over the settings above, DOCKMATE_PROFILE wins
+	Profile  string               `yaml:"profile,omitempty"`
+	Profiles map[string]yaml.Node `yaml:"profiles,omitempty"`
+
+	applied string      // the profile Load applied
+	base    *Config     // the file without it, for Save
+	file    *fileValues // the file's values for the command line overrides
+}
+
+// RegistryAuth is a login for a private registry. The password is read from
+// an environment variable so it never ends up in the config file.
+type RegistryAuth struct {
+	Host        string `yaml:"host"` // e.g. "registry.example.com:5000"
+	Username    string `yaml:"username"`
+	PasswordEnv string `yaml:"password_env"` // name of the variable holding the password or token
+}
+
+// RemoteProfile is a daemon on another machine. DockMate opens an SSH tunnel
+// from a local socket to the remote one itself and points the runtime at it.
+type RemoteProfile struct {
+	Name   string `yaml:"name"`
+	Host   string `yaml:"host"`   // host name, or an alias from ~/.ssh/config
+	User   string `yaml:"user"`   // optional, ssh's default otherwise
+	Port   int    `yaml:"port"`   // optional, ssh's default otherwise
+	Key    string `yaml:"key"`    // optional identity file, e.g. ~/.ssh/id_ed25519
+	Socket string `yaml:"socket"` // runtime socket on the remote host, empty for the runtime's usual one
+}
+
+// Remote returns the remotes entry called name, nil if there's none
+func (c *Config) Remote(name string) *RemoteProfile {
+	for i := range c.Remotes {
+		if c.Remotes[i].Name == name {
+			return &c.Remotes[i]
+		}
+	}
+	return nil
+}
+
+// CustomCommand runs an external command when its key is pressed. Command is a
+// text/template filled from the selected container: {{.ID}}, {{.Name}},
+// {{.Image}}, {{.State}}, {{.Project}}, {{.Service}}.
+type CustomCommand struct {
+	Key     string `yaml:"key"`  // e.g. "ctrl+t", "f7" or "z"
+	Name    string `yaml:"name"` // shown in help, defaults to the command
+	Command string `yaml:"command"`
+}
+
+// RunTemplate is a container DockMate runs on demand, e.g. a debug toolbox.
+// Args, Env and Command are text/templates filled like CustomCommand's, so
+// `--network container:{{.ID}}` joins the selected container's network.
+type RunTemplate struct {
+	Name        string   `yaml:"name"`
+	Key         string   `yaml:"key"` // optional, runs it straight away
+	Image       string   `yaml:"image"`
+	Args        []string `yaml:"args"`        // run options, e.g. ["--network", "host"]
+	Env         []string `yaml:"env"`         // KEY=value
+	Command     []string `yaml:"command"`     // after the image, replaces its CMD
+	Interactive bool     `yaml:"interactive"` // run -it --rm in the terminal instead of -d
 }
 
 type LayoutConfig struct {
@@ -26,6 +102,11 @@ type LayoutConfig struct {
 	ImageWidth         int `yaml:"image_width"`
 	StatusWidth        int `yaml:"status_width"`
 	PortWidth          int `yaml:"port_width"`
+	UptimeWidth        int `yaml:"uptime_width"`
+	CreatedWidth       int `yaml:"created_width"`
+	NetworksWidth      int `yaml:"networks_width"`
+	IPWidth            int `yaml:"ip_width"`
+	GPUWidth           int `yaml:"gpu_width"`
 
 	ContainerIdVisible   bool `yaml:"container_id_visible"`
 	ContainerNameVisible bool `yaml:"container_name_visible"`
@@ -36,20 +117,137 @@ type LayoutConfig struct {
 	ImageVisible         bool `yaml:"image_visible"`
 	StatusVisible        bool `yaml:"status_visible"`
 	PortVisible          bool `yaml:"port_visible"`
+	UptimeVisible        bool `yaml:"uptime_visible"`
+	CreatedVisible       bool `yaml:"created_visible"`
+	NetworksVisible      bool `yaml:"networks_visible"`
+	IPVisible            bool `yaml:"ip_visible"`
+	GPUVisible           bool `yaml:"gpu_visible"`
+
+	// ProjectColors marks each compose project's rows with a color of its own
+	ProjectColors bool `yaml:"project_colors"`
 }
 
 type PerformanceConfig struct {
 	PollRate int `yaml:"poll_rate"` // seconds
+	// StatsRate is the seconds between stats calls, 0 gets stats with every refresh
+	StatsRate int `yaml:"stats_rate"`
+	// ContainerStatsRates overrides StatsRate by container name. 0 fetches
+	// the container's stats only on demand, with F5 or the info panel.
+	ContainerStatsRates map[string]int `yaml:"container_stats_rates,omitempty"`
+	// ParallelActions is how many start/stop/restart/remove actions run at
+	// once, the rest wait in the queue
+	ParallelActions int `yaml:"parallel_actions"`
 }
 
 type RuntimeConfig struct {
-	Type         string `yaml:"type"`   // "docker" or "podman"
-	Socket       string `yaml:"socket"` // custom socket path (would add in future)
-	RunPreChecks bool   `yaml:"run_pre_checks"`
+	Type   string `yaml:"type"`   // "docker", "podman", "nerdctl" or "auto"
+	Socket string `yaml:"socket"` // custom socket path or endpoint URL, e.g. /run/user/1000/podman/podman.sock
+	// the full startup checks run again after this many days, after a
+	// failed start and when the runtime changes; 0 runs them every start
+	PrecheckTTLDays int `yaml:"precheck_ttl_days"`
+	// no longer used, older versions wrote it after the first start
+	RunPreChecks bool `yaml:"run_pre_checks,omitempty"`
+	// runtimes tried in order when type is "auto"; the first one installed
+	// with a reachable daemon wins
+	AutoOrder []string `yaml:"auto_order"`
+	// name of the remotes entry to reach the daemon through, empty for the
+	// local one. Takes over from socket and DOCKER_HOST.
+	Remote string    `yaml:"remote"`
+	TLS    TLSConfig `yaml:"tls"`
+}
+
+// TLSConfig is the client side of a tcp:// daemon behind TLS, the files
+// docker otherwise reads from DOCKER_CERT_PATH
+type TLSConfig struct {
+	CACert string `yaml:"ca_cert"` // the daemon's certificate is checked against it, empty skips the check
+	Cert   string `yaml:"cert"`    // client certificate
+	Key    string `yaml:"key"`     // the client certificate's private key
+}
+
+// Enabled says whether any of the files is set
+func (t TLSConfig) Enabled() bool {
+	return t.CACert != "" || t.Cert != "" || t.Key != ""
 }
 
 type ExecConfig struct {
-	Shell string `yaml:"shell"` // preferred shell for container exec
+	// shell for container exec: a path like /bin/bash, "auto" to use the best
+	// one the container has, or "ask" to pick from the ones it has
+	Shell string `yaml:"shell"`
+	// inside tmux, open shells and log follows in a new "pane" or "window"
+	// instead of suspending DockMate; "off" or empty suspends as elsewhere
+	Tmux string `yaml:"tmux"`
+	// key sequence that detaches from a shell and leaves it running, e.g.
+	// "ctrl-x,x"; empty keeps the runtime's own, ctrl-p,ctrl-q
+	DetachKeys string `yaml:"detach_keys"`
+}
+
+type LogsConfig struct {
+	StripANSI bool `yaml:"strip_ansi"` // show logs without the colors apps put in them
+	// color ERROR, WARN, INFO and DEBUG in lines the app didn't color itself
+	LevelColors bool `yaml:"level_colors"`
+	// Filters narrow the logs panel, keyed by container or compose project name
+	Filters map[string]LogFilter `yaml:"filters,omitempty"`
+}
+
+// ComposeConfig is where DockMate looks for compose projects that aren't
+// running, only running ones show up in the list
+type ComposeConfig struct {
+	Dirs      []string `yaml:"dirs"`       // e.g. ["~/src", "/srv"]
+	ScanDepth int      `yaml:"scan_depth"` // levels of subdirectories searched below each dir
+}
+
+// LogFilter keeps the log lines matching Include (all of them when empty) and
+// drops the ones matching any of Exclude, e.g. healthcheck requests. Both are
+// regular expressions.
+type LogFilter struct {
+	Include string   `yaml:"include,omitempty"`
+	Exclude []string `yaml:"exclude,omitempty"`
+}
+
+// RecordConfig appends the stats of every refresh to a file, to graph a
+// profiling session in other tools
+type RecordConfig struct {
+	File      string `yaml:"file"`        // empty doesn't record
+	Format    string `yaml:"format"`      // csv or jsonl, empty goes by the file's extension
+	MaxSizeMB int    `yaml:"max_size_mb"` // start a new file past this size, 0 never
+	MaxFiles  int    `yaml:"max_files"`   // full files kept next to the current one
+}
+
+// MetricsConfig pushes the stats of every refresh to InfluxDB or an OTLP
+// collector
+type MetricsConfig struct {
+	Sink string `yaml:"sink"` // influx, otlp, or empty for none
+	// e.g. http://localhost:8086/api/v2/write?org=me&bucket=docker
+	// or http://localhost:4318/v1/metrics
+	URL      string `yaml:"url"`
+	TokenEnv string `yaml:"token_env"` // name of the variable holding the API token
+}
+
+type SessionConfig struct {
+	// what to do on quit with containers started during the session:
+	// "ask", "always" (stop them) or "never"
+	StopOnQuit string `yaml:"stop_on_quit"`
+}
+
+type LoggingConfig struct {
+	Level string `yaml:"level"` // off, error, info or debug
+	File  string `yaml:"file"`  // empty for the XDG state dir
+}
+
+type ReportConfig struct {
+	OwnerLabel string `yaml:"owner_label"` // label used to group `dockmate report` output by owner
+}
+
+type AlertsConfig struct {
+	// a container restarting more than crash_loop_restarts times within
+	// crash_loop_minutes is flagged as crash looping
+	CrashLoopRestarts int `yaml:"crash_loop_restarts"`
+	CrashLoopMinutes  int `yaml:"crash_loop_minutes"`
+}
+
+type TTLConfig struct {
+	AutoStop   bool              `yaml:"auto_stop"`  // stop running containers once their ttl runs out
+	Containers map[string]string `yaml:"containers"` // local ttl by container name, for containers without a dockmate.ttl label
 }
 
 // Default config
@@ -74,6 +272,12 @@ func DefaultConfig() *Config {
 			ImageWidth:         18,
 			StatusWidth:        13,
 			PortWidth:          13,
+			// off by default, the widths above add up to 100 without them
+			UptimeWidth:   8,
+			CreatedWidth:  8,
+			NetworksWidth: 12,
+			IPWidth:       12,
+			GPUWidth:      10,
 
 			ContainerIdVisible:   true,
 			ContainerNameVisible: true,
@@ -84,24 +288,62 @@ func DefaultConfig() *Config {
 			ImageVisible:         true,
 			StatusVisible:        true,
 			PortVisible:          true,
+			UptimeVisible:        false,
+			CreatedVisible:       false,
+			NetworksVisible:      false,
+			IPVisible:            false,
+			GPUVisible:           false,
 		},
 		Performance: PerformanceConfig{
-			PollRate: 2,
+			PollRate:        2,
+			ParallelActions: 3,
 		},
 		Runtime: RuntimeConfig{
 			Type: "docker",
-			// optional, would add support later for custom sockets
-			Socket:       "",
-			RunPreChecks: true,
+			// optional custom socket, empty uses the runtime's default
+			Socket:          "",
+			PrecheckTTLDays: 7,
+			AutoOrder:       []string{"docker", "podman", "nerdctl"},
 		},
 		Exec: ExecConfig{
-			Shell: "/bin/sh",
+			Shell: "auto",
+		},
+		Report: ReportConfig{
+			OwnerLabel: "owner",
+		},
+		Logs: LogsConfig{
+			LevelColors: true,
+		},
+		Compose: ComposeConfig{
+			ScanDepth: 2,
+		},
+		Session: SessionConfig{
+			StopOnQuit: "ask",
+		},
+		Logging: LoggingConfig{
+			Level: "error",
+		},
+		Alerts: AlertsConfig{
+			CrashLoopRestarts: 3,
+			CrashLoopMinutes:  10,
+		},
+		Record: RecordConfig{
+			MaxSizeMB: 100,
+			MaxFiles:  3,
 		},
 	}
 }
 
+// ConfigEnv is a config file to use instead of the usual one, which
+// `dockmate --config` sets
+const ConfigEnv = "DOCKMATE_CONFIG"
+
 // Get config path
 func GetConfigPath() (string, error) {
+	if path := os.Getenv(ConfigEnv); path != "" {
+		return filepath.Abs(path)
+	}
+
 	// Try XDG_CONFIG_HOME first
 	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
 		return filepath.Join(xdg, "dockmate", "config.yml"), nil
@@ -116,8 +358,14 @@ func GetConfigPath() (string, error) {
 	return filepath.Join(home, ".config", "dockmate", "config.yml"), nil
 }
 
-// Load config
+// Load config, with the command line overrides
 func Load() (*Config, error) {
+	cfg, err := loadFile()
+	cfg.applyOverrides()
+	return cfg, err
+}
+
+func loadFile() (*Config, error) {
 	path, err := GetConfigPath()
 	if err != nil {
 		return DefaultConfig(), nil
@@ -141,14 +389,63 @@ func Load() (*Config, error) {
 		return DefaultConfig(), nil
 	}
 
-	// Apply defaults for missing fields
-	if cfg.Exec.Shell == "" {
-		cfg.Exec.Shell = "/bin/sh"
+	if name := cfg.selectedProfile(); name != "" {
+		base := DefaultConfig()
+		yaml.Unmarshal(data, base)
+		if err := cfg.applyProfile(name); err != nil {
+			// the file without it then, validate reports the profile
+			cfg = base
+		} else {
+			normalize(base)
+			cfg.applied = name
+			cfg.base = base
+		}
 	}
-
+	normalize(cfg)
 	return cfg, nil
 }
 
+// normalize applies the defaults for missing fields
+func normalize(cfg *Config) {
+	// run_pre_checks is deprecated: whether the checks are due now lives in
+	// PrecheckState, in the cache. Clearing it here means omitempty leaves it
+	// out the next time the config is written. A false there no longer skips
+	// anything, the full checks run every precheck_ttl_days.
+	cfg.Runtime.RunPreChecks = false
+	if cfg.Exec.Shell == "" {
+		cfg.Exec.Shell = "auto"
+	}
+	if len(cfg.Runtime.AutoOrder) == 0 {
+		cfg.Runtime.AutoOrder = []string{"docker", "podman", "nerdctl"}
+	}
+	if cfg.Report.OwnerLabel == "" {
+		cfg.Report.OwnerLabel = "owner"
+	}
+	if cfg.Logging.Level == "" {
+		cfg.Logging.Level = "error"
+	}
+	if cfg.Performance.StatsRate < 0 {
+		cfg.Performance.StatsRate = 0
+	}
+	if cfg.Performance.ParallelActions < 1 {
+		cfg.Performance.ParallelActions = 3
+	}
+	if cfg.Compose.ScanDepth < 0 {
+		cfg.Compose.ScanDepth = 2
+	}
+	if cfg.Alerts.CrashLoopRestarts < 1 {
+		cfg.Alerts.CrashLoopRestarts = 3
+	}
+	if cfg.Alerts.CrashLoopMinutes < 1 {
+		cfg.Alerts.CrashLoopMinutes = 10
+	}
+	switch cfg.Session.StopOnQuit {
+	case "ask", "always", "never":
+	default:
+		cfg.Session.StopOnQuit = "ask"
+	}
+}
+
 // Save config
 func (c *Config) Save() error {
 	path, err := GetConfigPath()
@@ -162,12 +459,21 @@ func (c *Config) Save() error {
 		return err
 	}
 
-	// Marshal to YAML
-	data, err := yaml.Marshal(c)
+	// Marshal to YAML, without the command line overrides and with the
+	// applied profile's settings back in the profile
+	out, err := c.withoutOverrides().unapplyProfile()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(out)
 	if err != nil {
 		return err
 	}
 
 	// Write file
-	return os.WriteFile(path, data, 0644)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+	Invalidate()
+	return nil
 }
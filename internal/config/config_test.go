@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -14,9 +15,15 @@ func TestDefaultConfig(t *testing.T) {
 
 	assert.Equal(t, "docker", cfg.Runtime.Type)
 	assert.Equal(t, "", cfg.Runtime.Socket)
-	assert.Equal(t, "/bin/sh", cfg.Exec.Shell)
+	assert.Equal(t, "auto", cfg.Exec.Shell)
 	assert.Equal(t, 2, cfg.Performance.PollRate)
+	assert.Equal(t, 3, cfg.Performance.ParallelActions)
 	assert.Equal(t, 8, cfg.Layout.ContainerId)
+	assert.Equal(t, "ask", cfg.Session.StopOnQuit)
+	assert.Equal(t, []string{"docker", "podman", "nerdctl"}, cfg.Runtime.AutoOrder)
+	assert.Equal(t, 7, cfg.Runtime.PrecheckTTLDays)
+	assert.True(t, cfg.Logs.LevelColors)
+	assert.Equal(t, 3, cfg.Record.MaxFiles)
 }
 
 func TestLoadNonExistent(t *testing.T) {
@@ -26,7 +33,7 @@ func TestLoadNonExistent(t *testing.T) {
 	cfg, err := Load()
 
 	require.NoError(t, err)
-	assert.Equal(t, "/bin/sh", cfg.Exec.Shell)
+	assert.Equal(t, "auto", cfg.Exec.Shell)
 	assert.Equal(t, "docker", cfg.Runtime.Type)
 }
 
@@ -77,7 +84,7 @@ performance:
 	cfg, err := Load()
 
 	require.NoError(t, err)
-	assert.Equal(t, "/bin/sh", cfg.Exec.Shell)
+	assert.Equal(t, "auto", cfg.Exec.Shell)
 	assert.Equal(t, "docker", cfg.Runtime.Type)
 	assert.Equal(t, 3, cfg.Performance.PollRate)
 }
@@ -118,6 +125,8 @@ func TestSaveAndLoad(t *testing.T) {
 		Exec: ExecConfig{
 			Shell: "/bin/bash",
 		},
+		Pinned:         []string{"prod-db", "redis"},
+		ContainerNotes: map[string]string{"prod-db": "DO NOT STOP"},
 	}
 
 	err := cfg.Save()
@@ -131,6 +140,8 @@ func TestSaveAndLoad(t *testing.T) {
 	assert.Equal(t, cfg.Exec.Shell, loaded.Exec.Shell)
 	assert.Equal(t, cfg.Performance.PollRate, loaded.Performance.PollRate)
 	assert.Equal(t, cfg.Layout.ContainerId, loaded.Layout.ContainerId)
+	assert.Equal(t, cfg.Pinned, loaded.Pinned)
+	assert.Equal(t, cfg.ContainerNotes, loaded.ContainerNotes)
 }
 
 func TestGetConfigPath(t *testing.T) {
@@ -167,6 +178,325 @@ func TestLoadInvalidYAML(t *testing.T) {
 	cfg, err := Load()
 
 	require.NoError(t, err)
-	assert.Equal(t, "/bin/sh", cfg.Exec.Shell)
+	assert.Equal(t, "auto", cfg.Exec.Shell)
 	assert.Equal(t, "docker", cfg.Runtime.Type)
 }
+
+func TestLoadTTL(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+
+	configDir := filepath.Join(tempDir, "dockmate")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+
+	configContent := `
+ttl:
+  auto_stop: true
+  containers:
+    scratch-db: 4h
+`
+	configPath := filepath.Join(configDir, "config.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	cfg, err := Load()
+
+	require.NoError(t, err)
+	assert.True(t, cfg.TTL.AutoStop)
+	assert.Equal(t, "4h", cfg.TTL.Containers["scratch-db"])
+}
+
+func TestLoadSessionStopOnQuit(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+
+	configDir := filepath.Join(tempDir, "dockmate")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+	configPath := filepath.Join(configDir, "config.yml")
+
+	require.NoError(t, os.WriteFile(configPath, []byte("session:\n  stop_on_quit: always\n"), 0644))
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "always", cfg.Session.StopOnQuit)
+
+	// unknown values fall back to asking
+	require.NoError(t, os.WriteFile(configPath, []byte("session:\n  stop_on_quit: sometimes\n"), 0644))
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "ask", cfg.Session.StopOnQuit)
+}
+
+func TestShellCache(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", tempDir)
+	t.Setenv("HOME", tempDir)
+
+	// missing file is an empty cache
+	assert.Empty(t, LoadShellCache())
+
+	cache := LoadShellCache()
+	cache["nginx:1.25"] = "/bin/bash"
+	cache["alpine:3.20"] = "/bin/ash"
+	require.NoError(t, cache.Save())
+
+	loaded := LoadShellCache()
+	assert.Equal(t, "/bin/bash", loaded["nginx:1.25"])
+	assert.Equal(t, "/bin/ash", loaded["alpine:3.20"])
+
+	// broken file is an empty cache too
+	path, err := GetShellCachePath()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte("not: [valid"), 0644))
+	assert.Empty(t, LoadShellCache())
+}
+
+func TestValidate(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+
+	// no file is fine
+	problems, err := Validate()
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	configDir := filepath.Join(tempDir, "dockmate")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+	configPath := filepath.Join(configDir, "config.yml")
+
+	require.NoError(t, DefaultConfig().Save())
+	problems, err = Validate()
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+runtime:
+  type: lxc
+  precheck_ttl_days: -1
+performance:
+  poll_rat: 5
+  poll_rate: 0
+  stats_rate: -1
+  parallel_actions: 0
+session:
+  stop_on_quit: sometimes
+exec:
+  shell: bash
+  tmux: split
+  detach_keys: ctrl-pq
+logs:
+  filters:
+    web:
+      include: "GET|POST"
+      exclude: ["/health", "(unclosed"]
+compose:
+  scan_depth: -1
+`), 0644))
+	problems, err = Validate()
+	require.NoError(t, err)
+	require.Len(t, problems, 12)
+	assert.Contains(t, problems[0], "poll_rat")
+	assert.Contains(t, problems[1], "runtime.type")
+	assert.Contains(t, problems[2], "precheck_ttl_days")
+	assert.Contains(t, problems[3], "poll_rate")
+	assert.Contains(t, problems[4], "stats_rate")
+	assert.Contains(t, problems[5], "parallel_actions")
+	assert.Contains(t, problems[6], "stop_on_quit")
+	assert.Contains(t, problems[7], "exec.shell")
+	assert.Contains(t, problems[8], "exec.tmux")
+	assert.Contains(t, problems[9], "exec.detach_keys")
+	assert.Contains(t, problems[10], "logs.filters.web")
+	assert.Contains(t, problems[11], "compose.scan_depth")
+
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+commands:
+  - key: ctrl+t
+    command: lazydocker
+  - key: f7
+    command: dive {{.Image}
+  - key: z
+templates:
+  - name: netshoot
+    image: nicolaka/netshoot
+    args: ["--network", "container:{{.ID}}"]
+    interactive: true
+  - name: psql
+registries:
+  - username: me
+remotes:
+  - name: nas
+    host: nas.lan
+  - name: nas
+    port: 70000
+runtime:
+  remote: prod
+  tls:
+    ca_cert: ~/.docker/ca.pem
+    cert: ~/.docker/cert.pem
+alerts:
+  crash_loop_minutes: 0
+record:
+  file: stats.xml
+  format: xml
+metrics:
+  sink: influx
+`), 0644))
+	problems, err = Validate()
+	require.NoError(t, err)
+	require.Len(t, problems, 12)
+	assert.Contains(t, problems[0], "f7")
+	assert.Contains(t, problems[1], "commands[2]")
+	assert.Contains(t, problems[2], "templates[1]")
+	assert.Contains(t, problems[3], "crash_loop_minutes")
+	assert.Contains(t, problems[4], "record.format")
+	assert.Contains(t, problems[5], "metrics.url")
+	assert.Contains(t, problems[6], "registries[0]")
+	assert.Contains(t, problems[7], "already a remote")
+	assert.Contains(t, problems[8], "remotes[1] needs a host")
+	assert.Contains(t, problems[9], "remotes[1].port")
+	assert.Contains(t, problems[10], "runtime.remote")
+	assert.Contains(t, problems[11], "runtime.tls")
+
+	require.NoError(t, os.WriteFile(configPath, []byte("invalid: yaml: content:"), 0644))
+	problems, err = Validate()
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0], "invalid YAML")
+}
+
+func TestCached(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	Invalidate()
+	t.Cleanup(Invalidate)
+
+	assert.Equal(t, "docker", Cached().Runtime.Type)
+
+	// edits behind its back are only seen after Invalidate
+	path, err := GetConfigPath()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte("runtime:\n  type: nerdctl\n"), 0644))
+	assert.Equal(t, "docker", Cached().Runtime.Type)
+	Invalidate()
+	assert.Equal(t, "nerdctl", Cached().Runtime.Type)
+
+	// Save invalidates on its own
+	cfg, _ := Load()
+	cfg.Runtime.Type = "podman"
+	require.NoError(t, cfg.Save())
+	assert.Equal(t, "podman", Cached().Runtime.Type)
+}
+
+func TestViewState(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", tempDir)
+	t.Setenv("HOME", tempDir)
+
+	assert.Equal(t, ViewState{}, LoadViewState())
+
+	state := ViewState{
+		SortBy:           "cpu",
+		SortAsc:          true,
+		ThenSortBy:       "name",
+		ComposeView:      true,
+		ExpandedProjects: map[string]bool{"shop": false, "blog": true},
+	}
+	require.NoError(t, state.Save())
+	assert.Equal(t, state, LoadViewState())
+
+	path, err := GetViewStatePath()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte("sort_by: [broken"), 0644))
+	assert.Equal(t, ViewState{}, LoadViewState())
+}
+
+func TestPrecheckState(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", tempDir)
+	t.Setenv("HOME", tempDir)
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	assert.True(t, LoadPrecheckState().Due("docker  ", 7, now))
+
+	state := PrecheckState{Setup: "docker  ", CheckedAt: now.Add(-48 * time.Hour)}
+	require.NoError(t, state.Save())
+	loaded := LoadPrecheckState()
+	assert.Equal(t, state.Setup, loaded.Setup)
+	assert.True(t, state.CheckedAt.Equal(loaded.CheckedAt))
+
+	assert.False(t, loaded.Due("docker  ", 7, now))
+	assert.True(t, loaded.Due("docker  ", 2, now), "older than the ttl")
+	assert.True(t, loaded.Due("docker  ", 0, now), "a ttl of 0 checks every start")
+	assert.True(t, loaded.Due("podman  ", 7, now), "another runtime")
+	loaded.Failed = true
+	assert.True(t, loaded.Due("docker  ", 7, now), "the last start failed")
+}
+
+func TestLoadDropsRunPreChecks(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	configDir := filepath.Join(tempDir, "dockmate")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+	configPath := filepath.Join(configDir, "config.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte("runtime:\n  type: podman\n  run_pre_checks: true\n"), 0644))
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	require.NoError(t, cfg.Save())
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "run_pre_checks")
+	assert.Contains(t, string(data), "precheck_ttl_days: 7")
+}
+
+func TestLoadIgnoresRunPreChecksFalse(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	configDir := filepath.Join(tempDir, "dockmate")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+	configPath := filepath.Join(configDir, "config.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte("runtime:\n  type: docker\n  run_pre_checks: false\n"), 0644))
+
+	// false used to skip the checks, now the ttl decides like for everyone
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.Runtime.RunPreChecks)
+	assert.Equal(t, 7, cfg.Runtime.PrecheckTTLDays)
+	assert.True(t, PrecheckState{}.Due("docker", cfg.Runtime.PrecheckTTLDays, time.Now()))
+
+	require.NoError(t, cfg.Save())
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "run_pre_checks")
+}
+
+func TestOverrides(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Cleanup(func() { SetOverrides(Overrides{}) })
+
+	SetOverrides(Overrides{Runtime: "podman", PollRate: 7})
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "podman", cfg.Runtime.Type)
+	assert.Equal(t, 7, cfg.Performance.PollRate)
+
+	// saved without them, except what was changed since
+	cfg.Performance.PollRate = 4
+	require.NoError(t, cfg.Save())
+	SetOverrides(Overrides{})
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "docker", cfg.Runtime.Type)
+	assert.Equal(t, 4, cfg.Performance.PollRate)
+
+	// --config
+	other := filepath.Join(tempDir, "other.yml")
+	require.NoError(t, os.WriteFile(other, []byte("runtime:\n  type: nerdctl\n"), 0644))
+	t.Setenv(ConfigEnv, other)
+	path, err := GetConfigPath()
+	require.NoError(t, err)
+	assert.Equal(t, other, path)
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "nerdctl", cfg.Runtime.Type)
+}
@@ -0,0 +1,66 @@
+package config
+
+import "sync"
+
+// Overrides are settings given on the command line, for one run. Load lays
+// them over the file, Save leaves them out unless they were changed since.
+type Overrides struct {
+	Runtime  string // runtime.type
+	PollRate int    // performance.poll_rate, 0 keeps the file's
+}
+
+var overrides struct {
+	mu sync.Mutex
+	o  Overrides
+}
+
+// SetOverrides replaces the command line overrides
+func SetOverrides(o Overrides) {
+	overrides.mu.Lock()
+	overrides.o = o
+	overrides.mu.Unlock()
+	Invalidate()
+}
+
+func currentOverrides() Overrides {
+	overrides.mu.Lock()
+	defer overrides.mu.Unlock()
+	return overrides.o
+}
+
+// fileValues are what the overridden settings were in the file
+type fileValues struct {
+	runtime  string
+	pollRate int
+}
+
+func (c *Config) applyOverrides() {
+	o := currentOverrides()
+	if o == (Overrides{}) {
+		return
+	}
+	c.file = &fileValues{runtime: c.Runtime.Type, pollRate: c.Performance.PollRate}
+	if o.Runtime != "" {
+		c.Runtime.Type = o.Runtime
+	}
+	if o.PollRate > 0 {
+		c.Performance.PollRate = o.PollRate
+	}
+}
+
+// withoutOverrides is c with the file's values back in place of the
+// overrides it still has
+func (c *Config) withoutOverrides() *Config {
+	if c.file == nil {
+		return c
+	}
+	o := currentOverrides()
+	out := *c
+	if o.Runtime != "" && out.Runtime.Type == o.Runtime {
+		out.Runtime.Type = c.file.runtime
+	}
+	if o.PollRate > 0 && out.Performance.PollRate == o.PollRate {
+		out.Performance.PollRate = c.file.pollRate
+	}
+	return &out
+}
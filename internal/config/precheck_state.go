@@ -0,0 +1,76 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PrecheckState is the outcome of the last startup checks. It lives in the
+// cache rather than the config, so passing them never rewrites the user's
+// file.
+type PrecheckState struct {
+	// Setup names what was checked, the runtime and how it's reached. A
+	// different one is checked again straight away.
+	Setup     string    `yaml:"setup"`
+	CheckedAt time.Time `yaml:"checked_at"`
+	Failed    bool      `yaml:"failed,omitempty"`
+}
+
+// GetPrecheckStatePath returns where the precheck state lives
+// ($XDG_CACHE_HOME/dockmate/precheck.yml or ~/.cache/dockmate/precheck.yml)
+func GetPrecheckStatePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "dockmate", "precheck.yml"), nil
+}
+
+// LoadPrecheckState reads the precheck state, a missing or broken file is the
+// zero state, which is always due
+func LoadPrecheckState() PrecheckState {
+	var state PrecheckState
+
+	path, err := GetPrecheckStatePath()
+	if err != nil {
+		return state
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return state
+	}
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return PrecheckState{}
+	}
+	return state
+}
+
+// Save writes the precheck state
+func (s PrecheckState) Save() error {
+	path, err := GetPrecheckStatePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Due reports whether the full checks should run for setup: after a failure,
+// for a different setup, and once ttlDays have passed. A ttl of 0 means
+// every start.
+func (s PrecheckState) Due(setup string, ttlDays int, now time.Time) bool {
+	if s.Failed || s.Setup != setup || s.CheckedAt.IsZero() || ttlDays <= 0 {
+		return true
+	}
+	return now.Sub(s.CheckedAt) >= time.Duration(ttlDays)*24*time.Hour
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// a profile is a named set of runtime, layout, performance and exec settings
+// laid over the rest of the file, e.g. a podman box with its own columns next
+// to the local docker. `profile:` picks one, DOCKMATE_PROFILE (which
+// `dockmate --profile` sets) wins over it. A profile only has to name the
+// settings it changes, the rest come from the top of the file.
+
+// ProfileEnv names the profile to use instead of the file's `profile:`
+const ProfileEnv = "DOCKMATE_PROFILE"
+
+// profileSections are the sections a profile can set
+var profileSections = map[string]func(c *Config) any{
+	"runtime":     func(c *Config) any { return &c.Runtime },
+	"layout":      func(c *Config) any { return &c.Layout },
+	"performance": func(c *Config) any { return &c.Performance },
+	"exec":        func(c *Config) any { return &c.Exec },
+}
+
+// ProfileNames lists the profiles, sorted
+func (c *Config) ProfileNames() []string {
+	return slices.Sorted(maps.Keys(c.Profiles))
+}
+
+// AppliedProfile is the profile Load laid over the file, "" for none
+func (c *Config) AppliedProfile() string {
+	return c.applied
+}
+
+// selectedProfile is the profile to apply, from the environment or the file
+func (c *Config) selectedProfile() string {
+	if name := os.Getenv(ProfileEnv); name != "" {
+		return name
+	}
+	return c.Profile
+}
+
+// applyProfile decodes the profile's sections over c
+func (c *Config) applyProfile(name string) error {
+	node, ok := c.Profiles[name]
+	if !ok {
+		return fmt.Errorf("there's no profile %q", name)
+	}
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("profile %q is not a mapping", name)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		section, ok := profileSections[node.Content[i].Value]
+		if !ok {
+			continue
+		}
+		if err := node.Content[i+1].Decode(section(c)); err != nil {
+			return fmt.Errorf("profile %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// unapplyProfile is c the way it's written to the file: what the applied
+// profile sets goes back into the profile, everything else to the top of
+// the file, so a save from a profile doesn't leak into the others
+func (c *Config) unapplyProfile() (*Config, error) {
+	node, ok := c.Profiles[c.applied]
+	if c.applied == "" || c.base == nil || !ok || node.Kind != yaml.MappingNode {
+		return c, nil
+	}
+	out := *c
+	updated := node
+	updated.Content = slices.Clone(node.Content)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		section, ok := profileSections[key]
+		if !ok || node.Content[i+1].Kind != yaml.MappingNode {
+			continue
+		}
+		var current, base yaml.Node
+		if err := current.Encode(section(c)); err != nil {
+			return nil, err
+		}
+		if err := base.Encode(section(c.base)); err != nil {
+			return nil, err
+		}
+
+		// the settings the profile has get their current value there, the
+		// file's own section keeps what it had for them
+		set := node.Content[i+1]
+		inProfile := make(map[string]bool)
+		profileSection := &yaml.Node{Kind: yaml.MappingNode, Tag: set.Tag, Style: set.Style}
+		for j := 0; j+1 < len(set.Content); j += 2 {
+			field := set.Content[j].Value
+			inProfile[field] = true
+			value := mappingValue(&current, field)
+			if value == nil {
+				value = set.Content[j+1]
+			}
+			profileSection.Content = append(profileSection.Content, set.Content[j], value)
+		}
+		updated.Content[i+1] = profileSection
+
+		for j := 0; j+1 < len(base.Content); j += 2 {
+			if field := base.Content[j].Value; !inProfile[field] {
+				if value := mappingValue(&current, field); value != nil {
+					base.Content[j+1] = value
+				}
+			}
+		}
+		// decoded into a fresh section, out shares c's maps
+		target := reflect.ValueOf(section(&out)).Elem()
+		target.SetZero()
+		if err := base.Decode(target.Addr().Interface()); err != nil {
+			return nil, err
+		}
+	}
+
+	out.Profiles = maps.Clone(c.Profiles)
+	out.Profiles[c.applied] = updated
+	return &out, nil
+}
+
+// mappingValue is the value of key in a mapping node, nil if it has none
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// validateProfiles lists what's wrong with the profiles and the one picked
+func validateProfiles(cfg *Config) []string {
+	var problems []string
+	for _, name := range cfg.ProfileNames() {
+		node := cfg.Profiles[name]
+		if node.Kind != yaml.MappingNode {
+			problems = append(problems, fmt.Sprintf("profiles.%s is not a mapping", name))
+			continue
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			section, ok := profileSections[key]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("profiles.%s.%s can't be set in a profile, only %s", name, key, "runtime, layout, performance and exec"))
+				continue
+			}
+			// Node.Decode doesn't catch unknown fields, a decoder does
+			data, err := yaml.Marshal(node.Content[i+1])
+			if err != nil {
+				continue
+			}
+			dec := yaml.NewDecoder(bytes.NewReader(data))
+			dec.KnownFields(true)
+			if err := dec.Decode(section(DefaultConfig())); err != nil && !errors.Is(err, io.EOF) {
+				problems = append(problems, fmt.Sprintf("profiles.%s.%s: %v", name, key, err))
+			}
+		}
+	}
+	if name := cfg.Profile; name != "" && cfg.Profiles[name].Kind == 0 {
+		problems = append(problems, fmt.Sprintf("profile %q is not one of the profiles", name))
+	}
+	return problems
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const profilesYAML = `
+runtime:
+  type: docker
+performance:
+  poll_rate: 2
+layout:
+  image_width: 18
+profile: homelab
+profiles:
+  homelab:
+    runtime:
+      type: podman
+      socket: /run/podman/podman.sock
+    layout:
+      image_width: 30
+  prod:
+    runtime:
+      remote: prod
+`
+
+func writeProfiles(t *testing.T) string {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	path := filepath.Join(tempDir, "dockmate", "config.yml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(profilesYAML), 0644))
+	return path
+}
+
+func TestLoadProfile(t *testing.T) {
+	writeProfiles(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "homelab", cfg.AppliedProfile())
+	assert.Equal(t, []string{"homelab", "prod"}, cfg.ProfileNames())
+	assert.Equal(t, "podman", cfg.Runtime.Type)
+	assert.Equal(t, "/run/podman/podman.sock", cfg.Runtime.Socket)
+	// what the profile doesn't set comes from the top of the file
+	assert.Equal(t, []string{"docker", "podman", "nerdctl"}, cfg.Runtime.AutoOrder)
+	assert.Equal(t, 30, cfg.Layout.ImageWidth)
+	assert.Equal(t, 13, cfg.Layout.StatusWidth)
+	assert.Equal(t, 2, cfg.Performance.PollRate)
+
+	t.Setenv(ProfileEnv, "prod")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "prod", cfg.AppliedProfile())
+	assert.Equal(t, "docker", cfg.Runtime.Type)
+	assert.Equal(t, "prod", cfg.Runtime.Remote)
+
+	// an unknown profile leaves the file as it is
+	t.Setenv(ProfileEnv, "nope")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.AppliedProfile())
+	assert.Equal(t, "docker", cfg.Runtime.Type)
+}
+
+func TestSaveProfile(t *testing.T) {
+	writeProfiles(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	cfg.Layout.ImageWidth = 25 // set by the profile
+	cfg.Layout.StatusWidth = 10
+	cfg.Performance.PollRate = 5
+	require.NoError(t, cfg.Save())
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "podman", cfg.Runtime.Type)
+	assert.Equal(t, 25, cfg.Layout.ImageWidth)
+	assert.Equal(t, 10, cfg.Layout.StatusWidth)
+	assert.Equal(t, 5, cfg.Performance.PollRate)
+
+	// the other profile doesn't get homelab's settings
+	t.Setenv(ProfileEnv, "prod")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "docker", cfg.Runtime.Type)
+	assert.Empty(t, cfg.Runtime.Socket)
+	assert.Equal(t, 18, cfg.Layout.ImageWidth)
+	assert.Equal(t, 10, cfg.Layout.StatusWidth)
+}
+
+func TestValidateProfiles(t *testing.T) {
+	path := writeProfiles(t)
+	problems, err := Validate()
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+profile: staging
+profiles:
+  homelab:
+    runtime:
+      typ: podman
+    alerts:
+      crash_loop_minutes: 5
+`), 0644))
+	problems, err = Validate()
+	require.NoError(t, err)
+	require.Len(t, problems, 3)
+	assert.Contains(t, problems[0], "profiles.homelab.runtime")
+	assert.Contains(t, problems[1], "profiles.homelab.alerts")
+	assert.Contains(t, problems[2], "staging")
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFileName is the repo-local marker/config file
+const ProjectFileName = ".dockmate.yml"
+
+// ProjectMarker is what a .dockmate.yml or .envrc in (or above) the working
+// directory says about which engine and compose project to look at
+type ProjectMarker struct {
+	Path           string `yaml:"-"` // file the settings came from
+	DockerHost     string `yaml:"docker_host"`
+	ComposeProject string `yaml:"compose_project"`
+}
+
+// FindProjectMarker walks up from dir and returns the first .dockmate.yml or
+// .envrc that sets an endpoint or compose project, nil if there is none
+func FindProjectMarker(dir string) (*ProjectMarker, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		for _, read := range []func(string) (*ProjectMarker, error){readProjectFile, readEnvrc} {
+			marker, err := read(dir)
+			if err != nil {
+				return nil, err
+			}
+			if marker != nil && (marker.DockerHost != "" || marker.ComposeProject != "") {
+				return marker, nil
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+func readProjectFile(dir string) (*ProjectMarker, error) {
+	path := filepath.Join(dir, ProjectFileName)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var marker ProjectMarker
+	if err := yaml.Unmarshal(data, &marker); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	marker.Path = path
+	return &marker, nil
+}
+
+// readEnvrc picks plain `export NAME=value` lines out of a direnv .envrc.
+// Nothing is executed, anything fancier than a literal value is ignored.
+func readEnvrc(dir string) (*ProjectMarker, error) {
+	path := filepath.Join(dir, ".envrc")
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	marker := &ProjectMarker{Path: path}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		line = strings.TrimPrefix(line, "export ")
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		if strings.ContainsAny(value, "$`") {
+			continue
+		}
+
+		switch strings.TrimSpace(name) {
+		case "DOCKER_HOST":
+			marker.DockerHost = value
+		case "COMPOSE_PROJECT_NAME", "COMPOSE_PROJECT":
+			marker.ComposeProject = value
+		}
+	}
+	return marker, scanner.Err()
+}
+
+// Apply exports the marker's settings the way direnv would, without
+// overriding anything already set in the environment
+func (p *ProjectMarker) Apply() {
+	if p.DockerHost != "" && os.Getenv("DOCKER_HOST") == "" {
+		os.Setenv("DOCKER_HOST", p.DockerHost)
+	}
+	if p.ComposeProject != "" && os.Getenv("COMPOSE_PROJECT_NAME") == "" {
+		os.Setenv("COMPOSE_PROJECT_NAME", p.ComposeProject)
+	}
+}
+
+// FindProjectFile returns the nearest .dockmate.yml at or above dir, "" if none
+func FindProjectFile(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, ProjectFileName)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// ProjectOverlay is the repo-local part of the effective config
+type ProjectOverlay struct {
+	Path   string   // the .dockmate.yml that was merged, "" when there is none
+	Pinned []string // pins that came from the repo; they can't be unpinned locally
+}
+
+// LoadWithProject loads the global config and merges the nearest .dockmate.yml
+// over it. Any config key can appear in the repo file and wins over the global
+// value, except pinned containers which are added to the personal ones.
+// Only the TUI uses the merged result; Save must keep getting a plain Load().
+func LoadWithProject(dir string) (*Config, ProjectOverlay, error) {
+	cfg, _ := Load()
+
+	path, err := FindProjectFile(dir)
+	if err != nil || path == "" {
+		return cfg, ProjectOverlay{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, ProjectOverlay{}, err
+	}
+
+	personalPins := cfg.Pinned
+	cfg.Pinned = nil
+
+	// unmarshalling over the loaded config only touches keys present in the file
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		global, _ := Load()
+		return global, ProjectOverlay{}, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	overlay := ProjectOverlay{Path: path, Pinned: cfg.Pinned}
+	for _, name := range personalPins {
+		if !slices.Contains(cfg.Pinned, name) {
+			cfg.Pinned = append(cfg.Pinned, name)
+		}
+	}
+	return cfg, overlay, nil
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindProjectMarkerEnvrc(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "services", "api")
+	require.NoError(t, os.MkdirAll(sub, 0755))
+
+	envrc := `# loaded by direnv
+export DOCKER_HOST=ssh://dev@buildbox
+export COMPOSE_PROJECT_NAME="shop"
+export PATH=$PWD/bin:$PATH
+`
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".envrc"), []byte(envrc), 0644))
+
+	marker, err := FindProjectMarker(sub)
+	require.NoError(t, err)
+	require.NotNil(t, marker)
+	assert.Equal(t, "ssh://dev@buildbox", marker.DockerHost)
+	assert.Equal(t, "shop", marker.ComposeProject)
+	assert.Equal(t, filepath.Join(root, ".envrc"), marker.Path)
+}
+
+func TestFindProjectMarkerPrefersDockmateYml(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".envrc"), []byte("export COMPOSE_PROJECT_NAME=from-envrc\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ProjectFileName), []byte("compose_project: from-yml\n"), 0644))
+
+	marker, err := FindProjectMarker(root)
+	require.NoError(t, err)
+	require.NotNil(t, marker)
+	assert.Equal(t, "from-yml", marker.ComposeProject)
+}
+
+func TestFindProjectMarkerNone(t *testing.T) {
+	root := t.TempDir()
+	// an .envrc that doesn't touch docker doesn't count
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".envrc"), []byte("export GOFLAGS=-mod=mod\n"), 0644))
+
+	marker, err := FindProjectMarker(root)
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+}
+
+func TestProjectMarkerApply(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "unix:///already/set.sock")
+	t.Setenv("COMPOSE_PROJECT_NAME", "")
+
+	(&ProjectMarker{DockerHost: "ssh://other", ComposeProject: "shop"}).Apply()
+
+	assert.Equal(t, "unix:///already/set.sock", os.Getenv("DOCKER_HOST"))
+	assert.Equal(t, "shop", os.Getenv("COMPOSE_PROJECT_NAME"))
+}
+
+func TestLoadWithProject(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	global := DefaultConfig()
+	global.Pinned = []string{"my-redis"}
+	global.Performance.PollRate = 5
+	require.NoError(t, global.Save())
+
+	repo := t.TempDir()
+	sub := filepath.Join(repo, "web")
+	require.NoError(t, os.MkdirAll(sub, 0755))
+	projectFile := `
+compose_project: shop
+pinned: [shop-db-1]
+layout:
+  image_visible: false
+`
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ProjectFileName), []byte(projectFile), 0644))
+
+	cfg, overlay, err := LoadWithProject(sub)
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(repo, ProjectFileName), overlay.Path)
+	assert.Equal(t, []string{"shop-db-1"}, overlay.Pinned)
+	assert.Equal(t, []string{"shop-db-1", "my-redis"}, cfg.Pinned)
+	assert.False(t, cfg.Layout.ImageVisible)
+	// untouched keys keep the global value
+	assert.True(t, cfg.Layout.StatusVisible)
+	assert.Equal(t, 5, cfg.Performance.PollRate)
+
+	// and the global file itself stays as it was
+	plain, err := Load()
+	require.NoError(t, err)
+	assert.True(t, plain.Layout.ImageVisible)
+	assert.Equal(t, []string{"my-redis"}, plain.Pinned)
+}
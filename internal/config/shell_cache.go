@@ -0,0 +1,57 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ShellCache remembers the shell picked for each image, so exec doesn't
+// have to probe the container again next time
+type ShellCache map[string]string
+
+// GetShellCachePath returns where the shell cache lives
+// ($XDG_CACHE_HOME/dockmate/shells.yml or ~/.cache/dockmate/shells.yml)
+func GetShellCachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "dockmate", "shells.yml"), nil
+}
+
+// LoadShellCache reads the shell cache, a missing or broken file is just an empty cache
+func LoadShellCache() ShellCache {
+	cache := make(ShellCache)
+
+	path, err := GetShellCachePath()
+	if err != nil {
+		return cache
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	if err := yaml.Unmarshal(data, &cache); err != nil || cache == nil {
+		return make(ShellCache)
+	}
+	return cache
+}
+
+// Save writes the shell cache
+func (c ShellCache) Save() error {
+	path, err := GetShellCachePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
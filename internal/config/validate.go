@@ -0,0 +1,207 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Validate reads the global config file and lists what is wrong with it:
+// YAML errors, unknown keys (usually typos) and values Load would quietly
+// replace. A missing file is fine, the defaults are used.
+func Validate() ([]string, error) {
+	path, err := GetConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return validateData(data), nil
+}
+
+// detachKeysPattern is the runtime's --detach-keys format: letters and
+// ctrl- combinations, separated by commas
+var detachKeysPattern = regexp.MustCompile(`^(ctrl-[a-z@^\[\\\]_]|[a-z])(,(ctrl-[a-z@^\[\\\]_]|[a-z]))*$`)
+
+func validateData(data []byte) []string {
+	var problems []string
+
+	cfg := DefaultConfig()
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			// not YAML at all, Load falls back to the defaults
+			return []string{fmt.Sprintf("invalid YAML, the defaults are used instead: %v", err)}
+		}
+		for _, e := range typeErr.Errors {
+			problems = append(problems, e)
+		}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(cfg.Runtime.Type)) {
+	case "docker", "podman", "nerdctl", "auto":
+	default:
+		problems = append(problems, fmt.Sprintf("runtime.type %q is not one of docker, podman, nerdctl, auto", cfg.Runtime.Type))
+	}
+	if cfg.Runtime.PrecheckTTLDays < 0 {
+		problems = append(problems, fmt.Sprintf("runtime.precheck_ttl_days can't be negative, got %d", cfg.Runtime.PrecheckTTLDays))
+	}
+	for _, rt := range cfg.Runtime.AutoOrder {
+		if !slices.Contains([]string{"docker", "podman", "nerdctl"}, strings.ToLower(strings.TrimSpace(rt))) {
+			problems = append(problems, fmt.Sprintf("runtime.auto_order has unknown runtime %q", rt))
+		}
+	}
+	if cfg.Performance.PollRate < 1 {
+		problems = append(problems, fmt.Sprintf("performance.poll_rate must be at least 1 second, got %d", cfg.Performance.PollRate))
+	}
+	if cfg.Performance.StatsRate < 0 {
+		problems = append(problems, fmt.Sprintf("performance.stats_rate can't be negative, got %d", cfg.Performance.StatsRate))
+	}
+	if cfg.Performance.ParallelActions < 1 {
+		problems = append(problems, fmt.Sprintf("performance.parallel_actions must be at least 1, got %d", cfg.Performance.ParallelActions))
+	}
+	for _, name := range slices.Sorted(maps.Keys(cfg.Performance.ContainerStatsRates)) {
+		if rate := cfg.Performance.ContainerStatsRates[name]; rate < 0 {
+			problems = append(problems, fmt.Sprintf("performance.container_stats_rates.%s can't be negative, got %d", name, rate))
+		}
+	}
+	switch cfg.Session.StopOnQuit {
+	case "", "ask", "always", "never":
+	default:
+		problems = append(problems, fmt.Sprintf("session.stop_on_quit %q is not one of ask, always, never (ask is used)", cfg.Session.StopOnQuit))
+	}
+	if s := cfg.Exec.Shell; s != "" && s != "auto" && s != "ask" && !strings.HasPrefix(s, "/") {
+		problems = append(problems, fmt.Sprintf("exec.shell %q should be auto, ask or an absolute path", s))
+	}
+	switch cfg.Exec.Tmux {
+	case "", "off", "pane", "window":
+	default:
+		problems = append(problems, fmt.Sprintf("exec.tmux %q is not one of off, pane, window", cfg.Exec.Tmux))
+	}
+	if keys := cfg.Exec.DetachKeys; keys != "" && !detachKeysPattern.MatchString(keys) {
+		problems = append(problems, fmt.Sprintf("exec.detach_keys %q should be keys like ctrl-x,x, separated by commas", keys))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(cfg.Logs.Filters)) {
+		f := cfg.Logs.Filters[name]
+		for _, re := range append([]string{f.Include}, f.Exclude...) {
+			if _, err := regexp.Compile(re); err != nil {
+				problems = append(problems, fmt.Sprintf("logs.filters.%s: %v", name, err))
+			}
+		}
+	}
+
+	if cfg.Compose.ScanDepth < 0 {
+		problems = append(problems, fmt.Sprintf("compose.scan_depth can't be negative, got %d", cfg.Compose.ScanDepth))
+	}
+
+	switch strings.ToLower(strings.TrimSpace(cfg.Logging.Level)) {
+	case "", "off", "error", "info", "debug":
+	default:
+		problems = append(problems, fmt.Sprintf("logging.level %q is not one of off, error, info, debug", cfg.Logging.Level))
+	}
+	for i, cc := range cfg.Commands {
+		if strings.TrimSpace(cc.Key) == "" || strings.TrimSpace(cc.Command) == "" {
+			problems = append(problems, fmt.Sprintf("commands[%d] needs both a key and a command", i))
+			continue
+		}
+		if _, err := template.New(cc.Key).Parse(cc.Command); err != nil {
+			problems = append(problems, fmt.Sprintf("commands[%d] (%s): %v", i, cc.Key, err))
+		}
+	}
+	for i, t := range cfg.Templates {
+		if strings.TrimSpace(t.Name) == "" || strings.TrimSpace(t.Image) == "" {
+			problems = append(problems, fmt.Sprintf("templates[%d] needs both a name and an image", i))
+			continue
+		}
+		for _, text := range slices.Concat(t.Args, t.Env, t.Command) {
+			if _, err := template.New(t.Name).Parse(text); err != nil {
+				problems = append(problems, fmt.Sprintf("templates[%d] (%s): %v", i, t.Name, err))
+				break
+			}
+		}
+	}
+
+	if cfg.Alerts.CrashLoopRestarts < 1 {
+		problems = append(problems, fmt.Sprintf("alerts.crash_loop_restarts must be at least 1, got %d", cfg.Alerts.CrashLoopRestarts))
+	}
+	if cfg.Alerts.CrashLoopMinutes < 1 {
+		problems = append(problems, fmt.Sprintf("alerts.crash_loop_minutes must be at least 1, got %d", cfg.Alerts.CrashLoopMinutes))
+	}
+
+	switch strings.ToLower(strings.TrimSpace(cfg.Record.Format)) {
+	case "", "csv", "jsonl":
+	default:
+		problems = append(problems, fmt.Sprintf("record.format %q is not one of csv, jsonl", cfg.Record.Format))
+	}
+	if cfg.Record.MaxSizeMB < 0 || cfg.Record.MaxFiles < 0 {
+		problems = append(problems, "record.max_size_mb and record.max_files can't be negative")
+	}
+
+	switch strings.ToLower(strings.TrimSpace(cfg.Metrics.Sink)) {
+	case "":
+	case "influx", "otlp":
+		if cfg.Metrics.URL == "" {
+			problems = append(problems, fmt.Sprintf("metrics.sink %s needs a metrics.url", cfg.Metrics.Sink))
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("metrics.sink %q is not one of influx, otlp", cfg.Metrics.Sink))
+	}
+
+	for i, r := range cfg.Registries {
+		if strings.TrimSpace(r.Host) == "" {
+			problems = append(problems, fmt.Sprintf("registries[%d] needs a host", i))
+		}
+	}
+
+	names := make(map[string]bool)
+	for i, r := range cfg.Remotes {
+		switch {
+		case strings.TrimSpace(r.Name) == "":
+			problems = append(problems, fmt.Sprintf("remotes[%d] needs a name", i))
+		case names[r.Name]:
+			problems = append(problems, fmt.Sprintf("remotes[%d]: there's already a remote called %q", i, r.Name))
+		}
+		names[r.Name] = true
+		if strings.TrimSpace(r.Host) == "" {
+			problems = append(problems, fmt.Sprintf("remotes[%d] needs a host", i))
+		}
+		if r.Port < 0 || r.Port > 65535 {
+			problems = append(problems, fmt.Sprintf("remotes[%d].port %d is not a port", i, r.Port))
+		}
+	}
+	if cfg.Runtime.Remote != "" && cfg.Remote(cfg.Runtime.Remote) == nil {
+		problems = append(problems, fmt.Sprintf("runtime.remote %q is not one of the remotes", cfg.Runtime.Remote))
+	}
+
+	problems = append(problems, validateProfiles(cfg)...)
+
+	if tls := cfg.Runtime.TLS; tls.Enabled() {
+		if (tls.Cert == "") != (tls.Key == "") {
+			problems = append(problems, "runtime.tls needs both cert and key, or neither")
+		}
+		switch strings.ToLower(strings.TrimSpace(cfg.Runtime.Type)) {
+		case "docker", "auto":
+		default:
+			problems = append(problems, fmt.Sprintf("runtime.tls only works with docker, not %s", cfg.Runtime.Type))
+		}
+	}
+
+	return problems
+}
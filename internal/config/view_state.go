@@ -0,0 +1,64 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ViewState is how the TUI was left: sorting, view mode and which compose
+// projects were expanded, restored on the next start
+type ViewState struct {
+	SortBy      string `yaml:"sort_by"` // column name, e.g. "cpu"
+	SortAsc     bool   `yaml:"sort_asc"`
+	ThenSortBy  string `yaml:"then_sort_by,omitempty"` // secondary column, ascending
+	ComposeView bool   `yaml:"compose_view"`
+	// projects not listed start expanded
+	ExpandedProjects map[string]bool `yaml:"expanded_projects"`
+}
+
+// GetViewStatePath returns where the view state lives
+// ($XDG_CACHE_HOME/dockmate/view.yml or ~/.cache/dockmate/view.yml)
+func GetViewStatePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "dockmate", "view.yml"), nil
+}
+
+// LoadViewState reads the view state, a missing or broken file is the zero state
+func LoadViewState() ViewState {
+	var state ViewState
+
+	path, err := GetViewStatePath()
+	if err != nil {
+		return state
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return state
+	}
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return ViewState{}
+	}
+	return state
+}
+
+// Save writes the view state
+func (s ViewState) Save() error {
+	path, err := GetViewStatePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,89 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+// AutoUpdateLabel opts a podman container into `podman auto-update`, with
+// the policy as its value: registry or local. Only containers run by a
+// systemd unit are updated, the unit restarts them on the new image.
+const AutoUpdateLabel = "io.containers.autoupdate"
+
+// AutoUpdatePolicy is the container's auto-update policy, empty if it has none
+func AutoUpdatePolicy(c Container) string {
+	return c.Labels[AutoUpdateLabel]
+}
+
+// AutoUpdate is a line of `podman auto-update`'s report
+type AutoUpdate struct {
+	ContainerID string
+	Name        string
+	Image       string
+	Policy      string
+	Unit        string
+	Updated     string // pending on a dry run with a newer image, else false, true, failed or rolled back
+}
+
+// Pending is whether a dry run found a newer image
+func (u AutoUpdate) Pending() bool {
+	return u.Updated == "pending"
+}
+
+const autoUpdateFormat = "{{.ContainerID}}\t{{.ContainerName}}\t{{.Image}}\t{{.Policy}}\t{{.Unit}}\t{{.Updated}}"
+
+// AutoUpdateDryRun asks podman which auto-update containers have a newer
+// image, without updating them
+func AutoUpdateDryRun() ([]AutoUpdate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "podman", "auto-update", "--dry-run", "--format", autoUpdateFormat)
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	logging.Command(cmd, start, err)
+	if err != nil {
+		return nil, fmt.Errorf("podman auto-update --dry-run: %s", strings.TrimSpace(string(output)))
+	}
+	return parseAutoUpdates(string(output)), nil
+}
+
+// AutoUpdateApply runs `podman auto-update`, streaming its output. podman
+// rolls a unit back to the old image if it fails to start on the new one.
+func AutoUpdateApply(onLine func(string)) ([]AutoUpdate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	var report []string
+	err := runStreaming(ctx, "", func(line string) {
+		report = append(report, line)
+		onLine(line)
+	}, "podman", "auto-update", "--format", autoUpdateFormat)
+	return parseAutoUpdates(strings.Join(report, "\n")), err
+}
+
+// parseAutoUpdates reads the report lines, skipping the pull progress and
+// anything else that isn't one
+func parseAutoUpdates(output string) []AutoUpdate {
+	var updates []AutoUpdate
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) != 6 || fields[0] == "" {
+			continue
+		}
+		updates = append(updates, AutoUpdate{
+			ContainerID: fields[0],
+			Name:        fields[1],
+			Image:       fields[2],
+			Policy:      fields[3],
+			Unit:        fields[4],
+			Updated:     fields[5],
+		})
+	}
+	return updates
+}
@@ -0,0 +1,28 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAutoUpdates(t *testing.T) {
+	out := "Trying to pull quay.io/app/web:latest...\n" +
+		"a1b2c3d4e5f6\tweb\tquay.io/app/web:latest\tregistry\tcontainer-web.service\tpending\n" +
+		"f6e5d4c3b2a1\tdb\tdocker.io/library/postgres:16\tregistry\tdb.service\tfalse\n" +
+		"\n"
+
+	updates := parseAutoUpdates(out)
+	require.Len(t, updates, 2)
+	assert.Equal(t, AutoUpdate{
+		ContainerID: "a1b2c3d4e5f6",
+		Name:        "web",
+		Image:       "quay.io/app/web:latest",
+		Policy:      "registry",
+		Unit:        "container-web.service",
+		Updated:     "pending",
+	}, updates[0])
+	assert.True(t, updates[0].Pending())
+	assert.False(t, updates[1].Pending())
+}
@@ -6,26 +6,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"os/exec"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/shubh-io/dockmate/internal/config"
+	"github.com/shubh-io/dockmate/internal/logging"
 )
 
-// runtimeBin returns the configured container runtime binary name (podman or docker).
-
+// runtimeBin returns the configured container runtime binary name (docker, podman or nerdctl).
 func runtimeBin() string {
-	cfg, err := config.Load()
-	if err != nil {
-		return "docker"
-	}
-
-	rt := strings.TrimSpace(strings.ToLower(cfg.Runtime.Type))
-	if rt == "podman" {
-		return "podman"
-	}
-
-	return "docker"
+	return resolveRuntime(runtimeConfig())
 }
 
 // GetContainerStats grabs cpu/mem/pids for a container
@@ -58,12 +48,36 @@ func GetContainerStats(containerID string) (cpu string, mem string, pids string,
 	return s.CPUPerc, s.MemPerc, s.PIDs, s.NetIO, s.BlockIO, nil
 }
 
-func GetLogs(containerID string) ([]string, error) {
+// LogOptions narrow what the logs calls return. The zero value is their
+// usual tail without timestamps.
+type LogOptions struct {
+	Timestamps bool          // prefix each line with the time the runtime got it
+	Since      time.Duration // only lines from this long ago on, 0 for no limit
+}
+
+// sinceTail caps the lines of a since window, a busy container can log a lot in 24h
+const sinceTail = 1000
+
+// args are the flags for `logs`, tail being the lines wanted without a since window
+func (o LogOptions) args(tail int) []string {
+	var args []string
+	if o.Timestamps {
+		args = append(args, "--timestamps")
+	}
+	if o.Since > 0 {
+		args = append(args, "--since", o.Since.String())
+		tail = sinceTail
+	}
+	return append(args, "--tail", strconv.Itoa(tail))
+}
+
+func GetLogs(containerID string, opts LogOptions) ([]string, error) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, runtimeBin(), "logs", "--tail", "100", containerID)
+	args := append([]string{"logs"}, opts.args(100)...)
+	cmd := exec.CommandContext(ctx, runtimeBin(), append(args, containerID)...)
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
 		return nil, err
@@ -99,7 +113,18 @@ func GetLogs(containerID string) ([]string, error) {
 	return out, nil
 }
 
+// ListContainers lists all containers with the stats of the running ones
 func ListContainers() ([]Container, error) {
+	return listContainers(true)
+}
+
+// ListContainersWithoutStats skips the stats call, which is the slow part on
+// big hosts. The TUI fetches stats on its own schedule, see GetAllContainerStats.
+func ListContainersWithoutStats() ([]Container, error) {
+	return listContainers(false)
+}
+
+func listContainers(withStats bool) ([]Container, error) {
 	// 30 sec timeout since we fetch stats for each running container
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -110,7 +135,9 @@ func ListContainers() ([]Container, error) {
 	// Docker returns newline-delimited JSON
 	cmd = exec.CommandContext(ctx, runtime, "ps", "--format", "{{json .}}", "--all")
 
+	start := time.Now()
 	output, err := cmd.Output()
+	logging.Command(cmd, start, err)
 	if err != nil {
 		return nil, err
 	}
@@ -121,13 +148,14 @@ func ListContainers() ([]Container, error) {
 	if runtime == "podman" {
 		// Podman format - JSON array
 		type podmanEntry struct {
-			Id     string            `json:"Id"`
-			Names  []string          `json:"Names"`
-			Image  string            `json:"Image"`
-			Status string            `json:"Status"`
-			State  string            `json:"State"`
-			Labels map[string]string `json:"Labels"`
-			Ports  []struct {
+			Id      string            `json:"Id"`
+			Names   []string          `json:"Names"`
+			Image   string            `json:"Image"`
+			Status  string            `json:"Status"`
+			State   string            `json:"State"`
+			Labels  map[string]string `json:"Labels"`
+			Created int64             `json:"Created"`
+			Ports   []struct {
 				HostPort      int    `json:"host_port"`
 				ContainerPort int    `json:"container_port"`
 				Protocol      string `json:"protocol"`
@@ -177,6 +205,8 @@ func ListContainers() ([]Container, error) {
 					ComposeService:       e.Labels["com.docker.compose.service"],
 					ComposeDirectory:     e.Labels["com.docker.compose.project.working_dir"],
 					ComposeFileDirectory: (e.Labels["com.docker.compose.project.working_dir"] + "/" + e.Labels["com.docker.compose.project.config_files"]),
+					Labels:               e.Labels,
+					CreatedAt:            unixTime(e.Created),
 				}
 
 				if state == "running" {
@@ -231,6 +261,8 @@ func ListContainers() ([]Container, error) {
 					ComposeService:       e.Labels["com.docker.compose.service"],
 					ComposeDirectory:     e.Labels["com.docker.compose.project.working_dir"],
 					ComposeFileDirectory: (e.Labels["com.docker.compose.project.working_dir"] + "/" + e.Labels["com.docker.compose.project.config_files"]),
+					Labels:               e.Labels,
+					CreatedAt:            unixTime(e.Created),
 				}
 
 				if state == "running" {
@@ -244,13 +276,16 @@ func ListContainers() ([]Container, error) {
 			}
 		}
 	} else {
+		// nerdctl prints the same shape, except it may leave out State
 		type dockerEntry struct {
-			ID     string `json:"ID"`
-			Names  string `json:"Names"`
-			Image  string `json:"Image"`
-			Status string `json:"Status"`
-			Ports  string `json:"Ports"`
-			Labels string `json:"Labels"`
+			ID        string `json:"ID"`
+			Names     string `json:"Names"`
+			Image     string `json:"Image"`
+			Status    string `json:"Status"`
+			State     string `json:"State"`
+			Ports     string `json:"Ports"`
+			Labels    string `json:"Labels"`
+			CreatedAt string `json:"CreatedAt"`
 		}
 
 		scanner := bufio.NewScanner(strings.NewReader(string(output)))
@@ -265,26 +300,18 @@ func ListContainers() ([]Container, error) {
 				return nil, fmt.Errorf("parsing docker output: %w", err)
 			}
 
+			labels := parseLabels(e.Labels)
+
 			names := []string{}
 			if e.Names != "" {
 				for _, n := range strings.Split(e.Names, ",") {
 					names = append(names, strings.TrimSpace(n))
 				}
+			} else if n := labels[nerdctlNameLabel]; n != "" {
+				names = append(names, n)
 			}
 
-			st := strings.ToLower(strings.TrimSpace(e.Status))
-			state := "unknown"
-			if strings.HasPrefix(st, "up") {
-				state = "running"
-			} else if strings.HasPrefix(st, "paused") || strings.Contains(st, "paused") {
-				state = "paused"
-			} else if strings.Contains(st, "restarting") {
-				state = "restarting"
-			} else if strings.HasPrefix(st, "exited") || strings.Contains(st, "exited") || strings.Contains(st, "dead") {
-				state = "exited"
-			} else if strings.HasPrefix(st, "created") {
-				state = "created"
-			}
+			state := normalizeState(e.State, e.Status)
 
 			container := Container{
 				ID:                   e.ID,
@@ -293,10 +320,12 @@ func ListContainers() ([]Container, error) {
 				Status:               e.Status,
 				State:                state,
 				Ports:                e.Ports,
-				ComposeProject:       parseLabels(e.Labels)["com.docker.compose.project"],
-				ComposeService:       parseLabels(e.Labels)["com.docker.compose.service"],
-				ComposeDirectory:     parseLabels(e.Labels)["com.docker.compose.project.working_dir"],
-				ComposeFileDirectory: parseLabels(e.Labels)["com.docker.compose.project.config_files"],
+				ComposeProject:       labels["com.docker.compose.project"],
+				ComposeService:       labels["com.docker.compose.service"],
+				ComposeDirectory:     labels["com.docker.compose.project.working_dir"],
+				ComposeFileDirectory: labels["com.docker.compose.project.config_files"],
+				Labels:               labels,
+				CreatedAt:            parseCreatedAt(e.CreatedAt),
 			}
 
 			if state == "running" {
@@ -311,13 +340,14 @@ func ListContainers() ([]Container, error) {
 	}
 
 	// Fetch stats for all running containers in ONE call
-	if len(runningIDs) > 0 {
+	if withStats && len(runningIDs) > 0 {
 		statsMap, err := GetAllContainerStats(runningIDs)
 		if err == nil {
 			for i := range out {
 				if stats, ok := statsMap[out[i].ID]; ok {
 					out[i].CPU = stats.CPU
 					out[i].Memory = stats.Memory
+					out[i].MemUsage = stats.MemUsage
 					out[i].NetIO = stats.NetIO
 					out[i].BlockIO = stats.BlockIO
 				}
@@ -343,7 +373,7 @@ func GetAllContainerStats(containerIDs []string) (map[string]ContainerStats, err
 	args := []string{"stats", "--no-stream", "--format"}
 
 	if runtime == "podman" {
-		args = append(args, `{"ID":"{{.ID}}","CPUPerc":"{{.CPUPerc}}","MemPerc":"{{.MemPerc}}","NetIO":"{{.NetIO}}","BlockIO":"{{.BlockIO}}"}`)
+		args = append(args, `{"ID":"{{.ID}}","CPUPerc":"{{.CPUPerc}}","MemPerc":"{{.MemPerc}}","MemUsage":"{{.MemUsage}}","NetIO":"{{.NetIO}}","BlockIO":"{{.BlockIO}}"}`)
 	} else {
 		// for docker
 		args = append(args, "{{json .}}")
@@ -352,6 +382,7 @@ func GetAllContainerStats(containerIDs []string) (map[string]ContainerStats, err
 	args = append(args, containerIDs...)
 
 	cmd := exec.CommandContext(ctx, runtime, args...)
+	start := time.Now()
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
 		return nil, err
@@ -365,11 +396,12 @@ func GetAllContainerStats(containerIDs []string) (map[string]ContainerStats, err
 	scanner := bufio.NewScanner(stdout)
 	statsMap := make(map[string]ContainerStats)
 	type statsEntry struct {
-		ID      string `json:"ID"`
-		CPUPerc string `json:"CPUPerc"`
-		MemPerc string `json:"MemPerc"`
-		NetIO   string `json:"NetIO"`
-		BlockIO string `json:"BlockIO"`
+		ID       string `json:"ID"`
+		CPUPerc  string `json:"CPUPerc"`
+		MemPerc  string `json:"MemPerc"`
+		MemUsage string `json:"MemUsage"`
+		NetIO    string `json:"NetIO"`
+		BlockIO  string `json:"BlockIO"`
 	}
 
 	for scanner.Scan() {
@@ -383,8 +415,9 @@ func GetAllContainerStats(containerIDs []string) (map[string]ContainerStats, err
 			continue // skip weird lines
 		}
 
+		// podman and nerdctl may print a shorter ID than `ps` gave us
 		mapID := s.ID
-		if runtime == "podman" {
+		if runtime != "docker" {
 			for _, longID := range containerIDs {
 				if strings.HasPrefix(longID, s.ID) {
 					mapID = longID
@@ -394,11 +427,12 @@ func GetAllContainerStats(containerIDs []string) (map[string]ContainerStats, err
 		}
 
 		statsMap[mapID] = ContainerStats{
-			ID:      mapID,
-			CPU:     s.CPUPerc,
-			Memory:  s.MemPerc,
-			NetIO:   s.NetIO,
-			BlockIO: s.BlockIO,
+			ID:       mapID,
+			CPU:      s.CPUPerc,
+			Memory:   s.MemPerc,
+			MemUsage: s.MemUsage,
+			NetIO:    s.NetIO,
+			BlockIO:  s.BlockIO,
 		}
 	}
 
@@ -407,7 +441,9 @@ func GetAllContainerStats(containerIDs []string) (map[string]ContainerStats, err
 		return nil, err
 	}
 
-	if err := cmd.Wait(); err != nil {
+	err = cmd.Wait()
+	logging.Command(cmd, start, err)
+	if err != nil {
 		return nil, err
 	}
 
@@ -419,7 +455,41 @@ func DoAction(action, containerID string) error {
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, runtimeBin(), action, containerID)
-	return cmd.Run()
+	start := time.Now()
+	err := cmd.Run()
+	logging.Command(cmd, start, err)
+	return err
+}
+
+// RemoveContainer removes a container. force stops a running one first, a
+// plain rm refuses it; volumes also removes its anonymous volumes.
+func RemoveContainer(containerID string, force, volumes bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, runtimeBin(), removeArgs(containerID, force, volumes)...)
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	logging.Command(cmd, start, err)
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return fmt.Errorf("removing: %s", msg)
+	}
+	return nil
+}
+
+func removeArgs(containerID string, force, volumes bool) []string {
+	args := []string{"rm"}
+	if force {
+		args = append(args, "--force")
+	}
+	if volumes {
+		args = append(args, "--volumes")
+	}
+	return append(args, containerID)
 }
 
 type ComposeCommand struct {
@@ -439,6 +509,8 @@ func GetComposeCommand() ComposeCommand {
 			return ComposeCommand{Binary: path, SubCommand: ""}
 		}
 
+	} else if runtimeBin() == "nerdctl" {
+		return ComposeCommand{Binary: "nerdctl", SubCommand: "compose"}
 	} else {
 		if path, err := exec.LookPath("podman-compose"); err == nil {
 			return ComposeCommand{Binary: path, SubCommand: ""}
@@ -453,6 +525,11 @@ func GetComposeCommand() ComposeCommand {
 }
 
 func RunComposeAction(action, project, workingDir string) error {
+	return RunComposeActionWith(action, project, workingDir, ComposeOptions{})
+}
+
+// RunComposeActionWith is RunComposeAction with files, env files and profiles
+func RunComposeActionWith(action, project, workingDir string, opts ComposeOptions) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Second)
 	defer cancel()
 
@@ -467,6 +544,7 @@ func RunComposeAction(action, project, workingDir string) error {
 		args = append(args, "-p", project)
 	}
 
+	args = append(args, opts.args()...)
 	args = append(args, action)
 
 	switch action {
@@ -482,7 +560,9 @@ func RunComposeAction(action, project, workingDir string) error {
 		cmd.Dir = workingDir
 	}
 
+	start := time.Now()
 	output, err := cmd.CombinedOutput()
+	logging.Command(cmd, start, err)
 	if err != nil {
 
 		return fmt.Errorf("compose error (%s %s): %v\nOutput: %s", cmdConfig.Binary, action, err, string(output))
@@ -492,7 +572,7 @@ func RunComposeAction(action, project, workingDir string) error {
 }
 
 // GetComposeLogs runs `compose logs` for a given project and returns the output lines
-func GetComposeLogs(project, workingDir string) ([]string, error) {
+func GetComposeLogs(project, workingDir string, opts LogOptions) ([]string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -504,7 +584,8 @@ func GetComposeLogs(project, workingDir string) ([]string, error) {
 	if project != "" {
 		args = append(args, "-p", project)
 	}
-	args = append(args, "logs", "--tail", "15")
+	args = append(args, "logs")
+	args = append(args, opts.args(15)...)
 
 	cmd := exec.CommandContext(ctx, cmdConfig.Binary, args...)
 	if workingDir != "" {
@@ -534,8 +615,16 @@ func GetComposeLogs(project, workingDir string) ([]string, error) {
 }
 
 // FetchComposeProjects fetches all Docker/Podman Compose projects with their containers
-
 func FetchComposeProjects() (map[string]*ComposeProject, error) {
+	return fetchComposeProjects(true)
+}
+
+// FetchComposeProjectsWithoutStats is FetchComposeProjects without the stats call
+func FetchComposeProjectsWithoutStats() (map[string]*ComposeProject, error) {
+	return fetchComposeProjects(false)
+}
+
+func fetchComposeProjects(withStats bool) (map[string]*ComposeProject, error) {
 	// 30 sec timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -555,7 +644,9 @@ func FetchComposeProjects() (map[string]*ComposeProject, error) {
 			"--format", "{{json .}}")
 	}
 
+	start := time.Now()
 	output, err := cmd.Output()
+	logging.Command(cmd, start, err)
 	if err != nil {
 		return nil, err
 	}
@@ -566,13 +657,14 @@ func FetchComposeProjects() (map[string]*ComposeProject, error) {
 	if runtime == "podman" {
 		// Podman format - json array
 		type podmanEntry struct {
-			Id     string            `json:"Id"`
-			Names  []string          `json:"Names"`
-			Image  string            `json:"Image"`
-			Status string            `json:"Status"`
-			State  string            `json:"State"`
-			Labels map[string]string `json:"Labels"`
-			Ports  []struct {
+			Id      string            `json:"Id"`
+			Names   []string          `json:"Names"`
+			Image   string            `json:"Image"`
+			Status  string            `json:"Status"`
+			State   string            `json:"State"`
+			Labels  map[string]string `json:"Labels"`
+			Created int64             `json:"Created"`
+			Ports   []struct {
 				HostPort      int    `json:"host_port"`
 				ContainerPort int    `json:"container_port"`
 				Protocol      string `json:"protocol"`
@@ -629,6 +721,8 @@ func FetchComposeProjects() (map[string]*ComposeProject, error) {
 				// ComposeNumber:  containerNumber,
 				ComposeDirectory:     workingDir,
 				ComposeFileDirectory: (workingDir + "/" + configFile),
+				Labels:               e.Labels,
+				CreatedAt:            unixTime(e.Created),
 			}
 
 			if state == "running" {
@@ -688,21 +782,11 @@ func FetchComposeProjects() (map[string]*ComposeProject, error) {
 				for _, n := range strings.Split(e.Names, ",") {
 					names = append(names, strings.TrimSpace(n))
 				}
+			} else if n := labels[nerdctlNameLabel]; n != "" {
+				names = append(names, n)
 			}
 
-			st := strings.ToLower(strings.TrimSpace(e.Status))
-			state := "unknown"
-			if strings.HasPrefix(st, "up") {
-				state = "running"
-			} else if strings.HasPrefix(st, "paused") || strings.Contains(st, "paused") {
-				state = "paused"
-			} else if strings.Contains(st, "restarting") {
-				state = "restarting"
-			} else if strings.HasPrefix(st, "exited") || strings.Contains(st, "exited") || strings.Contains(st, "dead") {
-				state = "exited"
-			} else if strings.HasPrefix(st, "created") {
-				state = "created"
-			}
+			state := normalizeState(e.State, e.Status)
 
 			container := Container{
 				ID:                   e.ID,
@@ -716,6 +800,8 @@ func FetchComposeProjects() (map[string]*ComposeProject, error) {
 				ComposeNumber:        containerNumber,
 				ComposeDirectory:     labels["com.docker.compose.project.working_dir"],
 				ComposeFileDirectory: labels["com.docker.compose.project.config_files"],
+				Labels:               labels,
+				CreatedAt:            parseCreatedAt(e.CreatedAt),
 			}
 
 			if state == "running" {
@@ -744,7 +830,7 @@ func FetchComposeProjects() (map[string]*ComposeProject, error) {
 		}
 	}
 
-	if len(runningIDs) > 0 {
+	if withStats && len(runningIDs) > 0 {
 		statsMap, err := GetAllContainerStats(runningIDs)
 		if err == nil {
 
@@ -753,6 +839,7 @@ func FetchComposeProjects() (map[string]*ComposeProject, error) {
 					if stats, ok := statsMap[project.Containers[i].ID]; ok {
 						project.Containers[i].CPU = stats.CPU
 						project.Containers[i].Memory = stats.Memory
+						project.Containers[i].MemUsage = stats.MemUsage
 						project.Containers[i].NetIO = stats.NetIO
 						project.Containers[i].BlockIO = stats.BlockIO
 					}
@@ -791,21 +878,46 @@ func parseLabels(labelsStr string) map[string]string {
 	}
 
 	parts := strings.Split(labelsStr, ",")
+	lastKey := ""
 	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		if part == "" {
-			continue
-		}
-
 		idx := strings.Index(part, "=")
 		if idx == -1 {
+			// a comma inside the previous value, e.g. nerdctl/networks=["a","b"]
+			if lastKey != "" {
+				labels[lastKey] += "," + part
+			}
 			continue
 		}
 
 		key := strings.TrimSpace(part[:idx])
 		value := strings.TrimSpace(part[idx+1:])
+		if key == "" {
+			continue
+		}
 		labels[key] = value
+		lastKey = key
 	}
 
 	return labels
 }
+
+// parseCreatedAt reads docker's CreatedAt format, e.g. "2024-05-01 12:34:56 +0000 UTC"
+func parseCreatedAt(s string) time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse("2006-01-02 15:04:05 -0700 MST", s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// podman reports Created as unix seconds
+func unixTime(sec int64) time.Time {
+	if sec <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
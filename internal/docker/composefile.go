@@ -0,0 +1,244 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// composeService is the subset of a compose service ComposeFile fills in,
+// in the order the keys are written
+type composeService struct {
+	Image         string            `yaml:"image"`
+	ContainerName string            `yaml:"container_name,omitempty"`
+	Entrypoint    []string          `yaml:"entrypoint,omitempty"`
+	Command       []string          `yaml:"command,omitempty"`
+	User          string            `yaml:"user,omitempty"`
+	WorkingDir    string            `yaml:"working_dir,omitempty"`
+	Ports         []string          `yaml:"ports,omitempty"`
+	Environment   []string          `yaml:"environment,omitempty"`
+	Volumes       []string          `yaml:"volumes,omitempty"`
+	Tmpfs         []string          `yaml:"tmpfs,omitempty"`
+	NetworkMode   string            `yaml:"network_mode,omitempty"`
+	Networks      []string          `yaml:"networks,omitempty"`
+	ExtraHosts    []string          `yaml:"extra_hosts,omitempty"`
+	Restart       string            `yaml:"restart,omitempty"`
+	Privileged    bool              `yaml:"privileged,omitempty"`
+	CapAdd        []string          `yaml:"cap_add,omitempty"`
+	CapDrop       []string          `yaml:"cap_drop,omitempty"`
+	Tty           bool              `yaml:"tty,omitempty"`
+	StdinOpen     bool              `yaml:"stdin_open,omitempty"`
+	Labels        map[string]string `yaml:"labels,omitempty"`
+}
+
+type composeExternal struct {
+	External bool `yaml:"external"`
+}
+
+type composeFile struct {
+	Services map[string]composeService  `yaml:"services"`
+	Volumes  map[string]composeExternal `yaml:"volumes,omitempty"`
+	Networks map[string]composeExternal `yaml:"networks,omitempty"`
+}
+
+// ComposeFile turns a standalone container into a compose file with one
+// service, from the same inspect data Recreate uses. Named volumes and
+// networks are marked external so the stack picks up the existing ones.
+// It returns the service name with the YAML.
+func ComposeFile(containerID string) (string, []byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	spec, imageDefaults, err := inspectWithImage(ctx, containerID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	name, file := composeFor(spec, imageDefaults)
+	out, err := marshalCompose(file)
+	if err != nil {
+		return "", nil, err
+	}
+	return name, out, nil
+}
+
+// marshalCompose indents by 2 like hand-written compose files, yaml.Marshal uses 4
+func marshalCompose(file composeFile) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(file); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// composeFor mirrors recreateArgs: settings that repeat the image's defaults are left out
+func composeFor(spec containerSpec, image imageConfig) (string, composeFile) {
+	containerName := strings.TrimPrefix(spec.Name, "/")
+	svc := composeService{
+		Image:         spec.Config.Image,
+		ContainerName: containerName,
+		Tty:           spec.Config.Tty,
+		StdinOpen:     spec.Config.OpenStdin,
+		Privileged:    spec.HostConfig.Privileged,
+		CapAdd:        spec.HostConfig.CapAdd,
+		CapDrop:       spec.HostConfig.CapDrop,
+		ExtraHosts:    spec.HostConfig.ExtraHosts,
+	}
+	file := composeFile{}
+
+	if !equalStrings(spec.Config.Entrypoint, image.Entrypoint) {
+		svc.Entrypoint = escapeCompose(spec.Config.Entrypoint)
+		svc.Command = escapeCompose(spec.Config.Cmd)
+	} else if !equalStrings(spec.Config.Cmd, image.Cmd) {
+		svc.Command = escapeCompose(spec.Config.Cmd)
+	}
+	if spec.Config.User != image.User {
+		svc.User = spec.Config.User
+	}
+	if spec.Config.WorkingDir != image.WorkingDir {
+		svc.WorkingDir = spec.Config.WorkingDir
+	}
+
+	imageEnv := make(map[string]bool)
+	for _, e := range image.Env {
+		imageEnv[e] = true
+	}
+	for _, e := range spec.Config.Env {
+		if !imageEnv[e] {
+			svc.Environment = append(svc.Environment, e)
+		}
+	}
+	svc.Environment = escapeCompose(svc.Environment)
+
+	for k, v := range spec.Config.Labels {
+		if iv, ok := image.Labels[k]; (ok && iv == v) || strings.HasPrefix(k, "com.docker.compose.") {
+			continue
+		}
+		if svc.Labels == nil {
+			svc.Labels = make(map[string]string)
+		}
+		svc.Labels[k] = strings.ReplaceAll(v, "$", "$$")
+	}
+
+	ports := make([]string, 0, len(spec.HostConfig.PortBindings))
+	for port := range spec.HostConfig.PortBindings {
+		ports = append(ports, port)
+	}
+	sort.Strings(ports)
+	for _, port := range ports {
+		for _, b := range spec.HostConfig.PortBindings[port] {
+			switch {
+			case b.HostPort == "":
+				svc.Ports = append(svc.Ports, port)
+			case b.HostIP != "" && b.HostIP != "0.0.0.0":
+				svc.Ports = append(svc.Ports, fmt.Sprintf("%s:%s:%s", b.HostIP, b.HostPort, port))
+			default:
+				svc.Ports = append(svc.Ports, fmt.Sprintf("%s:%s", b.HostPort, port))
+			}
+		}
+	}
+
+	for _, mnt := range spec.Mounts {
+		src := mnt.Source
+		switch mnt.Type {
+		case "volume":
+			src = mnt.Name
+			if file.Volumes == nil {
+				file.Volumes = make(map[string]composeExternal)
+			}
+			file.Volumes[mnt.Name] = composeExternal{External: true}
+		case "bind":
+		default:
+			continue
+		}
+		v := src + ":" + mnt.Destination
+		if !mnt.RW {
+			v += ":ro"
+		}
+		svc.Volumes = append(svc.Volumes, v)
+	}
+
+	for dest, opts := range spec.HostConfig.Tmpfs {
+		if opts != "" {
+			dest += ":" + opts
+		}
+		svc.Tmpfs = append(svc.Tmpfs, dest)
+	}
+	sort.Strings(svc.Tmpfs)
+
+	switch rp := spec.HostConfig.RestartPolicy; rp.Name {
+	case "", "no":
+	case "on-failure":
+		svc.Restart = "on-failure"
+		if rp.MaximumRetryCount > 0 {
+			svc.Restart = fmt.Sprintf("on-failure:%d", rp.MaximumRetryCount)
+		}
+	default:
+		svc.Restart = rp.Name
+	}
+
+	switch nm := spec.HostConfig.NetworkMode; {
+	case nm == "host" || nm == "none" || strings.HasPrefix(nm, "container:"):
+		svc.NetworkMode = nm
+	default:
+		for n := range spec.NetworkSettings.Networks {
+			if n == "bridge" {
+				continue
+			}
+			svc.Networks = append(svc.Networks, n)
+		}
+		if len(svc.Networks) == 0 && nm != "" && nm != "default" && nm != "bridge" {
+			svc.Networks = append(svc.Networks, nm)
+		}
+		sort.Strings(svc.Networks)
+		for _, n := range svc.Networks {
+			if file.Networks == nil {
+				file.Networks = make(map[string]composeExternal)
+			}
+			file.Networks[n] = composeExternal{External: true}
+		}
+	}
+
+	service := serviceName(containerName)
+	file.Services = map[string]composeService{service: svc}
+	return service, file
+}
+
+// escapeCompose doubles $ so compose doesn't try to interpolate the values
+func escapeCompose(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = strings.ReplaceAll(v, "$", "$$")
+	}
+	return out
+}
+
+// serviceName makes a container name a valid compose service name
+func serviceName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-', r == '.':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	if b.Len() == 0 {
+		return "app"
+	}
+	return b.String()
+}
@@ -0,0 +1,49 @@
+package docker
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComposeFor(t *testing.T) {
+	var specs []containerSpec
+	require.NoError(t, json.Unmarshal([]byte(inspectFixture), &specs))
+	spec := specs[0]
+	spec.Config.Env = append(spec.Config.Env, "PRICE=$5")
+
+	image := imageConfig{
+		Env:        []string{"PATH=/usr/bin", "NGINX_VERSION=1.25.0"},
+		Cmd:        []string{"nginx", "-g", "daemon off;"},
+		Entrypoint: []string{"/docker-entrypoint.sh"},
+		Labels:     map[string]string{"maintainer": "NGINX"},
+	}
+
+	name, file := composeFor(spec, image)
+	assert.Equal(t, "web", name)
+	assert.Equal(t, composeService{
+		Image:         "nginx:1.25",
+		ContainerName: "web",
+		Ports:         []string{"127.0.0.1:8443:443/tcp", "8080:80/tcp"},
+		Environment:   []string{"APP_MODE=prod", "PRICE=$$5"},
+		Volumes:       []string{"webdata:/data", "/srv/conf:/etc/nginx/conf.d:ro"},
+		Networks:      []string{"shop_default"},
+		Restart:       "unless-stopped",
+		Labels:        map[string]string{"owner": "alice"},
+	}, file.Services["web"])
+	assert.Equal(t, map[string]composeExternal{"webdata": {External: true}}, file.Volumes)
+	assert.Equal(t, map[string]composeExternal{"shop_default": {External: true}}, file.Networks)
+
+	out, err := marshalCompose(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "services:\n  web:\n    image: nginx:1.25\n")
+
+	spec.HostConfig.NetworkMode = "host"
+	spec.Name = "/My App"
+	name, file = composeFor(spec, image)
+	assert.Equal(t, "my-app", name)
+	assert.Equal(t, "host", file.Services[name].NetworkMode)
+	assert.Empty(t, file.Networks)
+}
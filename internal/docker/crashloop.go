@@ -0,0 +1,63 @@
+package docker
+
+import "time"
+
+// RestartTracker remembers when restart counts went up, inspect only has the
+// total. Restarts from before a container was first seen have no time and are
+// not counted.
+type RestartTracker struct {
+	counts   map[string]int
+	restarts map[string][]time.Time
+}
+
+// maxTrackedRestarts caps the times kept per container
+const maxTrackedRestarts = 100
+
+func NewRestartTracker() *RestartTracker {
+	return &RestartTracker{
+		counts:   make(map[string]int),
+		restarts: make(map[string][]time.Time),
+	}
+}
+
+// Observe records a container's restart count as seen at at
+func (t *RestartTracker) Observe(id string, count int, at time.Time) {
+	prev, seen := t.counts[id]
+	t.counts[id] = count
+	if !seen || count <= prev {
+		return
+	}
+	times := t.restarts[id]
+	for i := prev; i < count; i++ {
+		times = append(times, at)
+	}
+	if len(times) > maxTrackedRestarts {
+		times = times[len(times)-maxTrackedRestarts:]
+	}
+	t.restarts[id] = times
+}
+
+// Recent counts the restarts seen within window before now
+func (t *RestartTracker) Recent(id string, window time.Duration, now time.Time) int {
+	n := 0
+	for _, at := range t.restarts[id] {
+		if now.Sub(at) <= window {
+			n++
+		}
+	}
+	return n
+}
+
+// Keep forgets every container not in ids, e.g. removed ones
+func (t *RestartTracker) Keep(ids []string) {
+	keep := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		keep[id] = true
+	}
+	for id := range t.counts {
+		if !keep[id] {
+			delete(t.counts, id)
+			delete(t.restarts, id)
+		}
+	}
+}
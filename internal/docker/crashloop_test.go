@@ -0,0 +1,28 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestartTracker(t *testing.T) {
+	tr := NewRestartTracker()
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	// restarts from before the first look have no time
+	tr.Observe("web", 40, start)
+	assert.Equal(t, 0, tr.Recent("web", 10*time.Minute, start))
+
+	tr.Observe("web", 42, start.Add(time.Minute))
+	tr.Observe("web", 43, start.Add(5*time.Minute))
+	tr.Observe("web", 43, start.Add(6*time.Minute))
+	assert.Equal(t, 3, tr.Recent("web", 10*time.Minute, start.Add(6*time.Minute)))
+	assert.Equal(t, 1, tr.Recent("web", 10*time.Minute, start.Add(14*time.Minute)))
+	assert.Equal(t, 0, tr.Recent("web", 10*time.Minute, start.Add(time.Hour)))
+
+	tr.Keep([]string{"db"})
+	tr.Observe("web", 44, start.Add(7*time.Minute))
+	assert.Equal(t, 0, tr.Recent("web", 10*time.Minute, start.Add(7*time.Minute)))
+}
@@ -0,0 +1,176 @@
+package docker
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RegistryLogin is a registry the user has credentials for. Passwords are never read.
+type RegistryLogin struct {
+	Registry string // normalized host, docker hub is "docker.io"
+	User     string // empty when a credential helper holds it and won't say
+	Source   string // "config.json", "auth.json" or the credential helper, e.g. "desktop"
+}
+
+type authFile struct {
+	Auths map[string]struct {
+		Auth string `json:"auth"`
+	} `json:"auths"`
+	CredsStore  string            `json:"credsStore"`
+	CredHelpers map[string]string `json:"credHelpers"`
+}
+
+// authFilePaths returns where the runtime keeps logins: docker's config.json,
+// plus podman's auth.json when running podman
+func authFilePaths() []string {
+	var paths []string
+	if runtimeBin() == "podman" {
+		if p := os.Getenv("REGISTRY_AUTH_FILE"); p != "" {
+			paths = append(paths, p)
+		} else if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+			paths = append(paths, filepath.Join(dir, "containers", "auth.json"))
+		}
+	}
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		paths = append(paths, filepath.Join(dir, "config.json"))
+	} else if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".docker", "config.json"))
+	}
+	return paths
+}
+
+// RegistryLogins lists the registries the current user is logged into, from
+// the auth files and the credential store/helpers they point at
+func RegistryLogins() ([]RegistryLogin, error) {
+	found := make(map[string]RegistryLogin)
+	for _, path := range authFilePaths() {
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var f authFile
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, err
+		}
+		for _, l := range parseAuthFile(f, filepath.Base(path)) {
+			if _, ok := found[l.Registry]; !ok {
+				found[l.Registry] = l
+			}
+		}
+
+		if f.CredsStore != "" {
+			// the store knows every login, entries in "auths" are just placeholders then
+			for host, user := range credentialStoreList(f.CredsStore) {
+				reg := NormalizeRegistry(host)
+				found[reg] = RegistryLogin{Registry: reg, User: user, Source: f.CredsStore}
+			}
+		}
+	}
+
+	logins := make([]RegistryLogin, 0, len(found))
+	for _, l := range found {
+		logins = append(logins, l)
+	}
+	sort.Slice(logins, func(i, j int) bool { return logins[i].Registry < logins[j].Registry })
+	return logins, nil
+}
+
+// parseAuthFile reads the logins stored inline ("auth" is base64 user:password)
+// and the per-registry credential helpers
+func parseAuthFile(f authFile, source string) []RegistryLogin {
+	var logins []RegistryLogin
+	for host, entry := range f.Auths {
+		l := RegistryLogin{Registry: NormalizeRegistry(host), Source: source}
+		if entry.Auth != "" {
+			if raw, err := base64.StdEncoding.DecodeString(entry.Auth); err == nil {
+				l.User, _, _ = strings.Cut(string(raw), ":")
+			}
+		} else if f.CredsStore != "" {
+			l.Source = f.CredsStore
+		}
+		logins = append(logins, l)
+	}
+	for host, helper := range f.CredHelpers {
+		logins = append(logins, RegistryLogin{Registry: NormalizeRegistry(host), Source: helper})
+	}
+	return logins
+}
+
+// credentialStoreList asks docker-credential-<store> which logins it holds,
+// as server URL to username. A missing or failing helper just lists nothing.
+func credentialStoreList(store string) map[string]string {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "docker-credential-"+store, "list").Output()
+	if err != nil {
+		return nil
+	}
+	var list map[string]string
+	if err := json.Unmarshal(out, &list); err != nil {
+		return nil
+	}
+	return list
+}
+
+// NormalizeRegistry turns the forms registries are written in
+// ("https://index.docker.io/v1/", "registry-1.docker.io", "ghcr.io/") into a bare host
+func NormalizeRegistry(host string) string {
+	host = strings.TrimSpace(host)
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	host, _, _ = strings.Cut(host, "/")
+	host = strings.ToLower(host)
+	switch host {
+	case "", "index.docker.io", "registry-1.docker.io", "registry.hub.docker.com":
+		return "docker.io"
+	}
+	return host
+}
+
+// RegistriesOf returns the registries the images come from, sorted
+func RegistriesOf(images []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, image := range images {
+		ref, err := ParseImageRef(image)
+		if err != nil || isLocalImage(image) {
+			continue
+		}
+		reg := NormalizeRegistry(ref.Registry)
+		if !seen[reg] {
+			seen[reg] = true
+			out = append(out, reg)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// isLocalImage catches image IDs and podman's localhost/ builds, which come from no registry
+func isLocalImage(image string) bool {
+	if strings.HasPrefix(image, "sha256:") || strings.HasPrefix(image, "localhost/") {
+		return true
+	}
+	if len(image) < 12 {
+		return false
+	}
+	for _, r := range image {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package docker
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeRegistry(t *testing.T) {
+	tests := map[string]string{
+		"https://index.docker.io/v1/": "docker.io",
+		"registry-1.docker.io":        "docker.io",
+		"ghcr.io/":                    "ghcr.io",
+		"Registry.Example.com:5000":   "registry.example.com:5000",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, NormalizeRegistry(in), in)
+	}
+}
+
+func TestRegistryLogins(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", dir)
+	auth := base64.StdEncoding.EncodeToString([]byte("bob:secret"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{
+  "auths": {
+    "https://index.docker.io/v1/": {"auth": "`+auth+`"},
+    "ghcr.io": {}
+  },
+  "credHelpers": {"123.dkr.ecr.us-east-1.amazonaws.com": "ecr-login"}
+}`), 0600))
+
+	logins, err := RegistryLogins()
+	require.NoError(t, err)
+	assert.Equal(t, []RegistryLogin{
+		{Registry: "123.dkr.ecr.us-east-1.amazonaws.com", Source: "ecr-login"},
+		{Registry: "docker.io", User: "bob", Source: "config.json"},
+		{Registry: "ghcr.io", Source: "config.json"},
+	}, logins)
+}
+
+func TestRegistriesOf(t *testing.T) {
+	got := RegistriesOf([]string{
+		"nginx:latest",
+		"ghcr.io/org/app:1",
+		"docker.io/library/redis",
+		"localhost/built:dev",
+		"sha256:abc",
+		"5f2a9c3e1b7d",
+	})
+	assert.Equal(t, []string{"docker.io", "ghcr.io"}, got)
+}
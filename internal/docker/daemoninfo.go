@@ -0,0 +1,164 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+// DaemonInfo is the part of `docker info` / `podman info` worth putting in a bug report
+type DaemonInfo struct {
+	Runtime         string
+	ServerVersion   string
+	OS              string
+	Kernel          string
+	Arch            string
+	CPUs            int
+	MemTotal        int64
+	StorageDriver   string
+	CgroupVersion   string
+	CgroupDriver    string
+	Containers      int
+	Running         int
+	Images          int
+	RootDir         string
+	RegistryMirrors []string
+	Warnings        []string
+}
+
+// GetDaemonInfo asks the runtime about its daemon
+func GetDaemonInfo() (DaemonInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	runtime := runtimeBin()
+	cmd := exec.CommandContext(ctx, runtime, "info", "--format", "{{json .}}")
+	start := time.Now()
+	// not combined, docker prints its warnings on stderr
+	output, err := cmd.Output()
+	logging.Command(cmd, start, err)
+	if err != nil {
+		msg := err.Error()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			msg = strings.TrimSpace(string(exitErr.Stderr))
+		}
+		return DaemonInfo{}, fmt.Errorf("%s info: %s", runtime, msg)
+	}
+
+	var info DaemonInfo
+	if runtime == "podman" {
+		info, err = parsePodmanInfo(output)
+	} else {
+		info, err = parseDockerInfo(output)
+	}
+	info.Runtime = runtime
+	return info, err
+}
+
+// parseDockerInfo reads docker's info, nerdctl prints the same shape
+func parseDockerInfo(output []byte) (DaemonInfo, error) {
+	var raw struct {
+		ServerVersion     string `json:"ServerVersion"`
+		OperatingSystem   string `json:"OperatingSystem"`
+		KernelVersion     string `json:"KernelVersion"`
+		Architecture      string `json:"Architecture"`
+		NCPU              int    `json:"NCPU"`
+		MemTotal          int64  `json:"MemTotal"`
+		Driver            string `json:"Driver"`
+		CgroupVersion     string `json:"CgroupVersion"`
+		CgroupDriver      string `json:"CgroupDriver"`
+		Containers        int    `json:"Containers"`
+		ContainersRunning int    `json:"ContainersRunning"`
+		Images            int    `json:"Images"`
+		DockerRootDir     string `json:"DockerRootDir"`
+		RegistryConfig    *struct {
+			Mirrors []string `json:"Mirrors"`
+		} `json:"RegistryConfig"`
+		Warnings []string `json:"Warnings"`
+	}
+	if err := json.Unmarshal(output, &raw); err != nil {
+		return DaemonInfo{}, fmt.Errorf("parsing info output: %w", err)
+	}
+
+	info := DaemonInfo{
+		ServerVersion: raw.ServerVersion,
+		OS:            raw.OperatingSystem,
+		Kernel:        raw.KernelVersion,
+		Arch:          raw.Architecture,
+		CPUs:          raw.NCPU,
+		MemTotal:      raw.MemTotal,
+		StorageDriver: raw.Driver,
+		CgroupVersion: raw.CgroupVersion,
+		CgroupDriver:  raw.CgroupDriver,
+		Containers:    raw.Containers,
+		Running:       raw.ContainersRunning,
+		Images:        raw.Images,
+		RootDir:       raw.DockerRootDir,
+		Warnings:      raw.Warnings,
+	}
+	if raw.RegistryConfig != nil {
+		info.RegistryMirrors = raw.RegistryConfig.Mirrors
+	}
+	return info, nil
+}
+
+// parsePodmanInfo reads podman's info. Mirrors live in registries.conf and
+// are not part of it.
+func parsePodmanInfo(output []byte) (DaemonInfo, error) {
+	var raw struct {
+		Host struct {
+			Arch          string `json:"arch"`
+			CPUs          int    `json:"cpus"`
+			MemTotal      int64  `json:"memTotal"`
+			Kernel        string `json:"kernel"`
+			CgroupVersion string `json:"cgroupVersion"`
+			CgroupManager string `json:"cgroupManager"`
+			Distribution  struct {
+				Distribution string `json:"distribution"`
+				Version      string `json:"version"`
+			} `json:"distribution"`
+		} `json:"host"`
+		Store struct {
+			GraphDriverName string `json:"graphDriverName"`
+			GraphRoot       string `json:"graphRoot"`
+			ContainerStore  struct {
+				Number  int `json:"number"`
+				Running int `json:"running"`
+			} `json:"containerStore"`
+			ImageStore struct {
+				Number int `json:"number"`
+			} `json:"imageStore"`
+		} `json:"store"`
+		Version struct {
+			Version string `json:"Version"`
+		} `json:"version"`
+	}
+	if err := json.Unmarshal(output, &raw); err != nil {
+		return DaemonInfo{}, fmt.Errorf("parsing info output: %w", err)
+	}
+
+	dist := strings.TrimSpace(raw.Host.Distribution.Distribution + " " + raw.Host.Distribution.Version)
+	return DaemonInfo{
+		ServerVersion: raw.Version.Version,
+		OS:            dist,
+		Kernel:        raw.Host.Kernel,
+		Arch:          raw.Host.Arch,
+		CPUs:          raw.Host.CPUs,
+		MemTotal:      raw.Host.MemTotal,
+		StorageDriver: raw.Store.GraphDriverName,
+		// "v2" like docker's "2"
+		CgroupVersion: strings.TrimPrefix(raw.Host.CgroupVersion, "v"),
+		CgroupDriver:  raw.Host.CgroupManager,
+		Containers:    raw.Store.ContainerStore.Number,
+		Running:       raw.Store.ContainerStore.Running,
+		Images:        raw.Store.ImageStore.Number,
+		RootDir:       raw.Store.GraphRoot,
+	}, nil
+}
@@ -0,0 +1,55 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDockerInfo(t *testing.T) {
+	output := []byte(`{"Containers":12,"ContainersRunning":5,"Images":40,"Driver":"overlay2","DockerRootDir":"/var/lib/docker",` +
+		`"CgroupDriver":"systemd","CgroupVersion":"2","KernelVersion":"6.8.0-45-generic","OperatingSystem":"Ubuntu 24.04.1 LTS",` +
+		`"Architecture":"x86_64","NCPU":8,"MemTotal":16663543808,"ServerVersion":"27.3.1",` +
+		`"RegistryConfig":{"Mirrors":["https://mirror.gcr.io/"]},"Warnings":["WARNING: bridge-nf-call-iptables is disabled"]}`)
+	info, err := parseDockerInfo(output)
+	require.NoError(t, err)
+	assert.Equal(t, DaemonInfo{
+		ServerVersion:   "27.3.1",
+		OS:              "Ubuntu 24.04.1 LTS",
+		Kernel:          "6.8.0-45-generic",
+		Arch:            "x86_64",
+		CPUs:            8,
+		MemTotal:        16663543808,
+		StorageDriver:   "overlay2",
+		CgroupVersion:   "2",
+		CgroupDriver:    "systemd",
+		Containers:      12,
+		Running:         5,
+		Images:          40,
+		RootDir:         "/var/lib/docker",
+		RegistryMirrors: []string{"https://mirror.gcr.io/"},
+		Warnings:        []string{"WARNING: bridge-nf-call-iptables is disabled"},
+	}, info)
+
+	_, err = parseDockerInfo([]byte("Cannot connect to the Docker daemon"))
+	assert.Error(t, err)
+}
+
+func TestParsePodmanInfo(t *testing.T) {
+	output := []byte(`{"host":{"arch":"amd64","cpus":4,"memTotal":8000000000,"kernel":"6.10.6-200.fc40.x86_64",` +
+		`"cgroupVersion":"v2","cgroupManager":"systemd","distribution":{"distribution":"fedora","version":"40"}},` +
+		`"store":{"graphDriverName":"overlay","graphRoot":"/home/me/.local/share/containers/storage",` +
+		`"containerStore":{"number":3,"running":1},"imageStore":{"number":7}},"version":{"Version":"5.2.2"}}`)
+	info, err := parsePodmanInfo(output)
+	require.NoError(t, err)
+	assert.Equal(t, "5.2.2", info.ServerVersion)
+	assert.Equal(t, "fedora 40", info.OS)
+	assert.Equal(t, "2", info.CgroupVersion)
+	assert.Equal(t, "overlay", info.StorageDriver)
+	assert.Equal(t, 3, info.Containers)
+	assert.Equal(t, 1, info.Running)
+	assert.Equal(t, 7, info.Images)
+	assert.Equal(t, "/home/me/.local/share/containers/storage", info.RootDir)
+	assert.Empty(t, info.RegistryMirrors)
+}
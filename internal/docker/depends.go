@@ -0,0 +1,188 @@
+package docker
+
+import (
+	"sort"
+	"strings"
+)
+
+// DependsOnLabel is set by compose v2 on every service container, e.g.
+// "db:service_healthy:false,redis:service_started:false"
+const DependsOnLabel = "com.docker.compose.depends_on"
+
+// ServiceDependencies returns the compose services this container depends on
+func ServiceDependencies(c Container) []string {
+	var deps []string
+	for _, d := range ServiceDependencyConditions(c) {
+		deps = append(deps, d.Service)
+	}
+	return deps
+}
+
+// Dependency is a service a container depends on and the condition compose
+// waits for, e.g. service_healthy
+type Dependency struct {
+	Service   string
+	Condition string
+}
+
+// ServiceDependencyConditions is ServiceDependencies with the conditions
+func ServiceDependencyConditions(c Container) []Dependency {
+	raw := c.Labels[DependsOnLabel]
+	if raw == "" {
+		return nil
+	}
+
+	var deps []Dependency
+	for _, part := range strings.Split(raw, ",") {
+		name, rest, _ := strings.Cut(strings.TrimSpace(part), ":")
+		condition, _, _ := strings.Cut(rest, ":")
+		if name != "" {
+			deps = append(deps, Dependency{Service: name, Condition: condition})
+		}
+	}
+	return deps
+}
+
+// DepNode is a service in a project's dependency tree. The roots are the
+// services nothing depends on, their children what they depend on.
+type DepNode struct {
+	Service    string
+	Condition  string      // how the parent waits for it, empty for roots
+	Containers []Container // none when the service has no container, e.g. not created
+	Children   []DepNode
+	Cycle      bool // already further up this branch, children left out
+}
+
+// DependencyTree builds the depends_on tree of one compose project's
+// containers. Services several others depend on show up under each of them.
+func DependencyTree(containers []Container) []DepNode {
+	byService := make(map[string][]Container)
+	deps := make(map[string][]Dependency)
+	dependedOn := make(map[string]bool)
+	for _, c := range containers {
+		if c.ComposeService == "" {
+			continue
+		}
+		byService[c.ComposeService] = append(byService[c.ComposeService], c)
+		if _, ok := deps[c.ComposeService]; !ok {
+			deps[c.ComposeService] = ServiceDependencyConditions(c)
+			for _, d := range deps[c.ComposeService] {
+				dependedOn[d.Service] = true
+			}
+		}
+	}
+
+	var build func(service, condition string, path map[string]bool) DepNode
+	build = func(service, condition string, path map[string]bool) DepNode {
+		node := DepNode{Service: service, Condition: condition, Containers: sortedByName(byService[service])}
+		if path[service] {
+			node.Cycle = true
+			return node
+		}
+		path[service] = true
+		for _, d := range deps[service] {
+			node.Children = append(node.Children, build(d.Service, d.Condition, path))
+		}
+		delete(path, service)
+		return node
+	}
+
+	services := make([]string, 0, len(byService))
+	for service := range byService {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	var roots []DepNode
+	for _, service := range services {
+		if !dependedOn[service] {
+			roots = append(roots, build(service, "", map[string]bool{}))
+		}
+	}
+	if len(roots) == 0 && len(services) > 0 {
+		// everything is in a cycle, start anywhere
+		roots = append(roots, build(services[0], "", map[string]bool{}))
+	}
+	return roots
+}
+
+// StopStages splits containers into batches that can be stopped one after
+// another so dependents always go down before their dependencies (reverse
+// depends_on order). Containers in the same stage don't depend on each other.
+// Dependencies only count inside the same compose project; anything caught in
+// a cycle ends up in the last stage.
+func StopStages(containers []Container) [][]Container {
+	type node struct {
+		c    Container
+		deps []int // indexes of containers this one depends on
+	}
+
+	// project/service -> indexes, a service can have several replicas
+	byService := make(map[string][]int)
+	for i, c := range containers {
+		if c.ComposeService != "" {
+			key := c.ComposeProject + "/" + c.ComposeService
+			byService[key] = append(byService[key], i)
+		}
+	}
+
+	nodes := make([]node, len(containers))
+	dependents := make([]int, len(containers)) // how many still-running containers need this one
+	for i, c := range containers {
+		nodes[i].c = c
+		for _, dep := range ServiceDependencies(c) {
+			for _, j := range byService[c.ComposeProject+"/"+dep] {
+				if j == i {
+					continue
+				}
+				nodes[i].deps = append(nodes[i].deps, j)
+				dependents[j]++
+			}
+		}
+	}
+
+	done := make([]bool, len(containers))
+	remaining := len(containers)
+	var stages [][]Container
+
+	for remaining > 0 {
+		var ready []int
+		for i := range nodes {
+			if !done[i] && dependents[i] == 0 {
+				ready = append(ready, i)
+			}
+		}
+
+		if len(ready) == 0 {
+			// dependency cycle, just stop whatever is left together
+			var rest []Container
+			for i := range nodes {
+				if !done[i] {
+					rest = append(rest, nodes[i].c)
+				}
+			}
+			stages = append(stages, sortedByName(rest))
+			break
+		}
+
+		var stage []Container
+		for _, i := range ready {
+			done[i] = true
+			remaining--
+			stage = append(stage, nodes[i].c)
+			for _, j := range nodes[i].deps {
+				dependents[j]--
+			}
+		}
+		stages = append(stages, sortedByName(stage))
+	}
+
+	return stages
+}
+
+func sortedByName(cs []Container) []Container {
+	sort.SliceStable(cs, func(i, j int) bool {
+		return strings.Join(cs[i].Names, ",") < strings.Join(cs[j].Names, ",")
+	})
+	return cs
+}
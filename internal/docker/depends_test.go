@@ -0,0 +1,95 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func composeContainer(name, project, service, dependsOn string) Container {
+	c := Container{Names: []string{name}, ComposeProject: project, ComposeService: service}
+	if dependsOn != "" {
+		c.Labels = map[string]string{DependsOnLabel: dependsOn}
+	}
+	return c
+}
+
+func stageNames(stages [][]Container) [][]string {
+	var out [][]string
+	for _, s := range stages {
+		var names []string
+		for _, c := range s {
+			names = append(names, c.Names[0])
+		}
+		out = append(out, names)
+	}
+	return out
+}
+
+func TestServiceDependencies(t *testing.T) {
+	c := composeContainer("web", "shop", "web", "db:service_healthy:false,redis:service_started:true")
+	assert.Equal(t, []string{"db", "redis"}, ServiceDependencies(c))
+	assert.Nil(t, ServiceDependencies(Container{}))
+}
+
+func TestStopStages(t *testing.T) {
+	containers := []Container{
+		composeContainer("db", "shop", "db", ""),
+		composeContainer("web", "shop", "web", "api:service_started:false"),
+		composeContainer("api", "shop", "api", "db:service_healthy:false,cache:service_started:false"),
+		composeContainer("cache", "shop", "cache", ""),
+		composeContainer("worker", "shop", "worker", "db:service_started:false"),
+		// same service name in another project must not count
+		composeContainer("other-db", "blog", "db", ""),
+	}
+
+	stages := StopStages(containers)
+	assert.Equal(t, [][]string{
+		{"other-db", "web", "worker"},
+		{"api"},
+		{"cache", "db"},
+	}, stageNames(stages))
+}
+
+func TestStopStagesCycle(t *testing.T) {
+	containers := []Container{
+		composeContainer("a", "p", "a", "b:service_started:false"),
+		composeContainer("b", "p", "b", "a:service_started:false"),
+		composeContainer("c", "p", "c", "a:service_started:false"),
+	}
+
+	stages := StopStages(containers)
+	require.Len(t, stages, 2)
+	assert.Equal(t, []string{"c"}, stageNames(stages)[0])
+	assert.Equal(t, []string{"a", "b"}, stageNames(stages)[1])
+}
+
+func TestDependencyTree(t *testing.T) {
+	containers := []Container{
+		composeContainer("shop-db-1", "shop", "db", ""),
+		composeContainer("shop-web-1", "shop", "web", "api:service_started:false"),
+		composeContainer("shop-api-1", "shop", "api", "db:service_healthy:false,cache:service_started:false"),
+		composeContainer("shop-worker-1", "shop", "worker", "db:service_healthy:false"),
+	}
+	roots := DependencyTree(containers)
+	require.Len(t, roots, 2)
+	assert.Equal(t, "web", roots[0].Service)
+	assert.Equal(t, "worker", roots[1].Service)
+
+	api := roots[0].Children[0]
+	assert.Equal(t, "api", api.Service)
+	assert.Equal(t, "service_started", api.Condition)
+	require.Len(t, api.Children, 2)
+	assert.Equal(t, DepNode{Service: "db", Condition: "service_healthy", Containers: containers[:1]}, api.Children[0])
+	// cache has no container
+	assert.Equal(t, "cache", api.Children[1].Service)
+	assert.Empty(t, api.Children[1].Containers)
+
+	cycle := DependencyTree([]Container{
+		composeContainer("a", "p", "a", "b:service_started:false"),
+		composeContainer("b", "p", "b", "a:service_started:false"),
+	})
+	require.Len(t, cycle, 1)
+	assert.True(t, cycle[0].Children[0].Children[0].Cycle)
+}
@@ -0,0 +1,49 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+// FileChange is one line of `diff`: a path added (A), changed (C) or
+// deleted (D) in the container's writable layer
+type FileChange struct {
+	Kind byte
+	Path string
+}
+
+// ContainerDiff lists what the container changed on top of its image
+func ContainerDiff(containerID string) ([]FileChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, runtimeBin(), "diff", containerID)
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	logging.Command(cmd, start, err)
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("diff: %s", msg)
+	}
+	return parseDiff(string(output)), nil
+}
+
+func parseDiff(output string) []FileChange {
+	var changes []FileChange
+	for _, line := range strings.Split(output, "\n") {
+		kind, path, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok || len(kind) != 1 || !strings.Contains("ACD", kind) {
+			continue
+		}
+		changes = append(changes, FileChange{Kind: kind[0], Path: path})
+	}
+	return changes
+}
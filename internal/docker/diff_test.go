@@ -0,0 +1,17 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDiff(t *testing.T) {
+	output := "C /etc\nA /etc/app.conf\nD /var/cache/apt\nA /tmp/with space.txt\n\nnot a change\n"
+	assert.Equal(t, []FileChange{
+		{Kind: 'C', Path: "/etc"},
+		{Kind: 'A', Path: "/etc/app.conf"},
+		{Kind: 'D', Path: "/var/cache/apt"},
+		{Kind: 'A', Path: "/tmp/with space.txt"},
+	}, parseDiff(output))
+}
@@ -0,0 +1,123 @@
+package docker
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// composeFileNames are the files compose picks up in a directory, in the
+// order it prefers them
+var composeFileNames = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+// DefinedProject is a compose project found on disk, running or not
+type DefinedProject struct {
+	Name     string // the file's name: key, else derived from the directory like compose does
+	File     string
+	Dir      string
+	Services []string
+}
+
+// DiscoverComposeProjects looks for compose files in dirs and their
+// subdirectories, down to depth levels. Hidden directories and
+// node_modules are skipped. Directories that can't be read are skipped too,
+// except for the ones in dirs, which are reported.
+func DiscoverComposeProjects(dirs []string, depth int) ([]DefinedProject, error) {
+	var projects []DefinedProject
+	var errs []error
+	seen := make(map[string]bool)
+
+	var scan func(dir string, level int)
+	scan = func(dir string, level int) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if level == 0 {
+				errs = append(errs, err)
+			}
+			return
+		}
+		if !seen[dir] {
+			seen[dir] = true
+			if p, ok := definedProject(dir, entries); ok {
+				projects = append(projects, p)
+			}
+		}
+		if level >= depth {
+			return
+		}
+		for _, e := range entries {
+			if !e.IsDir() || strings.HasPrefix(e.Name(), ".") || e.Name() == "node_modules" {
+				continue
+			}
+			scan(filepath.Join(dir, e.Name()), level+1)
+		}
+	}
+	for _, dir := range dirs {
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		scan(dir, 0)
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		if projects[i].Name != projects[j].Name {
+			return projects[i].Name < projects[j].Name
+		}
+		return projects[i].Dir < projects[j].Dir
+	})
+	return projects, errors.Join(errs...)
+}
+
+// definedProject reads the compose file in dir, if it has one
+func definedProject(dir string, entries []os.DirEntry) (DefinedProject, bool) {
+	names := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() {
+			names[e.Name()] = true
+		}
+	}
+	for _, name := range composeFileNames {
+		if !names[name] {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return DefinedProject{}, false
+		}
+		var file struct {
+			Name     string               `yaml:"name"`
+			Services map[string]yaml.Node `yaml:"services"`
+		}
+		if err := yaml.Unmarshal(data, &file); err != nil || len(file.Services) == 0 {
+			// not a compose file after all, or one compose would reject
+			return DefinedProject{}, false
+		}
+		p := DefinedProject{Name: file.Name, File: path, Dir: dir}
+		if p.Name == "" || strings.Contains(p.Name, "$") {
+			p.Name = composeProjectName(filepath.Base(dir))
+		}
+		for service := range file.Services {
+			p.Services = append(p.Services, service)
+		}
+		sort.Strings(p.Services)
+		return p, true
+	}
+	return DefinedProject{}, false
+}
+
+// composeProjectName is the project name compose derives from a directory:
+// lowercased, with only letters, digits, dashes and underscores kept
+func composeProjectName(dir string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(dir) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || ((r == '-' || r == '_') && b.Len() > 0) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
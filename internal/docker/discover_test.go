@@ -0,0 +1,49 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverComposeProjects(t *testing.T) {
+	root := t.TempDir()
+	write := func(path, content string) {
+		path = filepath.Join(root, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	write("shop/compose.yaml", "name: webshop\nservices:\n  web:\n    image: nginx\n  db:\n    image: postgres\n")
+	// compose.yaml wins over docker-compose.yml
+	write("shop/docker-compose.yml", "services:\n  old:\n    image: nginx\n")
+	write("My App/docker-compose.yml", "services:\n  api:\n    image: api\n")
+	write("env/compose.yml", "name: ${PROJECT}\nservices:\n  api:\n    image: api\n")
+	write("not-compose/compose.yml", "just: yaml\n")
+	write(".hidden/compose.yml", "services:\n  x:\n    image: x\n")
+	write("node_modules/pkg/compose.yml", "services:\n  x:\n    image: x\n")
+	write("a/b/c/compose.yml", "services:\n  deep:\n    image: x\n")
+
+	projects, err := DiscoverComposeProjects([]string{root, filepath.Join(root, "shop")}, 2)
+	require.NoError(t, err)
+	require.Len(t, projects, 3)
+
+	assert.Equal(t, "env", projects[0].Name)
+	assert.Equal(t, "myapp", projects[1].Name)
+	assert.Equal(t, []string{"api"}, projects[1].Services)
+	assert.Equal(t, "webshop", projects[2].Name)
+	assert.Equal(t, filepath.Join(root, "shop", "compose.yaml"), projects[2].File)
+	assert.Equal(t, filepath.Join(root, "shop"), projects[2].Dir)
+	assert.Equal(t, []string{"db", "web"}, projects[2].Services)
+
+	projects, err = DiscoverComposeProjects([]string{root}, 3)
+	require.NoError(t, err)
+	assert.Len(t, projects, 4)
+
+	// a missing directory is reported, the others are still scanned
+	projects, err = DiscoverComposeProjects([]string{filepath.Join(root, "missing"), filepath.Join(root, "shop")}, 0)
+	assert.Error(t, err)
+	assert.Len(t, projects, 1)
+}
@@ -0,0 +1,129 @@
+package docker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is one container event from `docker events`
+type Event struct {
+	Time     time.Time
+	Action   string // create, start, die, oom, "health_status: unhealthy", ...
+	ID       string
+	Name     string
+	Image    string
+	ExitCode string // die only
+}
+
+// StreamEvents follows the runtime's container events, starting with the ones
+// of the last since, and hands each to onEvent until ctx is cancelled.
+// exec events are left out, health checks would drown everything else.
+func StreamEvents(ctx context.Context, since time.Duration, onEvent func(Event)) error {
+	runtime := runtimeBin()
+	cmd := exec.CommandContext(ctx, runtime, "events",
+		"--since", strconv.Itoa(int(since.Seconds()))+"s",
+		"--filter", "type=container",
+		"--format", "{{json .}}")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		ev, ok := parseEvent(scanner.Bytes())
+		if ok {
+			onEvent(ev)
+		}
+	}
+
+	err = cmd.Wait()
+	if ctx.Err() != nil {
+		// closed on purpose
+		return nil
+	}
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return fmt.Errorf("%s events: %s", runtime, msg)
+	}
+	return nil
+}
+
+// parseEvent reads a line of docker's or podman's event JSON. They share no
+// field names, and docker's lowercase "id" and "time" would land in podman's
+// fields, so docker's shape is tried on its own first.
+func parseEvent(line []byte) (Event, bool) {
+	line = bytes.TrimSpace(line)
+
+	var d struct {
+		Action string `json:"Action"`
+		Actor  struct {
+			ID         string            `json:"ID"`
+			Attributes map[string]string `json:"Attributes"`
+		} `json:"Actor"`
+		TimeNano int64 `json:"timeNano"`
+	}
+	if err := json.Unmarshal(line, &d); err != nil {
+		return Event{}, false
+	}
+
+	var ev Event
+	if d.Action != "" {
+		ev = Event{
+			Time:     time.Unix(0, d.TimeNano),
+			Action:   d.Action,
+			ID:       d.Actor.ID,
+			Name:     d.Actor.Attributes["name"],
+			Image:    d.Actor.Attributes["image"],
+			ExitCode: d.Actor.Attributes["exitCode"],
+		}
+	} else {
+		var p struct {
+			ID                string `json:"ID"`
+			Name              string `json:"Name"`
+			Image             string `json:"Image"`
+			Status            string `json:"Status"`
+			Time              string `json:"Time"`
+			ContainerExitCode *int   `json:"ContainerExitCode"`
+			HealthStatus      string `json:"HealthStatus"`
+		}
+		if err := json.Unmarshal(line, &p); err != nil {
+			return Event{}, false
+		}
+		action := p.Status
+		switch action {
+		case "died":
+			action = "die"
+		case "health_status":
+			if p.HealthStatus != "" {
+				action += ": " + p.HealthStatus
+			}
+		}
+		ev = Event{Action: action, ID: p.ID, Name: p.Name, Image: p.Image}
+		ev.Time, _ = time.Parse(time.RFC3339Nano, p.Time)
+		if action == "die" && p.ContainerExitCode != nil {
+			ev.ExitCode = strconv.Itoa(*p.ContainerExitCode)
+		}
+	}
+
+	if ev.Action == "" || strings.HasPrefix(ev.Action, "exec") {
+		return Event{}, false
+	}
+	return ev, true
+}
@@ -0,0 +1,53 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEvent(t *testing.T) {
+	t.Run("docker", func(t *testing.T) {
+		ev, ok := parseEvent([]byte(`{"status":"die","id":"abc123","from":"nginx:1.27","Type":"container","Action":"die",` +
+			`"Actor":{"ID":"abc123","Attributes":{"exitCode":"137","image":"nginx:1.27","name":"web"}},"scope":"local","time":1700000000,"timeNano":1700000000500000000}`))
+		require.True(t, ok)
+		assert.Equal(t, Event{
+			Time:     time.Unix(0, 1700000000500000000),
+			Action:   "die",
+			ID:       "abc123",
+			Name:     "web",
+			Image:    "nginx:1.27",
+			ExitCode: "137",
+		}, ev)
+	})
+
+	t.Run("docker health", func(t *testing.T) {
+		ev, ok := parseEvent([]byte(`{"Type":"container","Action":"health_status: unhealthy","Actor":{"ID":"abc123","Attributes":{"name":"web"}},"timeNano":1700000000000000000}`))
+		require.True(t, ok)
+		assert.Equal(t, "health_status: unhealthy", ev.Action)
+	})
+
+	t.Run("podman", func(t *testing.T) {
+		ev, ok := parseEvent([]byte(`{"ID":"def456","Image":"docker.io/library/redis:7","Name":"cache","Status":"died","Time":"2024-03-01T10:00:00.5+01:00","Type":"container","ContainerExitCode":1}`))
+		require.True(t, ok)
+		assert.Equal(t, "die", ev.Action)
+		assert.Equal(t, "cache", ev.Name)
+		assert.Equal(t, "1", ev.ExitCode)
+		assert.Equal(t, time.Date(2024, 3, 1, 9, 0, 0, 500000000, time.UTC), ev.Time.UTC())
+
+		ev, ok = parseEvent([]byte(`{"ID":"def456","Name":"cache","Status":"health_status","HealthStatus":"healthy","Time":"2024-03-01T10:00:00+01:00","Type":"container"}`))
+		require.True(t, ok)
+		assert.Equal(t, "health_status: healthy", ev.Action)
+	})
+
+	t.Run("exec and junk are skipped", func(t *testing.T) {
+		_, ok := parseEvent([]byte(`{"Type":"container","Action":"exec_start: sh -c true","Actor":{"ID":"abc123"}}`))
+		assert.False(t, ok)
+		_, ok = parseEvent([]byte(`{"ID":"x","Status":"exec_died","Type":"container"}`))
+		assert.False(t, ok)
+		_, ok = parseEvent([]byte(`not json`))
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,178 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FileEntry is one line of `ls -l` inside a container
+type FileEntry struct {
+	Name       string
+	Mode       string // e.g. "drwxr-xr-x"
+	Size       int64  // -1 for device files
+	Modified   string // as ls printed it
+	LinkTarget string // for symlinks
+}
+
+func (f FileEntry) IsDir() bool  { return strings.HasPrefix(f.Mode, "d") }
+func (f FileEntry) IsLink() bool { return strings.HasPrefix(f.Mode, "l") }
+
+// ListDir lists a directory inside a running container with `ls -lA`.
+// Images without ls (distroless, scratch) can't be browsed this way.
+func ListDir(containerID, dir string) ([]FileEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// trailing slash so a symlinked directory gets listed instead of the link itself
+	target := strings.TrimSuffix(dir, "/") + "/"
+	output, err := exec.CommandContext(ctx, runtimeBin(), "exec", containerID, "ls", "-lA", target).CombinedOutput()
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("ls %s: %s", dir, msg)
+	}
+	return parseLsOutput(string(output)), nil
+}
+
+// parseLsOutput parses GNU and busybox `ls -l` output
+func parseLsOutput(output string) []FileEntry {
+	var entries []FileEntry
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" || strings.HasPrefix(line, "total ") {
+			continue
+		}
+
+		fields, rest := splitFields(line, 9)
+		if len(fields) < 9 {
+			continue
+		}
+
+		e := FileEntry{Mode: fields[0], Size: -1}
+		dateStart := 5
+		if strings.HasSuffix(fields[4], ",") {
+			// device file: "major, minor" instead of a size
+			dateStart = 6
+			more, r := splitFields(rest, 1)
+			if len(more) == 0 {
+				continue
+			}
+			fields = append(fields, more...)
+			rest = r
+		} else if n, err := strconv.ParseInt(fields[4], 10, 64); err == nil {
+			e.Size = n
+		}
+
+		e.Modified = strings.Join(fields[dateStart:dateStart+3], " ")
+		name := strings.Join(fields[dateStart+3:], " ")
+		if rest != "" {
+			name += " " + rest
+		}
+
+		if e.IsLink() {
+			if n, t, ok := strings.Cut(name, " -> "); ok {
+				name, e.LinkTarget = n, t
+			}
+		}
+		e.Name = name
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// splitFields takes the first n whitespace separated fields and returns the
+// unsplit remainder, so file names with spaces survive
+func splitFields(s string, n int) ([]string, string) {
+	var fields []string
+	s = strings.TrimLeft(s, " \t")
+	for len(fields) < n && s != "" {
+		i := strings.IndexAny(s, " \t")
+		if i < 0 {
+			fields = append(fields, s)
+			return fields, ""
+		}
+		fields = append(fields, s[:i])
+		s = strings.TrimLeft(s[i:], " \t")
+	}
+	return fields, s
+}
+
+// ReadFile reads up to limit bytes of a file in a container through the
+// archive API (`cp container:path -`), which doesn't need anything inside the image
+func ReadFile(containerID, filePath string, limit int64) (data []byte, truncated bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, runtimeBin(), "cp", containerID+":"+filePath, "-")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, false, err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return nil, false, err
+	}
+	defer func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	}()
+
+	tr := tar.NewReader(stdout)
+	hdr, err := tr.Next()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, false, fmt.Errorf("%s", msg)
+		}
+		return nil, false, fmt.Errorf("reading %s: %w", filePath, err)
+	}
+	if hdr.Typeflag == tar.TypeDir {
+		return nil, false, fmt.Errorf("%s is a directory", filePath)
+	}
+
+	data, err = io.ReadAll(io.LimitReader(tr, limit+1))
+	if err != nil {
+		return nil, false, fmt.Errorf("reading %s: %w", filePath, err)
+	}
+	if int64(len(data)) > limit {
+		return data[:limit], true, nil
+	}
+	return data, false, nil
+}
+
+// DownloadPath copies a file or directory out of a container into destDir,
+// picking a name that doesn't overwrite anything. Returns where it went.
+func DownloadPath(containerID, srcPath, destDir string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	base := path.Base(srcPath)
+	if base == "/" || base == "." {
+		base = "root"
+	}
+	dest := filepath.Join(destDir, base)
+	for i := 1; ; i++ {
+		if _, err := os.Stat(dest); os.IsNotExist(err) {
+			break
+		}
+		dest = filepath.Join(destDir, fmt.Sprintf("%s.%d", base, i))
+	}
+
+	output, err := exec.CommandContext(ctx, runtimeBin(), "cp", containerID+":"+srcPath, dest).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("cp %s: %v %s", srcPath, err, strings.TrimSpace(string(output)))
+	}
+	return dest, nil
+}
@@ -0,0 +1,45 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLsOutput(t *testing.T) {
+	// GNU coreutils
+	gnu := `total 16
+drwxr-xr-x 2 root root 4096 Mar  4 10:12 conf.d
+-rw-r--r-- 1 root root 1007 Feb 14  2024 nginx.conf
+lrwxrwxrwx 1 root root   22 Mar  4 10:12 modules -> /usr/lib/nginx/modules
+-rw-r--r-- 1 app  app     5 Mar  4 10:12 my notes.txt
+crw-rw-rw- 1 root root 1, 3 Mar  4 10:12 null
+`
+	entries := parseLsOutput(gnu)
+	require.Len(t, entries, 5)
+
+	assert.Equal(t, "conf.d", entries[0].Name)
+	assert.True(t, entries[0].IsDir())
+
+	assert.Equal(t, "nginx.conf", entries[1].Name)
+	assert.Equal(t, int64(1007), entries[1].Size)
+	assert.Equal(t, "Feb 14 2024", entries[1].Modified)
+
+	assert.Equal(t, "modules", entries[2].Name)
+	assert.True(t, entries[2].IsLink())
+	assert.Equal(t, "/usr/lib/nginx/modules", entries[2].LinkTarget)
+
+	assert.Equal(t, "my notes.txt", entries[3].Name)
+
+	assert.Equal(t, "null", entries[4].Name)
+	assert.Equal(t, int64(-1), entries[4].Size)
+
+	// busybox pads differently but has the same columns
+	busybox := `drwxr-xr-x    2 root     root          4096 Mar  4 10:12 bin
+-rw-r--r--    1 root     root            12 Mar  4 10:12 hostname`
+	entries = parseLsOutput(busybox)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "bin", entries[0].Name)
+	assert.Equal(t, int64(12), entries[1].Size)
+}
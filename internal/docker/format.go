@@ -0,0 +1,99 @@
+package docker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatBytes renders a byte count the way docker does (decimal units)
+func FormatBytes(n int64) string {
+	if n < 0 {
+		return "─"
+	}
+	const unit = 1000
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(n)/float64(div), "kMGTPE"[exp])
+}
+
+// FormatBytesBinary renders a byte count in binary units, the way docker
+// stats shows memory ("12.5MiB"), so sums match the rows they're summed from
+func FormatBytesBinary(n int64) string {
+	if n < 0 {
+		return "─"
+	}
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// FormatAge renders a duration as a short human string like 45s, 12m, 8h or 3d
+func FormatAge(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// parseSizeBytes turns docker's human sizes ("12.3kB", "1.5GB") into bytes, -1 if unparseable
+func parseSizeBytes(s string) int64 {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return -1
+	}
+
+	i := 0
+	for i < len(s) && (s[i] == '.' || (s[i] >= '0' && s[i] <= '9')) {
+		i++
+	}
+	val, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return -1
+	}
+
+	switch strings.ToLower(strings.TrimSpace(s[i:])) {
+	case "", "b":
+		return int64(val)
+	case "kb":
+		return int64(val * 1000)
+	case "mb":
+		return int64(val * 1000 * 1000)
+	case "gb":
+		return int64(val * 1000 * 1000 * 1000)
+	case "tb":
+		return int64(val * 1000 * 1000 * 1000 * 1000)
+	case "kib":
+		return int64(val * 1024)
+	case "mib":
+		return int64(val * 1024 * 1024)
+	case "gib":
+		return int64(val * 1024 * 1024 * 1024)
+	case "tib":
+		return int64(val * 1024 * 1024 * 1024 * 1024)
+	}
+	return -1
+}
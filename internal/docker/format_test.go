@@ -0,0 +1,14 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatBytesBinary(t *testing.T) {
+	assert.Equal(t, "512B", FormatBytesBinary(512))
+	assert.Equal(t, "150.0MiB", FormatBytesBinary(150*1024*1024))
+	assert.Equal(t, "1.1GiB", FormatBytesBinary(150*1024*1024+1024*1024*1024))
+	assert.Equal(t, "─", FormatBytesBinary(-1))
+}
@@ -0,0 +1,223 @@
+package docker
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+// ErrNoNvidiaSMI means nvidia-smi isn't installed, so GPU usage is unknown
+var ErrNoNvidiaSMI = errors.New("nvidia-smi not found")
+
+// GPU is one NVIDIA GPU as nvidia-smi reports it
+type GPU struct {
+	Index       int
+	UUID        string
+	Name        string
+	Utilization int   // percent
+	MemUsed     int64 // bytes
+	MemTotal    int64 // bytes
+}
+
+// GPUProcess is a process using a GPU
+type GPUProcess struct {
+	PID     int
+	GPUUUID string
+	MemUsed int64 // bytes
+}
+
+// GPUUsage is what a container takes of the GPUs it can use
+type GPUUsage struct {
+	GPUs        []int // indexes of the GPUs it uses, or may use
+	Utilization int   // average over those GPUs, other containers on them count too
+	MemUsed     int64 // by the container's own processes
+	MemTotal    int64 // of those GPUs
+}
+
+// GPUUsageByContainer reads the GPUs with nvidia-smi and maps their processes
+// to containers through /proc, so it only works for the local daemon.
+// requests maps container IDs to their GPU request, see InspectInfo.GPUs.
+func GPUUsageByContainer(requests map[string]string) (map[string]GPUUsage, error) {
+	if _, err := exec.LookPath("nvidia-smi"); err != nil {
+		return nil, ErrNoNvidiaSMI
+	}
+	gpuOut, err := nvidiaSMI("--query-gpu=index,uuid,name,utilization.gpu,memory.used,memory.total", "--format=csv,noheader,nounits")
+	if err != nil {
+		return nil, err
+	}
+	gpus, err := parseGPUs(gpuOut)
+	if err != nil {
+		return nil, err
+	}
+	procOut, err := nvidiaSMI("--query-compute-apps=pid,gpu_uuid,used_memory", "--format=csv,noheader,nounits")
+	if err != nil {
+		return nil, err
+	}
+	procs, err := parseGPUProcesses(procOut)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make(map[int]string, len(procs))
+	for _, p := range procs {
+		if data, err := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", p.PID)); err == nil {
+			owners[p.PID] = containerIDFromCgroup(string(data))
+		}
+	}
+	return gpuUsage(requests, gpus, procs, owners), nil
+}
+
+func nvidiaSMI(args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "nvidia-smi", args...)
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	logging.Command(cmd, start, err)
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("nvidia-smi: %s", msg)
+	}
+	return output, nil
+}
+
+// gpuUsage works out each container's share. The GPUs its processes run on
+// win over the ones it requested, a request for "all" may use just one.
+func gpuUsage(requests map[string]string, gpus []GPU, procs []GPUProcess, owners map[int]string) map[string]GPUUsage {
+	out := make(map[string]GPUUsage, len(requests))
+	for id, request := range requests {
+		var usage GPUUsage
+		var used []string
+		for _, p := range procs {
+			if owner := owners[p.PID]; owner != "" && strings.HasPrefix(owner, id) {
+				usage.MemUsed += p.MemUsed
+				if !slices.Contains(used, p.GPUUUID) {
+					used = append(used, p.GPUUUID)
+				}
+			}
+		}
+		for _, g := range gpus {
+			if len(used) > 0 {
+				if !slices.Contains(used, g.UUID) {
+					continue
+				}
+			} else if !gpuRequested(request, g) {
+				continue
+			}
+			usage.GPUs = append(usage.GPUs, g.Index)
+			usage.Utilization += g.Utilization
+			usage.MemTotal += g.MemTotal
+		}
+		if len(usage.GPUs) > 0 {
+			usage.Utilization /= len(usage.GPUs)
+		}
+		out[id] = usage
+	}
+	return out
+}
+
+// gpuRequested matches a request ("all", "2" for a count, or device IDs like
+// "0,1" or "GPU-3b1...") against a GPU. A count could be any of them, it's
+// taken as the first ones.
+func gpuRequested(request string, g GPU) bool {
+	if request == "all" {
+		return true
+	}
+	if n, ok := strings.CutSuffix(request, " GPUs"); ok {
+		count, _ := strconv.Atoi(n)
+		return g.Index < count
+	}
+	for _, dev := range strings.Split(request, ",") {
+		if dev == strconv.Itoa(g.Index) || dev == g.UUID {
+			return true
+		}
+	}
+	return false
+}
+
+func parseGPUs(output []byte) ([]GPU, error) {
+	records, err := readSMICSV(output)
+	if err != nil {
+		return nil, err
+	}
+	var gpus []GPU
+	for _, r := range records {
+		if len(r) < 6 {
+			continue
+		}
+		index, err := strconv.Atoi(r[0])
+		if err != nil {
+			continue
+		}
+		gpus = append(gpus, GPU{
+			Index:       index,
+			UUID:        r[1],
+			Name:        r[2],
+			Utilization: smiInt(r[3]),
+			MemUsed:     int64(smiInt(r[4])) << 20,
+			MemTotal:    int64(smiInt(r[5])) << 20,
+		})
+	}
+	return gpus, nil
+}
+
+func parseGPUProcesses(output []byte) ([]GPUProcess, error) {
+	records, err := readSMICSV(output)
+	if err != nil {
+		return nil, err
+	}
+	var procs []GPUProcess
+	for _, r := range records {
+		if len(r) < 3 {
+			continue
+		}
+		pid, err := strconv.Atoi(r[0])
+		if err != nil {
+			continue
+		}
+		procs = append(procs, GPUProcess{PID: pid, GPUUUID: r[1], MemUsed: int64(smiInt(r[2])) << 20})
+	}
+	return procs, nil
+}
+
+func readSMICSV(output []byte) ([][]string, error) {
+	r := csv.NewReader(strings.NewReader(string(output)))
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parsing nvidia-smi output: %w", err)
+	}
+	return records, nil
+}
+
+// smiInt reads a number column, nvidia-smi prints "[N/A]" for what it doesn't know
+func smiInt(s string) int {
+	n, _ := strconv.Atoi(strings.TrimSpace(s))
+	return n
+}
+
+// the container ID in a cgroup path, e.g. "0::/system.slice/docker-<id>.scope",
+// "/docker/<id>" or podman's "libpod-<id>.scope"
+var cgroupContainerID = regexp.MustCompile(`(?:docker|libpod|cri-containerd|nerdctl)[-/]([0-9a-f]{64})`)
+
+func containerIDFromCgroup(content string) string {
+	if m := cgroupContainerID.FindStringSubmatch(content); m != nil {
+		return m[1]
+	}
+	return ""
+}
@@ -0,0 +1,50 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGPUs(t *testing.T) {
+	output := []byte(`0, GPU-3b1c2d4e, NVIDIA GeForce RTX 4090, 87, 20150, 24564
+1, GPU-9f8e7d6c, NVIDIA GeForce RTX 4090, [N/A], 3, 24564
+`)
+	gpus, err := parseGPUs(output)
+	require.NoError(t, err)
+	require.Len(t, gpus, 2)
+	assert.Equal(t, GPU{Index: 0, UUID: "GPU-3b1c2d4e", Name: "NVIDIA GeForce RTX 4090", Utilization: 87, MemUsed: 20150 << 20, MemTotal: 24564 << 20}, gpus[0])
+	assert.Zero(t, gpus[1].Utilization)
+
+	procs, err := parseGPUProcesses([]byte("4242, GPU-3b1c2d4e, 18000\n5151, GPU-3b1c2d4e, 2000\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []GPUProcess{{PID: 4242, GPUUUID: "GPU-3b1c2d4e", MemUsed: 18000 << 20}, {PID: 5151, GPUUUID: "GPU-3b1c2d4e", MemUsed: 2000 << 20}}, procs)
+}
+
+func TestContainerIDFromCgroup(t *testing.T) {
+	id := "3f2a1b0c9d8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a3b2c1d0e9f8a7b6c5d4e3f2a"
+	assert.Equal(t, id, containerIDFromCgroup("0::/system.slice/docker-"+id+".scope\n"))
+	assert.Equal(t, id, containerIDFromCgroup("12:memory:/docker/"+id+"\n"))
+	assert.Equal(t, id, containerIDFromCgroup("0::/machine.slice/libpod-"+id+".scope/container\n"))
+	assert.Empty(t, containerIDFromCgroup("0::/user.slice/user-1000.slice/session-2.scope\n"))
+}
+
+func TestGPUUsage(t *testing.T) {
+	gpus := []GPU{
+		{Index: 0, UUID: "GPU-a", Utilization: 90, MemTotal: 24 << 30},
+		{Index: 1, UUID: "GPU-b", Utilization: 10, MemTotal: 24 << 30},
+	}
+	procs := []GPUProcess{{PID: 10, GPUUUID: "GPU-b", MemUsed: 3 << 30}}
+	owners := map[int]string{10: "aaaaaaaaaaaa0000"}
+
+	usage := gpuUsage(map[string]string{
+		"aaaaaaaaaaaa": "all", // running on GPU-b only
+		"bbbbbbbbbbbb": "0",   // idle, by request
+		"cccccccccccc": "all",
+	}, gpus, procs, owners)
+
+	assert.Equal(t, GPUUsage{GPUs: []int{1}, Utilization: 10, MemUsed: 3 << 30, MemTotal: 24 << 30}, usage["aaaaaaaaaaaa"])
+	assert.Equal(t, GPUUsage{GPUs: []int{0}, Utilization: 90, MemTotal: 24 << 30}, usage["bbbbbbbbbbbb"])
+	assert.Equal(t, GPUUsage{GPUs: []int{0, 1}, Utilization: 50, MemTotal: 48 << 30}, usage["cccccccccccc"])
+}
@@ -0,0 +1,134 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ImageLayer is one step of an image's build history, newest first like `docker history`
+type ImageLayer struct {
+	ID        string // "<missing>" for layers pulled from a registry
+	Created   time.Time
+	CreatedBy string
+	Size      int64 // bytes, -1 if unknown
+	Comment   string
+}
+
+// ImageHistory returns the layers of image, newest first
+func ImageHistory(image string) ([]ImageLayer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	rt := runtimeBin()
+	format := "{{json .}}"
+	if rt == "podman" {
+		format = "json"
+	}
+	output, err := exec.CommandContext(ctx, rt, "history", "--no-trunc", "--format", format, image).CombinedOutput()
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("history of %s: %s", image, msg)
+	}
+	return parseHistory(output)
+}
+
+// parseHistory reads docker/nerdctl lines ({"Size":"7.38MB","CreatedAt":"..."})
+// as well as podman's array ({"size":7380000,"created":"..."})
+func parseHistory(output []byte) ([]ImageLayer, error) {
+	type historyEntry struct {
+		ID        string          `json:"ID"`
+		CreatedAt string          `json:"CreatedAt"`
+		Created   string          `json:"Created"`
+		CreatedBy string          `json:"CreatedBy"`
+		Size      json.RawMessage `json:"Size"`
+		Comment   string          `json:"Comment"`
+	}
+
+	entries, err := decodeEntries[historyEntry](output)
+	if err != nil {
+		return nil, err
+	}
+
+	layers := make([]ImageLayer, 0, len(entries))
+	for _, e := range entries {
+		created := e.CreatedAt
+		if created == "" {
+			created = e.Created
+		}
+		layers = append(layers, ImageLayer{
+			ID:        e.ID,
+			Created:   parseHistoryTime(created),
+			CreatedBy: cleanCreatedBy(e.CreatedBy),
+			Size:      historySize(e.Size),
+			Comment:   e.Comment,
+		})
+	}
+	return layers, nil
+}
+
+func historySize(raw json.RawMessage) int64 {
+	var n int64
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return parseSizeBytes(s)
+	}
+	return -1
+}
+
+func parseHistoryTime(s string) time.Time {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05 -0700 MST", "2006-01-02 15:04:05.999999999 -0700 MST"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// cleanCreatedBy drops the shell noise docker puts in front of build steps
+func cleanCreatedBy(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "/bin/sh -c #(nop) ")
+	if rest, ok := strings.CutPrefix(s, "/bin/sh -c "); ok {
+		s = "RUN " + rest
+	}
+	return strings.TrimSpace(s)
+}
+
+// BiggestLayers returns the indexes of the n largest non-empty layers
+func BiggestLayers(layers []ImageLayer, n int) map[int]bool {
+	idx := make([]int, 0, len(layers))
+	for i, l := range layers {
+		if l.Size > 0 {
+			idx = append(idx, i)
+		}
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return layers[idx[a]].Size > layers[idx[b]].Size })
+
+	out := make(map[int]bool)
+	for _, i := range idx[:min(n, len(idx))] {
+		out[i] = true
+	}
+	return out
+}
+
+// TotalSize adds up the known layer sizes
+func TotalSize(layers []ImageLayer) int64 {
+	var total int64
+	for _, l := range layers {
+		if l.Size > 0 {
+			total += l.Size
+		}
+	}
+	return total
+}
@@ -0,0 +1,43 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHistoryDocker(t *testing.T) {
+	output := []byte(`{"Comment":"","CreatedAt":"2024-05-01T10:00:00Z","CreatedBy":"/bin/sh -c #(nop)  CMD [\"nginx\"]","CreatedSince":"5 months ago","ID":"sha256:aaa","Size":"0B"}
+{"Comment":"buildkit.dockerfile.v0","CreatedAt":"2024-05-01T09:59:00Z","CreatedBy":"/bin/sh -c apt-get update && apt-get install -y curl","CreatedSince":"5 months ago","ID":"<missing>","Size":"52.3MB"}
+{"Comment":"","CreatedAt":"2024-04-01T00:00:00Z","CreatedBy":"/bin/sh -c #(nop) ADD file:abc in / ","CreatedSince":"6 months ago","ID":"<missing>","Size":"74.8MB"}
+`)
+	layers, err := parseHistory(output)
+	require.NoError(t, err)
+	require.Len(t, layers, 3)
+
+	assert.Equal(t, `CMD ["nginx"]`, layers[0].CreatedBy)
+	assert.Equal(t, int64(0), layers[0].Size)
+	assert.Equal(t, "RUN apt-get update && apt-get install -y curl", layers[1].CreatedBy)
+	assert.Equal(t, int64(52_300_000), layers[1].Size)
+	assert.Equal(t, "<missing>", layers[2].ID)
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), layers[2].Created)
+
+	assert.Equal(t, map[int]bool{2: true, 1: true}, BiggestLayers(layers, 3))
+	assert.Equal(t, map[int]bool{2: true}, BiggestLayers(layers, 1))
+	assert.Equal(t, int64(127_100_000), TotalSize(layers))
+}
+
+func TestParseHistoryPodman(t *testing.T) {
+	output := []byte(`[{"id":"abc","created":"2024-05-01T10:00:00.123Z","createdBy":"/bin/sh -c #(nop) ENV FOO=bar","size":0,"comment":""},
+{"id":"def","created":"2024-05-01T09:00:00Z","createdBy":"COPY . /app","size":1048576,"comment":"FROM base"}]`)
+	layers, err := parseHistory(output)
+	require.NoError(t, err)
+	require.Len(t, layers, 2)
+
+	assert.Equal(t, "ENV FOO=bar", layers[0].CreatedBy)
+	assert.Equal(t, int64(1048576), layers[1].Size)
+	assert.Equal(t, "FROM base", layers[1].Comment)
+	assert.False(t, layers[0].Created.IsZero())
+}
@@ -0,0 +1,220 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+// InspectInfo is what the list refresh takes from inspect, the parts that
+// `ps` doesn't print
+type InspectInfo struct {
+	RestartCount int // restarts by the restart policy, manual restarts don't count
+	OOMKilled    bool
+	ExitCode     int
+	StartedAt    time.Time // last start, for the uptime column
+	FinishedAt   time.Time // zero if it never stopped
+	Networks     []ContainerNetwork
+	Mounts       []Mount
+	CPULimit     float64 // in CPUs, 0 without a limit
+	MemoryLimit  int64   // in bytes, 0 without a limit
+	// RestartPolicy is no, always, unless-stopped or on-failure, with the
+	// retry limit as "on-failure:5"
+	RestartPolicy string
+	// GPUs is the container's GPU request: "all", a count like "2 GPUs" or
+	// device IDs like "0,1". Empty without GPUs.
+	GPUs string
+}
+
+// ContainerNetwork is one network a container is attached to
+type ContainerNetwork struct {
+	Name string
+	IP   string // empty while stopped
+	IPv6 string
+}
+
+// Mount is one of a container's volumes, bind mounts or tmpfs mounts
+type Mount struct {
+	Type        string // bind, volume, tmpfs
+	Name        string // volumes only
+	Source      string // host path, empty for tmpfs
+	Destination string
+	RW          bool
+}
+
+// BindSources lists the host paths of the bind mounts
+func (i InspectInfo) BindSources() []string {
+	var paths []string
+	for _, mt := range i.Mounts {
+		if mt.Type == "bind" && mt.Source != "" {
+			paths = append(paths, mt.Source)
+		}
+	}
+	return paths
+}
+
+// NetworkNames lists the attached networks, sorted
+func (i InspectInfo) NetworkNames() []string {
+	names := make([]string, 0, len(i.Networks))
+	for _, n := range i.Networks {
+		names = append(names, n.Name)
+	}
+	return names
+}
+
+// IPs lists the container's IPv4 addresses, in network order
+func (i InspectInfo) IPs() []string {
+	var ips []string
+	for _, n := range i.Networks {
+		if n.IP != "" {
+			ips = append(ips, n.IP)
+		}
+	}
+	return ips
+}
+
+// InspectContainers inspects the containers in one call. The map is keyed by
+// the IDs as passed in, usually the short ones of the list.
+func InspectContainers(ids []string) (map[string]InspectInfo, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	args := append([]string{"inspect", "--type", "container"}, ids...)
+	cmd := exec.CommandContext(ctx, runtimeBin(), args...)
+	start := time.Now()
+	// a container removed since the list still prints the others, with an error
+	output, err := cmd.Output()
+	logging.Command(cmd, start, err)
+	if err != nil && len(output) == 0 {
+		return nil, fmt.Errorf("inspecting containers: %w", err)
+	}
+	return parseInspectInfo(output, ids)
+}
+
+func parseInspectInfo(output []byte, ids []string) (map[string]InspectInfo, error) {
+	var entries []struct {
+		ID           string `json:"Id"`
+		RestartCount int    `json:"RestartCount"`
+		State        struct {
+			OOMKilled  bool   `json:"OOMKilled"`
+			ExitCode   int    `json:"ExitCode"`
+			StartedAt  string `json:"StartedAt"`
+			FinishedAt string `json:"FinishedAt"`
+		} `json:"State"`
+		NetworkSettings struct {
+			Networks map[string]struct {
+				IPAddress         string `json:"IPAddress"`
+				GlobalIPv6Address string `json:"GlobalIPv6Address"`
+			} `json:"Networks"`
+		} `json:"NetworkSettings"`
+		HostConfig struct {
+			NanoCpus  int64 `json:"NanoCpus"`
+			CpuQuota  int64 `json:"CpuQuota"`
+			CpuPeriod int64 `json:"CpuPeriod"`
+			Memory    int64 `json:"Memory"`
+			// Name is empty for "no" on older docker
+			RestartPolicy struct {
+				Name              string `json:"Name"`
+				MaximumRetryCount int    `json:"MaximumRetryCount"`
+			} `json:"RestartPolicy"`
+			// --gpus
+			DeviceRequests []struct {
+				Driver       string     `json:"Driver"`
+				Count        int        `json:"Count"`
+				DeviceIDs    []string   `json:"DeviceIDs"`
+				Capabilities [][]string `json:"Capabilities"`
+			} `json:"DeviceRequests"`
+		} `json:"HostConfig"`
+		Mounts []struct {
+			Type        string `json:"Type"`
+			Name        string `json:"Name"`
+			Source      string `json:"Source"`
+			Destination string `json:"Destination"`
+			RW          bool   `json:"RW"`
+		} `json:"Mounts"`
+	}
+	if err := json.Unmarshal(output, &entries); err != nil {
+		return nil, fmt.Errorf("parsing inspect output: %w", err)
+	}
+
+	out := make(map[string]InspectInfo, len(entries))
+	for _, e := range entries {
+		info := InspectInfo{
+			RestartCount: e.RestartCount,
+			OOMKilled:    e.State.OOMKilled,
+			ExitCode:     e.State.ExitCode,
+			StartedAt:    parseStateTime(e.State.StartedAt),
+			FinishedAt:   parseStateTime(e.State.FinishedAt),
+			MemoryLimit:  e.HostConfig.Memory,
+		}
+		info.RestartPolicy = restartPolicy(e.HostConfig.RestartPolicy.Name, e.HostConfig.RestartPolicy.MaximumRetryCount)
+		for _, r := range e.HostConfig.DeviceRequests {
+			isGPU := r.Driver == "nvidia"
+			for _, caps := range r.Capabilities {
+				isGPU = isGPU || slices.Contains(caps, "gpu")
+			}
+			switch {
+			case !isGPU:
+			case len(r.DeviceIDs) > 0:
+				info.GPUs = strings.Join(r.DeviceIDs, ",")
+			case r.Count < 0:
+				info.GPUs = "all"
+			case r.Count > 0:
+				info.GPUs = fmt.Sprintf("%d GPUs", r.Count)
+			}
+		}
+		// --cpus sets NanoCpus, the older --cpu-quota/--cpu-period pair the other two
+		switch hc := e.HostConfig; {
+		case hc.NanoCpus > 0:
+			info.CPULimit = float64(hc.NanoCpus) / 1e9
+		case hc.CpuQuota > 0 && hc.CpuPeriod > 0:
+			info.CPULimit = float64(hc.CpuQuota) / float64(hc.CpuPeriod)
+		}
+		for name, n := range e.NetworkSettings.Networks {
+			info.Networks = append(info.Networks, ContainerNetwork{Name: name, IP: n.IPAddress, IPv6: n.GlobalIPv6Address})
+		}
+		sort.Slice(info.Networks, func(a, b int) bool {
+			return info.Networks[a].Name < info.Networks[b].Name
+		})
+		for _, mt := range e.Mounts {
+			info.Mounts = append(info.Mounts, Mount(mt))
+		}
+		for _, id := range ids {
+			if strings.HasPrefix(e.ID, id) {
+				out[id] = info
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+func restartPolicy(name string, retries int) string {
+	switch {
+	case name == "":
+		return "no"
+	case name == "on-failure" && retries > 0:
+		return fmt.Sprintf("on-failure:%d", retries)
+	}
+	return name
+}
+
+// parseStateTime reads inspect's start and finish times, which are
+// "0001-01-01T00:00:00Z" until the first start or stop
+func parseStateTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil || t.Year() <= 1 {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,60 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInspectInfo(t *testing.T) {
+	output := []byte(`[
+  {"Id":"3f2a1b0c9d8e7f6a5b4c","RestartCount":7,"State":{"OOMKilled":true,"ExitCode":137,"StartedAt":"2024-05-01T11:59:58Z","FinishedAt":"2024-05-01T12:00:00.5Z"},
+   "NetworkSettings":{"Networks":{"shop_default":{"IPAddress":"172.19.0.4","GlobalIPv6Address":""},"bridge":{"IPAddress":"172.17.0.2","GlobalIPv6Address":"fd00::2"}}},
+   "Mounts":[{"Type":"bind","Source":"/srv/shop/conf","Destination":"/etc/shop","Mode":"ro","RW":false,"Propagation":"rprivate"},
+             {"Type":"volume","Name":"pgdata","Source":"/var/lib/docker/volumes/pgdata/_data","Destination":"/var/lib/postgresql/data","Driver":"local","Mode":"z","RW":true}],
+   "HostConfig":{"NanoCpus":1500000000,"CpuQuota":0,"CpuPeriod":0,"Memory":536870912,"RestartPolicy":{"Name":"on-failure","MaximumRetryCount":5},
+     "DeviceRequests":[{"Driver":"","Count":-1,"DeviceIDs":null,"Capabilities":[["gpu"]],"Options":{}}]}},
+  {"Id":"aa11bb22cc33dd44ee55","RestartCount":0,"State":{"OOMKilled":false,"ExitCode":0,"FinishedAt":"0001-01-01T00:00:00Z"},
+   "NetworkSettings":{"Networks":{"bridge":{"IPAddress":""}}},
+   "HostConfig":{"NanoCpus":0,"CpuQuota":50000,"CpuPeriod":100000,"Memory":0,"RestartPolicy":{"Name":"","MaximumRetryCount":0}}}
+]`)
+	infos, err := parseInspectInfo(output, []string{"3f2a1b0c9d8e", "aa11bb22cc33"})
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	assert.Equal(t, InspectInfo{
+		RestartCount: 7,
+		OOMKilled:    true,
+		ExitCode:     137,
+		StartedAt:    time.Date(2024, 5, 1, 11, 59, 58, 0, time.UTC),
+		FinishedAt:   time.Date(2024, 5, 1, 12, 0, 0, 500000000, time.UTC),
+		Networks: []ContainerNetwork{
+			{Name: "bridge", IP: "172.17.0.2", IPv6: "fd00::2"},
+			{Name: "shop_default", IP: "172.19.0.4"},
+		},
+		Mounts: []Mount{
+			{Type: "bind", Source: "/srv/shop/conf", Destination: "/etc/shop"},
+			{Type: "volume", Name: "pgdata", Source: "/var/lib/docker/volumes/pgdata/_data", Destination: "/var/lib/postgresql/data", RW: true},
+		},
+		CPULimit:      1.5,
+		MemoryLimit:   512 << 20,
+		RestartPolicy: "on-failure:5",
+		GPUs:          "all",
+	}, infos["3f2a1b0c9d8e"])
+	assert.Equal(t, []string{"/srv/shop/conf"}, infos["3f2a1b0c9d8e"].BindSources())
+	assert.Equal(t, []string{"bridge", "shop_default"}, infos["3f2a1b0c9d8e"].NetworkNames())
+	assert.Equal(t, []string{"172.17.0.2", "172.19.0.4"}, infos["3f2a1b0c9d8e"].IPs())
+
+	stopped := infos["aa11bb22cc33"]
+	assert.True(t, stopped.FinishedAt.IsZero())
+	assert.Equal(t, []string{"bridge"}, stopped.NetworkNames())
+	assert.Empty(t, stopped.IPs())
+	assert.Equal(t, 0.5, stopped.CPULimit)
+	assert.Zero(t, stopped.MemoryLimit)
+	assert.Equal(t, "no", stopped.RestartPolicy)
+	assert.Empty(t, stopped.GPUs)
+
+	_, err = parseInspectInfo([]byte("Error: No such container"), []string{"x"})
+	assert.Error(t, err)
+}
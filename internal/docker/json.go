@@ -0,0 +1,32 @@
+package docker
+
+import (
+	"bufio"
+	"encoding/json"
+	"strings"
+)
+
+// decodeEntries reads runtime JSON output that is either a single JSON array
+// (podman --format json) or one object per line (docker/podman {{json .}})
+func decodeEntries[T any](output []byte) ([]T, error) {
+	var entries []T
+	if err := json.Unmarshal(output, &entries); err == nil {
+		return entries, nil
+	}
+
+	entries = nil
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var e T
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			continue // skip weird lines
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
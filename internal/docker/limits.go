@@ -0,0 +1,94 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+// UpdateLimits changes a running container's limits with `docker update`.
+// cpus 0 removes the CPU limit. memory 0 leaves the memory limit as it is,
+// the runtimes can't take one away once it's set.
+func UpdateLimits(containerID string, cpus float64, memory int64) error {
+	args := []string{"update", "--cpus", strconv.FormatFloat(cpus, 'f', -1, 64)}
+	if memory > 0 {
+		// swap has to stay above memory, so it's set to twice it like
+		// `docker run --memory` does
+		args = append(args, "--memory", strconv.FormatInt(memory, 10), "--memory-swap", strconv.FormatInt(2*memory, 10))
+	}
+	return updateContainer(append(args, containerID)...)
+}
+
+// UpdateRestartPolicy sets the container's restart policy, one of no, always,
+// unless-stopped, on-failure or on-failure:N
+func UpdateRestartPolicy(containerID, policy string) error {
+	return updateContainer("update", "--restart", policy, containerID)
+}
+
+func updateContainer(args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	runtime := runtimeBin()
+	cmd := exec.CommandContext(ctx, runtime, args...)
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	logging.Command(cmd, start, err)
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return fmt.Errorf("%s update: %s", runtime, msg)
+	}
+	return nil
+}
+
+// ParseMemory reads a memory size the way `docker run --memory` does,
+// e.g. "512m", "1.5g" or "268435456". The units are binary.
+func ParseMemory(input string) (int64, error) {
+	s := strings.ToLower(strings.TrimSpace(input))
+	s = strings.TrimSuffix(s, "ib")
+	s = strings.TrimSuffix(s, "b")
+	mult := int64(1)
+	if s != "" {
+		switch s[len(s)-1] {
+		case 'k':
+			mult = 1 << 10
+		case 'm':
+			mult = 1 << 20
+		case 'g':
+			mult = 1 << 30
+		case 't':
+			mult = 1 << 40
+		}
+		if mult > 1 {
+			s = s[:len(s)-1]
+		}
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%q is not a memory size like 512m or 2g", input)
+	}
+	return int64(n * float64(mult)), nil
+}
+
+// FormatMemory is the reverse of ParseMemory in the largest unit that fits
+// exactly, e.g. "512m", "2g" or "1536m"
+func FormatMemory(n int64) string {
+	units := []struct {
+		size   int64
+		suffix string
+	}{{1 << 40, "t"}, {1 << 30, "g"}, {1 << 20, "m"}, {1 << 10, "k"}}
+	for _, u := range units {
+		if n >= u.size && n%u.size == 0 {
+			return strconv.FormatInt(n/u.size, 10) + u.suffix
+		}
+	}
+	return strconv.FormatInt(n, 10)
+}
@@ -0,0 +1,36 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMemory(t *testing.T) {
+	cases := map[string]int64{
+		"512m":      512 << 20,
+		"512M":      512 << 20,
+		"2g":        2 << 30,
+		"1.5GiB":    1536 << 20,
+		"64kb":      64 << 10,
+		"268435456": 268435456,
+	}
+	for in, want := range cases {
+		got, err := ParseMemory(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, bad := range []string{"", "lots", "-1g", "2x"} {
+		_, err := ParseMemory(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestFormatMemory(t *testing.T) {
+	assert.Equal(t, "512m", FormatMemory(512<<20))
+	assert.Equal(t, "2g", FormatMemory(2<<30))
+	assert.Equal(t, "1536m", FormatMemory(1536<<20))
+	assert.Equal(t, "1000", FormatMemory(1000))
+}
@@ -0,0 +1,101 @@
+package docker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+// LogLine is one line of a container's log with the time the runtime got it
+type LogLine struct {
+	Time      time.Time
+	Container string // the ID it was asked for with
+	Text      string
+}
+
+// GetInterleavedLogs tails the logs of several containers at once, merged in
+// time order like `docker compose logs`, tail lines of each or the ones within
+// opts.Since. Timestamps are always asked for, they do the ordering. A
+// container that fails (removed in the meantime) is left out, the error only
+// comes back when all of them fail.
+func GetInterleavedLogs(ids []string, tail int, opts LogOptions) ([]LogLine, error) {
+	opts.Timestamps = true
+	args := append([]string{"logs"}, opts.args(tail)...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		lines []LogLine
+		errs  []error
+	)
+	for _, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runtime := runtimeBin()
+			cmd := exec.CommandContext(ctx, runtime, append(slices.Clone(args), id)...)
+			start := time.Now()
+			// stdout and stderr together, the timestamps put them back in order
+			output, err := cmd.CombinedOutput()
+			logging.Command(cmd, start, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				msg := strings.TrimSpace(string(output))
+				if msg == "" {
+					msg = err.Error()
+				}
+				errs = append(errs, fmt.Errorf("%s logs %s: %s", runtime, id, msg))
+				return
+			}
+			lines = append(lines, parseTimedLogs(id, output)...)
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) == len(ids) && len(ids) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	slices.SortStableFunc(lines, func(a, b LogLine) int {
+		return a.Time.Compare(b.Time)
+	})
+	return lines, nil
+}
+
+// parseTimedLogs reads `logs --timestamps` output, "2024-05-01T12:00:00.123456789Z message".
+// A line without a timestamp keeps the one before it.
+func parseTimedLogs(container string, out []byte) []LogLine {
+	var lines []LogLine
+	var last time.Time
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		text := strings.TrimRight(scanner.Text(), " \r")
+		if text == "" {
+			continue
+		}
+		if stamp, rest, ok := strings.Cut(text, " "); ok {
+			if t, err := time.Parse(time.RFC3339Nano, stamp); err == nil {
+				last, text = t, rest
+			}
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		lines = append(lines, LogLine{Time: last, Container: container, Text: text})
+	}
+	return lines
+}
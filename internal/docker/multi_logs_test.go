@@ -0,0 +1,33 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimedLogs(t *testing.T) {
+	out := []byte("2024-05-01T12:00:00.500000000Z listening on :8080\n" +
+		"\n" +
+		"2024-05-01T12:00:01.25+00:00 GET / 200\n" +
+		"  at main.go:12\n")
+
+	lines := parseTimedLogs("abc", out)
+	require.Len(t, lines, 3)
+	assert.Equal(t, "listening on :8080", lines[0].Text)
+	assert.Equal(t, "abc", lines[0].Container)
+	assert.True(t, lines[0].Time.Equal(time.Date(2024, 5, 1, 12, 0, 0, 500000000, time.UTC)))
+	assert.Equal(t, "GET / 200", lines[1].Text)
+	assert.True(t, lines[1].Time.Equal(time.Date(2024, 5, 1, 12, 0, 1, 250000000, time.UTC)))
+	// continuation lines keep the time of the line before
+	assert.Equal(t, "  at main.go:12", lines[2].Text)
+	assert.Equal(t, lines[1].Time, lines[2].Time)
+}
+
+func TestLogOptionsArgs(t *testing.T) {
+	assert.Equal(t, []string{"--tail", "100"}, LogOptions{}.args(100))
+	assert.Equal(t, []string{"--timestamps", "--since", "1h0m0s", "--tail", "1000"},
+		LogOptions{Timestamps: true, Since: time.Hour}.args(100))
+}
@@ -0,0 +1,38 @@
+package docker
+
+import "strings"
+
+// nerdctl keeps its own bookkeeping in labels on every container
+const nerdctlNameLabel = "nerdctl/name"
+
+// normalizeState keeps the states the UI knows about from a `ps` State field,
+// falling back to the status text for anything else (or nothing)
+func normalizeState(state, status string) string {
+	switch st := strings.ToLower(strings.TrimSpace(state)); st {
+	case "running", "paused", "restarting", "exited", "created":
+		return st
+	case "dead":
+		return "exited"
+	}
+	return stateFromStatus(status)
+}
+
+// stateFromStatus derives the state from a human readable status
+// ("Up 5 minutes", "Exited (0) 2 hours ago") for runtimes whose `ps`
+// doesn't print State, like older docker and nerdctl
+func stateFromStatus(status string) string {
+	st := strings.ToLower(strings.TrimSpace(status))
+	switch {
+	case strings.Contains(st, "paused"):
+		return "paused"
+	case strings.Contains(st, "restarting"):
+		return "restarting"
+	case strings.HasPrefix(st, "up"):
+		return "running"
+	case strings.Contains(st, "exited"), strings.Contains(st, "dead"):
+		return "exited"
+	case strings.HasPrefix(st, "created"):
+		return "created"
+	}
+	return "unknown"
+}
@@ -0,0 +1,44 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeState(t *testing.T) {
+	tests := []struct {
+		state  string
+		status string
+		want   string
+	}{
+		{"running", "Up 5 minutes", "running"},
+		{"", "Up 5 minutes", "running"},
+		{"", "Up", "running"},
+		{"", "Up 2 hours (Paused)", "paused"},
+		{"", "Exited (0) 2 hours ago", "exited"},
+		{"", "Restarting (1) 3 seconds ago", "restarting"},
+		{"", "Created", "created"},
+		{"dead", "Dead", "exited"},
+		{"removing", "Removal In Progress", "unknown"},
+		{"", "", "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state+"/"+tt.status, func(t *testing.T) {
+			assert.Equal(t, tt.want, normalizeState(tt.state, tt.status))
+		})
+	}
+}
+
+func TestParseLabelsWithCommasInValues(t *testing.T) {
+	// nerdctl stores JSON in some of its own labels
+	labels := parseLabels(`com.docker.compose.project=shop,nerdctl/networks=["bridge","backend"],nerdctl/name=shop-web-1`)
+
+	assert.Equal(t, "shop", labels["com.docker.compose.project"])
+	assert.Equal(t, `["bridge","backend"]`, labels["nerdctl/networks"])
+	assert.Equal(t, "shop-web-1", labels[nerdctlNameLabel])
+	assert.Len(t, labels, 3)
+
+	assert.Empty(t, parseLabels(""))
+}
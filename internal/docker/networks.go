@@ -0,0 +1,69 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+// Network is one network from `docker network ls`
+type Network struct {
+	ID     string `json:"ID"`
+	Name   string `json:"Name"`
+	Driver string `json:"Driver"`
+	Scope  string `json:"Scope"` // empty on podman
+}
+
+// ListNetworks returns the runtime's networks sorted by name
+func ListNetworks() ([]Network, error) {
+	output, err := networkCommand(15*time.Second, "ls", "--format", "{{json .}}")
+	if err != nil {
+		return nil, err
+	}
+	return parseNetworks(output)
+}
+
+// ConnectNetwork attaches the container to network, it gets an address right away if running
+func ConnectNetwork(network, containerID string) error {
+	_, err := networkCommand(30*time.Second, "connect", network, containerID)
+	return err
+}
+
+// DisconnectNetwork detaches the container from network
+func DisconnectNetwork(network, containerID string) error {
+	_, err := networkCommand(30*time.Second, "disconnect", network, containerID)
+	return err
+}
+
+func networkCommand(timeout time.Duration, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	runtime := runtimeBin()
+	cmd := exec.CommandContext(ctx, runtime, append([]string{"network"}, args...)...)
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	logging.Command(cmd, start, err)
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("%s network %s: %s", runtime, args[0], msg)
+	}
+	return output, nil
+}
+
+func parseNetworks(output []byte) ([]Network, error) {
+	networks, err := decodeEntries[Network](output)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(networks, func(i, j int) bool { return networks[i].Name < networks[j].Name })
+	return networks, nil
+}
@@ -0,0 +1,25 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNetworks(t *testing.T) {
+	docker := []byte(`{"CreatedAt":"2024-05-01 12:00:00 +0000 UTC","Driver":"bridge","ID":"5e1f0c2a9b3d","IPv6":"false","Internal":"false","Labels":"","Name":"shop_default","Scope":"local"}
+{"CreatedAt":"2024-04-01 08:00:00 +0000 UTC","Driver":"bridge","ID":"a0b1c2d3e4f5","IPv6":"false","Internal":"false","Labels":"","Name":"bridge","Scope":"local"}
+`)
+	networks, err := parseNetworks(docker)
+	require.NoError(t, err)
+	require.Len(t, networks, 2)
+	assert.Equal(t, Network{ID: "a0b1c2d3e4f5", Name: "bridge", Driver: "bridge", Scope: "local"}, networks[0])
+	assert.Equal(t, "shop_default", networks[1].Name)
+
+	// podman prints lowercase keys
+	podman := []byte(`{"name":"podman","id":"2f259bab93aa","driver":"bridge","network_interface":"podman0"}`)
+	networks, err = parseNetworks(podman)
+	require.NoError(t, err)
+	assert.Equal(t, []Network{{ID: "2f259bab93aa", Name: "podman", Driver: "bridge"}}, networks)
+}
@@ -0,0 +1,90 @@
+package docker
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// podman serves its API on a socket per user: rootless containers are the
+// user's own, rootful ones root's, and neither sees the other's. DockMate
+// talks to one of them, picked with runtime.socket (settings cycles through
+// both), or to the local podman of whoever runs it when none is set.
+
+// RootfulPodmanSocket is where podman.socket listens for root
+const RootfulPodmanSocket = "/run/podman/podman.sock"
+
+// RootlessPodmanSocket is where the user's podman.socket listens
+func RootlessPodmanSocket() string {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = fmt.Sprintf("/run/user/%d", os.Getuid())
+	}
+	return filepath.Join(dir, "podman", "podman.sock")
+}
+
+// PodmanSocket is one of the two sockets and whether DockMate can use it
+type PodmanSocket struct {
+	Mode      string // rootless or rootful
+	Path      string
+	Exists    bool
+	Reachable bool // it answers, the rootful one only does for root or its group
+}
+
+// PodmanSockets looks at both sockets, rootless first
+func PodmanSockets() []PodmanSocket {
+	sockets := []PodmanSocket{
+		{Mode: "rootless", Path: RootlessPodmanSocket()},
+		{Mode: "rootful", Path: RootfulPodmanSocket},
+	}
+	for i := range sockets {
+		s := &sockets[i]
+		if _, err := os.Stat(s.Path); err == nil {
+			s.Exists = true
+			if conn, err := net.DialTimeout("unix", s.Path, 500*time.Millisecond); err == nil {
+				conn.Close()
+				s.Reachable = true
+			}
+		}
+	}
+	return sockets
+}
+
+// Hint says what it takes to use the socket, empty when it's usable
+func (s PodmanSocket) Hint() string {
+	switch {
+	case s.Reachable:
+		return ""
+	case s.Mode == "rootful" && !s.Exists:
+		return "not running, start it with: sudo systemctl enable --now podman.socket"
+	case s.Mode == "rootful":
+		return "needs root: run DockMate with sudo, or give your user access to " + s.Path
+	case !s.Exists:
+		return "not running, start it with: systemctl --user enable --now podman.socket"
+	default:
+		return "not answering, try: systemctl --user restart podman.socket"
+	}
+}
+
+// PodmanMode is the podman DockMate is connected to: rootless, rootful or
+// remote, from CONTAINER_HOST or else who DockMate runs as
+func PodmanMode() string {
+	host := os.Getenv("CONTAINER_HOST")
+	if host == "" {
+		if os.Geteuid() == 0 {
+			return "rootful"
+		}
+		return "rootless"
+	}
+	switch SocketPath(host) {
+	case RootfulPodmanSocket:
+		return "rootful"
+	case RootlessPodmanSocket():
+		return "rootless"
+	case "":
+		return "remote"
+	}
+	return "custom socket"
+}
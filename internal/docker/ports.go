@@ -0,0 +1,85 @@
+package docker
+
+import (
+	"strconv"
+	"strings"
+)
+
+// PortMapping is one published port, e.g. 0.0.0.0:8080->80/tcp
+type PortMapping struct {
+	HostIP        string
+	HostPort      int
+	ContainerPort int
+	Protocol      string
+}
+
+// ParsePorts reads the ports column as docker/podman print it:
+// "0.0.0.0:8080->80/tcp, :::8080->80/tcp, 0.0.0.0:9000-9001->9000-9001/tcp, 443/tcp".
+// Only published ports are returned; ranges are expanded.
+func ParsePorts(s string) []PortMapping {
+	var out []PortMapping
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		host, ctr, ok := strings.Cut(part, "->")
+		if !ok {
+			continue // exposed but not published
+		}
+
+		ctr, proto, _ := strings.Cut(ctr, "/")
+		if proto == "" {
+			proto = "tcp"
+		}
+
+		i := strings.LastIndex(host, ":")
+		if i < 0 {
+			continue
+		}
+		ip, hostPorts := host[:i], host[i+1:]
+
+		hostFrom, hostTo, ok := parsePortRange(hostPorts)
+		if !ok {
+			continue
+		}
+		ctrFrom, _, ok := parsePortRange(ctr)
+		if !ok {
+			continue
+		}
+
+		for p := hostFrom; p <= hostTo; p++ {
+			out = append(out, PortMapping{
+				HostIP:        ip,
+				HostPort:      p,
+				ContainerPort: ctrFrom + (p - hostFrom),
+				Protocol:      proto,
+			})
+		}
+	}
+	return out
+}
+
+func parsePortRange(s string) (from, to int, ok bool) {
+	a, b, isRange := strings.Cut(s, "-")
+	from, err := strconv.Atoi(a)
+	if err != nil {
+		return 0, 0, false
+	}
+	if !isRange {
+		return from, from, true
+	}
+	to, err = strconv.Atoi(b)
+	if err != nil || to < from {
+		return 0, 0, false
+	}
+	return from, to, true
+}
+
+// PublishedOn returns the mappings that publish the given host port
+func (c Container) PublishedOn(port int) []PortMapping {
+	var out []PortMapping
+	for _, p := range ParsePorts(c.Ports) {
+		if p.HostPort == port {
+			out = append(out, p)
+		}
+	}
+	return out
+}
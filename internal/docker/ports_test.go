@@ -0,0 +1,27 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePorts(t *testing.T) {
+	got := ParsePorts("0.0.0.0:8080->80/tcp, :::8080->80/tcp, 443/tcp, 127.0.0.1:9000-9001->7000-7001/udp")
+	assert.Equal(t, []PortMapping{
+		{HostIP: "0.0.0.0", HostPort: 8080, ContainerPort: 80, Protocol: "tcp"},
+		{HostIP: "::", HostPort: 8080, ContainerPort: 80, Protocol: "tcp"},
+		{HostIP: "127.0.0.1", HostPort: 9000, ContainerPort: 7000, Protocol: "udp"},
+		{HostIP: "127.0.0.1", HostPort: 9001, ContainerPort: 7001, Protocol: "udp"},
+	}, got)
+
+	assert.Empty(t, ParsePorts(""))
+	assert.Empty(t, ParsePorts("5432/tcp"))
+}
+
+func TestPublishedOn(t *testing.T) {
+	c := Container{Ports: "0.0.0.0:5432->5432/tcp, 0.0.0.0:8000-8002->8000-8002/tcp"}
+	assert.Len(t, c.PublishedOn(5432), 1)
+	assert.Equal(t, 8001, c.PublishedOn(8001)[0].ContainerPort)
+	assert.Empty(t, c.PublishedOn(8080))
+}
@@ -0,0 +1,212 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/logging"
+	"gopkg.in/yaml.v3"
+)
+
+// labels compose v2 puts on every service container. environment_file came
+// with compose 2.21, older ones leave it out.
+const (
+	ConfigFilesLabel = "com.docker.compose.project.config_files"
+	EnvFileLabel     = "com.docker.compose.project.environment_file"
+)
+
+// ComposeSetup is how a compose project was brought up: its files and env
+// files from the labels, and the profiles from the files. Compose doesn't
+// label the profiles, the active ones are those of services with containers.
+type ComposeSetup struct {
+	Files    []string
+	EnvFiles []string
+	Profiles []string // every profile the files define
+	Active   []string // profiles of the services that have containers
+}
+
+// Options are the files, env files and active profiles, to run compose
+// commands the way the project was brought up
+func (s ComposeSetup) Options() ComposeOptions {
+	return ComposeOptions{Files: s.Files, EnvFiles: s.EnvFiles, Profiles: s.Active}
+}
+
+// ProjectSetup reads the setup of a project from its containers
+func ProjectSetup(containers []Container) ComposeSetup {
+	var setup ComposeSetup
+	for _, c := range containers {
+		if len(setup.Files) == 0 {
+			setup.Files = labelPaths(c.Labels[ConfigFilesLabel], c.ComposeDirectory)
+		}
+		if len(setup.EnvFiles) == 0 {
+			setup.EnvFiles = labelPaths(c.Labels[EnvFileLabel], c.ComposeDirectory)
+		}
+	}
+
+	profiles := serviceProfiles(setup.Files)
+	defined := make(map[string]bool)
+	for _, ps := range profiles {
+		for _, p := range ps {
+			defined[p] = true
+		}
+	}
+	active := make(map[string]bool)
+	for _, c := range containers {
+		for _, p := range profiles[c.ComposeService] {
+			active[p] = true
+		}
+	}
+	setup.Profiles = sortedKeys(defined)
+	setup.Active = sortedKeys(active)
+	return setup
+}
+
+// ServiceProfiles are the profiles the container's service belongs to
+func ServiceProfiles(c Container) []string {
+	return serviceProfiles(labelPaths(c.Labels[ConfigFilesLabel], c.ComposeDirectory))[c.ComposeService]
+}
+
+// labelPaths splits a comma separated list of paths, relative ones are
+// relative to the project's directory
+func labelPaths(label, dir string) []string {
+	var paths []string
+	for _, p := range strings.Split(label, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if !filepath.IsAbs(p) && dir != "" {
+			p = filepath.Join(dir, p)
+		}
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// profileCache keeps the profiles read from each compose file until it
+// changes, the info panel asks on every render
+var profileCache = struct {
+	sync.Mutex
+	files map[string]cachedProfiles
+}{files: make(map[string]cachedProfiles)}
+
+type cachedProfiles struct {
+	modTime  time.Time
+	profiles map[string][]string
+}
+
+// serviceProfiles reads the profiles of each service from the compose files,
+// a later file overriding an earlier one like compose merges them. Files
+// that can't be read, e.g. on another host, are left out.
+func serviceProfiles(files []string) map[string][]string {
+	profiles := make(map[string][]string)
+	for _, file := range files {
+		for service, ps := range fileProfiles(file) {
+			profiles[service] = ps
+		}
+	}
+	return profiles
+}
+
+func fileProfiles(file string) map[string][]string {
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil
+	}
+	profileCache.Lock()
+	defer profileCache.Unlock()
+	if cached, ok := profileCache.files[file]; ok && cached.modTime.Equal(info.ModTime()) {
+		return cached.profiles
+	}
+
+	var parsed struct {
+		Services map[string]struct {
+			Profiles []string `yaml:"profiles"`
+		} `yaml:"services"`
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	profiles := make(map[string][]string)
+	if yaml.Unmarshal(data, &parsed) == nil {
+		for service, s := range parsed.Services {
+			if len(s.Profiles) > 0 {
+				profiles[service] = s.Profiles
+			}
+		}
+	}
+	profileCache.files[file] = cachedProfiles{modTime: info.ModTime(), profiles: profiles}
+	return profiles
+}
+
+// ComposeOptions pick the files, env files and profiles of a compose command
+type ComposeOptions struct {
+	Files    []string
+	EnvFiles []string
+	Profiles []string
+}
+
+func (o ComposeOptions) args() []string {
+	var args []string
+	for _, f := range o.Files {
+		args = append(args, "-f", f)
+	}
+	for _, f := range o.EnvFiles {
+		args = append(args, "--env-file", f)
+	}
+	for _, p := range o.Profiles {
+		args = append(args, "--profile", p)
+	}
+	return args
+}
+
+// ComposeConfig is the project's effective config, `compose config` with the
+// variables filled in and the files merged
+func ComposeConfig(project, workingDir string, opts ComposeOptions) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cmdConfig := GetComposeCommand()
+	var args []string
+	if cmdConfig.SubCommand != "" {
+		args = append(args, cmdConfig.SubCommand)
+	}
+	if project != "" {
+		args = append(args, "-p", project)
+	}
+	args = append(args, opts.args()...)
+	args = append(args, "config")
+
+	cmd := exec.CommandContext(ctx, cmdConfig.Binary, args...)
+	if workingDir != "" {
+		cmd.Dir = workingDir
+	}
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	logging.Command(cmd, start, err)
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("compose config: %s", msg)
+	}
+	return string(output), nil
+}
@@ -0,0 +1,54 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectSetup(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "compose.yaml"), []byte(`
+services:
+  web:
+    image: nginx
+  debug:
+    image: busybox
+    profiles: [debug]
+  seed:
+    image: busybox
+    profiles: [tools, init]
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "compose.override.yaml"), []byte(`
+services:
+  seed:
+    profiles: [init]
+`), 0644))
+
+	labels := map[string]string{
+		ConfigFilesLabel: "compose.yaml,compose.override.yaml",
+		EnvFileLabel:     filepath.Join(dir, "prod.env"),
+	}
+	containers := []Container{
+		{ComposeService: "web", ComposeDirectory: dir, Labels: labels},
+		{ComposeService: "debug", ComposeDirectory: dir, Labels: labels},
+	}
+
+	setup := ProjectSetup(containers)
+	assert.Equal(t, []string{filepath.Join(dir, "compose.yaml"), filepath.Join(dir, "compose.override.yaml")}, setup.Files)
+	assert.Equal(t, []string{filepath.Join(dir, "prod.env")}, setup.EnvFiles)
+	assert.Equal(t, []string{"debug", "init"}, setup.Profiles)
+	assert.Equal(t, []string{"debug"}, setup.Active)
+	assert.Equal(t, []string{"debug"}, ServiceProfiles(containers[1]))
+	assert.Empty(t, ServiceProfiles(containers[0]))
+
+	assert.Equal(t, []string{
+		"-f", filepath.Join(dir, "compose.yaml"),
+		"-f", filepath.Join(dir, "compose.override.yaml"),
+		"--env-file", filepath.Join(dir, "prod.env"),
+		"--profile", "debug",
+	}, setup.Options().args())
+}
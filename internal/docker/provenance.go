@@ -0,0 +1,89 @@
+package docker
+
+import (
+	"strings"
+	"time"
+)
+
+// OCI image annotations, see https://github.com/opencontainers/image-spec/blob/main/annotations.md.
+// Image labels are copied onto the container, so they show up in Container.Labels.
+const (
+	LabelRevision = "org.opencontainers.image.revision"
+	LabelSource   = "org.opencontainers.image.source"
+	LabelCreated  = "org.opencontainers.image.created"
+	LabelVersion  = "org.opencontainers.image.version"
+)
+
+// Provenance says which source an image was built from
+type Provenance struct {
+	Revision string // VCS commit, usually a git SHA
+	Source   string // repository URL
+	Created  time.Time
+	Version  string
+}
+
+// ImageProvenance reads the OCI build labels of a container's image
+func ImageProvenance(c Container) Provenance {
+	p := Provenance{
+		Revision: strings.TrimSpace(c.Labels[LabelRevision]),
+		Source:   strings.TrimSpace(c.Labels[LabelSource]),
+		Version:  strings.TrimSpace(c.Labels[LabelVersion]),
+	}
+	if created := c.Labels[LabelCreated]; created != "" {
+		p.Created, _ = time.Parse(time.RFC3339, strings.TrimSpace(created))
+	}
+	return p
+}
+
+// Empty reports whether the image has none of the labels
+func (p Provenance) Empty() bool {
+	return p.Revision == "" && p.Source == "" && p.Created.IsZero() && p.Version == ""
+}
+
+// CommitURL links to the revision on the source's web UI, or to the
+// repository itself when there's no revision. Empty if the source isn't a
+// URL we can turn into a web link.
+func (p Provenance) CommitURL() string {
+	repo := repoWebURL(p.Source)
+	if repo == "" {
+		return ""
+	}
+	if p.Revision == "" {
+		return repo
+	}
+	if strings.Contains(repo, "bitbucket.org") {
+		return repo + "/commits/" + p.Revision
+	}
+	if strings.Contains(repo, "gitlab") {
+		return repo + "/-/commit/" + p.Revision
+	}
+	// GitHub, Gitea, Forgejo and most others
+	return repo + "/commit/" + p.Revision
+}
+
+// repoWebURL turns the usual ways of writing a repository into an https URL:
+// https://github.com/org/app(.git), git@github.com:org/app.git, ssh://git@host/org/app
+func repoWebURL(source string) string {
+	s := strings.TrimSpace(source)
+	switch {
+	case strings.HasPrefix(s, "git@"):
+		host, path, ok := strings.Cut(strings.TrimPrefix(s, "git@"), ":")
+		if !ok {
+			return ""
+		}
+		s = "https://" + host + "/" + path
+	case strings.HasPrefix(s, "ssh://"):
+		s = strings.TrimPrefix(s, "ssh://")
+		if _, rest, ok := strings.Cut(s, "@"); ok {
+			s = rest
+		}
+		s = "https://" + s
+	case strings.HasPrefix(s, "git://"):
+		s = "https://" + strings.TrimPrefix(s, "git://")
+	case strings.HasPrefix(s, "http://"), strings.HasPrefix(s, "https://"):
+	default:
+		return ""
+	}
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "/"), ".git")
+	return s
+}
@@ -0,0 +1,52 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageProvenance(t *testing.T) {
+	c := Container{Labels: map[string]string{
+		LabelRevision: "4f2a9c1e0b7d",
+		LabelSource:   "https://github.com/acme/shop",
+		LabelCreated:  "2024-05-01T12:30:00Z",
+		LabelVersion:  "1.4.2",
+	}}
+
+	p := ImageProvenance(c)
+	assert.Equal(t, "4f2a9c1e0b7d", p.Revision)
+	assert.Equal(t, "https://github.com/acme/shop", p.Source)
+	assert.Equal(t, "1.4.2", p.Version)
+	assert.Equal(t, time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC), p.Created)
+	assert.False(t, p.Empty())
+
+	assert.True(t, ImageProvenance(Container{}).Empty())
+}
+
+func TestCommitURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		rev    string
+		want   string
+	}{
+		{"github", "https://github.com/acme/shop", "abc123", "https://github.com/acme/shop/commit/abc123"},
+		{"github .git", "https://github.com/acme/shop.git", "abc123", "https://github.com/acme/shop/commit/abc123"},
+		{"scp style", "git@github.com:acme/shop.git", "abc123", "https://github.com/acme/shop/commit/abc123"},
+		{"ssh url", "ssh://git@gitlab.example.com/acme/shop.git", "abc123", "https://gitlab.example.com/acme/shop/-/commit/abc123"},
+		{"gitlab", "https://gitlab.com/acme/shop/", "abc123", "https://gitlab.com/acme/shop/-/commit/abc123"},
+		{"bitbucket", "https://bitbucket.org/acme/shop", "abc123", "https://bitbucket.org/acme/shop/commits/abc123"},
+		{"no revision", "https://github.com/acme/shop", "", "https://github.com/acme/shop"},
+		{"not a url", "acme/shop", "abc123", ""},
+		{"no source", "", "abc123", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Provenance{Source: tt.source, Revision: tt.rev}
+			assert.Equal(t, tt.want, p.CommitURL())
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+)
+
+// PruneResult is what a prune run removed
+type PruneResult struct {
+	Deleted   int    // number of removed objects
+	Reclaimed string // e.g. "1.2GB", empty if the runtime doesn't say (podman)
+}
+
+// Prune removes stopped containers ("container"), exited and never started
+// alike, dangling images ("image") or unused volumes ("volume") and reports
+// what was freed
+func Prune(kind string) (PruneResult, error) {
+	switch kind {
+	case "container", "image", "volume":
+	default:
+		return PruneResult{}, fmt.Errorf("unknown prune target %q", kind)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	rt := runtimeBin()
+	args := pruneArgs(rt, kind)
+	output, err := exec.CommandContext(ctx, rt, args...).CombinedOutput()
+	if err != nil && slices.Contains(args, "--all") && strings.Contains(string(output), "unknown flag") {
+		// docker before 23 has no --all, and prunes named volumes without it
+		output, err = exec.CommandContext(ctx, rt, kind, "prune", "-f").CombinedOutput()
+	}
+	if err != nil {
+		return PruneResult{}, fmt.Errorf("%s prune failed: %v\nOutput: %s", kind, err, strings.TrimSpace(string(output)))
+	}
+
+	return parsePruneOutput(string(output)), nil
+}
+
+// pruneArgs are the prune command for kind. Since docker 23 `volume prune`
+// only takes anonymous volumes unless it's given --all, nerdctl followed, and
+// podman never needed it (nor has it).
+func pruneArgs(rt, kind string) []string {
+	args := []string{kind, "prune", "-f"}
+	if kind == "volume" && rt != "podman" {
+		args = append(args, "--all")
+	}
+	return args
+}
+
+// parsePruneOutput understands docker's
+//
+//	Deleted Containers:
+//	<id>
+//
+//	Total reclaimed space: 12.3MB
+//
+// and podman's plain list of removed ids
+func parsePruneOutput(output string) PruneResult {
+	var res PruneResult
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		lower := strings.ToLower(line)
+		switch {
+		case line == "":
+		case strings.HasPrefix(lower, "total reclaimed space:"):
+			res.Reclaimed = strings.TrimSpace(line[len("total reclaimed space:"):])
+		case strings.HasSuffix(line, ":"), strings.HasPrefix(lower, "untagged:"):
+			// section headers ("Deleted Containers:") and untag lines aren't removals
+		default:
+			// container ids, volume names, "deleted: sha256:..." image lines
+			res.Deleted++
+		}
+	}
+	return res
+}
@@ -0,0 +1,36 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePruneOutput(t *testing.T) {
+	docker := `Deleted Images:
+untagged: alpine@sha256:1111
+deleted: sha256:2222
+deleted: sha256:3333
+
+Total reclaimed space: 12.3MB
+`
+	res := parsePruneOutput(docker)
+	assert.Equal(t, 2, res.Deleted)
+	assert.Equal(t, "12.3MB", res.Reclaimed)
+
+	podman := "4f2a9c\n9b1e77\n"
+	res = parsePruneOutput(podman)
+	assert.Equal(t, 2, res.Deleted)
+	assert.Equal(t, "", res.Reclaimed)
+
+	res = parsePruneOutput("Total reclaimed space: 0B\n")
+	assert.Equal(t, 0, res.Deleted)
+	assert.Equal(t, "0B", res.Reclaimed)
+}
+
+func TestPruneArgs(t *testing.T) {
+	assert.Equal(t, []string{"container", "prune", "-f"}, pruneArgs("docker", "container"))
+	assert.Equal(t, []string{"volume", "prune", "-f", "--all"}, pruneArgs("docker", "volume"))
+	assert.Equal(t, []string{"volume", "prune", "-f", "--all"}, pruneArgs("nerdctl", "volume"))
+	assert.Equal(t, []string{"volume", "prune", "-f"}, pruneArgs("podman", "volume"))
+}
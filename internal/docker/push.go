@@ -0,0 +1,47 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+// TagImage gives an image another name, e.g. "ghcr.io/org/app:1.2" before pushing it
+func TagImage(source, target string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, runtimeBin(), "tag", source, target)
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	logging.Command(cmd, start, err)
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return fmt.Errorf("tagging %s as %s: %s", source, target, msg)
+	}
+	return nil
+}
+
+// PullImage pulls image, handing progress lines to onLine
+func PullImage(image string, onLine func(string)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	return runStreaming(ctx, "", onLine, runtimeBin(), "pull", image)
+}
+
+// PushImage pushes image to the registry in its name, handing progress lines to onLine.
+// Credentials are the runtime's own (`docker login`).
+func PushImage(image string, onLine func(string)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	return runStreaming(ctx, "", onLine, runtimeBin(), "push", image)
+}
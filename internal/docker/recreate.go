@@ -0,0 +1,481 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stringList accepts both a JSON string and an array of strings;
+// podman has reported Entrypoint both ways depending on version. A string is
+// one word, like `--entrypoint` takes it, not split on spaces.
+type stringList []string
+
+func (s *stringList) UnmarshalJSON(b []byte) error {
+	var list []string
+	if err := json.Unmarshal(b, &list); err == nil {
+		*s = list
+		return nil
+	}
+	var one string
+	if err := json.Unmarshal(b, &one); err != nil {
+		return err
+	}
+	if one == "" {
+		*s = nil
+	} else {
+		*s = []string{one}
+	}
+	return nil
+}
+
+// healthcheck is Config.Healthcheck, the durations are in nanoseconds
+type healthcheck struct {
+	Test        []string      `json:"Test"`
+	Interval    time.Duration `json:"Interval"`
+	Timeout     time.Duration `json:"Timeout"`
+	StartPeriod time.Duration `json:"StartPeriod"`
+	Retries     int           `json:"Retries"`
+}
+
+type imageConfig struct {
+	Env         []string          `json:"Env"`
+	Cmd         stringList        `json:"Cmd"`
+	Entrypoint  stringList        `json:"Entrypoint"`
+	WorkingDir  string            `json:"WorkingDir"`
+	User        string            `json:"User"`
+	Labels      map[string]string `json:"Labels"`
+	Healthcheck *healthcheck      `json:"Healthcheck"`
+}
+
+// containerSpec is the part of `inspect` needed to run the container again
+type containerSpec struct {
+	ID     string `json:"Id"`
+	Name   string `json:"Name"`
+	Image  string `json:"Image"` // image ID the container was created from
+	Config struct {
+		imageConfig
+		Image     string `json:"Image"`
+		Tty       bool   `json:"Tty"`
+		OpenStdin bool   `json:"OpenStdin"`
+	} `json:"Config"`
+	HostConfig struct {
+		PortBindings map[string][]struct {
+			HostIP   string `json:"HostIp"`
+			HostPort string `json:"HostPort"`
+		} `json:"PortBindings"`
+		RestartPolicy struct {
+			Name              string `json:"Name"`
+			MaximumRetryCount int    `json:"MaximumRetryCount"`
+		} `json:"RestartPolicy"`
+		NetworkMode string            `json:"NetworkMode"`
+		Privileged  bool              `json:"Privileged"`
+		CapAdd      []string          `json:"CapAdd"`
+		CapDrop     []string          `json:"CapDrop"`
+		ExtraHosts  []string          `json:"ExtraHosts"`
+		Tmpfs       map[string]string `json:"Tmpfs"`
+		AutoRemove  bool              `json:"AutoRemove"`
+
+		Memory            int64  `json:"Memory"`
+		MemoryReservation int64  `json:"MemoryReservation"`
+		MemorySwap        int64  `json:"MemorySwap"`
+		NanoCpus          int64  `json:"NanoCpus"`
+		CpuShares         int64  `json:"CpuShares"`
+		CpusetCpus        string `json:"CpusetCpus"`
+		PidsLimit         *int64 `json:"PidsLimit"`
+	} `json:"HostConfig"`
+	Mounts []struct {
+		Type        string `json:"Type"`
+		Name        string `json:"Name"`
+		Source      string `json:"Source"`
+		Destination string `json:"Destination"`
+		RW          bool   `json:"RW"`
+	} `json:"Mounts"`
+	State struct {
+		Running bool `json:"Running"`
+	} `json:"State"`
+	NetworkSettings struct {
+		Networks map[string]struct{} `json:"Networks"`
+	} `json:"NetworkSettings"`
+}
+
+// Recreate pulls the container's image and replaces the container with a new
+// one built from its inspect data (env, mounts, ports, networks, limits,
+// healthcheck, restart policy, ...). The old container is kept under a
+// temporary name until the new one runs, and put back if that fails. Pull
+// output goes to onLine.
+func Recreate(containerID string, onLine func(string)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	defer cancel()
+
+	runtime := runtimeBin()
+
+	// the old image's defaults, so they aren't frozen into the new container
+	spec, imageDefaults, err := inspectWithImage(ctx, containerID)
+	if err != nil {
+		return err
+	}
+	name := strings.TrimPrefix(spec.Name, "/")
+
+	onLine(fmt.Sprintf("Pulling %s", spec.Config.Image))
+	if err := runStreaming(ctx, "", onLine, runtime, "pull", spec.Config.Image); err != nil {
+		return err
+	}
+
+	snap := newSnapshot(spec, imageDefaults)
+
+	if spec.State.Running {
+		onLine(fmt.Sprintf("Stopping %s", name))
+		if err := exec.CommandContext(ctx, runtime, "stop", spec.ID).Run(); err != nil {
+			return fmt.Errorf("stopping %s: %w", name, err)
+		}
+	}
+
+	backup := name + "-dockmate-old"
+	if err := exec.CommandContext(ctx, runtime, "rename", spec.ID, backup).Run(); err != nil {
+		return fmt.Errorf("renaming %s: %w", name, err)
+	}
+
+	// rollback puts the old container back the way it was. created says
+	// the new one exists, when `run` failed it may not and removing it
+	// can't be expected to work.
+	rollback := func(created bool) error {
+		var errs []error
+		if err := exec.CommandContext(ctx, runtime, "rm", "-f", name).Run(); err != nil && created {
+			errs = append(errs, fmt.Errorf("removing the new %s: %w", name, err))
+		}
+		if err := exec.CommandContext(ctx, runtime, "rename", spec.ID, name).Run(); err != nil {
+			errs = append(errs, fmt.Errorf("renaming %s back to %s: %w", backup, name, err))
+		}
+		if spec.State.Running {
+			if err := exec.CommandContext(ctx, runtime, "start", spec.ID).Run(); err != nil {
+				errs = append(errs, fmt.Errorf("starting %s again: %w", name, err))
+			}
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("putting the old container back failed, it's left as %s: %w", backup, errors.Join(errs...))
+		}
+		return nil
+	}
+
+	onLine(fmt.Sprintf("Creating %s", name))
+	// `run` and `create` take one network, the others are connected once it exists
+	for i, args := range snap.commands() {
+		if output, err := exec.CommandContext(ctx, runtime, args...).CombinedOutput(); err != nil {
+			step := "creating new container"
+			if i > 0 {
+				step = fmt.Sprintf("connecting %s to %s", name, snap.Networks[i-1])
+			}
+			err = fmt.Errorf("%s: %v\nOutput: %s", step, err, strings.TrimSpace(string(output)))
+			return errors.Join(err, rollback(i > 0))
+		}
+	}
+
+	if err := exec.CommandContext(ctx, runtime, "rm", spec.ID).Run(); err != nil {
+		onLine(fmt.Sprintf("New container is up, but removing %s failed: %v", backup, err))
+	}
+	onLine(fmt.Sprintf("Recreated %s", name))
+	return nil
+}
+
+// RecreateComposeService pulls and recreates a compose service, streaming compose's output
+func RecreateComposeService(project, workingDir, service string, onLine func(string)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	defer cancel()
+
+	cmdConfig := GetComposeCommand()
+	base := []string{}
+	if cmdConfig.SubCommand != "" {
+		base = append(base, cmdConfig.SubCommand)
+	}
+	base = append(base, "-p", project)
+
+	pull := append(append([]string{}, base...), "pull", service)
+	if err := runStreaming(ctx, workingDir, onLine, cmdConfig.Binary, pull...); err != nil {
+		return err
+	}
+	up := append(append([]string{}, base...), "up", "-d", service)
+	return runStreaming(ctx, workingDir, onLine, cmdConfig.Binary, up...)
+}
+
+// inspectWithImage returns the container's inspect data and the config of the
+// image it was created from; the image part is empty if the image is gone
+func inspectWithImage(ctx context.Context, containerID string) (containerSpec, imageConfig, error) {
+	var specs []containerSpec
+	if err := inspectJSON(ctx, &specs, "inspect", "--type", "container", containerID); err != nil {
+		return containerSpec{}, imageConfig{}, fmt.Errorf("inspecting container: %w", err)
+	}
+	if len(specs) == 0 {
+		return containerSpec{}, imageConfig{}, fmt.Errorf("container %s not found", containerID)
+	}
+
+	var images []struct {
+		Config imageConfig `json:"Config"`
+	}
+	var image imageConfig
+	if err := inspectJSON(ctx, &images, "image", "inspect", specs[0].Image); err == nil && len(images) > 0 {
+		image = images[0].Config
+	}
+	return specs[0], image, nil
+}
+
+func inspectJSON(ctx context.Context, v any, args ...string) error {
+	output, err := exec.CommandContext(ctx, runtimeBin(), args...).Output()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(output, v)
+}
+
+// recreateArgs turns inspect output back into `run -d` arguments, or
+// `create` for a container that wasn't running so it stays stopped. Settings
+// that just repeat the image's defaults are left out so the new image's apply.
+func recreateArgs(spec containerSpec, image imageConfig) []string {
+	args := []string{"create"}
+	if spec.State.Running {
+		args = []string{"run", "-d"}
+	}
+	args = append(args, "--name", strings.TrimPrefix(spec.Name, "/"))
+
+	if spec.Config.Tty {
+		args = append(args, "-t")
+	}
+	if spec.Config.OpenStdin {
+		args = append(args, "-i")
+	}
+
+	imageEnv := make(map[string]bool)
+	for _, e := range image.Env {
+		imageEnv[e] = true
+	}
+	for _, e := range spec.Config.Env {
+		if !imageEnv[e] {
+			args = append(args, "-e", e)
+		}
+	}
+
+	labelKeys := make([]string, 0, len(spec.Config.Labels))
+	for k, v := range spec.Config.Labels {
+		if iv, ok := image.Labels[k]; ok && iv == v {
+			continue
+		}
+		labelKeys = append(labelKeys, k)
+	}
+	sort.Strings(labelKeys)
+	for _, k := range labelKeys {
+		args = append(args, "--label", k+"="+spec.Config.Labels[k])
+	}
+
+	ports := make([]string, 0, len(spec.HostConfig.PortBindings))
+	for port := range spec.HostConfig.PortBindings {
+		ports = append(ports, port)
+	}
+	sort.Strings(ports)
+	for _, port := range ports {
+		for _, b := range spec.HostConfig.PortBindings[port] {
+			switch {
+			case b.HostPort == "":
+				args = append(args, "-p", port)
+			case b.HostIP != "" && b.HostIP != "0.0.0.0":
+				args = append(args, "-p", fmt.Sprintf("%s:%s:%s", b.HostIP, b.HostPort, port))
+			default:
+				args = append(args, "-p", fmt.Sprintf("%s:%s", b.HostPort, port))
+			}
+		}
+	}
+
+	for _, mnt := range spec.Mounts {
+		src := mnt.Source
+		switch mnt.Type {
+		case "volume":
+			src = mnt.Name
+		case "bind":
+		default:
+			continue // tmpfs is handled below
+		}
+		v := src + ":" + mnt.Destination
+		if !mnt.RW {
+			v += ":ro"
+		}
+		args = append(args, "-v", v)
+	}
+
+	tmpfs := make([]string, 0, len(spec.HostConfig.Tmpfs))
+	for dest, opts := range spec.HostConfig.Tmpfs {
+		if opts != "" {
+			dest += ":" + opts
+		}
+		tmpfs = append(tmpfs, dest)
+	}
+	sort.Strings(tmpfs)
+	for _, t := range tmpfs {
+		args = append(args, "--tmpfs", t)
+	}
+
+	if rp := spec.HostConfig.RestartPolicy; rp.Name != "" && rp.Name != "no" {
+		policy := rp.Name
+		if rp.Name == "on-failure" && rp.MaximumRetryCount > 0 {
+			policy = fmt.Sprintf("on-failure:%d", rp.MaximumRetryCount)
+		}
+		args = append(args, "--restart", policy)
+	}
+	if spec.HostConfig.AutoRemove {
+		args = append(args, "--rm")
+	}
+
+	switch nm := spec.HostConfig.NetworkMode; nm {
+	case "", "default", "bridge":
+	default:
+		args = append(args, "--network", nm)
+	}
+	for _, h := range spec.HostConfig.ExtraHosts {
+		args = append(args, "--add-host", h)
+	}
+	if spec.HostConfig.Privileged {
+		args = append(args, "--privileged")
+	}
+	for _, c := range spec.HostConfig.CapAdd {
+		args = append(args, "--cap-add", c)
+	}
+	for _, c := range spec.HostConfig.CapDrop {
+		args = append(args, "--cap-drop", c)
+	}
+
+	args = append(args, limitArgs(spec)...)
+	if spec.Config.Healthcheck != nil && !reflect.DeepEqual(spec.Config.Healthcheck, image.Healthcheck) {
+		args = append(args, healthcheckArgs(*spec.Config.Healthcheck)...)
+	}
+
+	if spec.Config.User != "" && spec.Config.User != image.User {
+		args = append(args, "--user", spec.Config.User)
+	}
+	if spec.Config.WorkingDir != "" && spec.Config.WorkingDir != image.WorkingDir {
+		args = append(args, "--workdir", spec.Config.WorkingDir)
+	}
+
+	customEntrypoint := !equalStrings(spec.Config.Entrypoint, image.Entrypoint)
+	if customEntrypoint {
+		// --entrypoint takes one word, the rest goes in front of the command
+		ep := []string(spec.Config.Entrypoint)
+		if len(ep) == 0 {
+			args = append(args, "--entrypoint", "")
+		} else {
+			args = append(args, "--entrypoint", ep[0])
+		}
+	}
+
+	args = append(args, spec.Config.Image)
+
+	if customEntrypoint && len(spec.Config.Entrypoint) > 1 {
+		args = append(args, spec.Config.Entrypoint[1:]...)
+	}
+	if customEntrypoint || !equalStrings(spec.Config.Cmd, image.Cmd) {
+		args = append(args, spec.Config.Cmd...)
+	}
+
+	return args
+}
+
+// limitArgs are the `run` flags for the container's resource limits
+func limitArgs(spec containerSpec) []string {
+	hc := spec.HostConfig
+	var args []string
+	if hc.Memory > 0 {
+		args = append(args, "--memory", strconv.FormatInt(hc.Memory, 10))
+		// -1 is unlimited swap, 0 the runtime's default
+		if hc.MemorySwap != 0 {
+			args = append(args, "--memory-swap", strconv.FormatInt(hc.MemorySwap, 10))
+		}
+	}
+	if hc.MemoryReservation > 0 {
+		args = append(args, "--memory-reservation", strconv.FormatInt(hc.MemoryReservation, 10))
+	}
+	if hc.NanoCpus > 0 {
+		args = append(args, "--cpus", strconv.FormatFloat(float64(hc.NanoCpus)/1e9, 'f', -1, 64))
+	}
+	if hc.CpuShares > 0 {
+		args = append(args, "--cpu-shares", strconv.FormatInt(hc.CpuShares, 10))
+	}
+	if hc.CpusetCpus != "" {
+		args = append(args, "--cpuset-cpus", hc.CpusetCpus)
+	}
+	if hc.PidsLimit != nil && *hc.PidsLimit > 0 {
+		args = append(args, "--pids-limit", strconv.FormatInt(*hc.PidsLimit, 10))
+	}
+	return args
+}
+
+// healthcheckArgs are the `run` flags for a healthcheck that isn't the
+// image's. --health-cmd always runs through the shell, so an exec-form test
+// is quoted into one command line.
+func healthcheckArgs(h healthcheck) []string {
+	var args []string
+	if len(h.Test) > 0 {
+		switch h.Test[0] {
+		case "NONE":
+			return []string{"--no-healthcheck"}
+		case "CMD-SHELL":
+			args = append(args, "--health-cmd", strings.Join(h.Test[1:], " "))
+		case "CMD":
+			words := make([]string, 0, len(h.Test)-1)
+			for _, w := range h.Test[1:] {
+				words = append(words, shellQuote(w))
+			}
+			args = append(args, "--health-cmd", strings.Join(words, " "))
+		}
+	}
+	if h.Interval > 0 {
+		args = append(args, "--health-interval", h.Interval.String())
+	}
+	if h.Timeout > 0 {
+		args = append(args, "--health-timeout", h.Timeout.String())
+	}
+	if h.StartPeriod > 0 {
+		args = append(args, "--health-start-period", h.StartPeriod.String())
+	}
+	if h.Retries > 0 {
+		args = append(args, "--health-retries", strconv.Itoa(h.Retries))
+	}
+	return args
+}
+
+// extraNetworks are the networks the container is on besides the one `run`
+// puts it on, sorted
+func extraNetworks(spec containerSpec) []string {
+	primary := spec.HostConfig.NetworkMode
+	switch {
+	case primary == "host" || primary == "none" || strings.HasPrefix(primary, "container:"):
+		return nil
+	case primary == "" || primary == "default":
+		primary = "bridge"
+	}
+	var names []string
+	for name := range spec.NetworkSettings.Networks {
+		// podman calls its default bridge network "podman"
+		if name == primary || (primary == "bridge" && name == "podman") {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
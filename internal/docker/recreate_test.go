@@ -0,0 +1,136 @@
+package docker
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const inspectFixture = `[{
+	"Id": "abc123",
+	"Name": "/web",
+	"Image": "sha256:old",
+	"Config": {
+		"Image": "nginx:1.25",
+		"Env": ["PATH=/usr/bin", "NGINX_VERSION=1.25.0", "APP_MODE=prod"],
+		"Cmd": ["nginx", "-g", "daemon off;"],
+		"Entrypoint": "/docker-entrypoint.sh",
+		"Labels": {"maintainer": "NGINX", "owner": "alice"}
+	},
+	"HostConfig": {
+		"PortBindings": {
+			"443/tcp": [{"HostIp": "127.0.0.1", "HostPort": "8443"}],
+			"80/tcp": [{"HostIp": "", "HostPort": "8080"}]
+		},
+		"RestartPolicy": {"Name": "unless-stopped"},
+		"NetworkMode": "shop_default"
+	},
+	"Mounts": [
+		{"Type": "volume", "Name": "webdata", "Source": "/var/lib/docker/volumes/webdata/_data", "Destination": "/data", "RW": true},
+		{"Type": "bind", "Source": "/srv/conf", "Destination": "/etc/nginx/conf.d", "RW": false}
+	],
+	"State": {"Running": true}
+}]`
+
+func TestRecreateArgs(t *testing.T) {
+	var specs []containerSpec
+	require.NoError(t, json.Unmarshal([]byte(inspectFixture), &specs))
+	require.Len(t, specs, 1)
+
+	image := imageConfig{
+		Env:        []string{"PATH=/usr/bin", "NGINX_VERSION=1.25.0"},
+		Cmd:        []string{"nginx", "-g", "daemon off;"},
+		Entrypoint: []string{"/docker-entrypoint.sh"},
+		Labels:     map[string]string{"maintainer": "NGINX"},
+	}
+
+	assert.Equal(t, []string{
+		"run", "-d", "--name", "web",
+		"-e", "APP_MODE=prod",
+		"--label", "owner=alice",
+		"-p", "127.0.0.1:8443:443/tcp",
+		"-p", "8080:80/tcp",
+		"-v", "webdata:/data",
+		"-v", "/srv/conf:/etc/nginx/conf.d:ro",
+		"--restart", "unless-stopped",
+		"--network", "shop_default",
+		"nginx:1.25",
+	}, recreateArgs(specs[0], image))
+}
+
+func TestRecreateArgsCustomCommand(t *testing.T) {
+	var spec containerSpec
+	spec.Name = "/job"
+	spec.Config.Image = "alpine"
+	spec.Config.Entrypoint = []string{"sh", "-c"}
+	spec.Config.Cmd = []string{"sleep 10"}
+
+	// it wasn't running, so it's created and left stopped
+	assert.Equal(t, []string{
+		"create", "--name", "job",
+		"--entrypoint", "sh",
+		"alpine", "-c", "sleep 10",
+	}, recreateArgs(spec, imageConfig{Cmd: []string{"/bin/sh"}}))
+}
+
+func TestRecreateArgsLimitsAndHealthcheck(t *testing.T) {
+	var specs []containerSpec
+	require.NoError(t, json.Unmarshal([]byte(`[{
+		"Name": "/api",
+		"Config": {
+			"Image": "api:2",
+			"Entrypoint": "/usr/local/bin/start server",
+			"Healthcheck": {"Test": ["CMD", "curl", "-f", "http://localhost/health check"], "Interval": 30000000000, "Retries": 3}
+		},
+		"HostConfig": {"Memory": 536870912, "MemorySwap": -1, "NanoCpus": 1500000000, "PidsLimit": 100}
+	}]`), &specs))
+	require.Len(t, specs, 1)
+
+	// a string entrypoint is one word, not split on the space
+	image := imageConfig{
+		Entrypoint:  []string{"/usr/local/bin/start server"},
+		Healthcheck: &healthcheck{Test: []string{"NONE"}},
+	}
+	assert.Equal(t, []string{
+		"create", "--name", "api",
+		"--memory", "536870912", "--memory-swap", "-1",
+		"--cpus", "1.5",
+		"--pids-limit", "100",
+		"--health-cmd", "curl -f 'http://localhost/health check'",
+		"--health-interval", "30s",
+		"--health-retries", "3",
+		"api:2",
+	}, recreateArgs(specs[0], image))
+
+	// the image's own healthcheck is left to the new image
+	image.Healthcheck = specs[0].Config.Healthcheck
+	assert.NotContains(t, recreateArgs(specs[0], image), "--health-cmd")
+}
+
+func TestExtraNetworks(t *testing.T) {
+	var spec containerSpec
+	spec.NetworkSettings.Networks = map[string]struct{}{"shop_default": {}, "proxy": {}, "monitoring": {}}
+	spec.HostConfig.NetworkMode = "shop_default"
+	assert.Equal(t, []string{"monitoring", "proxy"}, extraNetworks(spec))
+
+	spec.NetworkSettings.Networks = map[string]struct{}{"podman": {}, "proxy": {}}
+	spec.HostConfig.NetworkMode = "bridge"
+	assert.Equal(t, []string{"proxy"}, extraNetworks(spec))
+
+	spec.HostConfig.NetworkMode = "host"
+	assert.Empty(t, extraNetworks(spec))
+}
+
+func TestScanLinesOrCR(t *testing.T) {
+	var lines []string
+	data := []byte("layer1: Downloading\rlayer1: Done\nStatus: ok")
+	for len(data) > 0 {
+		adv, tok, err := scanLinesOrCR(data, true)
+		require.NoError(t, err)
+		lines = append(lines, string(tok))
+		data = data[adv:]
+	}
+	assert.Equal(t, []string{"layer1: Downloading", "layer1: Done", "Status: ok"}, lines)
+}
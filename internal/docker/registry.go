@@ -0,0 +1,196 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ImageRef is an image reference split into the parts the registry API needs
+type ImageRef struct {
+	Registry   string // registry host, docker hub is "registry-1.docker.io"
+	Repository string // e.g. "library/nginx"
+	Tag        string
+	Digest     string // set when the reference is pinned with @sha256:...
+}
+
+const dockerHubRegistry = "registry-1.docker.io"
+
+// ParseImageRef parses references like "nginx", "user/app:1.2",
+// "ghcr.io/org/app:tag" or "docker.io/library/redis@sha256:..."
+func ParseImageRef(s string) (ImageRef, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ImageRef{}, fmt.Errorf("empty image reference")
+	}
+
+	var ref ImageRef
+	if name, digest, ok := strings.Cut(s, "@"); ok {
+		s, ref.Digest = name, digest
+	}
+
+	// the tag is after the last ":" as long as no "/" follows it (otherwise it's a registry port)
+	if i := strings.LastIndex(s, ":"); i > strings.LastIndex(s, "/") {
+		s, ref.Tag = s[:i], s[i+1:]
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+
+	first, rest, hasSlash := strings.Cut(s, "/")
+	if hasSlash && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		ref.Registry, s = first, rest
+	} else {
+		ref.Registry = dockerHubRegistry
+	}
+
+	if ref.Registry == "docker.io" || ref.Registry == "index.docker.io" {
+		ref.Registry = dockerHubRegistry
+	}
+	if ref.Registry == dockerHubRegistry && !strings.Contains(s, "/") {
+		s = "library/" + s
+	}
+	if s == "" {
+		return ImageRef{}, fmt.Errorf("invalid image reference %q", s)
+	}
+
+	ref.Repository = s
+	return ref, nil
+}
+
+var manifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+}
+
+// registryScheme is swapped to http in tests
+var registryScheme = "https"
+
+// RemoteDigest asks the registry for the digest the tag currently points at.
+// Only anonymous pulls are supported, private repositories come back as an error.
+func RemoteDigest(ref ImageRef) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	manifestURL := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", registryScheme, ref.Registry, ref.Repository, ref.Tag)
+
+	resp, err := headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := fetchRegistryToken(ctx, resp.Header.Get("WWW-Authenticate"), "", "")
+		if err != nil {
+			return "", err
+		}
+		resp, err = headManifest(ctx, manifestURL, token)
+		if err != nil {
+			return "", err
+		}
+		resp.Body.Close()
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry returned %s for %s", resp.Status, ref.Repository)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("registry sent no digest for %s", ref.Repository)
+	}
+	return digest, nil
+}
+
+func headManifest(ctx context.Context, manifestURL, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return http.DefaultClient.Do(req)
+}
+
+// fetchRegistryToken gets a pull token from the realm in a
+// `WWW-Authenticate: Bearer realm="...",service="...",scope="..."` challenge,
+// anonymous unless user is set
+func fetchRegistryToken(ctx context.Context, challenge, user, password string) (string, error) {
+	params := parseAuthChallenge(challenge)
+	realm := params["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("registry wants authentication we don't support")
+	}
+
+	q := url.Values{}
+	if params["service"] != "" {
+		q.Set("service", params["service"])
+	}
+	if params["scope"] != "" {
+		q.Set("scope", params["scope"])
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+q.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	if user != "" {
+		req.SetBasicAuth(user, password)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request failed: %s", resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("parsing token response: %w", err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+// parseAuthChallenge turns `Bearer realm="x",service="y"` into a map
+func parseAuthChallenge(h string) map[string]string {
+	params := make(map[string]string)
+
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(h), " ")
+	if !strings.EqualFold(scheme, "bearer") {
+		return params
+	}
+
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(rest, "=")
+		key = strings.ToLower(strings.TrimSpace(strings.TrimLeft(key, ", ")))
+
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if key != "" {
+			params[key] = value
+		}
+	}
+	return params
+}
@@ -0,0 +1,84 @@
+package docker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImageRef(t *testing.T) {
+	tests := []struct {
+		in   string
+		want ImageRef
+	}{
+		{"nginx", ImageRef{Registry: dockerHubRegistry, Repository: "library/nginx", Tag: "latest"}},
+		{"nginx:1.25", ImageRef{Registry: dockerHubRegistry, Repository: "library/nginx", Tag: "1.25"}},
+		{"user/app:v2", ImageRef{Registry: dockerHubRegistry, Repository: "user/app", Tag: "v2"}},
+		{"docker.io/library/redis:7", ImageRef{Registry: dockerHubRegistry, Repository: "library/redis", Tag: "7"}},
+		{"ghcr.io/org/tool", ImageRef{Registry: "ghcr.io", Repository: "org/tool", Tag: "latest"}},
+		{"localhost:5000/app:dev", ImageRef{Registry: "localhost:5000", Repository: "app", Tag: "dev"}},
+		{"localhost/built", ImageRef{Registry: "localhost", Repository: "built", Tag: "latest"}},
+		{"alpine@sha256:abc", ImageRef{Registry: dockerHubRegistry, Repository: "library/alpine", Digest: "sha256:abc"}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseImageRef(tt.in)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+
+	_, err := ParseImageRef("")
+	assert.Error(t, err)
+}
+
+func TestParseAuthChallenge(t *testing.T) {
+	got := parseAuthChallenge(`Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/nginx:pull"`)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:library/nginx:pull",
+	}, got)
+
+	assert.Empty(t, parseAuthChallenge(`Basic realm="x"`))
+}
+
+func TestRemoteDigest(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			assert.Equal(t, "repository:library/nginx:pull", r.URL.Query().Get("scope"))
+			w.Write([]byte(`{"token":"secret"}`))
+		case r.Header.Get("Authorization") != "Bearer secret":
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",scope="repository:library/nginx:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v2/library/nginx/manifests/latest":
+			assert.Contains(t, r.Header.Get("Accept"), "manifest.list.v2+json")
+			w.Header().Set("Docker-Content-Digest", "sha256:feed")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	registryScheme = "http"
+	defer func() { registryScheme = "https" }()
+
+	host := strings.TrimPrefix(srv.URL, "http://")
+	digest, err := RemoteDigest(ImageRef{Registry: host, Repository: "library/nginx", Tag: "latest"})
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:feed", digest)
+
+	_, err = RemoteDigest(ImageRef{Registry: host, Repository: "library/missing", Tag: "latest"})
+	assert.Error(t, err)
+}
+
+func TestHasDigest(t *testing.T) {
+	local := []string{"nginx@sha256:old", "docker.io/library/nginx@sha256:feed"}
+	assert.True(t, hasDigest(local, "sha256:feed"))
+	assert.False(t, hasDigest(local, "sha256:new"))
+}
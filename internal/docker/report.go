@@ -0,0 +1,374 @@
+package docker
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OwnedResource is one container, image or volume in a cleanup report
+type OwnedResource struct {
+	Kind      string // container, image or volume
+	Name      string
+	Owner     string // value of the owner label, empty if unset
+	State     string // containers only
+	CreatedAt time.Time
+	SizeBytes int64 // -1 when the runtime didn't report a size
+}
+
+// CollectResources lists containers, images and volumes with their owner label,
+// creation time and size, for the workspace cleanup report
+func CollectResources(ownerLabel string) ([]OwnedResource, error) {
+	var out []OwnedResource
+
+	containers, err := collectContainerResources(ownerLabel)
+	if err != nil {
+		return nil, fmt.Errorf("listing containers: %w", err)
+	}
+	out = append(out, containers...)
+
+	images, err := collectImageResources(ownerLabel)
+	if err != nil {
+		return nil, fmt.Errorf("listing images: %w", err)
+	}
+	out = append(out, images...)
+
+	volumes, err := collectVolumeResources(ownerLabel)
+	if err != nil {
+		return nil, fmt.Errorf("listing volumes: %w", err)
+	}
+	out = append(out, volumes...)
+
+	return out, nil
+}
+
+func collectContainerResources(ownerLabel string) ([]OwnedResource, error) {
+	// --size is slow on big hosts, hence the generous timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	runtime := runtimeBin()
+	output, err := exec.CommandContext(ctx, runtime, "ps", "-a", "--size", "--format", "{{json .}}").Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseContainerResources(runtime, output, ownerLabel)
+}
+
+// parseContainerResources reads `ps -a --size --format {{json .}}`: a JSON
+// array from podman, one object per line from docker and nerdctl
+func parseContainerResources(runtime string, output []byte, ownerLabel string) ([]OwnedResource, error) {
+	var out []OwnedResource
+
+	if runtime == "podman" {
+		type podmanEntry struct {
+			Names   []string          `json:"Names"`
+			State   string            `json:"State"`
+			Labels  map[string]string `json:"Labels"`
+			Created int64             `json:"Created"`
+			Size    *struct {
+				RwSize int64 `json:"rwSize"`
+			} `json:"Size"`
+		}
+
+		entries, err := decodeEntries[podmanEntry](output)
+		if err != nil {
+			return nil, fmt.Errorf("parsing podman output: %w", err)
+		}
+		for _, e := range entries {
+			size := int64(-1)
+			if e.Size != nil {
+				size = e.Size.RwSize
+			}
+			name := ""
+			if len(e.Names) > 0 {
+				name = e.Names[0]
+			}
+			out = append(out, OwnedResource{
+				Kind:      "container",
+				Name:      name,
+				Owner:     e.Labels[ownerLabel],
+				State:     strings.ToLower(e.State),
+				CreatedAt: unixTime(e.Created),
+				SizeBytes: size,
+			})
+		}
+		return out, nil
+	}
+
+	type dockerEntry struct {
+		Names     string `json:"Names"`
+		State     string `json:"State"`
+		Labels    string `json:"Labels"`
+		CreatedAt string `json:"CreatedAt"`
+		Size      string `json:"Size"` // "12.3kB (virtual 187MB)"
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var e dockerEntry
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			continue // skip weird lines
+		}
+
+		size := int64(-1)
+		if fields := strings.Fields(e.Size); len(fields) > 0 {
+			size = parseSizeBytes(fields[0])
+		}
+
+		out = append(out, OwnedResource{
+			Kind:      "container",
+			Name:      e.Names,
+			Owner:     parseLabels(e.Labels)[ownerLabel],
+			State:     strings.ToLower(e.State),
+			CreatedAt: parseCreatedAt(e.CreatedAt),
+			SizeBytes: size,
+		})
+	}
+	return out, scanner.Err()
+}
+
+func collectImageResources(ownerLabel string) ([]OwnedResource, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	runtime := runtimeBin()
+	idsOut, err := exec.CommandContext(ctx, runtime, "images", "-q", "--no-trunc").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := uniqueFields(string(idsOut))
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	// image ls has no labels, so inspect them all in one call
+	args := append([]string{"image", "inspect"}, ids...)
+	output, err := exec.CommandContext(ctx, runtime, args...).Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseImageResources(output, ownerLabel)
+}
+
+// parseImageResources reads `image inspect` output
+func parseImageResources(output []byte, ownerLabel string) ([]OwnedResource, error) {
+	var entries []struct {
+		ID       string   `json:"Id"`
+		RepoTags []string `json:"RepoTags"`
+		Created  string   `json:"Created"`
+		Size     int64    `json:"Size"`
+		Config   struct {
+			Labels map[string]string `json:"Labels"`
+		} `json:"Config"`
+	}
+	if err := json.Unmarshal(output, &entries); err != nil {
+		return nil, fmt.Errorf("parsing image inspect output: %w", err)
+	}
+
+	var out []OwnedResource
+	for _, e := range entries {
+		name := shortImageID(e.ID)
+		if len(e.RepoTags) > 0 {
+			name = e.RepoTags[0]
+		}
+		created, _ := time.Parse(time.RFC3339Nano, e.Created)
+		out = append(out, OwnedResource{
+			Kind:      "image",
+			Name:      name,
+			Owner:     e.Config.Labels[ownerLabel],
+			CreatedAt: created,
+			SizeBytes: e.Size,
+		})
+	}
+	return out, nil
+}
+
+func collectVolumeResources(ownerLabel string) ([]OwnedResource, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	runtime := runtimeBin()
+	namesOut, err := exec.CommandContext(ctx, runtime, "volume", "ls", "-q").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	names := uniqueFields(string(namesOut))
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	args := append([]string{"volume", "inspect"}, names...)
+	output, err := exec.CommandContext(ctx, runtime, args...).Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseVolumeResources(output, volumeSizes(), ownerLabel)
+}
+
+// parseVolumeResources reads `volume inspect` output, with the sizes from
+// volumeSizes; a volume missing from sizes has an unknown size
+func parseVolumeResources(output []byte, sizes map[string]int64, ownerLabel string) ([]OwnedResource, error) {
+	var entries []struct {
+		Name      string            `json:"Name"`
+		CreatedAt string            `json:"CreatedAt"`
+		Labels    map[string]string `json:"Labels"`
+	}
+	if err := json.Unmarshal(output, &entries); err != nil {
+		return nil, fmt.Errorf("parsing volume inspect output: %w", err)
+	}
+
+	var out []OwnedResource
+	for _, e := range entries {
+		created, _ := time.Parse(time.RFC3339Nano, e.CreatedAt)
+		size, ok := sizes[e.Name]
+		if !ok {
+			size = -1
+		}
+		out = append(out, OwnedResource{
+			Kind:      "volume",
+			Name:      e.Name,
+			Owner:     e.Labels[ownerLabel],
+			CreatedAt: created,
+			SizeBytes: size,
+		})
+	}
+	return out, nil
+}
+
+// volumeSizes asks `system df -v` for volume sizes; best effort, empty map on failure
+func volumeSizes() map[string]int64 {
+	sizes := make(map[string]int64)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, runtimeBin(), "system", "df", "-v", "--format", "{{json .}}").Output()
+	if err != nil {
+		return sizes
+	}
+	return parseVolumeSizes(output)
+}
+
+// parseVolumeSizes reads the volumes out of `system df -v --format {{json .}}`
+func parseVolumeSizes(output []byte) map[string]int64 {
+	sizes := make(map[string]int64)
+	var df struct {
+		Volumes []struct {
+			Name string `json:"Name"`
+			Size string `json:"Size"`
+		} `json:"Volumes"`
+	}
+	if err := json.Unmarshal(output, &df); err != nil {
+		return sizes
+	}
+	for _, v := range df.Volumes {
+		sizes[v.Name] = parseSizeBytes(v.Size)
+	}
+	return sizes
+}
+
+// shortImageID trims "sha256:" and keeps the usual 12 characters
+func shortImageID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// uniqueFields splits whitespace separated output and drops duplicates
+func uniqueFields(s string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, f := range strings.Fields(s) {
+		if !seen[f] {
+			seen[f] = true
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// UnownedLabel is the owner reports show for resources without the label
+const UnownedLabel = "(unowned)"
+
+// OwnerOf is the resource's owner, UnownedLabel when it has none
+func OwnerOf(r OwnedResource) string {
+	if r.Owner == "" {
+		return UnownedLabel
+	}
+	return r.Owner
+}
+
+// SortByOwner groups resources by owner, unowned last, oldest first inside
+// each owner, that's what people need to look at
+func SortByOwner(resources []OwnedResource) {
+	sort.SliceStable(resources, func(i, j int) bool {
+		oi, oj := OwnerOf(resources[i]), OwnerOf(resources[j])
+		if oi != oj {
+			if oi == UnownedLabel || oj == UnownedLabel {
+				return oj == UnownedLabel
+			}
+			return oi < oj
+		}
+		return resources[i].CreatedAt.Before(resources[j].CreatedAt)
+	})
+}
+
+// OwnerTotals counts an owner's resources and adds up the sizes that are known
+func OwnerTotals(resources []OwnedResource, owner string) (int, int64) {
+	count := 0
+	var total int64
+	for _, r := range resources {
+		if OwnerOf(r) != owner {
+			continue
+		}
+		count++
+		if r.SizeBytes > 0 {
+			total += r.SizeBytes
+		}
+	}
+	return count, total
+}
+
+// WriteReportCSV writes the report as CSV, ages in whole days as of now
+func WriteReportCSV(w io.Writer, resources []OwnedResource, now time.Time) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"owner", "kind", "name", "state", "created", "age_days", "size_bytes"}); err != nil {
+		return err
+	}
+
+	for _, r := range resources {
+		created, ageDays := "", ""
+		if !r.CreatedAt.IsZero() {
+			created = r.CreatedAt.UTC().Format(time.RFC3339)
+			ageDays = strconv.Itoa(int(now.Sub(r.CreatedAt).Hours() / 24))
+		}
+		size := ""
+		if r.SizeBytes >= 0 {
+			size = strconv.FormatInt(r.SizeBytes, 10)
+		}
+		if err := cw.Write([]string{r.Owner, r.Kind, r.Name, r.State, created, ageDays, size}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,156 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSizeBytes(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"0B", 0},
+		{"512", 512},
+		{"12.5kB", 12500},
+		{"1.5MB", 1500000},
+		{"2GB", 2000000000},
+		{"1KiB", 1024},
+		{"1.5MiB", 1572864},
+		{"1GiB", 1 << 30},
+		{" 3 MB ", 3000000},
+		{"", -1},
+		{"N/A", -1},
+		{"12XB", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseSizeBytes(tt.in))
+		})
+	}
+}
+
+func TestParseContainerResources(t *testing.T) {
+	tests := []struct {
+		name    string
+		runtime string
+		output  string
+		want    []OwnedResource
+	}{
+		{
+			name:    "docker",
+			runtime: "docker",
+			output: `{"Names":"web","State":"running","Labels":"owner=alice,app=shop","CreatedAt":"2024-05-01 12:00:00 +0000 UTC","Size":"12.5kB (virtual 187MB)"}
+not json
+{"Names":"tmp","State":"Exited","Labels":"","CreatedAt":"","Size":""}
+`,
+			want: []OwnedResource{
+				{Kind: "container", Name: "web", Owner: "alice", State: "running", CreatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), SizeBytes: 12500},
+				{Kind: "container", Name: "tmp", State: "exited", SizeBytes: -1},
+			},
+		},
+		{
+			name:    "podman",
+			runtime: "podman",
+			output:  `[{"Names":["db"],"State":"Running","Labels":{"owner":"bob"},"Created":1714564800,"Size":{"rwSize":2048}},{"Names":[],"State":"created","Created":0}]`,
+			want: []OwnedResource{
+				{Kind: "container", Name: "db", Owner: "bob", State: "running", CreatedAt: time.Unix(1714564800, 0), SizeBytes: 2048},
+				{Kind: "container", State: "created", CreatedAt: unixTime(0), SizeBytes: -1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseContainerResources(tt.runtime, []byte(tt.output), "owner")
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.want))
+			for i := range tt.want {
+				assert.True(t, tt.want[i].CreatedAt.Equal(got[i].CreatedAt), "created of %s", tt.want[i].Name)
+				tt.want[i].CreatedAt, got[i].CreatedAt = time.Time{}, time.Time{}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseImageAndVolumeResources(t *testing.T) {
+	images, err := parseImageResources([]byte(`[
+		{"Id":"sha256:0123456789abcdef","RepoTags":["nginx:1.25"],"Created":"2024-05-01T12:00:00.5Z","Size":187000000,"Config":{"Labels":{"owner":"alice"}}},
+		{"Id":"sha256:fedcba9876543210","RepoTags":[],"Created":"","Size":1000,"Config":{"Labels":null}}
+	]`), "owner")
+	require.NoError(t, err)
+	require.Len(t, images, 2)
+	assert.Equal(t, "nginx:1.25", images[0].Name)
+	assert.Equal(t, "alice", images[0].Owner)
+	assert.Equal(t, int64(187000000), images[0].SizeBytes)
+	assert.Equal(t, "fedcba987654", images[1].Name, "untagged images go by their short id")
+	assert.True(t, images[1].CreatedAt.IsZero())
+
+	sizes := parseVolumeSizes([]byte(`{"Volumes":[{"Name":"data","Size":"1.5GB"},{"Name":"cache","Size":"N/A"}]}`))
+	assert.Equal(t, map[string]int64{"data": 1500000000, "cache": -1}, sizes)
+	assert.Empty(t, parseVolumeSizes([]byte("not json")))
+
+	volumes, err := parseVolumeResources([]byte(`[
+		{"Name":"data","CreatedAt":"2024-05-01T12:00:00Z","Labels":{"owner":"bob"}},
+		{"Name":"new","CreatedAt":"","Labels":null}
+	]`), sizes, "owner")
+	require.NoError(t, err)
+	require.Len(t, volumes, 2)
+	assert.Equal(t, OwnedResource{Kind: "volume", Name: "data", Owner: "bob", CreatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), SizeBytes: 1500000000}, volumes[0])
+	assert.Equal(t, int64(-1), volumes[1].SizeBytes, "a volume system df doesn't know has no size")
+
+	_, err = parseImageResources([]byte("garbage"), "owner")
+	assert.Error(t, err)
+}
+
+func TestSortByOwner(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC) }
+	resources := []OwnedResource{
+		{Name: "stray", CreatedAt: day(1), SizeBytes: 10},
+		{Name: "bob-new", Owner: "bob", CreatedAt: day(9), SizeBytes: 5},
+		{Name: "alice", Owner: "alice", CreatedAt: day(3), SizeBytes: -1},
+		{Name: "bob-old", Owner: "bob", CreatedAt: day(2), SizeBytes: 7},
+	}
+	SortByOwner(resources)
+
+	var names []string
+	for _, r := range resources {
+		names = append(names, r.Name)
+	}
+	assert.Equal(t, []string{"alice", "bob-old", "bob-new", "stray"}, names)
+
+	tests := []struct {
+		owner string
+		count int
+		total int64
+	}{
+		{"alice", 1, 0}, // unknown sizes aren't added
+		{"bob", 2, 12},
+		{UnownedLabel, 1, 10},
+		{"carol", 0, 0},
+	}
+	for _, tt := range tests {
+		count, total := OwnerTotals(resources, tt.owner)
+		assert.Equal(t, tt.count, count, tt.owner)
+		assert.Equal(t, tt.total, total, tt.owner)
+	}
+}
+
+func TestWriteReportCSV(t *testing.T) {
+	now := time.Date(2024, 5, 11, 12, 0, 0, 0, time.UTC)
+	resources := []OwnedResource{
+		{Kind: "container", Name: "web, the shop", Owner: "alice", State: "running", CreatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), SizeBytes: 12500},
+		{Kind: "volume", Name: "cache", SizeBytes: -1},
+	}
+
+	var b strings.Builder
+	require.NoError(t, WriteReportCSV(&b, resources, now))
+	assert.Equal(t, `owner,kind,name,state,created,age_days,size_bytes
+alice,container,"web, the shop",running,2024-05-01T12:00:00Z,10,12500
+,volume,cache,,,,
+`, b.String())
+}
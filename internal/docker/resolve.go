@@ -0,0 +1,55 @@
+package docker
+
+import (
+	"path"
+	"strings"
+)
+
+// ContainerName returns the first name of a container, without the leading slash
+func ContainerName(c Container) string {
+	if len(c.Names) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(c.Names[0], "/")
+}
+
+// ResolveContainers finds the containers a name on the command line refers to.
+// In order: an exact container name, a compose "project/service" (all its
+// replicas), a glob on container names ("web-*"), then an ID prefix.
+func ResolveContainers(containers []Container, query string) []Container {
+	for _, c := range containers {
+		if ContainerName(c) == query {
+			return []Container{c}
+		}
+	}
+
+	if project, service, ok := strings.Cut(query, "/"); ok {
+		var out []Container
+		for _, c := range containers {
+			if c.ComposeProject == project && c.ComposeService == service {
+				out = append(out, c)
+			}
+		}
+		if len(out) > 0 {
+			return out
+		}
+	}
+
+	if strings.ContainsAny(query, "*?[") {
+		var out []Container
+		for _, c := range containers {
+			if ok, _ := path.Match(query, ContainerName(c)); ok {
+				out = append(out, c)
+			}
+		}
+		return out
+	}
+
+	var out []Container
+	for _, c := range containers {
+		if query != "" && strings.HasPrefix(c.ID, query) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,46 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveContainers(t *testing.T) {
+	containers := []Container{
+		{ID: "aaa111", Names: []string{"/web-1"}, ComposeProject: "shop", ComposeService: "web"},
+		{ID: "aaa222", Names: []string{"web-2"}, ComposeProject: "shop", ComposeService: "web"},
+		{ID: "bbb333", Names: []string{"db"}, ComposeProject: "shop", ComposeService: "db"},
+		{ID: "ccc444", Names: []string{"shop/web"}},
+	}
+
+	ids := func(cs []Container) []string {
+		var out []string
+		for _, c := range cs {
+			out = append(out, c.ID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"exact name", "db", []string{"bbb333"}},
+		{"leading slash ignored", "web-1", []string{"aaa111"}},
+		{"exact name beats project/service", "shop/web", []string{"ccc444"}},
+		{"project/service", "shop/db", []string{"bbb333"}},
+		{"glob", "web-*", []string{"aaa111", "aaa222"}},
+		{"id prefix", "bbb", []string{"bbb333"}},
+		{"ambiguous id prefix", "aaa", []string{"aaa111", "aaa222"}},
+		{"no match", "cache", nil},
+		{"empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ids(ResolveContainers(containers, tt.query)))
+		})
+	}
+}
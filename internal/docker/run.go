@@ -0,0 +1,183 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+// RunSpec is a new container for `run -d`, as filled in by the run wizard
+type RunSpec struct {
+	Image   string
+	Name    string   // empty lets the runtime pick one
+	Ports   []string // host:container, as for -p
+	Env     []string // KEY=value
+	Volumes []string // source:target[:ro], as for -v
+	Restart string   // restart policy, empty for the runtime's default (no)
+	Args    []string // any other run options
+	Command []string // after the image, replaces its CMD
+	// -it --rm in the terminal instead of -d, for debug and client containers
+	Interactive bool
+}
+
+func (s RunSpec) args() []string {
+	args := []string{"run", "-d"}
+	if s.Interactive {
+		args = []string{"run", "-it", "--rm"}
+	}
+	if s.Name != "" {
+		args = append(args, "--name", s.Name)
+	}
+	for _, p := range s.Ports {
+		args = append(args, "-p", p)
+	}
+	for _, e := range s.Env {
+		args = append(args, "-e", e)
+	}
+	for _, v := range s.Volumes {
+		args = append(args, "-v", v)
+	}
+	if s.Restart != "" && s.Restart != "no" {
+		args = append(args, "--restart", s.Restart)
+	}
+	args = append(args, s.Args...)
+	args = append(args, s.Image)
+	return append(args, s.Command...)
+}
+
+// Argv is the whole run command, for an interactive spec to hand the terminal to
+func (s RunSpec) Argv() []string {
+	return append([]string{runtimeBin()}, s.args()...)
+}
+
+// CommandLine is the command line RunContainer runs, for showing before it does
+func (s RunSpec) CommandLine() string {
+	var quoted []string
+	for _, arg := range s.Argv() {
+		quoted = append(quoted, shellQuote(arg))
+	}
+	return strings.Join(quoted, " ")
+}
+
+// RunContainer starts a new container in the background and returns its ID.
+// An image that isn't there yet is pulled first, which can take a while.
+func RunContainer(spec RunSpec) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, runtimeBin(), spec.args()...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	start := time.Now()
+	output, err := cmd.Output()
+	logging.Command(cmd, start, err)
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("running %s: %s", spec.Image, msg)
+	}
+	// the pull progress goes to stderr, the ID is the last line on stdout
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1]), nil
+}
+
+// ListImages lists the local images as repo:tag, newest first. Untagged
+// (dangling) images are left out, there's no name to run them by.
+func ListImages() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, runtimeBin(), "images", "--format", "{{.Repository}}:{{.Tag}}")
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	logging.Command(cmd, start, err)
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("listing images: %s", msg)
+	}
+	return parseImageList(string(output)), nil
+}
+
+func parseImageList(output string) []string {
+	var images []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.Contains(line, "<none>") || seen[line] {
+			continue
+		}
+		seen[line] = true
+		images = append(images, line)
+	}
+	return images
+}
+
+// Snapshot is what it takes to bring a removed container back: its `run -d`
+// or `create` arguments, rebuilt like Recreate does, and the networks to
+// connect it to after
+type Snapshot struct {
+	Name     string
+	Args     []string
+	Networks []string
+}
+
+func newSnapshot(spec containerSpec, image imageConfig) Snapshot {
+	return Snapshot{
+		Name:     strings.TrimPrefix(spec.Name, "/"),
+		Args:     recreateArgs(spec, image),
+		Networks: extraNetworks(spec),
+	}
+}
+
+// commands are the runtime commands that bring the container back, in order
+func (s Snapshot) commands() [][]string {
+	cmds := [][]string{s.Args}
+	for _, network := range s.Networks {
+		cmds = append(cmds, []string{"network", "connect", network, s.Name})
+	}
+	return cmds
+}
+
+// SnapshotContainer takes a container's snapshot, before removing it
+func SnapshotContainer(containerID string) (Snapshot, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	spec, image, err := inspectWithImage(ctx, containerID)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	return newSnapshot(spec, image), nil
+}
+
+// RestoreSnapshot creates the container again, on all its networks, and
+// starts it if it was running. Anonymous volumes removed with it come back
+// empty.
+func RestoreSnapshot(s Snapshot) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	for _, args := range s.commands() {
+		cmd := exec.CommandContext(ctx, runtimeBin(), args...)
+		start := time.Now()
+		output, err := cmd.CombinedOutput()
+		logging.Command(cmd, start, err)
+		if err != nil {
+			msg := strings.TrimSpace(string(output))
+			if msg == "" {
+				msg = err.Error()
+			}
+			return fmt.Errorf("recreating %s: %s", s.Name, msg)
+		}
+	}
+	return nil
+}
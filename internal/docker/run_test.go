@@ -0,0 +1,75 @@
+package docker
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSpecArgs(t *testing.T) {
+	assert.Equal(t, []string{"run", "-d", "nginx:latest"}, RunSpec{Image: "nginx:latest", Restart: "no"}.args())
+
+	spec := RunSpec{
+		Image:   "postgres:16",
+		Name:    "db",
+		Ports:   []string{"5432:5432"},
+		Env:     []string{"POSTGRES_PASSWORD=secret word"},
+		Volumes: []string{"pgdata:/var/lib/postgresql/data"},
+		Restart: "unless-stopped",
+	}
+	assert.Equal(t, []string{
+		"run", "-d", "--name", "db",
+		"-p", "5432:5432",
+		"-e", "POSTGRES_PASSWORD=secret word",
+		"-v", "pgdata:/var/lib/postgresql/data",
+		"--restart", "unless-stopped",
+		"postgres:16",
+	}, spec.args())
+
+	debug := RunSpec{
+		Image:       "nicolaka/netshoot",
+		Args:        []string{"--network", "container:web"},
+		Command:     []string{"tcpdump", "-i", "eth0"},
+		Interactive: true,
+	}
+	assert.Equal(t, []string{
+		"run", "-it", "--rm", "--network", "container:web", "nicolaka/netshoot", "tcpdump", "-i", "eth0",
+	}, debug.args())
+}
+
+func TestParseImageList(t *testing.T) {
+	output := "nginx:latest\n<none>:<none>\npostgres:16\nnginx:latest\n\n"
+	assert.Equal(t, []string{"nginx:latest", "postgres:16"}, parseImageList(output))
+}
+
+func TestRemoveArgs(t *testing.T) {
+	assert.Equal(t, []string{"rm", "web"}, removeArgs("web", false, false))
+	assert.Equal(t, []string{"rm", "--force", "--volumes", "web"}, removeArgs("web", true, true))
+}
+
+func TestSnapshotCommands(t *testing.T) {
+	var specs []containerSpec
+	require.NoError(t, json.Unmarshal([]byte(`[{
+		"Name": "/web",
+		"Config": {"Image": "nginx"},
+		"HostConfig": {"NetworkMode": "shop_default"},
+		"NetworkSettings": {"Networks": {"shop_default": {}, "proxy": {}, "monitoring": {}}},
+		"State": {"Running": true}
+	}]`), &specs))
+	require.Len(t, specs, 1)
+
+	s := newSnapshot(specs[0], imageConfig{})
+	assert.Equal(t, [][]string{
+		{"run", "-d", "--name", "web", "--network", "shop_default", "nginx"},
+		{"network", "connect", "monitoring", "web"},
+		{"network", "connect", "proxy", "web"},
+	}, s.commands())
+
+	// a stopped one comes back stopped, still on every network
+	specs[0].State.Running = false
+	s = newSnapshot(specs[0], imageConfig{})
+	assert.Equal(t, []string{"create", "--name", "web", "--network", "shop_default", "nginx"}, s.commands()[0])
+	assert.Len(t, s.commands(), 3)
+}
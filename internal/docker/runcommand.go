@@ -0,0 +1,77 @@
+package docker
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// run flags recreateArgs writes with a value after them
+var runValueFlags = map[string]bool{
+	"--name": true, "-e": true, "--label": true, "-p": true, "-v": true,
+	"--tmpfs": true, "--restart": true, "--network": true, "--add-host": true,
+	"--cap-add": true, "--cap-drop": true, "--user": true, "--workdir": true,
+	"--entrypoint": true,
+}
+
+// RunCommand reconstructs the `run` command of a container from inspect data,
+// like runlike. It's the command Recreate would run, so settings that repeat
+// the image's defaults are left out.
+func RunCommand(containerID string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	spec, image, err := inspectWithImage(ctx, containerID)
+	if err != nil {
+		return "", err
+	}
+	return formatRunCommand(runtimeBin(), recreateArgs(spec, image)), nil
+}
+
+// formatRunCommand puts each option on its own line, ready to paste into a shell
+func formatRunCommand(bin string, args []string) string {
+	if len(args) == 0 {
+		return bin
+	}
+	lines := []string{bin + " " + args[0]}
+	i := 1
+	for ; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			break // the image, the command follows it
+		}
+		if runValueFlags[arg] && i+1 < len(args) {
+			lines = append(lines, arg+" "+shellQuote(args[i+1]))
+			i++
+			continue
+		}
+		lines = append(lines, arg)
+	}
+
+	var tail []string
+	for _, arg := range args[i:] {
+		tail = append(tail, shellQuote(arg))
+	}
+	if len(tail) > 0 {
+		lines = append(lines, strings.Join(tail, " "))
+	}
+	return strings.Join(lines, " \\\n  ")
+}
+
+// shellQuote single-quotes s for sh unless it's made of safe characters only
+func shellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	safe := true
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:=@%+,", r)) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
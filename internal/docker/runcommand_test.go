@@ -0,0 +1,29 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatRunCommand(t *testing.T) {
+	got := formatRunCommand("docker", []string{
+		"run", "-d", "--name", "web", "-t",
+		"-e", "GREETING=hello world",
+		"--entrypoint", "",
+		"nginx:1.25", "sh", "-c", "echo 'hi'",
+	})
+	assert.Equal(t, `docker run \
+  -d \
+  --name web \
+  -t \
+  -e 'GREETING=hello world' \
+  --entrypoint '' \
+  nginx:1.25 sh -c 'echo '\''hi'\'''`, got)
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, "8080:80/tcp", shellQuote("8080:80/tcp"))
+	assert.Equal(t, "'$HOME'", shellQuote("$HOME"))
+	assert.Equal(t, "''", shellQuote(""))
+}
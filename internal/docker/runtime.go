@@ -0,0 +1,179 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/config"
+)
+
+// KnownRuntimes are the CLIs DockMate can drive, in the default auto-detect order
+var KnownRuntimes = []string{"docker", "podman", "nerdctl"}
+
+// runtimeAvailable reports whether bin is installed and its daemon answers.
+// a var so tests can fake it.
+var runtimeAvailable = func(bin string) bool {
+	if _, err := exec.LookPath(bin); err != nil {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return exec.CommandContext(ctx, bin, "info").Run() == nil
+}
+
+// DetectRuntime returns the first runtime in order that is installed and has a
+// reachable daemon. Unknown names are skipped, an empty order means KnownRuntimes.
+func DetectRuntime(order []string) (string, error) {
+	if len(order) == 0 {
+		order = KnownRuntimes
+	}
+	var tried []string
+	for _, rt := range order {
+		rt = strings.TrimSpace(strings.ToLower(rt))
+		if !isKnownRuntime(rt) {
+			continue
+		}
+		tried = append(tried, rt)
+		if runtimeAvailable(rt) {
+			return rt, nil
+		}
+	}
+	return "", fmt.Errorf("none of %s is installed with a running daemon", strings.Join(tried, ", "))
+}
+
+func isKnownRuntime(rt string) bool {
+	for _, k := range KnownRuntimes {
+		if k == rt {
+			return true
+		}
+	}
+	return false
+}
+
+// auto-detection runs once per auto_order, `info` on every call would be far too slow
+var autoRuntime struct {
+	mu       sync.Mutex
+	detected bool
+	order    []string
+	bin      string
+}
+
+// runtimeConfig returns the runtime section of the cached config, so the
+// file is only read again after a save or an edit
+func runtimeConfig() config.RuntimeConfig {
+	return config.Cached().Runtime
+}
+
+// ReloadRuntimeConfig drops the cached config and auto-detect result,
+// e.g. after the prechecks picked a runtime
+func ReloadRuntimeConfig() {
+	config.Invalidate()
+
+	autoRuntime.mu.Lock()
+	autoRuntime.detected = false
+	autoRuntime.mu.Unlock()
+}
+
+// resolveRuntime maps the configured runtime type to a binary, detecting it for "auto"
+func resolveRuntime(rc config.RuntimeConfig) string {
+	rt := strings.TrimSpace(strings.ToLower(rc.Type))
+	switch rt {
+	case "podman", "nerdctl":
+		return rt
+	case "auto":
+		autoRuntime.mu.Lock()
+		defer autoRuntime.mu.Unlock()
+		if !autoRuntime.detected || !slices.Equal(autoRuntime.order, rc.AutoOrder) {
+			bin, err := DetectRuntime(rc.AutoOrder)
+			if err != nil {
+				bin = "docker"
+			}
+			autoRuntime.detected = true
+			autoRuntime.order = slices.Clone(rc.AutoOrder)
+			autoRuntime.bin = bin
+		}
+		return autoRuntime.bin
+	}
+	return "docker"
+}
+
+// RuntimeBinary returns the runtime CLI DockMate talks to, with "auto" resolved
+func RuntimeBinary() string {
+	return runtimeBin()
+}
+
+// socketEnv lists the variables each runtime CLI reads its endpoint from
+var socketEnv = map[string][]string{
+	"docker":  {"DOCKER_HOST"},
+	"podman":  {"CONTAINER_HOST"},
+	"nerdctl": {"CONTAINERD_ADDRESS"},
+	// auto could end up on either API-compatible engine, containerd has its own socket
+	"auto": {"DOCKER_HOST", "CONTAINER_HOST"},
+}
+
+// SocketURL turns runtime.socket into an endpoint URL; plain paths become unix:// URLs
+func SocketURL(socket string) string {
+	socket = strings.TrimSpace(socket)
+	if strings.HasPrefix(socket, "/") {
+		return "unix://" + socket
+	}
+	return socket
+}
+
+// SocketPath returns the filesystem path of a unix socket endpoint, or "" for
+// tcp://, ssh:// and other remote endpoints that can't be checked locally
+func SocketPath(socket string) string {
+	url := SocketURL(socket)
+	if strings.HasPrefix(url, "unix://") {
+		return strings.TrimPrefix(url, "unix://")
+	}
+	return ""
+}
+
+// socketApplied is what ApplySocket exported, so the next call, after a
+// settings restart or a config reload, can replace it with another socket
+var socketApplied = map[string]string{}
+
+// ApplySocket points the runtime CLIs at runtime.socket by exporting the
+// endpoint variable they read, so every command (compose and exec included)
+// talks to it. Variables already set in the environment are left alone.
+func ApplySocket(rc config.RuntimeConfig) {
+	for name, value := range socketApplied {
+		// one the remote tunnel holds for now is still ours, it's put back
+		// when the tunnel closes and taken back then
+		if os.Getenv(name) == value {
+			os.Unsetenv(name)
+			delete(socketApplied, name)
+		}
+	}
+
+	socket := strings.TrimSpace(rc.Socket)
+	if socket == "" {
+		return
+	}
+
+	rt := strings.TrimSpace(strings.ToLower(rc.Type))
+	if _, ok := socketEnv[rt]; !ok {
+		rt = "docker"
+	}
+	for _, name := range socketEnv[rt] {
+		if os.Getenv(name) != "" {
+			continue
+		}
+		value := SocketURL(socket)
+		if name == "CONTAINERD_ADDRESS" {
+			// containerd wants a plain path
+			if path := SocketPath(socket); path != "" {
+				value = path
+			}
+		}
+		os.Setenv(name, value)
+		socketApplied[name] = value
+	}
+}
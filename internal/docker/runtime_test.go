@@ -0,0 +1,87 @@
+package docker
+
+import (
+	"os"
+	"testing"
+
+	"github.com/shubh-io/dockmate/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectRuntime(t *testing.T) {
+	orig := runtimeAvailable
+	defer func() { runtimeAvailable = orig }()
+
+	available := map[string]bool{"podman": true, "nerdctl": true}
+	var probed []string
+	runtimeAvailable = func(bin string) bool {
+		probed = append(probed, bin)
+		return available[bin]
+	}
+
+	rt, err := DetectRuntime(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "podman", rt)
+	assert.Equal(t, []string{"docker", "podman"}, probed)
+
+	// configured order wins, unknown names are skipped
+	probed = nil
+	rt, err = DetectRuntime([]string{"lima", "Nerdctl", "podman"})
+	require.NoError(t, err)
+	assert.Equal(t, "nerdctl", rt)
+	assert.Equal(t, []string{"nerdctl"}, probed)
+
+	available = map[string]bool{}
+	_, err = DetectRuntime([]string{"docker", "podman"})
+	assert.ErrorContains(t, err, "docker, podman")
+}
+
+func TestSocketURL(t *testing.T) {
+	assert.Equal(t, "unix:///run/user/1000/docker.sock", SocketURL("/run/user/1000/docker.sock"))
+	assert.Equal(t, "unix:///var/run/docker.sock", SocketURL("unix:///var/run/docker.sock"))
+	assert.Equal(t, "tcp://10.0.0.5:2375", SocketURL(" tcp://10.0.0.5:2375 "))
+
+	assert.Equal(t, "/run/user/1000/docker.sock", SocketPath("/run/user/1000/docker.sock"))
+	assert.Equal(t, "/var/run/docker.sock", SocketPath("unix:///var/run/docker.sock"))
+	assert.Equal(t, "", SocketPath("ssh://me@buildbox"))
+}
+
+func TestApplySocket(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "")
+	t.Setenv("CONTAINER_HOST", "")
+	t.Setenv("CONTAINERD_ADDRESS", "")
+
+	ApplySocket(config.RuntimeConfig{Type: "podman", Socket: "/run/podman/podman.sock"})
+	assert.Equal(t, "unix:///run/podman/podman.sock", os.Getenv("CONTAINER_HOST"))
+	assert.Equal(t, "", os.Getenv("DOCKER_HOST"))
+
+	// switching sockets replaces the one set before
+	ApplySocket(config.RuntimeConfig{Type: "podman", Socket: "/run/user/1000/podman/podman.sock"})
+	assert.Equal(t, "unix:///run/user/1000/podman/podman.sock", os.Getenv("CONTAINER_HOST"))
+	ApplySocket(config.RuntimeConfig{Type: "podman"})
+	assert.Equal(t, "", os.Getenv("CONTAINER_HOST"))
+
+	ApplySocket(config.RuntimeConfig{Type: "nerdctl", Socket: "unix:///run/containerd/containerd.sock"})
+	assert.Equal(t, "/run/containerd/containerd.sock", os.Getenv("CONTAINERD_ADDRESS"))
+
+	// the environment wins over the config
+	t.Setenv("DOCKER_HOST", "ssh://me@buildbox")
+	ApplySocket(config.RuntimeConfig{Type: "docker", Socket: "/var/run/other.sock"})
+	assert.Equal(t, "ssh://me@buildbox", os.Getenv("DOCKER_HOST"))
+}
+
+func TestPodmanMode(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	assert.Equal(t, "/run/user/1000/podman/podman.sock", RootlessPodmanSocket())
+
+	t.Setenv("CONTAINER_HOST", "unix:///run/podman/podman.sock")
+	assert.Equal(t, "rootful", PodmanMode())
+	t.Setenv("CONTAINER_HOST", "unix:///run/user/1000/podman/podman.sock")
+	assert.Equal(t, "rootless", PodmanMode())
+	t.Setenv("CONTAINER_HOST", "ssh://core@buildbox/run/podman/podman.sock")
+	assert.Equal(t, "remote", PodmanMode())
+
+	assert.Contains(t, PodmanSocket{Mode: "rootful", Exists: true, Path: RootfulPodmanSocket}.Hint(), "sudo")
+	assert.Empty(t, PodmanSocket{Mode: "rootless", Exists: true, Reachable: true}.Hint())
+}
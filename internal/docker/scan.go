@@ -0,0 +1,204 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+// Scanners are the vulnerability scanners ScanImage can use, in order of preference
+var Scanners = []string{"trivy", "grype"}
+
+// SeverityOrder is how vulnerabilities are sorted and counted, worst first
+var SeverityOrder = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "UNKNOWN"}
+
+// ErrNoScanner is returned by FindScanner when neither trivy nor grype is installed
+var ErrNoScanner = errors.New("no vulnerability scanner found, install trivy (https://trivy.dev) or grype (https://github.com/anchore/grype)")
+
+type Vulnerability struct {
+	ID        string // CVE-2024-1234, GHSA-...
+	Package   string
+	Installed string
+	FixedIn   string // empty when there is no fix yet
+	Severity  string // one of SeverityOrder
+	Title     string
+}
+
+type ScanResult struct {
+	Scanner         string
+	Image           string
+	Vulnerabilities []Vulnerability // worst first
+}
+
+// Counts returns the number of vulnerabilities per severity
+func (r ScanResult) Counts() map[string]int {
+	counts := make(map[string]int)
+	for _, v := range r.Vulnerabilities {
+		counts[v.Severity]++
+	}
+	return counts
+}
+
+// FindScanner returns the first of Scanners that is installed
+func FindScanner() (string, error) {
+	for _, s := range Scanners {
+		if _, err := exec.LookPath(s); err == nil {
+			return s, nil
+		}
+	}
+	return "", ErrNoScanner
+}
+
+// ScanImage scans a local image with trivy or grype, whichever is installed.
+// The first run can take minutes while the scanner downloads its database.
+func ScanImage(image string) (ScanResult, error) {
+	scanner, err := FindScanner()
+	if err != nil {
+		return ScanResult{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if scanner == "trivy" {
+		cmd = exec.CommandContext(ctx, "trivy", "image", "--quiet", "--format", "json", image)
+	} else {
+		cmd = exec.CommandContext(ctx, "grype", image, "--output", "json", "--quiet")
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	start := time.Now()
+	output, err := cmd.Output()
+	logging.Command(cmd, start, err)
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return ScanResult{}, fmt.Errorf("%s: %s", scanner, msg)
+	}
+
+	var vulns []Vulnerability
+	if scanner == "trivy" {
+		vulns, err = parseTrivy(output)
+	} else {
+		vulns, err = parseGrype(output)
+	}
+	if err != nil {
+		return ScanResult{}, fmt.Errorf("reading %s output: %w", scanner, err)
+	}
+	sortVulnerabilities(vulns)
+	return ScanResult{Scanner: scanner, Image: image, Vulnerabilities: vulns}, nil
+}
+
+func parseTrivy(output []byte) ([]Vulnerability, error) {
+	var report struct {
+		Results []struct {
+			Vulnerabilities []struct {
+				VulnerabilityID  string
+				PkgName          string
+				InstalledVersion string
+				FixedVersion     string
+				Severity         string
+				Title            string
+			}
+		}
+	}
+	if err := json.Unmarshal(output, &report); err != nil {
+		return nil, err
+	}
+
+	var vulns []Vulnerability
+	for _, r := range report.Results {
+		for _, v := range r.Vulnerabilities {
+			vulns = append(vulns, Vulnerability{
+				ID:        v.VulnerabilityID,
+				Package:   v.PkgName,
+				Installed: v.InstalledVersion,
+				FixedIn:   v.FixedVersion,
+				Severity:  normalizeSeverity(v.Severity),
+				Title:     v.Title,
+			})
+		}
+	}
+	return vulns, nil
+}
+
+func parseGrype(output []byte) ([]Vulnerability, error) {
+	var report struct {
+		Matches []struct {
+			Vulnerability struct {
+				ID          string `json:"id"`
+				Severity    string `json:"severity"`
+				Description string `json:"description"`
+				Fix         struct {
+					Versions []string `json:"versions"`
+				} `json:"fix"`
+			} `json:"vulnerability"`
+			Artifact struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+			} `json:"artifact"`
+		} `json:"matches"`
+	}
+	if err := json.Unmarshal(output, &report); err != nil {
+		return nil, err
+	}
+
+	var vulns []Vulnerability
+	for _, m := range report.Matches {
+		vulns = append(vulns, Vulnerability{
+			ID:        m.Vulnerability.ID,
+			Package:   m.Artifact.Name,
+			Installed: m.Artifact.Version,
+			FixedIn:   strings.Join(m.Vulnerability.Fix.Versions, ", "),
+			Severity:  normalizeSeverity(m.Vulnerability.Severity),
+			Title:     m.Vulnerability.Description,
+		})
+	}
+	return vulns, nil
+}
+
+// normalizeSeverity maps grype's "High" and trivy's "HIGH" (and "Negligible") onto SeverityOrder
+func normalizeSeverity(s string) string {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	for _, known := range SeverityOrder {
+		if s == known {
+			return s
+		}
+	}
+	if s == "NEGLIGIBLE" {
+		return "LOW"
+	}
+	return "UNKNOWN"
+}
+
+func severityRank(s string) int {
+	for i, known := range SeverityOrder {
+		if s == known {
+			return i
+		}
+	}
+	return len(SeverityOrder)
+}
+
+// sortVulnerabilities puts the worst first, then by package and ID
+func sortVulnerabilities(vulns []Vulnerability) {
+	sort.SliceStable(vulns, func(i, j int) bool {
+		if ri, rj := severityRank(vulns[i].Severity), severityRank(vulns[j].Severity); ri != rj {
+			return ri < rj
+		}
+		if vulns[i].Package != vulns[j].Package {
+			return vulns[i].Package < vulns[j].Package
+		}
+		return vulns[i].ID < vulns[j].ID
+	})
+}
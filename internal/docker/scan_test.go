@@ -0,0 +1,53 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTrivy(t *testing.T) {
+	output := []byte(`{"Results":[
+{"Target":"nginx (debian 12.5)","Vulnerabilities":[
+ {"VulnerabilityID":"CVE-2024-0002","PkgName":"openssl","InstalledVersion":"3.0.11","FixedVersion":"3.0.13","Severity":"HIGH","Title":"openssl: bad thing"},
+ {"VulnerabilityID":"CVE-2024-0001","PkgName":"zlib","InstalledVersion":"1.2.13","Severity":"LOW"}]},
+{"Target":"app.jar"}]}`)
+	vulns, err := parseTrivy(output)
+	require.NoError(t, err)
+	require.Len(t, vulns, 2)
+	assert.Equal(t, Vulnerability{ID: "CVE-2024-0002", Package: "openssl", Installed: "3.0.11", FixedIn: "3.0.13", Severity: "HIGH", Title: "openssl: bad thing"}, vulns[0])
+	assert.Equal(t, "", vulns[1].FixedIn)
+}
+
+func TestParseGrype(t *testing.T) {
+	output := []byte(`{"matches":[
+{"vulnerability":{"id":"CVE-2024-0003","severity":"Negligible","fix":{"versions":[]}},"artifact":{"name":"bash","version":"5.2"}},
+{"vulnerability":{"id":"GHSA-xxxx","severity":"Critical","description":"rce","fix":{"versions":["1.2.3"]}},"artifact":{"name":"lodash","version":"1.0.0"}}]}`)
+	vulns, err := parseGrype(output)
+	require.NoError(t, err)
+	require.Len(t, vulns, 2)
+	assert.Equal(t, "LOW", vulns[0].Severity)
+	assert.Equal(t, "CRITICAL", vulns[1].Severity)
+	assert.Equal(t, "1.2.3", vulns[1].FixedIn)
+	assert.Equal(t, "lodash", vulns[1].Package)
+}
+
+func TestSortAndCount(t *testing.T) {
+	vulns := []Vulnerability{
+		{ID: "a", Package: "z", Severity: "LOW"},
+		{ID: "b", Package: "y", Severity: "UNKNOWN"},
+		{ID: "c", Package: "x", Severity: "CRITICAL"},
+		{ID: "d", Package: "a", Severity: "LOW"},
+	}
+	sortVulnerabilities(vulns)
+	var ids []string
+	for _, v := range vulns {
+		ids = append(ids, v.ID)
+	}
+	assert.Equal(t, []string{"c", "d", "a", "b"}, ids)
+
+	counts := ScanResult{Vulnerabilities: vulns}.Counts()
+	assert.Equal(t, map[string]int{"CRITICAL": 1, "LOW": 2, "UNKNOWN": 1}, counts)
+	assert.Equal(t, "UNKNOWN", normalizeSeverity("weird"))
+}
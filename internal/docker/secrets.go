@@ -0,0 +1,179 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+// Secret is a swarm secret or config, or a podman secret. Their contents
+// can't be read back, only replaced.
+type Secret struct {
+	ID      string
+	Name    string
+	Kind    string // secret or config
+	Driver  string // empty for the default
+	Created string // as the runtime prints it, e.g. "3 days ago"
+	UsedBy  []string
+}
+
+// SecretsSupported says whether the runtime has secrets, and why not if it
+// doesn't: docker only has them in swarm mode, nerdctl not at all
+func SecretsSupported() (bool, string) {
+	switch runtimeBin() {
+	case "podman":
+		return true, ""
+	case "docker":
+		if SwarmActive() {
+			return true, ""
+		}
+		return false, "Secrets and configs need swarm mode, `docker swarm init` turns it on"
+	}
+	return false, fmt.Sprintf("%s has no secrets", runtimeBin())
+}
+
+// ListSecrets returns the secrets, and the configs on swarm, sorted by name,
+// with the services (swarm) or containers (podman) using them
+func ListSecrets() ([]Secret, error) {
+	kinds := []string{"secret"}
+	if runtimeBin() == "docker" {
+		kinds = append(kinds, "config")
+	}
+
+	var secrets []Secret
+	for _, kind := range kinds {
+		format := "{{.ID}}\t{{.Name}}\t{{.Driver}}\t{{.CreatedAt}}"
+		if kind == "config" {
+			// configs have no driver
+			format = "{{.ID}}\t{{.Name}}\t\t{{.CreatedAt}}"
+		}
+		output, err := secretCommand(15*time.Second, kind, "ls", "--format", format)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, parseSecrets(string(output), kind)...)
+	}
+
+	users, err := secretUsers()
+	if err != nil {
+		// the list is still worth showing without them
+		logging.Errorf("finding what uses secrets: %v", err)
+	}
+	for i := range secrets {
+		secrets[i].UsedBy = users[secrets[i].Kind+":"+secrets[i].Name]
+	}
+
+	sort.SliceStable(secrets, func(i, j int) bool {
+		if secrets[i].Name != secrets[j].Name {
+			return secrets[i].Name < secrets[j].Name
+		}
+		return secrets[i].Kind > secrets[j].Kind
+	})
+	return secrets, nil
+}
+
+// CreateSecret creates a secret or config from the contents of file
+func CreateSecret(kind, name, file string) error {
+	_, err := secretCommand(30*time.Second, kind, "create", name, file)
+	return err
+}
+
+// RemoveSecret removes a secret or config. The runtime refuses one that's
+// still in use.
+func RemoveSecret(kind, name string) error {
+	_, err := secretCommand(30*time.Second, kind, "rm", name)
+	return err
+}
+
+func parseSecrets(output, kind string) []Secret {
+	var secrets []Secret
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(strings.TrimRight(line, "\r"), "\t")
+		if len(fields) != 4 || fields[1] == "" {
+			continue
+		}
+		secrets = append(secrets, Secret{
+			ID:      fields[0],
+			Name:    fields[1],
+			Kind:    kind,
+			Driver:  fields[2],
+			Created: fields[3],
+		})
+	}
+	return secrets
+}
+
+// secretUsers maps "secret:name" and "config:name" to the swarm services, or
+// podman containers, that use them
+func secretUsers() (map[string][]string, error) {
+	var listArgs, inspectArgs []string
+	if runtimeBin() == "podman" {
+		listArgs = []string{"ps", "-aq"}
+		inspectArgs = []string{"container", "inspect", "--format", "{{.Name}}\t{{range .Config.Secrets}}{{.Name}} {{end}}\t"}
+	} else {
+		listArgs = []string{"service", "ls", "-q"}
+		inspectArgs = []string{"service", "inspect", "--format",
+			"{{.Spec.Name}}\t{{range .Spec.TaskTemplate.ContainerSpec.Secrets}}{{.SecretName}} {{end}}\t{{range .Spec.TaskTemplate.ContainerSpec.Configs}}{{.ConfigName}} {{end}}"}
+	}
+
+	output, err := secretCommand(15*time.Second, listArgs...)
+	if err != nil {
+		return nil, err
+	}
+	ids := strings.Fields(string(output))
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	output, err = secretCommand(30*time.Second, append(inspectArgs, ids...)...)
+	if err != nil {
+		return nil, err
+	}
+	return parseSecretUsers(string(output)), nil
+}
+
+func parseSecretUsers(output string) map[string][]string {
+	users := make(map[string][]string)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(strings.TrimRight(line, "\r"), "\t")
+		if len(fields) < 2 || fields[0] == "" {
+			continue
+		}
+		user := strings.TrimPrefix(fields[0], "/")
+		for i, kind := range []string{"secret", "config"} {
+			if i+1 >= len(fields) {
+				break
+			}
+			for _, name := range strings.Fields(fields[i+1]) {
+				users[kind+":"+name] = append(users[kind+":"+name], user)
+			}
+		}
+	}
+	for _, u := range users {
+		sort.Strings(u)
+	}
+	return users
+}
+
+func secretCommand(timeout time.Duration, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	runtime := runtimeBin()
+	cmd := exec.CommandContext(ctx, runtime, args...)
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	logging.Command(cmd, start, err)
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("%s %s %s: %s", runtime, args[0], args[1], msg)
+	}
+	return output, nil
+}
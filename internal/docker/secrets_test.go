@@ -0,0 +1,30 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSecrets(t *testing.T) {
+	out := "k3v9x2m1q8w7\tdb_password\t\t3 days ago\n" +
+		"p0o9i8u7y6t5\tapi_key\tfile\t2 hours ago\n" +
+		"\n"
+	assert.Equal(t, []Secret{
+		{ID: "k3v9x2m1q8w7", Name: "db_password", Kind: "secret", Created: "3 days ago"},
+		{ID: "p0o9i8u7y6t5", Name: "api_key", Kind: "secret", Driver: "file", Created: "2 hours ago"},
+	}, parseSecrets(out, "secret"))
+}
+
+func TestParseSecretUsers(t *testing.T) {
+	swarm := "shop_web\tdb_password api_key \tnginx_conf \n" +
+		"shop_db\tdb_password \t\n"
+	users := parseSecretUsers(swarm)
+	assert.Equal(t, []string{"shop_db", "shop_web"}, users["secret:db_password"])
+	assert.Equal(t, []string{"shop_web"}, users["secret:api_key"])
+	assert.Equal(t, []string{"shop_web"}, users["config:nginx_conf"])
+
+	// podman names start with a slash on some versions, and have no configs
+	podman := "/web\tapi_key \t\n"
+	assert.Equal(t, []string{"web"}, parseSecretUsers(podman)["secret:api_key"])
+}
@@ -0,0 +1,142 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ShellCandidates are the shells DetectShells looks for, best first
+var ShellCandidates = []string{
+	"/bin/bash", "/usr/bin/bash",
+	"/bin/zsh", "/usr/bin/zsh",
+	"/bin/ash",
+	"/bin/sh",
+}
+
+// WindowsShells are looked for instead when the engine runs Windows containers, best first
+var WindowsShells = []string{"pwsh", "powershell", "cmd"}
+
+var engineOS struct {
+	once    sync.Once
+	windows bool
+}
+
+// WindowsContainers reports whether the engine runs Windows containers
+// (Docker Desktop on Windows switched to "Windows containers"). Only asked
+// once, and only on Windows hosts since nothing else can run them.
+func WindowsContainers() bool {
+	if runtime.GOOS != "windows" {
+		return false
+	}
+	engineOS.once.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		out, err := exec.CommandContext(ctx, runtimeBin(), "info", "--format", "{{.OSType}}").Output()
+		engineOS.windows = err == nil && strings.TrimSpace(string(out)) == "windows"
+	})
+	return engineOS.windows
+}
+
+// IsWindowsShell reports whether shell is one of WindowsShells
+func IsWindowsShell(shell string) bool {
+	return slices.Contains(WindowsShells, shell)
+}
+
+// DetectShells probes a running container for the shells in ShellCandidates
+// and returns the ones it has, best first. The probe itself needs /bin/sh,
+// so images without any shell come back with an error.
+// Windows containers are probed for WindowsShells instead.
+func DetectShells(containerID string) ([]string, error) {
+	if WindowsContainers() {
+		return detectWindowsShells(containerID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	probe := "for s in " + strings.Join(ShellCandidates, " ") + `; do [ -x "$s" ] && echo "$s"; done; true`
+	output, err := exec.CommandContext(ctx, runtimeBin(), "exec", containerID, "sh", "-c", probe).CombinedOutput()
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("probing shells: %s", msg)
+	}
+
+	shells := pickShells(string(output))
+	if len(shells) == 0 {
+		return nil, fmt.Errorf("no shell found in container")
+	}
+	return shells, nil
+}
+
+// pickShells keeps the known shells from the probe output, in ShellCandidates
+// order. /usr/bin/bash is dropped when /bin/bash is there (usually a symlink
+// on merged-/usr distros).
+func pickShells(output string) []string {
+	found := make(map[string]bool)
+	for _, line := range strings.Split(output, "\n") {
+		found[strings.TrimSpace(line)] = true
+	}
+
+	var shells []string
+	seen := make(map[string]bool)
+	for _, s := range ShellCandidates {
+		base := s[strings.LastIndex(s, "/")+1:]
+		if !found[s] || seen[base] {
+			continue
+		}
+		seen[base] = true
+		shells = append(shells, s)
+	}
+	return shells
+}
+
+// detectWindowsShells tries to start each of WindowsShells in the container,
+// there's no sh to run a single probe with
+func detectWindowsShells(containerID string) ([]string, error) {
+	var shells []string
+	for _, shell := range WindowsShells {
+		args := []string{"exec", containerID, shell}
+		if shell == "cmd" {
+			args = append(args, "/c", "exit 0")
+		} else {
+			args = append(args, "-NoProfile", "-Command", "exit 0")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		err := exec.CommandContext(ctx, runtimeBin(), args...).Run()
+		cancel()
+		if err == nil {
+			shells = append(shells, shell)
+		}
+	}
+	if len(shells) == 0 {
+		return nil, fmt.Errorf("no shell found in container")
+	}
+	return shells, nil
+}
+
+// RunInContainer runs a one-shot sh script in a running container and returns
+// what it printed, stdout and stderr together. Needs /bin/sh like the probe.
+func RunInContainer(containerID, script string) (string, error) {
+	if WindowsContainers() {
+		return "", fmt.Errorf("not available for Windows containers")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, runtimeBin(), "exec", containerID, "sh", "-c", script).CombinedOutput()
+	if ctx.Err() != nil {
+		return string(output), fmt.Errorf("gave up after 30s")
+	}
+	return string(output), err
+}
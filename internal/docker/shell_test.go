@@ -0,0 +1,28 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickShells(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []string
+	}{
+		{"alpine", "/bin/ash\n/bin/sh\n", []string{"/bin/ash", "/bin/sh"}},
+		{"debian", "/bin/bash\n/usr/bin/bash\n/bin/sh\n", []string{"/bin/bash", "/bin/sh"}},
+		{"bash only in /usr/bin", "/usr/bin/bash\n/bin/sh\n", []string{"/usr/bin/bash", "/bin/sh"}},
+		{"sorted best first", "/bin/sh\n/bin/zsh\n/bin/bash\n", []string{"/bin/bash", "/bin/zsh", "/bin/sh"}},
+		{"unknown lines ignored", "/bin/fish\n\n/bin/sh\r\n", []string{"/bin/sh"}},
+		{"nothing", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pickShells(tt.output))
+		})
+	}
+}
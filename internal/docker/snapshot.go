@@ -0,0 +1,51 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+// CommitContainer saves the container's current filesystem as a new image and
+// returns the image ID. The container is paused while it's being committed.
+func CommitContainer(containerID, image string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, runtimeBin(), "commit", containerID, image)
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	logging.Command(cmd, start, err)
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("committing to %s: %s", image, msg)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// ExportContainer writes the container's filesystem to a tarball at path,
+// flattened into one layer. Volumes aren't part of it.
+func ExportContainer(containerID, path string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, runtimeBin(), "export", "--output", path, containerID)
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	logging.Command(cmd, start, err)
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return fmt.Errorf("exporting to %s: %s", path, msg)
+	}
+	return nil
+}
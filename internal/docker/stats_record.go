@@ -0,0 +1,176 @@
+package docker
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StatsSample is one container's stats at one moment, a row of the recording
+type StatsSample struct {
+	Time            time.Time `json:"time"`
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	Project         string    `json:"project,omitempty"`
+	CPUPercent      float64   `json:"cpu_percent"`
+	MemPercent      float64   `json:"mem_percent"`
+	MemBytes        int64     `json:"mem_bytes"`
+	NetRxBytes      int64     `json:"net_rx_bytes"`
+	NetTxBytes      int64     `json:"net_tx_bytes"`
+	BlockReadBytes  int64     `json:"block_read_bytes"`
+	BlockWriteBytes int64     `json:"block_write_bytes"`
+}
+
+var statsSampleHeader = []string{
+	"time", "id", "name", "project", "cpu_percent", "mem_percent", "mem_bytes",
+	"net_rx_bytes", "net_tx_bytes", "block_read_bytes", "block_write_bytes",
+}
+
+// NewStatsSample reads c's stats strings into numbers. Sizes that can't be
+// read are 0.
+func NewStatsSample(c Container, at time.Time) StatsSample {
+	name := ""
+	if len(c.Names) > 0 {
+		name = strings.TrimPrefix(c.Names[0], "/")
+	}
+	s := StatsSample{
+		Time:       at,
+		ID:         c.ID,
+		Name:       name,
+		Project:    c.ComposeProject,
+		CPUPercent: parsePercentValue(c.CPU),
+		MemPercent: parsePercentValue(c.Memory),
+		MemBytes:   max(0, MemUsedBytes(c.MemUsage)),
+	}
+	s.NetRxBytes, s.NetTxBytes = ioHalves(c.NetIO)
+	s.BlockReadBytes, s.BlockWriteBytes = ioHalves(c.BlockIO)
+	return s
+}
+
+// ioHalves splits an I/O string like "1.2kB / 3MB" into its bytes
+func ioHalves(io string) (int64, int64) {
+	in, out, _ := strings.Cut(io, "/")
+	return max(0, parseSizeBytes(in)), max(0, parseSizeBytes(out))
+}
+
+// StatsRecorder appends stats samples to a CSV or JSONL file for graphing in
+// other tools. The file is opened for each write, so it can be moved away or
+// tailed while recording. Past maxBytes it's rotated to name.1.csv,
+// name.2.csv and so on, keeping maxFiles of them.
+type StatsRecorder struct {
+	path     string
+	format   string // "csv" or "jsonl"
+	maxBytes int64  // 0 never rotates
+	maxFiles int
+}
+
+// NewStatsRecorder checks the format, "" picks it by the file's extension
+// (.jsonl and .json are JSONL, the rest CSV)
+func NewStatsRecorder(path, format string, maxBytes int64, maxFiles int) (*StatsRecorder, error) {
+	if path == "" {
+		return nil, fmt.Errorf("no file to record stats to")
+	}
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".jsonl", ".json", ".ndjson":
+			format = "jsonl"
+		default:
+			format = "csv"
+		}
+	}
+	if format != "csv" && format != "jsonl" {
+		return nil, fmt.Errorf("unknown stats record format %q, use csv or jsonl", format)
+	}
+	return &StatsRecorder{path: path, format: format, maxBytes: maxBytes, maxFiles: maxFiles}, nil
+}
+
+// Path is the file being recorded to
+func (r *StatsRecorder) Path() string {
+	return r.path
+}
+
+// Record appends the samples, with a header first when a CSV file is new
+func (r *StatsRecorder) Record(samples []StatsSample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	if err := r.rotate(); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	if r.format == "jsonl" {
+		enc := json.NewEncoder(f)
+		for _, s := range samples {
+			if err := enc.Encode(s); err != nil {
+				f.Close()
+				return err
+			}
+		}
+		return f.Close()
+	}
+
+	w := csv.NewWriter(f)
+	if info.Size() == 0 {
+		w.Write(statsSampleHeader)
+	}
+	for _, s := range samples {
+		w.Write([]string{
+			s.Time.Format(time.RFC3339Nano), s.ID, s.Name, s.Project,
+			strconv.FormatFloat(s.CPUPercent, 'f', -1, 64),
+			strconv.FormatFloat(s.MemPercent, 'f', -1, 64),
+			strconv.FormatInt(s.MemBytes, 10),
+			strconv.FormatInt(s.NetRxBytes, 10), strconv.FormatInt(s.NetTxBytes, 10),
+			strconv.FormatInt(s.BlockReadBytes, 10), strconv.FormatInt(s.BlockWriteBytes, 10),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// rotate moves the file aside once it's past maxBytes
+func (r *StatsRecorder) rotate() error {
+	if r.maxBytes <= 0 {
+		return nil
+	}
+	info, err := os.Stat(r.path)
+	if err != nil || info.Size() < r.maxBytes {
+		return nil
+	}
+	if r.maxFiles < 1 {
+		return os.Remove(r.path)
+	}
+	os.Remove(r.rotated(r.maxFiles))
+	for i := r.maxFiles - 1; i >= 1; i-- {
+		os.Rename(r.rotated(i), r.rotated(i+1))
+	}
+	return os.Rename(r.path, r.rotated(1))
+}
+
+// rotated is the name of the n-th rotated file, stats.csv -> stats.1.csv
+func (r *StatsRecorder) rotated(n int) string {
+	ext := filepath.Ext(r.path)
+	return fmt.Sprintf("%s.%d%s", strings.TrimSuffix(r.path, ext), n, ext)
+}
@@ -0,0 +1,75 @@
+package docker
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStatsSample(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	s := NewStatsSample(Container{
+		ID:             "abc",
+		Names:          []string{"/web"},
+		ComposeProject: "shop",
+		CPU:            "12.5%",
+		Memory:         "3.1%",
+		MemUsage:       "64MiB / 2GiB",
+		NetIO:          "1.5kB / 2MB",
+		BlockIO:        "0B / --",
+	}, at)
+
+	assert.Equal(t, "web", s.Name)
+	assert.Equal(t, 12.5, s.CPUPercent)
+	assert.Equal(t, int64(64*1024*1024), s.MemBytes)
+	assert.Equal(t, int64(1500), s.NetRxBytes)
+	assert.Equal(t, int64(2000000), s.NetTxBytes)
+	assert.Equal(t, int64(0), s.BlockWriteBytes)
+}
+
+func TestStatsRecorder(t *testing.T) {
+	dir := t.TempDir()
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	sample := StatsSample{Time: at, ID: "abc", Name: "web", CPUPercent: 1.5}
+
+	_, err := NewStatsRecorder(filepath.Join(dir, "stats.txt"), "xml", 0, 0)
+	assert.Error(t, err)
+
+	csvPath := filepath.Join(dir, "stats.csv")
+	r, err := NewStatsRecorder(csvPath, "", 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, r.Record([]StatsSample{sample}))
+	require.NoError(t, r.Record([]StatsSample{sample}))
+	data, err := os.ReadFile(csvPath)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 3) // one header
+	assert.Equal(t, strings.Join(statsSampleHeader, ","), lines[0])
+	assert.Equal(t, "2024-05-01T12:00:00Z,abc,web,,1.5,0,0,0,0,0,0", lines[1])
+
+	jsonPath := filepath.Join(dir, "stats.jsonl")
+	r, err = NewStatsRecorder(jsonPath, "", 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, r.Record([]StatsSample{sample}))
+	data, err = os.ReadFile(jsonPath)
+	require.NoError(t, err)
+	var got StatsSample
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, sample, got)
+
+	// rotates once past the size, keeping two old files
+	r, err = NewStatsRecorder(csvPath, "csv", 10, 2)
+	require.NoError(t, err)
+	for range 4 {
+		require.NoError(t, r.Record([]StatsSample{sample}))
+	}
+	assert.FileExists(t, filepath.Join(dir, "stats.1.csv"))
+	assert.FileExists(t, filepath.Join(dir, "stats.2.csv"))
+	assert.NoFileExists(t, filepath.Join(dir, "stats.3.csv"))
+}
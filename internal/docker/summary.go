@@ -0,0 +1,103 @@
+package docker
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// UsageTotals adds up stats across a set of containers
+type UsageTotals struct {
+	Running    int
+	Total      int
+	CPUPercent float64 // sum of per-container CPU%, can exceed 100 on multi-core hosts
+	MemPercent float64 // sum of per-container memory %
+	MemBytes   int64   // sum of memory in use
+	NetBytes   int64   // network I/O since start, in and out
+	BlockBytes int64   // disk I/O since start, read and written
+}
+
+// ProjectUsage is the usage of one compose project
+type ProjectUsage struct {
+	Name string
+	UsageTotals
+}
+
+// StatsSummary is host-wide usage plus a per compose project breakdown
+type StatsSummary struct {
+	UsageTotals
+	Projects []ProjectUsage // sorted by CPU, heaviest first
+}
+
+// Add folds one container's stats into the totals
+func (t *UsageTotals) Add(c Container) {
+	t.Total++
+	if strings.ToLower(c.State) != "running" {
+		return
+	}
+	t.Running++
+	t.CPUPercent += parsePercentValue(c.CPU)
+	t.MemPercent += parsePercentValue(c.Memory)
+	if used := MemUsedBytes(c.MemUsage); used > 0 {
+		t.MemBytes += used
+	}
+	t.NetBytes += ioBytes(c.NetIO)
+	t.BlockBytes += ioBytes(c.BlockIO)
+}
+
+// ioBytes adds up both halves of an I/O string like "1.2kB / 3MB"
+func ioBytes(io string) int64 {
+	in, out, _ := strings.Cut(io, "/")
+	var n int64
+	for _, s := range []string{in, out} {
+		if b := parseSizeBytes(s); b > 0 {
+			n += b
+		}
+	}
+	return n
+}
+
+// Summarize computes totals for the whole host and per compose project.
+// containers without a project are grouped under "" (standalone).
+func Summarize(containers []Container) StatsSummary {
+	var sum StatsSummary
+	byProject := make(map[string]*ProjectUsage)
+
+	for _, c := range containers {
+		sum.Add(c)
+
+		p, ok := byProject[c.ComposeProject]
+		if !ok {
+			p = &ProjectUsage{Name: c.ComposeProject}
+			byProject[c.ComposeProject] = p
+		}
+		p.Add(c)
+	}
+
+	for _, p := range byProject {
+		sum.Projects = append(sum.Projects, *p)
+	}
+	sort.Slice(sum.Projects, func(i, j int) bool {
+		if sum.Projects[i].CPUPercent != sum.Projects[j].CPUPercent {
+			return sum.Projects[i].CPUPercent > sum.Projects[j].CPUPercent
+		}
+		return sum.Projects[i].Name < sum.Projects[j].Name
+	})
+
+	return sum
+}
+
+// MemUsedBytes reads the "used" half of a MemUsage string like "12.5MiB / 1.9GiB"
+func MemUsedBytes(memUsage string) int64 {
+	used, _, _ := strings.Cut(memUsage, "/")
+	return parseSizeBytes(used)
+}
+
+func parsePercentValue(s string) float64 {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "%")
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
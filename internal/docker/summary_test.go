@@ -0,0 +1,34 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarize(t *testing.T) {
+	containers := []Container{
+		{State: "running", CPU: "10.5%", Memory: "2.0%", MemUsage: "100MiB / 4GiB", NetIO: "1.5kB / 500B", BlockIO: "2MB / 0B", ComposeProject: "shop"},
+		{State: "running", CPU: "4.5%", Memory: "1.0%", MemUsage: "50MiB / 4GiB", NetIO: "1kB / 1kB", BlockIO: "─", ComposeProject: "shop"},
+		{State: "exited", ComposeProject: "shop"},
+		{State: "running", CPU: "30%", Memory: "5%", MemUsage: "1GiB / 4GiB"},
+	}
+
+	sum := Summarize(containers)
+	assert.Equal(t, 3, sum.Running)
+	assert.Equal(t, 4, sum.Total)
+	assert.InDelta(t, 45.0, sum.CPUPercent, 0.001)
+	assert.Equal(t, int64(150*1024*1024+1024*1024*1024), sum.MemBytes)
+
+	require.Len(t, sum.Projects, 2)
+	// heaviest project first
+	assert.Equal(t, "", sum.Projects[0].Name)
+	assert.Equal(t, "shop", sum.Projects[1].Name)
+	assert.Equal(t, 2, sum.Projects[1].Running)
+	assert.Equal(t, 3, sum.Projects[1].Total)
+	assert.InDelta(t, 15.0, sum.Projects[1].CPUPercent, 0.001)
+	assert.InDelta(t, 3.0, sum.Projects[1].MemPercent, 0.001)
+	assert.Equal(t, int64(4000), sum.Projects[1].NetBytes)
+	assert.Equal(t, int64(2000*1000), sum.Projects[1].BlockBytes)
+}
@@ -0,0 +1,193 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+// SwarmService is one row of `docker service ls`
+type SwarmService struct {
+	ID       string
+	Name     string
+	Mode     string // replicated, global
+	Replicas string // running/desired, e.g. "2/3"
+	Image    string
+	Ports    string
+}
+
+// SwarmTask is one row of `docker service ps`, a slot of a service on a node
+type SwarmTask struct {
+	ID           string
+	Name         string // service.slot, e.g. "web.2"
+	Image        string
+	Node         string
+	DesiredState string
+	CurrentState string // e.g. "Running 3 hours ago"
+	Error        string
+}
+
+// SwarmNode is one row of `docker node ls`
+type SwarmNode struct {
+	ID            string
+	Hostname      string
+	Status        string // Ready, Down
+	Availability  string // Active, Pause, Drain
+	ManagerStatus string // Leader, Reachable, Unreachable, empty for workers
+	EngineVersion string
+	Self          bool // the node DockMate talks to
+}
+
+// SwarmStack is one row of `docker stack ls`
+type SwarmStack struct {
+	Name     string
+	Services string // count, as docker prints it
+}
+
+// Degraded reports whether fewer replicas run than wanted
+func (s SwarmService) Degraded() bool {
+	running, desired, ok := strings.Cut(s.Replicas, "/")
+	if !ok {
+		return false
+	}
+	// "2/3 (max 1 per node)"
+	desired, _, _ = strings.Cut(desired, " ")
+	r, err1 := strconv.Atoi(running)
+	d, err2 := strconv.Atoi(desired)
+	return err1 == nil && err2 == nil && r < d
+}
+
+// SwarmActive reports whether the daemon is a swarm node; only docker has swarm mode
+func SwarmActive() bool {
+	if runtimeBin() != "docker" {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, runtimeBin(), "info", "--format", "{{.Swarm.LocalNodeState}}").Output()
+	return err == nil && strings.TrimSpace(string(out)) == "active"
+}
+
+// ListServices returns the swarm's services sorted by name
+func ListServices() ([]SwarmService, error) {
+	output, err := swarmCommand(15*time.Second, "service", "ls", "--format", "{{json .}}")
+	if err != nil {
+		return nil, err
+	}
+	return parseServices(output)
+}
+
+// ServiceTasks returns the tasks of a service, current ones first like `docker service ps`
+func ServiceTasks(service string) ([]SwarmTask, error) {
+	output, err := swarmCommand(15*time.Second, "service", "ps", "--no-trunc", "--format", "{{json .}}", service)
+	if err != nil {
+		return nil, err
+	}
+	return parseTasks(output)
+}
+
+// ScaleService sets the number of replicas, without waiting for them to converge
+func ScaleService(service string, replicas int) error {
+	_, err := swarmCommand(30*time.Second, "service", "scale", "--detach", fmt.Sprintf("%s=%d", service, replicas))
+	return err
+}
+
+// UpdateServiceImage rolls the service onto image, with the service's own update config
+func UpdateServiceImage(service, image string) error {
+	_, err := swarmCommand(30*time.Second, "service", "update", "--detach", "--image", image, service)
+	return err
+}
+
+// RollbackService reverts the service to its previous spec
+func RollbackService(service string) error {
+	_, err := swarmCommand(30*time.Second, "service", "rollback", "--detach", service)
+	return err
+}
+
+// ListNodes returns the swarm's nodes, managers first
+func ListNodes() ([]SwarmNode, error) {
+	output, err := swarmCommand(15*time.Second, "node", "ls", "--format", "{{json .}}")
+	if err != nil {
+		return nil, err
+	}
+	return parseNodes(output)
+}
+
+// ListStacks returns the deployed stacks sorted by name
+func ListStacks() ([]SwarmStack, error) {
+	output, err := swarmCommand(15*time.Second, "stack", "ls", "--format", "{{json .}}")
+	if err != nil {
+		return nil, err
+	}
+	stacks, err := decodeEntries[SwarmStack](output)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(stacks, func(i, j int) bool { return stacks[i].Name < stacks[j].Name })
+	return stacks, nil
+}
+
+// StackOf returns the stack a service was deployed with, `docker stack deploy`
+// names services "<stack>_<service>". Empty for services created by hand.
+func StackOf(service string, stacks []SwarmStack) string {
+	best := ""
+	for _, s := range stacks {
+		// the longest match wins, for stacks like "shop" and "shop_admin"
+		if strings.HasPrefix(service, s.Name+"_") && len(s.Name) > len(best) {
+			best = s.Name
+		}
+	}
+	return best
+}
+
+func swarmCommand(timeout time.Duration, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, runtimeBin(), args...)
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	logging.Command(cmd, start, err)
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("docker %s %s: %s", args[0], args[1], msg)
+	}
+	return output, nil
+}
+
+func parseServices(output []byte) ([]SwarmService, error) {
+	services, err := decodeEntries[SwarmService](output)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].Name < services[j].Name })
+	return services, nil
+}
+
+func parseNodes(output []byte) ([]SwarmNode, error) {
+	nodes, err := decodeEntries[SwarmNode](output)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		mi, mj := nodes[i].ManagerStatus != "", nodes[j].ManagerStatus != ""
+		if mi != mj {
+			return mi
+		}
+		return nodes[i].Hostname < nodes[j].Hostname
+	})
+	return nodes, nil
+}
+
+func parseTasks(output []byte) ([]SwarmTask, error) {
+	return decodeEntries[SwarmTask](output)
+}
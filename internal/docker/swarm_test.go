@@ -0,0 +1,54 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseServices(t *testing.T) {
+	output := []byte(`{"ID":"x1","Image":"nginx:1.27","Mode":"replicated","Name":"web","Ports":"*:80->80/tcp","Replicas":"2/3"}
+{"ID":"x2","Image":"prom/node-exporter:latest","Mode":"global","Name":"agent","Ports":"","Replicas":"3/3"}
+`)
+	services, err := parseServices(output)
+	require.NoError(t, err)
+	require.Len(t, services, 2)
+	assert.Equal(t, "agent", services[0].Name)
+	assert.Equal(t, SwarmService{ID: "x1", Name: "web", Mode: "replicated", Replicas: "2/3", Image: "nginx:1.27", Ports: "*:80->80/tcp"}, services[1])
+	assert.False(t, services[0].Degraded())
+	assert.True(t, services[1].Degraded())
+	assert.True(t, SwarmService{Replicas: "0/2 (max 1 per node)"}.Degraded())
+}
+
+func TestParseTasks(t *testing.T) {
+	output := []byte(`{"CurrentState":"Running 2 hours ago","DesiredState":"Running","Error":"","ID":"t1","Image":"nginx:1.27","Name":"web.1","Node":"node-a","Ports":""}
+{"CurrentState":"Failed 3 hours ago","DesiredState":"Shutdown","Error":"task: non-zero exit (1)","ID":"t0","Image":"nginx:1.26","Name":"web.1","Node":"node-b","Ports":""}
+`)
+	tasks, err := parseTasks(output)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, "node-a", tasks[0].Node)
+	assert.Equal(t, "task: non-zero exit (1)", tasks[1].Error)
+}
+
+func TestParseNodes(t *testing.T) {
+	output := []byte(`{"Availability":"Active","EngineVersion":"27.1.1","Hostname":"worker-1","ID":"n3","ManagerStatus":"","Self":false,"Status":"Ready"}
+{"Availability":"Drain","EngineVersion":"27.1.1","Hostname":"mgr-2","ID":"n2","ManagerStatus":"Reachable","Self":false,"Status":"Down"}
+{"Availability":"Active","EngineVersion":"27.1.1","Hostname":"mgr-1","ID":"n1","ManagerStatus":"Leader","Self":true,"Status":"Ready"}
+`)
+	nodes, err := parseNodes(output)
+	require.NoError(t, err)
+	require.Len(t, nodes, 3)
+	assert.Equal(t, []string{"mgr-1", "mgr-2", "worker-1"}, []string{nodes[0].Hostname, nodes[1].Hostname, nodes[2].Hostname})
+	assert.True(t, nodes[0].Self)
+	assert.Equal(t, "Drain", nodes[1].Availability)
+}
+
+func TestStackOf(t *testing.T) {
+	stacks := []SwarmStack{{Name: "shop"}, {Name: "shop_admin"}, {Name: "mon"}}
+	assert.Equal(t, "shop", StackOf("shop_web", stacks))
+	assert.Equal(t, "shop_admin", StackOf("shop_admin_api", stacks))
+	assert.Equal(t, "", StackOf("standalone", stacks))
+	assert.Equal(t, "", StackOf("monitor_x", stacks))
+}
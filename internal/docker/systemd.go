@@ -0,0 +1,110 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+// SystemdUnitLabel is set by podman on containers a systemd unit runs, from a
+// quadlet or `podman generate systemd --new`. systemd restarts those when
+// they're stopped with podman, so they're controlled through the unit.
+const SystemdUnitLabel = "PODMAN_SYSTEMD_UNIT"
+
+// SystemdUnit is the unit running the container, empty for most
+func SystemdUnit(c Container) string {
+	return c.Labels[SystemdUnitLabel]
+}
+
+// ContainerAction starts, stops or restarts a container, through its unit
+// if it has one
+func ContainerAction(action string, c Container) error {
+	if unit := SystemdUnit(c); unit != "" {
+		return UnitAction(action, unit)
+	}
+	return DoAction(action, c.ID)
+}
+
+// userUnits is whether the units belong to the user's systemd: rootless
+// podman's do, rootful podman's are system units
+func userUnits() bool {
+	return PodmanMode() != "rootful"
+}
+
+func unitArgs(user bool, args ...string) []string {
+	if user {
+		return append([]string{"--user"}, args...)
+	}
+	return args
+}
+
+// UnitAction starts, stops or restarts a unit with systemctl
+func UnitAction(action, unit string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "systemctl", unitArgs(userUnits(), action, unit)...)
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	logging.Command(cmd, start, err)
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return fmt.Errorf("systemctl %s %s: %s", action, unit, msg)
+	}
+	return nil
+}
+
+// UnitStatus is the head of `systemctl status`, without its log lines
+func UnitStatus(unit string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "systemctl", unitArgs(userUnits(), "status", "--no-pager", "--lines=0", unit)...)
+	start := time.Now()
+	output, err := cmd.Output()
+	logging.Command(cmd, start, err)
+	// status exits 3 for a unit that isn't running, its output is still there
+	var exitErr *exec.ExitError
+	if err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 3) {
+		return nil, fmt.Errorf("systemctl status %s: %w", unit, err)
+	}
+	return nonEmptyLines(string(output)), nil
+}
+
+// UnitJournal is the unit's last lines in the journal
+func UnitJournal(unit string, lines int) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "journalctl", unitArgs(userUnits(), "--unit", unit, "--lines", strconv.Itoa(lines), "--no-pager", "--output", "short-iso")...)
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	logging.Command(cmd, start, err)
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("journalctl %s: %s", unit, msg)
+	}
+	return nonEmptyLines(string(output)), nil
+}
+
+func nonEmptyLines(s string) []string {
+	var lines []string
+	for _, l := range strings.Split(s, "\n") {
+		if strings.TrimSpace(l) != "" {
+			lines = append(lines, strings.TrimRight(l, " \r"))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,15 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitArgs(t *testing.T) {
+	assert.Equal(t, []string{"--user", "restart", "web.service"}, unitArgs(true, "restart", "web.service"))
+	assert.Equal(t, []string{"restart", "web.service"}, unitArgs(false, "restart", "web.service"))
+
+	assert.Equal(t, "web.service", SystemdUnit(Container{Labels: map[string]string{SystemdUnitLabel: "web.service"}}))
+	assert.Empty(t, SystemdUnit(Container{}))
+}
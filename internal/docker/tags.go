@@ -0,0 +1,249 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/config"
+)
+
+// RemoteTag is one tag of a repository in a registry
+type RemoteTag struct {
+	Name    string
+	Digest  string    // empty if the registry didn't say
+	Size    int64     // compressed bytes, -1 if unknown (multi-arch images outside Docker Hub)
+	Updated time.Time // zero if unknown, only Docker Hub reports it
+}
+
+// how many tags get their manifest fetched for digest and size, on registries without the Hub API
+const maxManifestLookups = 50
+
+// dockerHubAPI is swapped for a test server in tests
+var dockerHubAPI = "https://hub.docker.com"
+
+// ListRemoteTags lists the tags of a repository like "nginx", "user/app" or
+// "registry.example.com:5000/team/app" (a tag in the name is ignored).
+// Docker Hub is asked through its own API, newest first. Other registries go
+// through the v2 API, using the login from the config's registries section.
+func ListRemoteTags(repo string) ([]RemoteTag, error) {
+	ref, err := ParseImageRef(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if ref.Registry == dockerHubRegistry {
+		return hubTags(ctx, ref.Repository)
+	}
+	return registryTags(ctx, ref)
+}
+
+// hubTags returns the 100 most recently pushed tags
+func hubTags(ctx context.Context, repository string) ([]RemoteTag, error) {
+	tagsURL := fmt.Sprintf("%s/v2/repositories/%s/tags?page_size=100&ordering=last_updated", dockerHubAPI, repository)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tagsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s not found on Docker Hub (private repositories can't be listed)", repository)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("docker hub returned %s for %s", resp.Status, repository)
+	}
+
+	var body struct {
+		Results []struct {
+			Name        string    `json:"name"`
+			Digest      string    `json:"digest"`
+			FullSize    int64     `json:"full_size"`
+			LastUpdated time.Time `json:"last_updated"`
+			Images      []struct {
+				Digest string `json:"digest"`
+			} `json:"images"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("parsing docker hub response: %w", err)
+	}
+
+	tags := make([]RemoteTag, 0, len(body.Results))
+	for _, r := range body.Results {
+		digest := r.Digest
+		if digest == "" && len(r.Images) == 1 {
+			digest = r.Images[0].Digest
+		}
+		tags = append(tags, RemoteTag{Name: r.Name, Digest: digest, Size: r.FullSize, Updated: r.LastUpdated})
+	}
+	return tags, nil
+}
+
+// registryTags lists tags through the v2 API, highest name first, and fills
+// in digest and size for the first maxManifestLookups of them
+func registryTags(ctx context.Context, ref ImageRef) ([]RemoteTag, error) {
+	c := &registryClient{host: ref.Registry}
+	c.user, c.password = registryLogin(ref.Registry)
+
+	resp, err := c.get(ctx, "/v2/"+ref.Repository+"/tags/list", "application/json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry returned %s for %s", resp.Status, ref.Repository)
+	}
+
+	var list struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("parsing tag list: %w", err)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(list.Tags)))
+
+	tags := make([]RemoteTag, len(list.Tags))
+	for i, name := range list.Tags {
+		tags[i] = RemoteTag{Name: name, Size: -1}
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 8)
+	for i := range tags[:min(len(tags), maxManifestLookups)] {
+		wg.Add(1)
+		go func(t *RemoteTag) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			// a tag without digest and size is still worth listing
+			t.Digest, t.Size, _ = c.manifest(ctx, ref.Repository, t.Name)
+		}(&tags[i])
+	}
+	wg.Wait()
+	return tags, nil
+}
+
+// registryLogin returns the configured login for host, if any
+func registryLogin(host string) (user, password string) {
+	for _, r := range config.Cached().Registries {
+		if strings.EqualFold(strings.TrimSpace(r.Host), host) {
+			if r.PasswordEnv != "" {
+				password = os.Getenv(r.PasswordEnv)
+			}
+			return r.Username, password
+		}
+	}
+	return "", ""
+}
+
+// registryClient talks to one registry, answering its auth challenge once
+type registryClient struct {
+	host           string
+	user, password string
+
+	mu    sync.Mutex
+	token string
+	basic bool // the registry wants basic auth rather than a token
+}
+
+func (c *registryClient) get(ctx context.Context, path, accept string) (*http.Response, error) {
+	resp, err := c.send(ctx, path, accept)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	resp.Body.Close()
+
+	if err := c.authenticate(ctx, resp.Header.Get("WWW-Authenticate")); err != nil {
+		return nil, err
+	}
+	return c.send(ctx, path, accept)
+}
+
+func (c *registryClient) send(ctx context.Context, path, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, registryScheme+"://"+c.host+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+
+	c.mu.Lock()
+	switch {
+	case c.token != "":
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	case c.basic:
+		req.SetBasicAuth(c.user, c.password)
+	}
+	c.mu.Unlock()
+	return http.DefaultClient.Do(req)
+}
+
+func (c *registryClient) authenticate(ctx context.Context, challenge string) error {
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(challenge)), "basic") {
+		if c.user == "" {
+			return fmt.Errorf("%s needs a login, add it under registries in the config", c.host)
+		}
+		c.mu.Lock()
+		c.basic = true
+		c.mu.Unlock()
+		return nil
+	}
+
+	token, err := fetchRegistryToken(ctx, challenge, c.user, c.password)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.token = token
+	c.mu.Unlock()
+	return nil
+}
+
+// manifest returns the digest and compressed size of a tag; the size of a
+// multi-arch index depends on the platform, so it's -1
+func (c *registryClient) manifest(ctx context.Context, repository, tag string) (string, int64, error) {
+	resp, err := c.get(ctx, "/v2/"+repository+"/manifests/"+tag, strings.Join(manifestMediaTypes, ", "))
+	if err != nil {
+		return "", -1, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", -1, fmt.Errorf("registry returned %s for %s:%s", resp.Status, repository, tag)
+	}
+
+	var m struct {
+		Config struct {
+			Size int64 `json:"size"`
+		} `json:"config"`
+		Layers []struct {
+			Size int64 `json:"size"`
+		} `json:"layers"`
+		Manifests []json.RawMessage `json:"manifests"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return "", -1, err
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if len(m.Manifests) > 0 {
+		return digest, -1, nil
+	}
+	size := m.Config.Size
+	for _, l := range m.Layers {
+		size += l.Size
+	}
+	return digest, size, nil
+}
@@ -0,0 +1,102 @@
+package docker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shubh-io/dockmate/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHubTags(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/repositories/library/nginx/tags" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "last_updated", r.URL.Query().Get("ordering"))
+		w.Write([]byte(`{"results":[
+{"name":"1.27","digest":"sha256:aaa","full_size":1000,"last_updated":"2024-06-01T10:00:00Z"},
+{"name":"old","full_size":5,"last_updated":"2020-01-01T00:00:00Z","images":[{"digest":"sha256:bbb"}]}]}`))
+	}))
+	defer srv.Close()
+
+	dockerHubAPI = srv.URL
+	defer func() { dockerHubAPI = "https://hub.docker.com" }()
+
+	tags, err := ListRemoteTags("nginx:latest")
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+	assert.Equal(t, "1.27", tags[0].Name)
+	assert.Equal(t, "sha256:aaa", tags[0].Digest)
+	assert.Equal(t, int64(1000), tags[0].Size)
+	assert.Equal(t, 2024, tags[0].Updated.Year())
+	assert.Equal(t, "sha256:bbb", tags[1].Digest)
+
+	_, err = ListRemoteTags("nobody/missing")
+	assert.Error(t, err)
+}
+
+func TestRegistryTags(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv("TEST_REGISTRY_PASSWORD", "hunter2")
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			user, pass, ok := r.BasicAuth()
+			if !ok || user != "me" || pass != "hunter2" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"token":"secret"}`))
+		case r.Header.Get("Authorization") != "Bearer secret":
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",scope="repository:team/app:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v2/team/app/tags/list":
+			w.Write([]byte(`{"name":"team/app","tags":["1.0","2.0","multi"]}`))
+		case r.URL.Path == "/v2/team/app/manifests/multi":
+			w.Header().Set("Docker-Content-Digest", "sha256:index")
+			w.Write([]byte(`{"manifests":[{"digest":"sha256:x"}]}`))
+		case strings.HasPrefix(r.URL.Path, "/v2/team/app/manifests/"):
+			w.Header().Set("Docker-Content-Digest", "sha256:"+strings.TrimPrefix(r.URL.Path, "/v2/team/app/manifests/"))
+			w.Write([]byte(`{"config":{"size":10},"layers":[{"size":100},{"size":1000}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	registryScheme = "http"
+	defer func() { registryScheme = "https" }()
+
+	host := strings.TrimPrefix(srv.URL, "http://")
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "dockmate"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "dockmate", "config.yml"), []byte(`
+registries:
+  - host: `+host+`
+    username: me
+    password_env: TEST_REGISTRY_PASSWORD
+`), 0644))
+	config.Invalidate()
+	defer config.Invalidate()
+
+	tags, err := ListRemoteTags(host + "/team/app")
+	require.NoError(t, err)
+	assert.Equal(t, []RemoteTag{
+		{Name: "multi", Digest: "sha256:index", Size: -1},
+		{Name: "2.0", Digest: "sha256:2.0", Size: 1110},
+		{Name: "1.0", Digest: "sha256:1.0", Size: 1110},
+	}, tags)
+
+	t.Setenv("TEST_REGISTRY_PASSWORD", "wrong")
+	_, err = ListRemoteTags(host + "/team/app")
+	assert.Error(t, err)
+}
@@ -0,0 +1,146 @@
+package docker
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/config"
+)
+
+// the docker CLI and compose only take TLS files from DOCKER_CERT_PATH, a
+// directory with ca.pem, cert.pem and key.pem in it. runtime.tls names the
+// files wherever they are, so DockMate links them into a directory of its
+// own under those names and points DOCKER_CERT_PATH at it.
+
+// tlsApplied is what ApplyTLS exported, so the next call can take it back
+var tlsApplied = map[string]string{}
+
+// expandUser expands a leading ~/ to the home directory
+func expandUser(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// tlsFiles are runtime.tls's files by the name docker looks for, the unset
+// ones left out
+func tlsFiles(t config.TLSConfig) map[string]string {
+	files := make(map[string]string)
+	for name, path := range map[string]string{"ca.pem": t.CACert, "cert.pem": t.Cert, "key.pem": t.Key} {
+		if path = strings.TrimSpace(path); path != "" {
+			if abs, err := filepath.Abs(expandUser(path)); err == nil {
+				path = abs
+			}
+			files[name] = path
+		}
+	}
+	return files
+}
+
+// tlsEnv are the variables that turn TLS on for dir, verifying the daemon
+// only when there's a CA to verify it against
+func tlsEnv(t config.TLSConfig, dir string) map[string]string {
+	env := map[string]string{"DOCKER_CERT_PATH": dir}
+	if strings.TrimSpace(t.CACert) != "" {
+		env["DOCKER_TLS_VERIFY"] = "1"
+	} else {
+		env["DOCKER_TLS"] = "1"
+	}
+	return env
+}
+
+// ApplyTLS points docker at runtime.tls's files. Variables already set in
+// the environment are left alone, like ApplySocket does.
+func ApplyTLS(rc config.RuntimeConfig) error {
+	for name, value := range tlsApplied {
+		if os.Getenv(name) == value {
+			os.Unsetenv(name)
+		}
+		delete(tlsApplied, name)
+	}
+	if !rc.TLS.Enabled() || os.Getenv("DOCKER_CERT_PATH") != "" {
+		return nil
+	}
+
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(cache, "dockmate", "tls")
+	// links from an earlier config would be picked up too
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	for name, path := range tlsFiles(rc.TLS) {
+		if err := os.Symlink(path, filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+
+	for name, value := range tlsEnv(rc.TLS, dir) {
+		if os.Getenv(name) != "" {
+			continue
+		}
+		os.Setenv(name, value)
+		tlsApplied[name] = value
+	}
+	return nil
+}
+
+// CheckTLS makes sure runtime.tls's files are there and hold what they
+// should: a CA certificate, and a client certificate that matches its key
+// and hasn't expired
+func CheckTLS(t config.TLSConfig) error {
+	files := tlsFiles(t)
+	var errs []error
+	for _, name := range []string{"ca.pem", "cert.pem", "key.pem"} {
+		if path, ok := files[name]; ok {
+			if _, err := os.ReadFile(path); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	if path, ok := files["ca.pem"]; ok {
+		data, _ := os.ReadFile(path)
+		if !x509.NewCertPool().AppendCertsFromPEM(data) {
+			return fmt.Errorf("%s has no PEM certificate in it", path)
+		}
+	}
+
+	cert, hasCert := files["cert.pem"]
+	key, hasKey := files["key.pem"]
+	if hasCert != hasKey {
+		return fmt.Errorf("runtime.tls needs both cert and key, or neither")
+	}
+	if !hasCert {
+		return nil
+	}
+	pair, err := tls.LoadX509KeyPair(cert, key)
+	if err != nil {
+		return fmt.Errorf("%s and %s: %w", cert, key, err)
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cert, err)
+	}
+	if time.Now().After(leaf.NotAfter) {
+		return fmt.Errorf("%s expired on %s", cert, leaf.NotAfter.Format("2006-01-02"))
+	}
+	return nil
+}
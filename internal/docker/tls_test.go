@@ -0,0 +1,67 @@
+package docker
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCert writes a self-signed certificate and its key to dir
+func writeCert(t *testing.T, dir, name string, notAfter time.Time) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    notAfter.Add(-48 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPath := filepath.Join(dir, name+".crt")
+	keyPath := filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certPath, keyPath
+}
+
+func TestCheckTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca, _ := writeCert(t, dir, "ca", time.Now().Add(time.Hour))
+	cert, key := writeCert(t, dir, "client", time.Now().Add(time.Hour))
+	_, otherKey := writeCert(t, dir, "other", time.Now().Add(time.Hour))
+	old, oldKey := writeCert(t, dir, "old", time.Now().Add(-time.Hour))
+
+	assert.NoError(t, CheckTLS(config.TLSConfig{CACert: ca, Cert: cert, Key: key}))
+	assert.NoError(t, CheckTLS(config.TLSConfig{CACert: ca}))
+
+	assert.Error(t, CheckTLS(config.TLSConfig{CACert: filepath.Join(dir, "missing.pem")}))
+	assert.ErrorContains(t, CheckTLS(config.TLSConfig{CACert: key}), "no PEM certificate")
+	assert.ErrorContains(t, CheckTLS(config.TLSConfig{Cert: cert}), "both cert and key")
+	assert.ErrorContains(t, CheckTLS(config.TLSConfig{Cert: cert, Key: otherKey}), "client.crt")
+	assert.ErrorContains(t, CheckTLS(config.TLSConfig{Cert: old, Key: oldKey}), "expired")
+}
+
+func TestTLSEnv(t *testing.T) {
+	files := tlsFiles(config.TLSConfig{CACert: "/certs/ca.crt", Key: "/certs/client.key"})
+	assert.Equal(t, map[string]string{"ca.pem": "/certs/ca.crt", "key.pem": "/certs/client.key"}, files)
+
+	// no CA, nothing to verify the daemon against
+	assert.Equal(t, map[string]string{"DOCKER_CERT_PATH": "/d", "DOCKER_TLS": "1"}, tlsEnv(config.TLSConfig{Cert: "c", Key: "k"}, "/d"))
+	assert.Equal(t, map[string]string{"DOCKER_CERT_PATH": "/d", "DOCKER_TLS_VERIFY": "1"}, tlsEnv(config.TLSConfig{CACert: "ca"}, "/d"))
+}
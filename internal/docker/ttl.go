@@ -0,0 +1,67 @@
+package docker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TTLLabel lets a container declare how long it should live, e.g. dockmate.ttl=8h or 3d
+const TTLLabel = "dockmate.ttl"
+
+// ParseTTL parses a time-to-live like "90m", "8h" or "3d".
+// time.ParseDuration has no day unit, so "d" is handled here.
+func ParseTTL(s string) (time.Duration, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if s == "" {
+		return 0, fmt.Errorf("empty ttl")
+	}
+
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.ParseFloat(strings.TrimSuffix(s, "d"), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid ttl %q", s)
+		}
+		s = fmt.Sprintf("%gh", days*24)
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ttl %q", s)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("ttl must be positive: %q", s)
+	}
+	return d, nil
+}
+
+// TTL returns the container's time-to-live.
+// override (a locally configured ttl) wins over the label when set.
+func (c Container) TTL(override string) (time.Duration, bool) {
+	raw := override
+	if raw == "" {
+		raw = c.Labels[TTLLabel]
+	}
+	if raw == "" {
+		return 0, false
+	}
+
+	d, err := ParseTTL(raw)
+	if err != nil {
+		return 0, false
+	}
+	return d, true
+}
+
+// ExpiresAt returns when the container's ttl runs out, counted from its creation time
+func (c Container) ExpiresAt(override string) (time.Time, bool) {
+	if c.CreatedAt.IsZero() {
+		return time.Time{}, false
+	}
+	d, ok := c.TTL(override)
+	if !ok {
+		return time.Time{}, false
+	}
+	return c.CreatedAt.Add(d), true
+}
@@ -0,0 +1,52 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTTL(t *testing.T) {
+	d, err := ParseTTL("90m")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, d)
+
+	d, err = ParseTTL("3d")
+	require.NoError(t, err)
+	assert.Equal(t, 72*time.Hour, d)
+
+	_, err = ParseTTL("")
+	assert.Error(t, err)
+	_, err = ParseTTL("soon")
+	assert.Error(t, err)
+	_, err = ParseTTL("-1h")
+	assert.Error(t, err)
+}
+
+func TestContainerExpiresAt(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	c := Container{
+		Labels:    map[string]string{TTLLabel: "8h"},
+		CreatedAt: created,
+	}
+
+	expires, ok := c.ExpiresAt("")
+	require.True(t, ok)
+	assert.Equal(t, created.Add(8*time.Hour), expires)
+
+	// local override wins over the label
+	expires, ok = c.ExpiresAt("1d")
+	require.True(t, ok)
+	assert.Equal(t, created.Add(24*time.Hour), expires)
+
+	_, ok = Container{CreatedAt: created}.ExpiresAt("")
+	assert.False(t, ok)
+}
+
+func TestParseCreatedAt(t *testing.T) {
+	got := parseCreatedAt("2024-05-01 12:34:56 +0000 UTC")
+	assert.Equal(t, time.Date(2024, 5, 1, 12, 34, 56, 0, time.UTC), got.UTC())
+	assert.True(t, parseCreatedAt("garbage").IsZero())
+}
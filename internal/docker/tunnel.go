@@ -0,0 +1,248 @@
+package docker
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/config"
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+// a remote profile is reached through `ssh -N -L local.sock:remote.sock`, ssh
+// does the auth (agent, keys, ~/.ssh/config) and the runtime CLIs just see a
+// local unix socket. The tunnel outlives the settings restarts, only a
+// different remote closes it.
+
+// tunnelTimeout is how long ssh gets to log in and open the forward
+const tunnelTimeout = 20 * time.Second
+
+// remoteSockets are the usual sockets on the remote host, when the profile
+// doesn't name one
+var remoteSockets = map[string]string{
+	"docker":  "/var/run/docker.sock",
+	"podman":  "/run/podman/podman.sock",
+	"nerdctl": "/run/containerd/containerd.sock",
+}
+
+// Tunnel is an ssh process forwarding Socket to a remote runtime socket
+type Tunnel struct {
+	Profile config.RemoteProfile
+	Socket  string // the local end
+
+	cmd    *exec.Cmd
+	dir    string
+	done   chan struct{}
+	stderr *bytes.Buffer
+}
+
+// tunnelArgs are ssh's arguments to forward local to the profile's socket
+func tunnelArgs(p config.RemoteProfile, rt, local string) []string {
+	socket := p.Socket
+	if socket == "" {
+		socket = remoteSockets[rt]
+		if socket == "" {
+			socket = remoteSockets["docker"]
+		}
+	}
+	args := []string{"-N",
+		"-o", "ExitOnForwardFailure=yes",
+		// a password prompt would end up under the TUI
+		"-o", "BatchMode=yes",
+		"-o", "ServerAliveInterval=15",
+		"-o", "StreamLocalBindUnlink=yes",
+	}
+	if p.Port > 0 {
+		args = append(args, "-p", strconv.Itoa(p.Port))
+	}
+	if p.Key != "" {
+		args = append(args, "-i", expandUser(p.Key))
+	}
+	dest := p.Host
+	if p.User != "" {
+		dest = p.User + "@" + p.Host
+	}
+	return append(args, "-L", local+":"+socket, dest)
+}
+
+// OpenTunnel starts ssh for the profile and waits for the local socket to
+// show up. rt picks the default remote socket.
+func OpenTunnel(p config.RemoteProfile, rt string) (*Tunnel, error) {
+	if _, err := exec.LookPath("ssh"); err != nil {
+		return nil, fmt.Errorf("remote %s needs ssh, which isn't installed", p.Name)
+	}
+	dir, err := os.MkdirTemp("", "dockmate-ssh-")
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Tunnel{
+		Profile: p,
+		Socket:  filepath.Join(dir, "runtime.sock"),
+		dir:     dir,
+		done:    make(chan struct{}),
+		stderr:  &bytes.Buffer{},
+	}
+	t.cmd = exec.Command("ssh", tunnelArgs(p, rt, t.Socket)...)
+	t.cmd.Stderr = t.stderr
+	start := time.Now()
+	if err := t.cmd.Start(); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	go func() {
+		err := t.cmd.Wait()
+		logging.Command(t.cmd, start, err)
+		close(t.done)
+	}()
+
+	deadline := time.After(tunnelTimeout)
+	tick := time.NewTicker(100 * time.Millisecond)
+	defer tick.Stop()
+	for {
+		select {
+		case <-t.done:
+			os.RemoveAll(dir)
+			return nil, fmt.Errorf("ssh to %s: %s", p.Host, t.failure())
+		case <-deadline:
+			t.Close()
+			return nil, fmt.Errorf("ssh to %s: no tunnel after %s", p.Host, tunnelTimeout)
+		case <-tick.C:
+			if _, err := os.Stat(t.Socket); err == nil {
+				logging.Infof("tunnel to %s open at %s", p.Host, t.Socket)
+				return t, nil
+			}
+		}
+	}
+}
+
+// failure is what ssh said when it gave up
+func (t *Tunnel) failure() string {
+	msg := strings.TrimSpace(t.stderr.String())
+	if msg == "" && t.cmd.ProcessState != nil {
+		msg = t.cmd.ProcessState.String()
+	}
+	return msg
+}
+
+// Err says why the tunnel is gone, nil while it's up
+func (t *Tunnel) Err() error {
+	select {
+	case <-t.done:
+		return fmt.Errorf("ssh tunnel to %s closed: %s", t.Profile.Host, t.failure())
+	default:
+		return nil
+	}
+}
+
+// Close stops ssh and removes the local socket
+func (t *Tunnel) Close() {
+	select {
+	case <-t.done:
+	default:
+		t.cmd.Process.Kill()
+		<-t.done
+	}
+	os.RemoveAll(t.dir)
+}
+
+// Target is the profile as ssh would name it, user@host:port
+func (t *Tunnel) Target() string {
+	s := t.Profile.Host
+	if t.Profile.User != "" {
+		s = t.Profile.User + "@" + s
+	}
+	if t.Profile.Port > 0 {
+		s += ":" + strconv.Itoa(t.Profile.Port)
+	}
+	return s
+}
+
+var remote struct {
+	mu     sync.Mutex
+	tunnel *Tunnel
+	saved  map[string]*string // the variables the tunnel replaced, nil for unset
+}
+
+// UseRemote routes every runtime command through the tunnel of the profile
+// runtime.remote names, opening it unless it's already open. It replaces
+// DOCKER_HOST and the other endpoint variables while it's in use; with no
+// remote configured any open tunnel is closed and they're put back.
+func UseRemote(cfg *config.Config) error {
+	remote.mu.Lock()
+	defer remote.mu.Unlock()
+
+	name := cfg.Runtime.Remote
+	p := cfg.Remote(name)
+	if p != nil && remote.tunnel != nil && remote.tunnel.Profile == *p && remote.tunnel.Err() == nil {
+		return nil
+	}
+	closeRemote()
+	if name == "" {
+		return nil
+	}
+	if p == nil {
+		return fmt.Errorf("runtime.remote %q is not one of the remotes in the config", name)
+	}
+
+	rt := strings.TrimSpace(strings.ToLower(cfg.Runtime.Type))
+	t, err := OpenTunnel(*p, rt)
+	if err != nil {
+		return err
+	}
+	remote.tunnel = t
+	remote.saved = make(map[string]*string)
+	names, ok := socketEnv[rt]
+	if !ok {
+		names = socketEnv["docker"]
+	}
+	for _, env := range names {
+		if old, ok := os.LookupEnv(env); ok {
+			remote.saved[env] = &old
+		} else {
+			remote.saved[env] = nil
+		}
+		value := "unix://" + t.Socket
+		if env == "CONTAINERD_ADDRESS" {
+			value = t.Socket
+		}
+		os.Setenv(env, value)
+	}
+	return nil
+}
+
+// CloseRemote closes the tunnel, if one is open, e.g. on exit
+func CloseRemote() {
+	remote.mu.Lock()
+	defer remote.mu.Unlock()
+	closeRemote()
+}
+
+func closeRemote() {
+	if remote.tunnel == nil {
+		return
+	}
+	for env, old := range remote.saved {
+		if old == nil {
+			os.Unsetenv(env)
+		} else {
+			os.Setenv(env, *old)
+		}
+	}
+	remote.tunnel.Close()
+	remote.tunnel = nil
+	remote.saved = nil
+}
+
+// ActiveRemote is the tunnel commands go through, nil when the daemon is local
+func ActiveRemote() *Tunnel {
+	remote.mu.Lock()
+	defer remote.mu.Unlock()
+	return remote.tunnel
+}
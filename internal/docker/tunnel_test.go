@@ -0,0 +1,32 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/shubh-io/dockmate/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTunnelArgs(t *testing.T) {
+	args := tunnelArgs(config.RemoteProfile{Name: "nas", Host: "nas.lan"}, "docker", "/tmp/d/runtime.sock")
+	assert.Equal(t, []string{"-L", "/tmp/d/runtime.sock:/var/run/docker.sock", "nas.lan"}, args[len(args)-3:])
+	assert.NotContains(t, args, "-p")
+	assert.NotContains(t, args, "-i")
+
+	args = tunnelArgs(config.RemoteProfile{
+		Host:   "10.0.0.5",
+		User:   "deploy",
+		Port:   2222,
+		Key:    "/keys/id_ed25519",
+		Socket: "/run/user/1000/podman/podman.sock",
+	}, "podman", "/tmp/d/runtime.sock")
+	assert.Contains(t, args, "BatchMode=yes")
+	assert.Subset(t, args, []string{"-p", "2222", "-i", "/keys/id_ed25519"})
+	assert.Equal(t, []string{"-L", "/tmp/d/runtime.sock:/run/user/1000/podman/podman.sock", "deploy@10.0.0.5"}, args[len(args)-3:])
+
+	// podman's usual rootful socket, and docker's for the runtimes that have none
+	args = tunnelArgs(config.RemoteProfile{Host: "h"}, "podman", "/l")
+	assert.Equal(t, "/l:/run/podman/podman.sock", args[len(args)-2])
+	args = tunnelArgs(config.RemoteProfile{Host: "h"}, "auto", "/l")
+	assert.Equal(t, "/l:/var/run/docker.sock", args[len(args)-2])
+}
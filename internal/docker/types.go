@@ -1,5 +1,7 @@
 package docker
 
+import "time"
+
 type ProjectStatus int
 
 const (
@@ -25,7 +27,9 @@ type Container struct {
 	Status string   // human readable status
 	State  string   // running/exited/etc
 	Memory string   // mem usage %
-	CPU    string   // cpu usage %
+	// MemUsage is the raw "used / limit" pair, e.g. "12.5MiB / 1.9GiB"
+	MemUsage string
+	CPU      string // cpu usage %
 	//PIDs    string // process count
 	Ports                string // ports
 	NetIO                string // network I/O
@@ -35,6 +39,8 @@ type Container struct {
 	ComposeNumber        string // compose container number
 	ComposeDirectory     string
 	ComposeFileDirectory string
+	Labels               map[string]string // all labels on the container
+	CreatedAt            time.Time         // zero if the runtime didn't report it
 }
 type ComposeInfo struct {
 	Project string
@@ -44,9 +50,10 @@ type ComposeInfo struct {
 
 // ContainerStats holds stats for a single container
 type ContainerStats struct {
-	ID     string
-	CPU    string
-	Memory string
+	ID       string
+	CPU      string
+	Memory   string
+	MemUsage string
 	// PIDs    string
 	NetIO   string
 	BlockIO string
@@ -56,6 +63,7 @@ type ContainerStats struct {
 type ContainersMsg struct {
 	Containers []Container
 	Err        error
+	Took       time.Duration // how long the fetch took
 }
 
 // sent when logs are ready
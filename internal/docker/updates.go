@@ -0,0 +1,176 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ImageUpdate is the result of checking a container's image against its registry
+type ImageUpdate struct {
+	Available bool
+	Err       error
+}
+
+// CheckImageUpdates compares the image each container runs with what its tag
+// points at in the registry (like watchtower's check mode). The result is keyed
+// by container ID; containers running digest-pinned or local-only images are left out.
+func CheckImageUpdates(containers []Container) (map[string]ImageUpdate, error) {
+	if len(containers) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(containers))
+	for i, c := range containers {
+		ids[i] = c.ID
+	}
+
+	imageIDs, err := containerImageIDs(ids)
+	if err != nil {
+		return nil, fmt.Errorf("inspecting containers: %w", err)
+	}
+	digests, err := imageRepoDigests(imageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("inspecting images: %w", err)
+	}
+
+	// several containers usually share an image, only ask the registry once per reference
+	refs := make(map[string]ImageRef)
+	for _, c := range containers {
+		ref, err := ParseImageRef(c.Image)
+		if err != nil || ref.Digest != "" || ref.Registry == "localhost" {
+			continue
+		}
+		refs[c.Image] = ref
+	}
+
+	latest := make(map[string]string)
+	failures := make(map[string]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 4) // don't hammer registries
+
+	for image, ref := range refs {
+		wg.Add(1)
+		go func(image string, ref ImageRef) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			digest, err := RemoteDigest(ref)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures[image] = err
+				return
+			}
+			latest[image] = digest
+		}(image, ref)
+	}
+	wg.Wait()
+
+	out := make(map[string]ImageUpdate)
+	for _, c := range containers {
+		if err, ok := failures[c.Image]; ok {
+			out[c.ID] = ImageUpdate{Err: err}
+			continue
+		}
+		digest, ok := latest[c.Image]
+		if !ok {
+			continue
+		}
+		local := digests[imageIDs[c.ID]]
+		if len(local) == 0 {
+			continue // built locally, never came from a registry
+		}
+		out[c.ID] = ImageUpdate{Available: !hasDigest(local, digest)}
+	}
+	return out, nil
+}
+
+// containerImageIDs maps container IDs to the ID of the image they were created from
+func containerImageIDs(ids []string) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	args := append([]string{"inspect", "--format", "{{.Id}} {{.Image}}"}, ids...)
+	output, err := exec.CommandContext(ctx, runtimeBin(), args...).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	full := make(map[string]string)
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 {
+			full[fields[0]] = fields[1]
+		}
+	}
+
+	// ps hands out short ids, inspect answers with full ones
+	out := make(map[string]string)
+	for _, id := range ids {
+		for fullID, image := range full {
+			if strings.HasPrefix(fullID, id) {
+				out[id] = image
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+// imageRepoDigests returns the registry digests recorded for each image ID
+func imageRepoDigests(imageIDs map[string]string) (map[string][]string, error) {
+	images := make([]string, 0, len(imageIDs))
+	for _, img := range imageIDs {
+		images = append(images, img)
+	}
+	images = uniqueFields(strings.Join(images, " "))
+	if len(images) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	args := append([]string{"image", "inspect"}, images...)
+	output, err := exec.CommandContext(ctx, runtimeBin(), args...).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []struct {
+		ID          string   `json:"Id"`
+		RepoDigests []string `json:"RepoDigests"`
+	}
+	if err := json.Unmarshal(output, &entries); err != nil {
+		return nil, fmt.Errorf("parsing image inspect output: %w", err)
+	}
+
+	out := make(map[string][]string)
+	for _, e := range entries {
+		// docker says "sha256:abc", podman just "abc"
+		id := e.ID
+		if !strings.HasPrefix(id, "sha256:") {
+			id = "sha256:" + id
+		}
+		out[id] = e.RepoDigests
+		out[strings.TrimPrefix(id, "sha256:")] = e.RepoDigests
+	}
+	return out, nil
+}
+
+// hasDigest reports whether any "repo@sha256:..." entry carries digest
+func hasDigest(repoDigests []string, digest string) bool {
+	for _, rd := range repoDigests {
+		if _, d, ok := strings.Cut(rd, "@"); ok && d == digest {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,209 @@
+// Package logging is DockMate's leveled log file. Nothing is written (and no
+// file is created) unless a message passes the configured level.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Level int
+
+const (
+	LevelOff Level = iota
+	LevelError
+	LevelInfo
+	LevelDebug
+)
+
+// Levels are the accepted level names, quietest first
+var Levels = []string{"off", "error", "info", "debug"}
+
+// slowCommand is how long a runtime command may take before it is logged at info level
+const slowCommand = 2 * time.Second
+
+func (l Level) String() string {
+	if l < LevelOff || int(l) >= len(Levels) {
+		return fmt.Sprintf("Level(%d)", int(l))
+	}
+	return Levels[l]
+}
+
+// ParseLevel turns "error", "info", "debug" or "off" into a Level
+func ParseLevel(s string) (Level, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for i, name := range Levels {
+		if s == name {
+			return Level(i), nil
+		}
+	}
+	return LevelError, fmt.Errorf("unknown log level %q, use one of %s", s, strings.Join(Levels, ", "))
+}
+
+var logger struct {
+	mu     sync.Mutex
+	level  Level
+	path   string
+	file   *os.File
+	out    *log.Logger
+	opened bool // tried to open the file, even if that failed
+}
+
+func init() {
+	logger.level = LevelError
+}
+
+// DefaultPath is $XDG_STATE_HOME/dockmate/dockmate.log, ~/.local/state/dockmate/dockmate.log
+// without it, and the user cache dir on Windows where there is no state dir
+func DefaultPath() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "dockmate", "dockmate.log"), nil
+	}
+	if runtime.GOOS == "windows" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, "dockmate", "dockmate.log"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "state", "dockmate", "dockmate.log"), nil
+}
+
+// Setup sets the level and the file to write to, "" for DefaultPath
+func Setup(level Level, path string) error {
+	if path == "" {
+		p, err := DefaultPath()
+		if err != nil {
+			return err
+		}
+		path = p
+	}
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	closeLocked()
+	logger.level = level
+	logger.path = path
+	return nil
+}
+
+// Enabled reports whether messages at l are written
+func Enabled(l Level) bool {
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	return l != LevelOff && l <= logger.level
+}
+
+// Path is where the log is written
+func Path() string {
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	return logger.path
+}
+
+func Errorf(format string, args ...any) { logf(LevelError, format, args...) }
+func Infof(format string, args ...any)  { logf(LevelInfo, format, args...) }
+func Debugf(format string, args ...any) { logf(LevelDebug, format, args...) }
+
+// Command logs a finished runtime command with how long it took: failures and
+// slow commands at info level, everything at debug level
+func Command(cmd *exec.Cmd, start time.Time, err error) {
+	took := time.Since(start).Round(time.Millisecond)
+	line := strings.Join(cmd.Args, " ")
+	switch {
+	case err != nil:
+		Infof("%s failed after %s: %v", line, took, err)
+	case took >= slowCommand:
+		Infof("slow: %s took %s", line, took)
+	default:
+		Debugf("%s took %s", line, took)
+	}
+}
+
+func logf(l Level, format string, args ...any) {
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	if l == LevelOff || l > logger.level {
+		return
+	}
+	if !logger.opened {
+		open()
+	}
+	logger.out.Printf("%-5s "+format, append([]any{strings.ToUpper(l.String())}, args...)...)
+}
+
+// open creates the log file on the first message; if that fails the
+// messages are dropped, logging must never get in the way
+func open() {
+	logger.opened = true
+	logger.out = log.New(io.Discard, "", 0)
+	if logger.path == "" {
+		p, err := DefaultPath()
+		if err != nil {
+			return
+		}
+		logger.path = p
+	}
+	if err := os.MkdirAll(filepath.Dir(logger.path), 0o755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(logger.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return
+	}
+	logger.file = f
+	logger.out = log.New(f, "", log.LstdFlags|log.Lmicroseconds)
+}
+
+// Close closes the log file, a later message opens it again
+func Close() error {
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	return closeLocked()
+}
+
+func closeLocked() error {
+	logger.opened = false
+	if logger.file == nil {
+		return nil
+	}
+	err := logger.file.Close()
+	logger.file = nil
+	return err
+}
+
+// WriteCrashReport saves report as crash-<time>.txt next to the log file and
+// returns its path. It is written whatever the log level.
+func WriteCrashReport(report string) (string, error) {
+	path := Path()
+	if path == "" {
+		p, err := DefaultPath()
+		if err != nil {
+			return "", err
+		}
+		path = p
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	crashPath := filepath.Join(dir, "crash-"+time.Now().Format("20060102-150405")+".txt")
+	if err := os.WriteFile(crashPath, []byte(report), 0o644); err != nil {
+		return "", err
+	}
+	Errorf("crashed, report in %s", crashPath)
+	return crashPath, nil
+}
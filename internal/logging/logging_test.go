@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel(t *testing.T) {
+	for _, name := range Levels {
+		l, err := ParseLevel(name)
+		require.NoError(t, err)
+		assert.Equal(t, name, l.String())
+	}
+
+	l, err := ParseLevel(" DEBUG ")
+	require.NoError(t, err)
+	assert.Equal(t, LevelDebug, l)
+
+	_, err = ParseLevel("verbose")
+	assert.Error(t, err)
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/state")
+	path, err := DefaultPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/state", "dockmate", "dockmate.log"), path)
+}
+
+func TestLevels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "dockmate.log")
+	t.Cleanup(func() { _ = Setup(LevelError, path); _ = Close() })
+
+	// below the level nothing is written, not even the file
+	require.NoError(t, Setup(LevelError, path))
+	Infof("hidden %d", 1)
+	Debugf("hidden %d", 2)
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+
+	Errorf("shown %d", 3)
+	require.NoError(t, Setup(LevelInfo, path))
+	Infof("shown %d", 4)
+	Debugf("hidden %d", 5)
+	Command(exec.Command("docker", "ps"), time.Now(), errors.New("boom"))
+	require.NoError(t, Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	out := string(data)
+	assert.Contains(t, out, "ERROR shown 3")
+	assert.Contains(t, out, "INFO  shown 4")
+	assert.Contains(t, out, "docker ps failed after")
+	assert.NotContains(t, out, "hidden")
+
+	require.NoError(t, Setup(LevelOff, path))
+	assert.False(t, Enabled(LevelError))
+}
+
+func TestWriteCrashReport(t *testing.T) {
+	dir := t.TempDir()
+	t.Cleanup(func() { _ = Setup(LevelError, filepath.Join(dir, "dockmate.log")); _ = Close() })
+
+	// written even with logging off
+	require.NoError(t, Setup(LevelOff, filepath.Join(dir, "dockmate.log")))
+	path, err := WriteCrashReport("panic: boom\n")
+	require.NoError(t, err)
+	assert.Equal(t, dir, filepath.Dir(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "panic: boom\n", string(data))
+}
@@ -0,0 +1,207 @@
+// Package metrics pushes container stats to a metrics backend, as InfluxDB
+// line protocol or as OTLP over HTTP with JSON, so DockMate can double as a
+// small agent on a dev box.
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/docker"
+	"github.com/shubh-io/dockmate/pkg/version"
+)
+
+// Kinds are the accepted sink names
+var Kinds = []string{"influx", "otlp"}
+
+// Sink sends samples to one endpoint
+type Sink struct {
+	kind  string
+	url   string
+	token string
+	host  string
+}
+
+// New makes a sink of kind ("influx" or "otlp") for url, e.g.
+// http://localhost:8086/api/v2/write?org=me&bucket=docker or
+// http://localhost:4318/v1/metrics. token is sent as "Token" to InfluxDB
+// and as "Bearer" to OTLP, none when empty.
+func New(kind, url, token string) (*Sink, error) {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if kind != "influx" && kind != "otlp" {
+		return nil, fmt.Errorf("unknown metrics sink %q, use one of %s", kind, strings.Join(Kinds, ", "))
+	}
+	if url == "" {
+		return nil, fmt.Errorf("the %s metrics sink needs a url", kind)
+	}
+	host, _ := os.Hostname()
+	return &Sink{kind: kind, url: url, token: token, host: host}, nil
+}
+
+// URL is where the samples go
+func (s *Sink) URL() string {
+	return s.url
+}
+
+// Push sends the samples in one request
+func (s *Sink) Push(samples []docker.StatsSample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var body []byte
+	var contentType, auth string
+	if s.kind == "influx" {
+		body = influxLines(samples)
+		contentType = "text/plain; charset=utf-8"
+		auth = "Token "
+	} else {
+		var err error
+		if body, err = otlpPayload(samples, s.host); err != nil {
+			return err
+		}
+		contentType = "application/json"
+		auth = "Bearer "
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if s.token != "" {
+		req.Header.Set("Authorization", auth+s.token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s %s", s.kind, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// influxLines writes one docker_container point per sample, tagged by id,
+// name and compose project
+func influxLines(samples []docker.StatsSample) []byte {
+	tag := strings.NewReplacer(",", `\,`, " ", `\ `, "=", `\=`)
+	var b bytes.Buffer
+	for _, s := range samples {
+		fmt.Fprintf(&b, "docker_container,id=%s,name=%s", tag.Replace(s.ID), tag.Replace(s.Name))
+		if s.Project != "" {
+			fmt.Fprintf(&b, ",project=%s", tag.Replace(s.Project))
+		}
+		fmt.Fprintf(&b, " cpu_percent=%s,mem_percent=%s,mem_bytes=%di,net_rx_bytes=%di,net_tx_bytes=%di,block_read_bytes=%di,block_write_bytes=%di %d\n",
+			strconv.FormatFloat(s.CPUPercent, 'f', -1, 64),
+			strconv.FormatFloat(s.MemPercent, 'f', -1, 64),
+			s.MemBytes, s.NetRxBytes, s.NetTxBytes, s.BlockReadBytes, s.BlockWriteBytes,
+			s.Time.UnixNano())
+	}
+	return b.Bytes()
+}
+
+// the parts of the OTLP JSON encoding the payload uses
+
+type otlpValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpAttr struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpPoint struct {
+	Attributes   []otlpAttr `json:"attributes"`
+	TimeUnixNano string     `json:"timeUnixNano"`
+	AsDouble     *float64   `json:"asDouble,omitempty"`
+	AsInt        string     `json:"asInt,omitempty"` // int64 goes as a string in JSON
+}
+
+type otlpData struct {
+	DataPoints             []otlpPoint `json:"dataPoints"`
+	AggregationTemporality int         `json:"aggregationTemporality,omitempty"`
+	IsMonotonic            bool        `json:"isMonotonic,omitempty"`
+}
+
+type otlpMetric struct {
+	Name  string    `json:"name"`
+	Unit  string    `json:"unit"`
+	Gauge *otlpData `json:"gauge,omitempty"`
+	Sum   *otlpData `json:"sum,omitempty"`
+}
+
+// cumulative is AGGREGATION_TEMPORALITY_CUMULATIVE, the I/O counters count
+// from the container's start
+const cumulative = 2
+
+// otlpPayload is an ExportMetricsServiceRequest with gauges for CPU and
+// memory and cumulative sums for network and disk I/O, split by direction
+func otlpPayload(samples []docker.StatsSample, host string) ([]byte, error) {
+	cpu := &otlpData{}
+	memPercent := &otlpData{}
+	mem := &otlpData{}
+	net := &otlpData{AggregationTemporality: cumulative, IsMonotonic: true}
+	disk := &otlpData{AggregationTemporality: cumulative, IsMonotonic: true}
+
+	for _, s := range samples {
+		attrs := []otlpAttr{
+			{"container.id", otlpValue{s.ID}},
+			{"container.name", otlpValue{s.Name}},
+		}
+		if s.Project != "" {
+			attrs = append(attrs, otlpAttr{"compose.project", otlpValue{s.Project}})
+		}
+		at := strconv.FormatInt(s.Time.UnixNano(), 10)
+		double := func(v float64) otlpPoint {
+			return otlpPoint{Attributes: attrs, TimeUnixNano: at, AsDouble: &v}
+		}
+		integer := func(v int64, direction string) otlpPoint {
+			a := attrs
+			if direction != "" {
+				a = append(append([]otlpAttr(nil), attrs...), otlpAttr{"direction", otlpValue{direction}})
+			}
+			return otlpPoint{Attributes: a, TimeUnixNano: at, AsInt: strconv.FormatInt(v, 10)}
+		}
+
+		cpu.DataPoints = append(cpu.DataPoints, double(s.CPUPercent))
+		memPercent.DataPoints = append(memPercent.DataPoints, double(s.MemPercent))
+		mem.DataPoints = append(mem.DataPoints, integer(s.MemBytes, ""))
+		net.DataPoints = append(net.DataPoints, integer(s.NetRxBytes, "receive"), integer(s.NetTxBytes, "transmit"))
+		disk.DataPoints = append(disk.DataPoints, integer(s.BlockReadBytes, "read"), integer(s.BlockWriteBytes, "write"))
+	}
+
+	resource := []otlpAttr{{"service.name", otlpValue{"dockmate"}}}
+	if host != "" {
+		resource = append(resource, otlpAttr{"host.name", otlpValue{host}})
+	}
+	payload := map[string]any{
+		"resourceMetrics": []any{map[string]any{
+			"resource": map[string]any{"attributes": resource},
+			"scopeMetrics": []any{map[string]any{
+				"scope": map[string]any{"name": "dockmate", "version": version.Dockmate_Version},
+				"metrics": []otlpMetric{
+					{Name: "container.cpu.percent", Unit: "%", Gauge: cpu},
+					{Name: "container.memory.percent", Unit: "%", Gauge: memPercent},
+					{Name: "container.memory.usage", Unit: "By", Gauge: mem},
+					{Name: "container.network.io", Unit: "By", Sum: net},
+					{Name: "container.blockio", Unit: "By", Sum: disk},
+				},
+			}},
+		}},
+	}
+	return json.Marshal(payload)
+}
@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/docker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sample = docker.StatsSample{
+	Time:       time.Unix(1714564800, 0),
+	ID:         "abc",
+	Name:       "my web",
+	Project:    "shop",
+	CPUPercent: 12.5,
+	MemPercent: 3,
+	MemBytes:   1024,
+	NetRxBytes: 10,
+	NetTxBytes: 20,
+}
+
+func TestInfluxLines(t *testing.T) {
+	assert.Equal(t,
+		`docker_container,id=abc,name=my\ web,project=shop cpu_percent=12.5,mem_percent=3,mem_bytes=1024i,net_rx_bytes=10i,net_tx_bytes=20i,block_read_bytes=0i,block_write_bytes=0i 1714564800000000000`+"\n",
+		string(influxLines([]docker.StatsSample{sample})))
+}
+
+func TestOTLPPayload(t *testing.T) {
+	data, err := otlpPayload([]docker.StatsSample{sample}, "devbox")
+	require.NoError(t, err)
+
+	var req struct {
+		ResourceMetrics []struct {
+			ScopeMetrics []struct {
+				Metrics []otlpMetric `json:"metrics"`
+			} `json:"scopeMetrics"`
+		} `json:"resourceMetrics"`
+	}
+	require.NoError(t, json.Unmarshal(data, &req))
+	metrics := req.ResourceMetrics[0].ScopeMetrics[0].Metrics
+	require.Len(t, metrics, 5)
+	assert.Equal(t, "container.cpu.percent", metrics[0].Name)
+	assert.Equal(t, 12.5, *metrics[0].Gauge.DataPoints[0].AsDouble)
+	assert.Equal(t, "1714564800000000000", metrics[0].Gauge.DataPoints[0].TimeUnixNano)
+	net := metrics[3].Sum
+	require.Len(t, net.DataPoints, 2)
+	assert.Equal(t, "20", net.DataPoints[1].AsInt)
+	assert.Equal(t, "transmit", net.DataPoints[1].Attributes[3].Value.StringValue)
+	assert.True(t, net.IsMonotonic)
+}
+
+func TestPush(t *testing.T) {
+	var got string
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got, auth = string(body), r.Header.Get("Authorization")
+		if r.URL.Path == "/fail" {
+			http.Error(w, "bucket not found", http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	_, err := New("prometheus", srv.URL, "")
+	assert.Error(t, err)
+
+	s, err := New("influx", srv.URL+"/api/v2/write", "secret")
+	require.NoError(t, err)
+	require.NoError(t, s.Push([]docker.StatsSample{sample}))
+	assert.Contains(t, got, "docker_container,id=abc")
+	assert.Equal(t, "Token secret", auth)
+
+	s, err = New("otlp", srv.URL+"/fail", "")
+	require.NoError(t, err)
+	err = s.Push([]docker.StatsSample{sample})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bucket not found")
+}
@@ -0,0 +1,214 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+// container actions (start, stop, restart, remove) go through a queue: up to
+// performance.parallel_actions run at once, each with a row of its own above
+// the status line, and the rest wait their turn. Waiting ones can be
+// cancelled, a running one is up to the runtime by then.
+
+// maxActionRows caps the rows, more actions are summed up in the last one
+const maxActionRows = 4
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+type queuedAction struct {
+	id      int
+	label   string // e.g. "stop web"
+	done    string // status once it worked, e.g. "Stopped web"
+	run     func() error
+	undo    *undoAction // offered once it worked, nil for none
+	started time.Time   // zero while it waits
+}
+
+type actionFinishedMsg struct {
+	id  int
+	err error
+}
+
+type actionTickMsg struct{}
+
+func actionTick() tea.Cmd {
+	return tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg { return actionTickMsg{} })
+}
+
+// containerAction queues `action` on a container, start, stop or restart
+func (m *model) containerAction(action string, c docker.Container) tea.Cmd {
+	name := docker.ContainerName(c)
+	if action == "start" {
+		m.trackStarted(c.ID)
+	}
+	done := map[string]string{"start": "Started", "stop": "Stopped", "restart": "Restarted"}[action]
+	var undo *undoAction
+	if action == "stop" {
+		undo = &undoAction{
+			prompt: fmt.Sprintf("Stopped %s, Ctrl+Z to start it again", name),
+			run: func(m *model) tea.Cmd {
+				return m.containerAction("start", c)
+			},
+		}
+	}
+	if unit := docker.SystemdUnit(c); unit != "" {
+		name += " (" + unit + ")"
+	}
+	return m.queueAction(action+" "+name, fmt.Sprintf("%s %s", done, name), func() error {
+		return docker.ContainerAction(action, c)
+	}, undo)
+}
+
+// queueAction adds an action to the queue and starts it if there's room.
+// The same action twice is only queued once.
+func (m *model) queueAction(label, done string, run func() error, undo *undoAction) tea.Cmd {
+	for _, a := range m.actions {
+		if a.label == label {
+			m.statusMessage = fmt.Sprintf("Already on it: %s", label)
+			return nil
+		}
+	}
+	m.nextActionID++
+	m.actions = append(m.actions, &queuedAction{id: m.nextActionID, label: label, done: done, run: run, undo: undo})
+	m.updateViewport()
+	return m.startActions()
+}
+
+// startActions starts waiting actions up to the parallel limit
+func (m *model) startActions() tea.Cmd {
+	running := 0
+	for _, a := range m.actions {
+		if !a.started.IsZero() {
+			running++
+		}
+	}
+	var cmds []tea.Cmd
+	for _, a := range m.actions {
+		if running >= m.settings.ParallelActions {
+			break
+		}
+		if !a.started.IsZero() {
+			continue
+		}
+		a.started = time.Now()
+		running++
+		id, run := a.id, a.run
+		cmds = append(cmds, func() tea.Msg {
+			return actionFinishedMsg{id: id, err: run()}
+		})
+	}
+	if len(cmds) > 0 && !m.actionTicking {
+		m.actionTicking = true
+		cmds = append(cmds, actionTick())
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *model) handleActionFinished(msg actionFinishedMsg) tea.Cmd {
+	var undoCmd tea.Cmd
+	for i, a := range m.actions {
+		if a.id != msg.id {
+			continue
+		}
+		m.actions = append(m.actions[:i:i], m.actions[i+1:]...)
+		if msg.err != nil {
+			logging.Errorf("%s: %v", a.label, msg.err)
+			m.statusMessage = fmt.Sprintf("Error: %s: %v", a.label, msg.err)
+		} else if a.undo != nil {
+			undoCmd = m.offerUndo(a.undo)
+		} else {
+			m.statusMessage = a.done
+		}
+		break
+	}
+	m.updateViewport()
+	return tea.Batch(m.startActions(), fetchContainers(), undoCmd)
+}
+
+// handleActionTick keeps the spinners turning while anything runs
+func (m *model) handleActionTick() tea.Cmd {
+	if len(m.actions) == 0 {
+		m.actionTicking = false
+		return nil
+	}
+	return actionTick()
+}
+
+// openActionQueue lists the waiting actions to cancel
+func (m *model) openActionQueue() {
+	var items []menuItem
+	waiting := 0
+	for _, a := range m.actions {
+		if !a.started.IsZero() {
+			continue
+		}
+		waiting++
+		if waiting > 9 {
+			continue
+		}
+		id, label := a.id, a.label
+		items = append(items, menuItem{key: strconv.Itoa(waiting), label: "Cancel " + label, action: func(m *model) tea.Cmd {
+			m.cancelActions(func(a *queuedAction) bool { return a.id == id })
+			m.statusMessage = fmt.Sprintf("Cancelled %s", label)
+			return nil
+		}})
+	}
+	if waiting == 0 {
+		m.statusMessage = fmt.Sprintf("No actions waiting (%d running)", len(m.actions))
+		return
+	}
+	items = append(items, menuItem{key: "a", label: fmt.Sprintf("Cancel all %d waiting", waiting), action: func(m *model) tea.Cmd {
+		m.cancelActions(func(a *queuedAction) bool { return true })
+		m.statusMessage = fmt.Sprintf("Cancelled %d actions", waiting)
+		return nil
+	}})
+	m.openMenu(fmt.Sprintf("%d action(s) waiting, %d running", waiting, len(m.actions)-waiting), items)
+}
+
+// cancelActions drops the waiting actions that match
+func (m *model) cancelActions(match func(*queuedAction) bool) {
+	var keep []*queuedAction
+	for _, a := range m.actions {
+		if a.started.IsZero() && match(a) {
+			continue
+		}
+		keep = append(keep, a)
+	}
+	m.actions = keep
+	m.updateViewport()
+}
+
+// actionRows is how many rows the queue takes on screen
+func (m model) actionRows() int {
+	return min(len(m.actions), maxActionRows)
+}
+
+func (m model) renderActionQueue(width int) string {
+	var b strings.Builder
+	now := time.Now()
+	for i, a := range m.actions {
+		if i == maxActionRows-1 && len(m.actions) > maxActionRows {
+			line := fmt.Sprintf(" … and %d more, Ctrl+W to cancel the waiting ones", len(m.actions)-i)
+			b.WriteString(infoLabelStyle.Render(padRight(line, width)))
+			b.WriteString("\n")
+			break
+		}
+		var line string
+		if a.started.IsZero() {
+			line = fmt.Sprintf(" · %s (waiting)", a.label)
+		} else {
+			elapsed := now.Sub(a.started)
+			frame := spinnerFrames[int(elapsed/(100*time.Millisecond))%len(spinnerFrames)]
+			line = fmt.Sprintf(" %s %s %ds", frame, a.label, int(elapsed.Seconds()))
+		}
+		b.WriteString(messageStyle.Render(padRight(line, width)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/config"
+)
+
+// projectLabel is the name a compose project is shown under: its alias if it has one
+func (m model) projectLabel(project string) string {
+	if alias := m.settings.ProjectAliases[project]; alias != "" {
+		return alias
+	}
+	return project
+}
+
+// openAliasPrompt asks for a display alias for a compose project; empty removes it
+func (m *model) openAliasPrompt(project string) tea.Cmd {
+	cmd := m.prompt(fmt.Sprintf("Alias for project %s (empty to clear)", project), "display name", func(m *model, value string) tea.Cmd {
+		m.setProjectAlias(project, value)
+		return nil
+	})
+	m.promptInput.SetValue(m.settings.ProjectAliases[project])
+	return cmd
+}
+
+func (m *model) setProjectAlias(project, alias string) {
+	aliases := make(map[string]string, len(m.settings.ProjectAliases)+1)
+	for k, v := range m.settings.ProjectAliases {
+		aliases[k] = v
+	}
+	if alias == "" {
+		delete(aliases, project)
+		m.statusMessage = fmt.Sprintf("Removed alias of %s", project)
+	} else {
+		aliases[project] = alias
+		m.statusMessage = fmt.Sprintf("%s is now shown as %s", project, alias)
+	}
+	m.settings.ProjectAliases = aliases
+
+	cfg, _ := config.Load()
+	cfg.ProjectAliases = aliases
+	if err := cfg.Save(); err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to save alias: %v", err)
+	}
+
+	if m.composeViewMode {
+		m.buildFlatList()
+	}
+}
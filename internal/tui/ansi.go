@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	// CSI sequences: colors end in "m", everything else moves the cursor or clears
+	csiSequence = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]`)
+	// OSC sequences (window titles, hyperlinks), ended by BEL or ST
+	oscSequence = regexp.MustCompile(`\x1b\][^\x07\x1b]*(\x07|\x1b\\)`)
+)
+
+// cleanLogLine makes an application's log line safe to draw: colors (SGR) are
+// kept unless strip is set, anything that would move the cursor or redraw the
+// screen is dropped
+func cleanLogLine(s string, strip bool) string {
+	if !strings.ContainsAny(s, "\x1b\r") {
+		return s
+	}
+	if strip {
+		return ansi.Strip(strings.ReplaceAll(s, "\r", ""))
+	}
+
+	s = oscSequence.ReplaceAllString(s, "")
+	s = csiSequence.ReplaceAllStringFunc(s, func(seq string) string {
+		if strings.HasSuffix(seq, "m") {
+			return seq
+		}
+		return ""
+	})
+	// whatever is left over is a stray escape or carriage return
+	s = strings.ReplaceAll(s, "\r", "")
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\x1b' && (i+1 >= len(s) || s[i+1] != '[') {
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// log levels, as a bare word ("ERROR", "[WARN]") or as a JSON or logfmt field
+// ("level":"error", level=warn). Bare words are only taken in capitals, a
+// lowercase "error" is more often part of the message.
+var (
+	logLevelField = regexp.MustCompile(`(?i)"?\b(level|lvl|severity)"?\s*[:=]\s*"?(error|err|fatal|panic|crit|critical|warn|warning|info|debug|trace)\b"?`)
+	logLevelWord  = regexp.MustCompile(`\b(ERROR|ERR|FATAL|PANIC|CRIT|CRITICAL|WARN|WARNING|INFO|DEBUG|TRACE)\b`)
+)
+
+var (
+	logErrorStyle = lipgloss.NewStyle().Foreground(meterRed).Bold(true)
+	logWarnStyle  = lipgloss.NewStyle().Foreground(yellowColor)
+	logInfoStyle  = lipgloss.NewStyle().Foreground(meterGreen)
+	logDebugStyle = lipgloss.NewStyle().Foreground(textSecondary)
+)
+
+func logLevelStyle(level string) lipgloss.Style {
+	switch strings.ToLower(level) {
+	case "error", "err", "fatal", "panic", "crit", "critical":
+		return logErrorStyle
+	case "warn", "warning":
+		return logWarnStyle
+	case "info":
+		return logInfoStyle
+	}
+	return logDebugStyle
+}
+
+// colorLogLevel colors the first log level in a line. Lines the app colored
+// itself are left alone.
+func colorLogLevel(s string) string {
+	if strings.Contains(s, "\x1b[") {
+		return s
+	}
+	loc := logLevelField.FindStringSubmatchIndex(s)
+	if loc == nil {
+		loc = logLevelWord.FindStringSubmatchIndex(s)
+	}
+	if loc == nil {
+		return s
+	}
+	// the level is the last group
+	level := s[loc[len(loc)-2]:loc[len(loc)-1]]
+	return s[:loc[0]] + logLevelStyle(level).Render(s[loc[0]:loc[1]]) + s[loc[1]:]
+}
+
+func cleanLogLines(lines []string, strip bool) []string {
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = cleanLogLine(l, strip)
+	}
+	return out
+}
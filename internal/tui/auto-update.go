@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// podman updates the containers labeled io.containers.autoupdate itself,
+// restarting their units on the new image. Ctrl+P runs its dry run, which
+// marks the ones with a newer image like the registry check does, or the
+// update itself. Pull & recreate would bypass the unit, so it's not offered
+// for those.
+
+type autoUpdateMsg struct {
+	updates []docker.AutoUpdate
+	err     error
+}
+
+func autoUpdateDryRunCmd() tea.Cmd {
+	return func() tea.Msg {
+		updates, err := docker.AutoUpdateDryRun()
+		return autoUpdateMsg{updates: updates, err: err}
+	}
+}
+
+// autoUpdateContainers are the listed containers podman auto-updates
+func (m model) autoUpdateContainers() []docker.Container {
+	var out []docker.Container
+	for _, c := range m.statsContainers() {
+		if docker.AutoUpdatePolicy(c) != "" {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func (m *model) openAutoUpdate() {
+	cs := m.autoUpdateContainers()
+	if len(cs) == 0 {
+		m.statusMessage = "No container has the io.containers.autoupdate label"
+		return
+	}
+	names := make([]string, len(cs))
+	for i, c := range cs {
+		names[i] = docker.ContainerName(c)
+	}
+	m.openMenu(fmt.Sprintf("Podman auto-update: %s", strings.Join(names, ", ")), []menuItem{
+		{key: "d", label: "Dry run: check for newer images", action: func(m *model) tea.Cmd {
+			m.statusMessage = "Running podman auto-update --dry-run..."
+			return autoUpdateDryRunCmd()
+		}},
+		{key: "a", label: "Update: pull newer images and restart their units", action: func(m *model) tea.Cmd {
+			return m.startTask("podman auto-update", func(onLine func(string)) (string, error) {
+				updates, err := docker.AutoUpdateApply(onLine)
+				if err != nil {
+					return "", err
+				}
+				var updated []string
+				for _, u := range updates {
+					if u.Updated == "true" {
+						updated = append(updated, u.Name)
+					}
+				}
+				if len(updated) == 0 {
+					return "podman auto-update: everything is up to date", nil
+				}
+				return fmt.Sprintf("podman auto-update updated %s", strings.Join(updated, ", ")), nil
+			})
+		}},
+	})
+}
+
+func (m *model) handleAutoUpdate(msg autoUpdateMsg) {
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Auto-update check failed: %v", msg.err)
+		return
+	}
+	// the report has short IDs
+	m.autoUpdates = make(map[string]docker.AutoUpdate)
+	var pending []string
+	for _, c := range m.autoUpdateContainers() {
+		for _, u := range msg.updates {
+			if u.ContainerID != "" && strings.HasPrefix(c.ID, u.ContainerID) {
+				m.autoUpdates[c.ID] = u
+				if u.Pending() {
+					pending = append(pending, docker.ContainerName(c))
+				}
+			}
+		}
+	}
+	if len(pending) == 0 {
+		m.statusMessage = fmt.Sprintf("No newer images for the %d auto-update containers", len(m.autoUpdates))
+		return
+	}
+	m.statusMessage = fmt.Sprintf("Newer images for %s, Ctrl+P to update", strings.Join(pending, ", "))
+}
+
+// autoUpdateDescription is the info panel text, empty for containers podman
+// doesn't auto-update
+func (m model) autoUpdateDescription(c docker.Container) string {
+	policy := docker.AutoUpdatePolicy(c)
+	if policy == "" {
+		return ""
+	}
+	u, ok := m.autoUpdates[c.ID]
+	switch {
+	case !ok:
+		return policy + " (Ctrl+P to check)"
+	case u.Pending():
+		return policy + ", newer image available (Ctrl+P to update)"
+	default:
+		return policy + ", up to date"
+	}
+}
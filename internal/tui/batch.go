@@ -0,0 +1,192 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// batchStageMsg is sent when every container in the current stage is done
+type batchStageMsg struct {
+	done   int
+	failed []string
+}
+
+// stopInOrder stops the running containers among cs in reverse depends_on
+// order, one stage at a time, so apps don't spam reconnects while their
+// database goes away underneath them
+func (m *model) stopInOrder(label string, cs []docker.Container) tea.Cmd {
+	if m.batchBusy() {
+		return nil
+	}
+
+	var running []docker.Container
+	for _, c := range cs {
+		switch strings.ToLower(c.State) {
+		case "running", "paused", "restarting":
+			running = append(running, c)
+		}
+	}
+	if len(running) == 0 {
+		m.statusMessage = fmt.Sprintf("Nothing running in %s", label)
+		return nil
+	}
+
+	return m.startBatch("stop", label, docker.StopStages(running))
+}
+
+// startInOrder starts the stopped containers among cs in depends_on order,
+// the reverse of stopInOrder, so databases are up before the apps using them
+func (m *model) startInOrder(label string, cs []docker.Container) tea.Cmd {
+	if m.batchBusy() {
+		return nil
+	}
+
+	var stopped []docker.Container
+	for _, c := range cs {
+		switch strings.ToLower(c.State) {
+		case "running", "paused", "restarting":
+		default:
+			stopped = append(stopped, c)
+		}
+	}
+	if len(stopped) == 0 {
+		m.statusMessage = fmt.Sprintf("Everything in %s is running", label)
+		return nil
+	}
+
+	for _, c := range stopped {
+		m.trackStarted(c.ID)
+	}
+	stages := docker.StopStages(stopped)
+	slices.Reverse(stages)
+	return m.startBatch("start", label, stages)
+}
+
+// batchBusy says so when a batch is still going, one at a time
+func (m *model) batchBusy() bool {
+	if len(m.batchStages) == 0 {
+		return false
+	}
+	m.statusMessage = fmt.Sprintf("Still %s %s, try again in a moment", batchVerbs[m.batchAction][0], m.batchLabel)
+	return true
+}
+
+// batchVerbs are the progress and done words of each batch action
+var batchVerbs = map[string][2]string{
+	"stop":  {"stopping", "Stopped"},
+	"start": {"starting", "Started"},
+}
+
+func (m *model) startBatch(action, label string, stages [][]docker.Container) tea.Cmd {
+	total := 0
+	for _, stage := range stages {
+		total += len(stage)
+	}
+	m.batchAction = action
+	m.batchStages = stages
+	m.batchLabel = label
+	m.batchTotal = total
+	m.batchDone = 0
+	m.batchFailed = nil
+	return m.nextStage()
+}
+
+// nextStage kicks off the first pending stage; its containers go in parallel
+func (m *model) nextStage() tea.Cmd {
+	stage := m.batchStages[0]
+	action := m.batchAction
+
+	names := make([]string, len(stage))
+	for i, c := range stage {
+		names[i] = docker.ContainerName(c)
+	}
+	verb := batchVerbs[action][0]
+	m.statusMessage = fmt.Sprintf("%s%s %s (%d/%d): %s", strings.ToUpper(verb[:1]), verb[1:], m.batchLabel, m.batchDone, m.batchTotal, strings.Join(names, ", "))
+
+	return func() tea.Msg {
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		var failed []string
+
+		for _, c := range stage {
+			wg.Add(1)
+			go func(c docker.Container) {
+				defer wg.Done()
+				if err := docker.ContainerAction(action, c); err != nil {
+					mu.Lock()
+					failed = append(failed, docker.ContainerName(c))
+					mu.Unlock()
+				}
+			}(c)
+		}
+		wg.Wait()
+
+		return batchStageMsg{done: len(stage), failed: failed}
+	}
+}
+
+// handleBatchStage records a finished stage and moves on to the next one
+func (m *model) handleBatchStage(msg batchStageMsg) tea.Cmd {
+	if len(m.batchStages) == 0 {
+		return nil
+	}
+
+	m.batchStages = m.batchStages[1:]
+	m.batchDone += msg.done
+	m.batchFailed = append(m.batchFailed, msg.failed...)
+
+	if len(m.batchStages) > 0 {
+		return tea.Batch(m.nextStage(), fetchContainers())
+	}
+
+	done := batchVerbs[m.batchAction][1]
+	if len(m.batchFailed) > 0 {
+		m.statusMessage = fmt.Sprintf("%s %s, failed: %s", done, m.batchLabel, strings.Join(m.batchFailed, ", "))
+	} else {
+		m.statusMessage = fmt.Sprintf("%s %s (%d containers)", done, m.batchLabel, m.batchDone)
+	}
+	m.batchStages = nil
+	if m.quitAfterBatch {
+		return tea.Quit
+	}
+	return fetchContainers()
+}
+
+// openAllActions offers to start or stop everything in the compose project
+// under the cursor, or else every container the list shows with its filters
+func (m *model) openAllActions() {
+	cs := m.statsContainers()
+	label := "containers in view"
+	if m.isProjectSelected() {
+		proj, _ := m.getSelectedProject()
+		if p, ok := m.projects[proj]; ok {
+			cs = p.Containers
+			label = "project " + m.projectLabel(proj)
+		}
+	}
+	if len(cs) == 0 {
+		m.statusMessage = "No containers in view"
+		return
+	}
+
+	running := 0
+	for _, c := range cs {
+		switch strings.ToLower(c.State) {
+		case "running", "paused", "restarting":
+			running++
+		}
+	}
+	m.openMenu(fmt.Sprintf("%d container(s) in %s (%d running)", len(cs), strings.TrimPrefix(label, "containers in "), running), []menuItem{
+		{key: "s", label: fmt.Sprintf("Start the %d stopped, dependencies first", len(cs)-running), action: func(m *model) tea.Cmd {
+			return m.startInOrder(label, cs)
+		}},
+		{key: "x", label: fmt.Sprintf("Stop the %d running, dependents first", running), action: func(m *model) tea.Cmd {
+			return m.stopInOrder(label, cs)
+		}},
+	})
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// copyToClipboard sends text to the terminal's clipboard with an OSC 52
+// escape sequence, which works over ssh and without xclip. The local system
+// clipboard gets it too when there is one, for terminals without OSC 52.
+func (m *model) copyToClipboard(text, what string) {
+	termenv.Copy(text)
+	_ = clipboard.WriteAll(text)
+	m.statusMessage = fmt.Sprintf("Copied the %s", what)
+}
+
+// copySelection copies what's in focus: the logs or info when their panel is
+// open, otherwise it asks which of the container's name, ID or image
+func (m *model) copySelection() {
+	switch {
+	case m.logsVisible:
+		m.openCopyLogsMenu()
+		return
+	case m.infoVisible:
+		fields := m.infoFields(m.infoTarget())
+		if len(fields) == 0 {
+			m.statusMessage = "No info to copy"
+			return
+		}
+		var b strings.Builder
+		for _, f := range fields {
+			fmt.Fprintf(&b, "%s: %s\n", f.label, f.value)
+		}
+		m.copyToClipboard(b.String(), "container info")
+		return
+	}
+
+	c := m.selectedContainer()
+	if c == nil {
+		m.statusMessage = "Select a container to copy from"
+		return
+	}
+	name, id, image := docker.ContainerName(*c), c.ID, c.Image
+	m.openMenu(fmt.Sprintf("Copy from %s", name), []menuItem{
+		{key: "n", label: "Name: " + name, action: func(m *model) tea.Cmd {
+			m.copyToClipboard(name, "name")
+			return nil
+		}},
+		{key: "i", label: "ID: " + id, action: func(m *model) tea.Cmd {
+			m.copyToClipboard(id, "ID")
+			return nil
+		}},
+		{key: "m", label: "Image: " + image, action: func(m *model) tea.Cmd {
+			m.copyToClipboard(image, "image")
+			return nil
+		}},
+	})
+}
+
+// containerRow is the container's row as tab separated text: the visible
+// columns in the table's order, untruncated
+func (m model) containerRow(c docker.Container) string {
+	visible := m.settings.VisibleColumns
+	if len(visible) != numColumns {
+		visible = defaultVisibleColumns()
+	}
+	values := []string{c.ID, docker.ContainerName(c), c.Memory, c.CPU, c.NetIO, c.BlockIO, c.Image, c.Status, c.Ports, m.uptimeText(c), createdText(c), m.networksText(c), m.ipText(c), m.gpuText(c)}
+
+	fields := make([]string, 0, numColumns)
+	for i, v := range values {
+		if visible[i] {
+			fields = append(fields, v)
+		}
+	}
+	return strings.Join(fields, "\t")
+}
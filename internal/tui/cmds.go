@@ -11,19 +11,21 @@ import (
 // Async commands
 // ============================================================================
 
-// grab container list in background
+// grab container list in background, the stats follow on their own (stats.go)
 func fetchContainers() tea.Cmd {
 	return func() tea.Msg {
-		containers, err := docker.ListContainers()
-		return docker.ContainersMsg{Containers: containers, Err: err}
+		start := time.Now()
+		containers, err := docker.ListContainersWithoutStats()
+		return docker.ContainersMsg{Containers: containers, Err: err, Took: time.Since(start)}
 	}
 }
 
 // fetch compose projects asynchronously
 func fetchComposeProjects() tea.Cmd {
 	return func() tea.Msg {
-		projects, err := docker.FetchComposeProjects()
-		return composeProjectsMsg{Projects: projects, Err: err}
+		start := time.Now()
+		projects, err := docker.FetchComposeProjectsWithoutStats()
+		return composeProjectsMsg{Projects: projects, Err: err, Took: time.Since(start)}
 	}
 }
 
@@ -38,13 +40,6 @@ func tickCmd(d time.Duration) tea.Cmd {
 }
 
 // run docker action in background (start/stop/etc)
-func doAction(action, containerID string) tea.Cmd {
-	return func() tea.Msg {
-		err := docker.DoAction(action, containerID)
-		return actionDoneMsg{err: err}
-	}
-}
-
 func composeActionCmd(action, project, workingDir string) tea.Cmd {
 	return func() tea.Msg {
 		err := docker.RunComposeAction(action, project, workingDir)
@@ -53,16 +48,16 @@ func composeActionCmd(action, project, workingDir string) tea.Cmd {
 }
 
 // fetch logs for a container
-func fetchLogsCmd(id string) tea.Cmd {
+func fetchLogsCmd(id string, opts docker.LogOptions) tea.Cmd {
 	return func() tea.Msg {
-		lines, err := docker.GetLogs(id)
+		lines, err := docker.GetLogs(id, opts)
 		return docker.LogsMsg{ID: id, Lines: lines, Err: err}
 	}
 }
 
-func fetchComposeLogsCmd(project, workingDir string) tea.Cmd {
+func fetchComposeLogsCmd(project, workingDir string, opts docker.LogOptions) tea.Cmd {
 	return func() tea.Msg {
-		lines, err := docker.GetComposeLogs(project, workingDir)
+		lines, err := docker.GetComposeLogs(project, workingDir, opts)
 		return docker.LogsMsg{ID: project, Lines: lines, Err: err}
 	}
 }
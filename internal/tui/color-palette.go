@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"hash/fnv"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -101,3 +103,26 @@ var (
 	dividerStyle = lipgloss.NewStyle().
 			Foreground(borderColor)
 )
+
+// projectColors are handed out to compose projects by a hash of the name, so a
+// project keeps its color across refreshes and restarts
+var projectColors = []lipgloss.Color{
+	"#F472B6", // pink
+	"#A78BFA", // violet
+	"#60A5FA", // blue
+	"#34D399", // emerald
+	"#FBBF24", // amber
+	"#FB923C", // orange
+	"#2DD4BF", // teal
+	"#E879F9", // fuchsia
+}
+
+func projectColor(project string) lipgloss.Color {
+	h := fnv.New32a()
+	h.Write([]byte(project))
+	return projectColors[h.Sum32()%uint32(len(projectColors))]
+}
+
+func projectBar(project string) string {
+	return lipgloss.NewStyle().Foreground(projectColor(project)).Render("▌")
+}
@@ -0,0 +1,227 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// compose projects remember little of how they were brought up: the labels
+// have the files and the env file, the profiles come from the files. `u`
+// offers those profiles and `i` on a project shows the setup with the
+// effective config, as `compose config` fills it in.
+
+func composeUpCmd(project, workingDir string, opts docker.ComposeOptions) tea.Cmd {
+	return func() tea.Msg {
+		err := docker.RunComposeActionWith("up", project, workingDir, opts)
+		return actionDoneMsg{err: err}
+	}
+}
+
+// openComposeUp asks before bringing a project up. Projects whose files
+// define profiles get them to toggle, starting with the active ones.
+func (m *model) openComposeUp(proj, dir string) {
+	var setup docker.ComposeSetup
+	if p, ok := m.projects[proj]; ok {
+		setup = docker.ProjectSetup(p.Containers)
+	}
+	if len(setup.Profiles) == 0 {
+		m.confirm(fmt.Sprintf("ARE YOU SURE you want to START compose project %q?", m.projectLabel(proj)), func(m *model) tea.Cmd {
+			m.statusMessage = fmt.Sprintf("Starting project %s...", m.projectLabel(proj))
+			m.trackProjectUp(proj)
+			return composeActionCmd("up", proj, dir)
+		})
+		return
+	}
+	selected := make(map[string]bool)
+	for _, p := range setup.Active {
+		selected[p] = true
+	}
+	m.openComposeUpProfiles(proj, dir, setup, selected)
+}
+
+func (m *model) openComposeUpProfiles(proj, dir string, setup docker.ComposeSetup, selected map[string]bool) {
+	var items []menuItem
+	var chosen []string
+	for i, profile := range setup.Profiles {
+		if selected[profile] {
+			chosen = append(chosen, profile)
+		}
+		if i >= 9 {
+			continue
+		}
+		check := "[ ]"
+		if selected[profile] {
+			check = "[x]"
+		}
+		items = append(items, menuItem{key: strconv.Itoa(i + 1), label: check + " --profile " + profile, action: func(m *model) tea.Cmd {
+			toggled := make(map[string]bool)
+			for p, on := range selected {
+				toggled[p] = on
+			}
+			toggled[profile] = !toggled[profile]
+			m.openComposeUpProfiles(proj, dir, setup, toggled)
+			return nil
+		}})
+	}
+	label := "Up without profiles"
+	if len(chosen) > 0 {
+		label = "Up with " + strings.Join(chosen, ", ")
+	}
+	items = append(items, menuItem{key: "y", label: label, action: func(m *model) tea.Cmd {
+		opts := setup.Options()
+		opts.Profiles = chosen
+		m.statusMessage = fmt.Sprintf("Starting project %s...", m.projectLabel(proj))
+		m.trackProjectUp(proj)
+		return composeUpCmd(proj, dir, opts)
+	}})
+	m.openMenu(fmt.Sprintf("START compose project %q?", m.projectLabel(proj)), items)
+}
+
+type composeConfigMsg struct {
+	project string
+	config  string
+	err     error
+}
+
+func composeConfigCmd(project, workingDir string, opts docker.ComposeOptions) tea.Cmd {
+	return func() tea.Msg {
+		config, err := docker.ComposeConfig(project, workingDir, opts)
+		return composeConfigMsg{project: project, config: config, err: err}
+	}
+}
+
+// openComposeConfig shows how the project under the cursor was brought up
+func (m *model) openComposeConfig(proj, dir string) tea.Cmd {
+	var setup docker.ComposeSetup
+	if p, ok := m.projects[proj]; ok {
+		setup = docker.ProjectSetup(p.Containers)
+	}
+	m.configProject = proj
+	m.configSetup = setup
+	m.configLines = nil
+	m.configErr = nil
+	m.configOffset = 0
+	m.configLoading = true
+	m.returnMode = m.currentMode
+	m.currentMode = modeComposeConfig
+	m.statusMessage = ""
+	m.configDir = dir
+	return composeConfigCmd(proj, dir, setup.Options())
+}
+
+func (m *model) handleComposeConfig(msg composeConfigMsg) {
+	if msg.project != m.configProject {
+		return
+	}
+	m.configLoading = false
+	m.configErr = msg.err
+	m.configLines = strings.Split(strings.TrimRight(msg.config, "\n"), "\n")
+	m.configOffset = min(m.configOffset, max(0, len(m.configLines)-m.configPageSize()))
+}
+
+// configHeader lists the files, env files and profiles above the config
+func (m model) configHeader() []string {
+	none := func(list []string, empty string) string {
+		if len(list) == 0 {
+			return empty
+		}
+		return strings.Join(list, ", ")
+	}
+	s := m.configSetup
+	var profiles []string
+	for _, p := range s.Profiles {
+		active := false
+		for _, a := range s.Active {
+			active = active || a == p
+		}
+		if active {
+			p += " (active)"
+		}
+		profiles = append(profiles, p)
+	}
+	return []string{
+		"Files:     " + none(s.Files, "unknown"),
+		"Env files: " + none(s.EnvFiles, ".env in the project directory, if any"),
+		"Profiles:  " + none(profiles, "none"),
+	}
+}
+
+func (m model) configPageSize() int {
+	// title bar, title, header, blank line and footer
+	return max(1, m.terminalHeight-5-len(m.configHeader()))
+}
+
+func (m model) updateComposeConfig(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	last := max(0, len(m.configLines)-m.configPageSize())
+	switch msg.String() {
+	case "esc", "q", "i", "I":
+		m.currentMode = m.returnMode
+		m.configLines = nil
+		m.statusMessage = "Compose config closed"
+	case "up", "k":
+		m.configOffset = max(0, m.configOffset-1)
+	case "down", "j":
+		m.configOffset = min(last, m.configOffset+1)
+	case "pgup", "ctrl+u":
+		m.configOffset = max(0, m.configOffset-m.configPageSize())
+	case "pgdown", "ctrl+d":
+		m.configOffset = min(last, m.configOffset+m.configPageSize())
+	case "home", "g":
+		m.configOffset = 0
+	case "end", "G":
+		m.configOffset = last
+	case "y", "Y":
+		if len(m.configLines) > 0 && m.configErr == nil {
+			m.copyToClipboard(strings.Join(m.configLines, "\n")+"\n", "compose config")
+		}
+	case "f5":
+		if !m.configLoading {
+			m.configLoading = true
+			m.configErr = nil
+			return m, composeConfigCmd(m.configProject, m.configDir, m.configSetup.Options())
+		}
+	}
+	return m, nil
+}
+
+func (m model) renderComposeConfig(width int) string {
+	var b strings.Builder
+
+	b.WriteString(m.renderTitleBar(width))
+	b.WriteString("\n")
+	b.WriteString(titleStyle.Render(padRight(truncateToWidth(fmt.Sprintf("Effective config of %s", m.projectLabel(m.configProject)), width-2), width-2)))
+	b.WriteString("\n")
+	for _, line := range m.configHeader() {
+		b.WriteString(infoLabelStyle.Render(truncateToWidth(" "+line, width)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	rows := m.configPageSize()
+	var lines []string
+	switch {
+	case m.configLoading:
+		lines = append(lines, normalStyle.Render("  Running compose config..."))
+	case m.configErr != nil:
+		for _, l := range strings.Split(m.configErr.Error(), "\n") {
+			lines = append(lines, messageStyle.Render(truncateToWidth("  "+l, width)))
+		}
+	default:
+		for _, l := range m.configLines[m.configOffset:min(m.configOffset+rows, len(m.configLines))] {
+			lines = append(lines, normalStyle.Render(truncateToWidth(" "+l, width)))
+		}
+	}
+	for i := 0; i < rows; i++ {
+		if i < len(lines) {
+			b.WriteString(lines[i])
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.renderFilesFooter(width, [][2]string{{"↑↓", "scroll"}, {"y", "copy"}, {"F5", "reload"}, {"Esc", "close"}}))
+	return b.String()
+}
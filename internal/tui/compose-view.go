@@ -17,26 +17,26 @@ func (m *model) buildFlatList() {
 	for name := range m.projects {
 		projectNames = append(projectNames, name)
 	}
-	sort.Strings(projectNames)
+	sort.Slice(projectNames, func(i, j int) bool {
+		return strings.ToLower(m.projectLabel(projectNames[i])) < strings.ToLower(m.projectLabel(projectNames[j]))
+	})
 
 	// Add compose projects
 	for _, projectName := range projectNames {
 		project := m.projects[projectName]
-		running := 0
+		var usage docker.UsageTotals
 		for _, c := range project.Containers {
-			if strings.ToLower(c.State) == "running" {
-				running++
-			}
+			usage.Add(c)
 		}
-		total := len(project.Containers)
 
 		// Add project row
 		m.flatList = append(m.flatList, treeRow{
 			isProject:   true,
 			projectName: projectName,
-			running:     running,
-			total:       total,
+			running:     usage.Running,
+			total:       usage.Total,
 			indent:      0,
+			usage:       usage,
 		})
 
 		// Add container rows if expanded
@@ -69,11 +69,17 @@ func (m *model) buildFlatList() {
 
 	// Add standalone section if any exist
 	if len(standaloneContainers) > 0 {
+		var usage docker.UsageTotals
+		for _, c := range standaloneContainers {
+			usage.Add(*c)
+		}
 		m.flatList = append(m.flatList, treeRow{
 			isProject:   true,
 			projectName: "Standalone Containers",
-			total:       len(standaloneContainers),
+			running:     usage.Running,
+			total:       usage.Total,
 			indent:      0,
+			usage:       usage,
 		})
 
 		if m.expandedProjects["Standalone Containers"] {
@@ -88,7 +94,7 @@ func (m *model) buildFlatList() {
 	}
 }
 
-func (m model) renderTreeRow(row treeRow, selected bool, idW, nameW, memoryW, cpuW, netIOW, blockIOW, imageW, statusW, portsW, totalWidth int) string {
+func (m model) renderTreeRow(row treeRow, selected bool, idW, nameW, memoryW, cpuW, netIOW, blockIOW, imageW, statusW, portsW, uptimeW, createdW, networksW, ipW, gpuW, totalWidth int) string {
 	if row.isProject {
 		// Project header row
 		expandIcon := "▼"
@@ -96,10 +102,17 @@ func (m model) renderTreeRow(row treeRow, selected bool, idW, nameW, memoryW, cp
 			expandIcon = "▶"
 		}
 
-		projectLabel := fmt.Sprintf(" %s %s [%d/%d running]", expandIcon, row.projectName, row.running, row.total)
-		if visibleLen(projectLabel) < totalWidth {
-			projectLabel += strings.Repeat(" ", totalWidth-visibleLen(projectLabel))
+		name := row.projectName
+		if alias := m.projectLabel(name); alias != name {
+			name = fmt.Sprintf("%s (%s)", alias, name)
+		}
+		projectLabel := fmt.Sprintf(" %s %s [%d/%d running]", expandIcon, name, row.running, row.total)
+		if u := row.usage; u.Running > 0 {
+			// the stack's weight at a glance, folded or not
+			projectLabel += fmt.Sprintf("   CPU %.1f%%  MEM %.1f%% (%s)  NET %s  DISK %s",
+				u.CPUPercent, u.MemPercent, docker.FormatBytesBinary(u.MemBytes), docker.FormatBytes(u.NetBytes), docker.FormatBytes(u.BlockBytes))
 		}
+		projectLabel = padRight(truncateToWidth(projectLabel, totalWidth), totalWidth)
 
 		// Project row style
 		projectStyle := lipgloss.NewStyle().Bold(true).Foreground(accent)
@@ -130,7 +143,7 @@ func (m model) renderTreeRow(row treeRow, selected bool, idW, nameW, memoryW, cp
 		id = truncateToWidth(id, idW-2)
 	}
 
-	containerName := indentStr + name
+	containerName := indentStr + m.nameBadges(*c) + name + m.noteSuffix(*c)
 	if visibleLen(containerName) > nameW-2 {
 		containerName = truncateToWidth(containerName, nameW-2)
 	}
@@ -186,8 +199,8 @@ func (m model) renderTreeRow(row treeRow, selected bool, idW, nameW, memoryW, cp
 	}
 
 	visible := m.settings.VisibleColumns
-	if visible == nil || len(visible) != 9 {
-		visible = []bool{true, true, true, true, true, true, true, true, true}
+	if len(visible) != numColumns {
+		visible = defaultVisibleColumns()
 		m.settings.VisibleColumns = visible
 	}
 	rows := []struct {
@@ -204,6 +217,11 @@ func (m model) renderTreeRow(row treeRow, selected bool, idW, nameW, memoryW, cp
 		{6, imageW - 1, img},
 		{7, statusW, status},
 		{8, portsW - 2, ports},
+		{9, uptimeW - 1, m.uptimeText(*c)},
+		{10, createdW - 1, createdText(*c)},
+		{11, networksW - 1, m.networksText(*c)},
+		{12, ipW - 1, m.ipText(*c)},
+		{13, gpuW - 1, m.gpuText(*c)},
 	}
 
 	var rowStr string
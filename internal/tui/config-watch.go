@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+	"github.com/shubh-io/dockmate/internal/config"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+type configChangedMsg struct{}
+
+// one watcher per process, it outlives TUI restarts. changed is closed and
+// replaced on every change so every waiter wakes up, including ones left
+// behind by a previous run of the TUI.
+var configWatch struct {
+	once    sync.Once
+	mu      sync.Mutex
+	changed chan struct{}
+}
+
+// startConfigWatch watches the directory holding config.yml, not the file:
+// editors often save by writing a new file and renaming it over the old one
+func startConfigWatch() {
+	configWatch.changed = make(chan struct{})
+
+	path, err := config.GetConfigPath()
+	if err != nil {
+		return
+	}
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return
+	}
+	// no config dir yet means nothing to reload until the first save creates it
+	if err := w.Add(filepath.Dir(path)); err != nil {
+		w.Close()
+		return
+	}
+
+	go func() {
+		var debounce *time.Timer
+		for {
+			select {
+			case ev, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(ev.Name) != path || !ev.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+					continue
+				}
+				// a save is often several events, reload once it settles
+				if debounce != nil {
+					debounce.Stop()
+				}
+				debounce = time.AfterFunc(200*time.Millisecond, notifyConfigChanged)
+			case _, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+}
+
+func notifyConfigChanged() {
+	configWatch.mu.Lock()
+	close(configWatch.changed)
+	configWatch.changed = make(chan struct{})
+	configWatch.mu.Unlock()
+}
+
+// waitForConfigChange delivers a configChangedMsg the next time config.yml changes
+func waitForConfigChange() tea.Cmd {
+	configWatch.once.Do(startConfigWatch)
+
+	configWatch.mu.Lock()
+	ch := configWatch.changed
+	configWatch.mu.Unlock()
+
+	return func() tea.Msg {
+		<-ch
+		return configChangedMsg{}
+	}
+}
+
+// reloadConfig applies config.yml after it changed on disk. A broken file is
+// left alone instead of falling back to the defaults mid-session.
+func (m *model) reloadConfig() {
+	if problems, _ := config.Validate(); len(problems) > 0 {
+		m.statusMessage = fmt.Sprintf("Config not reloaded, it has %d problem(s): run dockmate config validate", len(problems))
+		return
+	}
+	// docker calls pick the new runtime settings up from here on
+	config.Invalidate()
+
+	cwd, _ := os.Getwd()
+	cfg, overlay, _ := config.LoadWithProject(cwd)
+	endpointErr := applyEndpoint(cfg)
+	settings := settingsFromConfig(cfg)
+	if endpointErr != nil {
+		m.statusMessage = "Config reloaded, but " + endpointErr.Error()
+	}
+	if reflect.DeepEqual(settings, m.settings) {
+		// our own save, or nothing the TUI uses
+		return
+	}
+	if m.currentMode == modeSettings {
+		// don't throw away edits in progress
+		m.statusMessage = "Config file changed on disk, saving settings will overwrite it"
+		return
+	}
+
+	m.settings = settings
+	m.projectPins = overlay.Pinned
+	m.detectedRuntime = docker.RuntimeBinary()
+	if endpointErr == nil {
+		m.statusMessage = "Config reloaded"
+	}
+
+	m.sortContainers()
+	if m.composeViewMode {
+		m.buildFlatList()
+	}
+}
+
+// applyEndpoint points the runtime at what the reloaded config names, the
+// same way main does at startup: the tunnel to runtime.remote, runtime.socket
+// and runtime.tls. Without it a changed socket or remote only took effect
+// after a restart.
+func applyEndpoint(cfg *config.Config) error {
+	if err := docker.UseRemote(cfg); err != nil {
+		return fmt.Errorf("can't connect to remote %s: %w", cfg.Runtime.Remote, err)
+	}
+	docker.ApplySocket(cfg.Runtime)
+	if err := docker.ApplyTLS(cfg.Runtime); err != nil {
+		return fmt.Errorf("can't set up runtime.tls: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// the diff screen lists what a container changed on top of its image, the
+// files `docker diff` reports as added, changed or deleted
+
+var (
+	diffAddedStyle   = lipgloss.NewStyle().Foreground(meterGreen)
+	diffChangedStyle = lipgloss.NewStyle().Foreground(yellowColor)
+	diffDeletedStyle = lipgloss.NewStyle().Foreground(meterRed)
+)
+
+type containerDiffMsg struct {
+	id      string
+	changes []docker.FileChange
+	err     error
+}
+
+func containerDiffCmd(id string) tea.Cmd {
+	return func() tea.Msg {
+		changes, err := docker.ContainerDiff(id)
+		return containerDiffMsg{id: id, changes: changes, err: err}
+	}
+}
+
+func (m *model) openContainerDiff(c docker.Container) tea.Cmd {
+	m.diffID = c.ID
+	m.diffName = docker.ContainerName(c)
+	m.diffChanges = nil
+	m.diffErr = nil
+	m.diffOffset = 0
+	m.diffLoading = true
+	m.returnMode = m.currentMode
+	m.currentMode = modeDiff
+	m.statusMessage = ""
+	return containerDiffCmd(c.ID)
+}
+
+func (m *model) handleContainerDiff(msg containerDiffMsg) {
+	if msg.id != m.diffID {
+		return
+	}
+	m.diffLoading = false
+	m.diffErr = msg.err
+	m.diffChanges = msg.changes
+	m.diffOffset = min(m.diffOffset, max(0, len(m.diffChanges)-m.diffPageSize()))
+}
+
+func (m model) diffPageSize() int {
+	// title bar, title, summary and footer
+	return max(1, m.terminalHeight-5)
+}
+
+func (m model) updateDiff(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	last := max(0, len(m.diffChanges)-m.diffPageSize())
+	switch msg.String() {
+	case "esc", "q", "#":
+		m.currentMode = m.returnMode
+		m.diffChanges = nil
+		m.statusMessage = "Diff closed"
+	case "up", "k":
+		m.diffOffset = max(0, m.diffOffset-1)
+	case "down", "j":
+		m.diffOffset = min(last, m.diffOffset+1)
+	case "pgup", "ctrl+u":
+		m.diffOffset = max(0, m.diffOffset-m.diffPageSize())
+	case "pgdown", "ctrl+d":
+		m.diffOffset = min(last, m.diffOffset+m.diffPageSize())
+	case "home", "g":
+		m.diffOffset = 0
+	case "end", "G":
+		m.diffOffset = last
+	case "y", "Y":
+		if len(m.diffChanges) > 0 {
+			var b strings.Builder
+			for _, c := range m.diffChanges {
+				fmt.Fprintf(&b, "%c %s\n", c.Kind, c.Path)
+			}
+			m.copyToClipboard(b.String(), "diff")
+		}
+	case "f5":
+		if !m.diffLoading {
+			m.diffLoading = true
+			m.diffErr = nil
+			return m, containerDiffCmd(m.diffID)
+		}
+	}
+	return m, nil
+}
+
+// diffSummary counts the changes by kind
+func diffSummary(changes []docker.FileChange) string {
+	counts := map[byte]int{}
+	for _, c := range changes {
+		counts[c.Kind]++
+	}
+	return fmt.Sprintf("%d added, %d changed, %d deleted", counts['A'], counts['C'], counts['D'])
+}
+
+func (m model) renderContainerDiff(width int) string {
+	var b strings.Builder
+
+	b.WriteString(m.renderTitleBar(width))
+	b.WriteString("\n")
+	b.WriteString(titleStyle.Render(padRight(truncateToWidth(fmt.Sprintf("Changes in the writable layer of %s", m.diffName), width-2), width-2)))
+	b.WriteString("\n")
+
+	rows := m.diffPageSize()
+	var lines []string
+	switch {
+	case m.diffLoading:
+		b.WriteString("\n")
+		lines = append(lines, normalStyle.Render("  Asking for the diff..."))
+	case m.diffErr != nil:
+		b.WriteString("\n")
+		lines = append(lines, messageStyle.Render(truncateToWidth("  "+m.diffErr.Error(), width)))
+	case len(m.diffChanges) == 0:
+		b.WriteString("\n")
+		lines = append(lines, normalStyle.Render("  No changes, the container's files are the image's"))
+	default:
+		summary := diffSummary(m.diffChanges)
+		if len(m.diffChanges) > rows {
+			summary += fmt.Sprintf("  (%d-%d of %d)", m.diffOffset+1, min(m.diffOffset+rows, len(m.diffChanges)), len(m.diffChanges))
+		}
+		b.WriteString(infoLabelStyle.Render(" " + summary))
+		b.WriteString("\n")
+		for _, c := range m.diffChanges[m.diffOffset:min(m.diffOffset+rows, len(m.diffChanges))] {
+			path := truncateToWidth(c.Path, width-4)
+			switch c.Kind {
+			case 'A':
+				lines = append(lines, diffAddedStyle.Render(" + "+path))
+			case 'C':
+				lines = append(lines, diffChangedStyle.Render(" ~ "+path))
+			default:
+				lines = append(lines, diffDeletedStyle.Render(" - "+path))
+			}
+		}
+	}
+	for i := 0; i < rows; i++ {
+		if i < len(lines) {
+			b.WriteString(lines[i])
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.renderFilesFooter(width, [][2]string{{"↑↓", "scroll"}, {"y", "copy"}, {"F5", "reload"}, {"Esc", "close"}}))
+	return b.String()
+}
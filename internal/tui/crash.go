@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/logging"
+	"github.com/shubh-io/dockmate/pkg/version"
+)
+
+// crashFile is where the last crash report went, empty if there was none
+var crashFile string
+
+// CrashFile returns the crash report written by the last panic, if any
+func CrashFile() string {
+	return crashFile
+}
+
+// crashGuard wraps the model so a panic in Init, Update or View leaves a crash
+// report behind. The panic is passed on, bubbletea restores the terminal and
+// Run returns tea.ErrProgramPanic.
+type crashGuard struct {
+	m model
+}
+
+// WithCrashReports wraps m for tea.NewProgram
+func WithCrashReports(m model) tea.Model {
+	return crashGuard{m: m}
+}
+
+func (g crashGuard) Init() tea.Cmd {
+	defer g.recover(nil)
+	return g.m.Init()
+}
+
+func (g crashGuard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer g.recover(msg)
+	next, cmd := g.m.Update(msg)
+	if nm, ok := next.(model); ok {
+		g.m = nm
+		return g, cmd
+	}
+	return next, cmd
+}
+
+func (g crashGuard) View() string {
+	defer g.recover(nil)
+	return g.m.View()
+}
+
+func (g crashGuard) recover(msg tea.Msg) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if path, err := logging.WriteCrashReport(crashReport(g.m, msg, r, debug.Stack())); err == nil {
+		crashFile = path
+	}
+	panic(r)
+}
+
+// crashReport is what goes in the crash file: enough of the state to reproduce
+// the panic, without container details people may not want to share
+func crashReport(m model, msg tea.Msg, r any, stack []byte) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "DockMate %s crashed at %s\n", version.Dockmate_Version, time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&b, "panic: %v\n\n", r)
+
+	fmt.Fprintf(&b, "os/arch:      %s/%s, %s\n", runtime.GOOS, runtime.GOARCH, runtime.Version())
+	fmt.Fprintf(&b, "runtime:      %s\n", m.runtimeLabel())
+	fmt.Fprintf(&b, "message:      %T\n", msg)
+	fmt.Fprintf(&b, "terminal:     %dx%d\n", m.terminalWidth, m.terminalHeight)
+	fmt.Fprintf(&b, "mode:         %d (compose view %t, column mode %t)\n", m.currentMode, m.composeViewMode, m.columnMode)
+	fmt.Fprintf(&b, "cursor:       %d, offset %d, %d rows\n", m.cursor, m.offset, m.listHeight)
+	fmt.Fprintf(&b, "containers:   %d, %d compose project(s), %d tree rows\n", len(m.containers), len(m.projects), len(m.flatList))
+	fmt.Fprintf(&b, "sort:         %s asc=%t\n", sortColumnNames[m.sortBy], m.sortAsc)
+	fmt.Fprintf(&b, "panels:       logs %t, info %t, tasks %t, events %t\n", m.logsVisible, m.infoVisible, m.taskVisible, m.eventsVisible)
+	fmt.Fprintf(&b, "disconnected: %t\n\n", m.disconnected)
+
+	b.Write(stack)
+	return b.String()
+}
@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// recentRestarts counts the container's restarts within alerts.crash_loop_minutes
+func (m model) recentRestarts(c docker.Container) int {
+	window := time.Duration(m.settings.CrashLoopMinutes) * time.Minute
+	return m.restartTracker.Recent(c.ID, window, time.Now())
+}
+
+func (m model) crashLooping(c docker.Container) bool {
+	return m.recentRestarts(c) > m.settings.CrashLoopRestarts
+}
+
+func (m model) oomKilled(c docker.Container) bool {
+	return m.inspectInfo[c.ID].OOMKilled
+}
+
+// crashDescription is the info panel text, empty for containers that look healthy
+func (m model) crashDescription(c docker.Container) string {
+	info, ok := m.inspectInfo[c.ID]
+	if !ok {
+		return ""
+	}
+	var desc string
+	if info.OOMKilled {
+		desc = fmt.Sprintf("OOM-killed (exit %d)", info.ExitCode)
+		if !info.FinishedAt.IsZero() {
+			desc += fmt.Sprintf(" %s ago", docker.FormatAge(time.Since(info.FinishedAt)))
+		}
+	}
+	if n := m.recentRestarts(c); n > 0 {
+		if desc != "" {
+			desc += ", "
+		}
+		desc += fmt.Sprintf("restarted %d times in the last %d minutes", n, m.settings.CrashLoopMinutes)
+		if m.crashLooping(c) {
+			desc = "crash loop: " + desc
+		}
+		desc += fmt.Sprintf(", last exit code %d", info.ExitCode)
+	}
+	if desc != "" && info.RestartCount > 0 {
+		desc += fmt.Sprintf(" (%d restarts in total)", info.RestartCount)
+	}
+	return desc
+}
@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/config"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// keys handled outside of Keys, a custom command can't use them either
+var reservedKeys = []string{"esc", "ctrl+c", "q", "`", " ", "tab", "enter", "f1", "f2", "l", "L", "h", "left", "right", "c", "C"}
+
+// commandData is what a custom command's template can use
+type commandData struct {
+	ID      string
+	Name    string
+	Image   string
+	State   string
+	Project string
+	Service string
+}
+
+// normalizeKey makes "F6" or "Ctrl+T" match how bubbletea names keys; single
+// characters keep their case, "T" and "t" are different keys
+func normalizeKey(k string) string {
+	k = strings.TrimSpace(k)
+	if len([]rune(k)) > 1 {
+		return strings.ToLower(k)
+	}
+	return k
+}
+
+// keyTaken reports whether DockMate already uses k
+func keyTaken(k string) bool {
+	if slices.Contains(reservedKeys, k) {
+		return true
+	}
+	v := reflect.ValueOf(Keys)
+	for i := 0; i < v.NumField(); i++ {
+		if b, ok := v.Field(i).Interface().(key.Binding); ok && slices.Contains(b.Keys(), k) {
+			return true
+		}
+	}
+	return false
+}
+
+// customCommandFor returns the configured command bound to k
+func (m model) customCommandFor(k string) (config.CustomCommand, bool) {
+	for _, cc := range m.settings.Commands {
+		if normalizeKey(cc.Key) == k {
+			return cc, true
+		}
+	}
+	return config.CustomCommand{}, false
+}
+
+// shadowedCommandKeys lists configured keys DockMate already uses, those
+// commands and templates never run
+func (m model) shadowedCommandKeys() []string {
+	var out []string
+	for _, cc := range m.settings.Commands {
+		if k := normalizeKey(cc.Key); keyTaken(k) {
+			out = append(out, k)
+		}
+	}
+	for _, t := range m.settings.Templates {
+		if k := normalizeKey(t.Key); k != "" && keyTaken(k) {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// commandData is the selected container for command templates, false when
+// there's none
+func (m model) commandData() (commandData, bool) {
+	c := m.selectedContainer()
+	if c == nil {
+		return commandData{}, false
+	}
+	return commandData{
+		ID:      c.ID,
+		Name:    docker.ContainerName(*c),
+		Image:   c.Image,
+		State:   c.State,
+		Project: c.ComposeProject,
+		Service: c.ComposeService,
+	}, true
+}
+
+// fillTemplate executes text as a Go template over data
+func fillTemplate(name, text string, data commandData) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// runCustomCommand fills in the command's template from the selected container
+// and hands it the terminal until it exits
+func (m *model) runCustomCommand(cc config.CustomCommand) tea.Cmd {
+	label := cc.Name
+	if label == "" {
+		label = cc.Command
+	}
+
+	data, selected := m.commandData()
+	if !selected && strings.Contains(cc.Command, "{{") {
+		m.statusMessage = fmt.Sprintf("Select a container to run %s on", label)
+		return nil
+	}
+	command, err := fillTemplate(cc.Key, cc.Command, data)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Bad command for %s: %v", cc.Key, err)
+		return nil
+	}
+
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.Command("cmd", "/C", command)
+	} else {
+		c = exec.Command("sh", "-c", command)
+	}
+	m.statusMessage = fmt.Sprintf("Running %s...", label)
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		if err != nil {
+			return actionDoneMsg{err: fmt.Errorf("%s: %v", label, err)}
+		}
+		return actionDoneMsg{msg: fmt.Sprintf("%s finished", label)}
+	})
+}
@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+	"github.com/shubh-io/dockmate/pkg/version"
+)
+
+type daemonInfoMsg struct {
+	info docker.DaemonInfo
+	err  error
+}
+
+func daemonInfoCmd() tea.Cmd {
+	return func() tea.Msg {
+		info, err := docker.GetDaemonInfo()
+		return daemonInfoMsg{info: info, err: err}
+	}
+}
+
+func (m *model) openDaemonInfo() tea.Cmd {
+	m.daemonInfo = docker.DaemonInfo{}
+	m.daemonInfoErr = nil
+	m.daemonInfoLoading = true
+	m.returnMode = m.currentMode
+	m.currentMode = modeDaemonInfo
+	m.statusMessage = ""
+	return daemonInfoCmd()
+}
+
+func (m *model) handleDaemonInfo(msg daemonInfoMsg) {
+	m.daemonInfoLoading = false
+	m.daemonInfoErr = msg.err
+	m.daemonInfo = msg.info
+}
+
+// daemonInfoFields are the label/value rows of the screen, and of the copied report
+func daemonInfoFields(info docker.DaemonInfo) [][2]string {
+	mirrors := "none"
+	if len(info.RegistryMirrors) > 0 {
+		mirrors = strings.Join(info.RegistryMirrors, ", ")
+	}
+	cgroup := info.CgroupVersion
+	if info.CgroupDriver != "" {
+		cgroup = fmt.Sprintf("v%s (%s)", info.CgroupVersion, info.CgroupDriver)
+	}
+	runtime := info.Runtime
+	if runtime == "podman" {
+		runtime += " (" + docker.PodmanMode() + ")"
+	}
+	return [][2]string{
+		{"DockMate", version.Dockmate_Version},
+		{"Runtime", runtime},
+		{"Server version", info.ServerVersion},
+		{"OS", info.OS},
+		{"Kernel", info.Kernel},
+		{"Architecture", info.Arch},
+		{"CPUs", strconv.Itoa(info.CPUs)},
+		{"Memory", docker.FormatBytes(info.MemTotal)},
+		{"Storage driver", info.StorageDriver},
+		{"Cgroup", cgroup},
+		{"Containers", fmt.Sprintf("%d (%d running)", info.Containers, info.Running)},
+		{"Images", strconv.Itoa(info.Images)},
+		{"Root dir", info.RootDir},
+		{"Registry mirrors", mirrors},
+	}
+}
+
+// daemonInfoReport is the plain text copied with y, for pasting into an issue
+func daemonInfoReport(info docker.DaemonInfo) string {
+	var b strings.Builder
+	for _, f := range daemonInfoFields(info) {
+		fmt.Fprintf(&b, "%s: %s\n", f[0], f[1])
+	}
+	for _, w := range info.Warnings {
+		fmt.Fprintf(&b, "%s\n", w)
+	}
+	return b.String()
+}
+
+func (m model) updateDaemonInfo(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "!":
+		m.currentMode = m.returnMode
+		m.statusMessage = "Daemon info closed"
+	case "y", "Y":
+		if !m.daemonInfoLoading && m.daemonInfoErr == nil {
+			m.copyToClipboard(daemonInfoReport(m.daemonInfo), "daemon info")
+		}
+	case "f5":
+		if !m.daemonInfoLoading {
+			m.daemonInfoLoading = true
+			m.daemonInfoErr = nil
+			return m, daemonInfoCmd()
+		}
+	}
+	return m, nil
+}
+
+func (m model) renderDaemonInfo(width int) string {
+	var b strings.Builder
+
+	b.WriteString(m.renderTitleBar(width))
+	b.WriteString("\n")
+	title := "Daemon info"
+	if m.daemonInfo.Runtime != "" {
+		title = fmt.Sprintf("%s info", m.daemonInfo.Runtime)
+	}
+	b.WriteString(titleStyle.Render(padRight(truncateToWidth(title, width-2), width-2)))
+	b.WriteString("\n")
+
+	// title bar, title and footer
+	rows := max(1, m.terminalHeight-4)
+	var lines []string
+	switch {
+	case m.daemonInfoLoading:
+		lines = append(lines, normalStyle.Render("  Asking the daemon..."))
+	case m.daemonInfoErr != nil:
+		lines = append(lines, messageStyle.Render(truncateToWidth("  "+m.daemonInfoErr.Error(), width)))
+	default:
+		for _, f := range daemonInfoFields(m.daemonInfo) {
+			value := f[1]
+			if value == "" {
+				value = "─"
+			}
+			lines = append(lines, " "+infoLabelStyle.Render(fmt.Sprintf("%-18s", f[0]))+infoValueStyle.Render(truncateToWidth(value, width-20)))
+		}
+		if len(m.daemonInfo.Warnings) > 0 {
+			lines = append(lines, "")
+			for _, w := range m.daemonInfo.Warnings {
+				lines = append(lines, degradedStyle.Render(" "+truncateToWidth(w, width-1)))
+			}
+		}
+	}
+	for i := 0; i < rows; i++ {
+		if i < len(lines) {
+			b.WriteString(lines[i])
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.renderFilesFooter(width, [][2]string{{"y", "copy for a bug report"}, {"F5", "reload"}, {"Esc", "close"}}))
+	return b.String()
+}
@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// the dependency screen draws a compose project's depends_on (and links) as
+// a tree: each service with what it waits for underneath, and the state of
+// its containers, so a service stuck waiting on an unhealthy one stands out.
+// It's built from the labels of the listed containers and follows refreshes.
+
+// projectContainers are the listed containers of a compose project
+func (m model) projectContainers(project string) []docker.Container {
+	if p, ok := m.projects[project]; ok {
+		return p.Containers
+	}
+	var out []docker.Container
+	for _, c := range m.containers {
+		if c.ComposeProject == project {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// openDepGraph shows the tree of the project under the cursor
+func (m *model) openDepGraph() {
+	project, _ := m.getSelectedProject()
+	if project == "" {
+		if c := m.selectedContainer(); c != nil {
+			project = c.ComposeProject
+		}
+	}
+	if project == "" || len(m.projectContainers(project)) == 0 {
+		m.statusMessage = "Select a compose project or one of its containers to see its dependencies"
+		return
+	}
+	m.graphProject = project
+	m.graphOffset = 0
+	m.returnMode = m.currentMode
+	m.currentMode = modeDepGraph
+	m.statusMessage = ""
+}
+
+// depGraphLines draws the tree, one line per service
+func depGraphLines(roots []docker.DepNode) []string {
+	var lines []string
+	var walk func(n docker.DepNode, prefix, branch string)
+	walk = func(n docker.DepNode, prefix, branch string) {
+		line := prefix + branch + n.Service
+		if n.Condition != "" {
+			line += " " + infoLabelStyle.Render(strings.TrimPrefix(n.Condition, "service_"))
+		}
+		switch {
+		case n.Cycle:
+			line += " " + stoppedStyle.Render("(cycle)")
+		case len(n.Containers) == 0:
+			line += " " + infoLabelStyle.Render("no container")
+		default:
+			c := n.Containers[0]
+			state := c.Status
+			if len(n.Containers) > 1 {
+				state = fmt.Sprintf("%s (×%d)", state, len(n.Containers))
+			}
+			style := normalStyle
+			switch strings.ToLower(c.State) {
+			case "running":
+				style = runningStyle
+			case "paused":
+				style = pausedStyle
+			case "exited", "dead":
+				style = stoppedStyle
+			}
+			if strings.Contains(c.Status, "unhealthy") {
+				style = stoppedStyle
+			}
+			line += "  " + style.Render(state)
+		}
+		lines = append(lines, line)
+
+		childPrefix := prefix
+		switch branch {
+		case "├─ ":
+			childPrefix += "│  "
+		case "└─ ":
+			childPrefix += "   "
+		}
+		for i, child := range n.Children {
+			b := "├─ "
+			if i == len(n.Children)-1 {
+				b = "└─ "
+			}
+			walk(child, childPrefix, b)
+		}
+	}
+	for i, root := range roots {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		walk(root, " ", "")
+	}
+	return lines
+}
+
+// startOrder is the order compose starts the services in, stage by stage
+func startOrder(containers []docker.Container) string {
+	stages := docker.StopStages(append([]docker.Container(nil), containers...))
+	var parts []string
+	for i := len(stages) - 1; i >= 0; i-- {
+		var services []string
+		for _, c := range stages[i] {
+			if len(services) == 0 || services[len(services)-1] != c.ComposeService {
+				services = append(services, c.ComposeService)
+			}
+		}
+		parts = append(parts, strings.Join(services, ", "))
+	}
+	return strings.Join(parts, " → ")
+}
+
+func (m model) depGraphPageSize() int {
+	// title bar, title, start order, blank line and footer
+	return max(1, m.terminalHeight-6)
+}
+
+func (m model) updateDepGraph(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	lines := len(depGraphLines(docker.DependencyTree(m.projectContainers(m.graphProject))))
+	last := max(0, lines-m.depGraphPageSize())
+	switch msg.String() {
+	case "esc", "q", "ctrl+g":
+		m.currentMode = m.returnMode
+		m.statusMessage = "Dependencies closed"
+	case "up", "k":
+		m.graphOffset = max(0, m.graphOffset-1)
+	case "down", "j":
+		m.graphOffset = min(last, m.graphOffset+1)
+	case "pgup":
+		m.graphOffset = max(0, m.graphOffset-m.depGraphPageSize())
+	case "pgdown":
+		m.graphOffset = min(last, m.graphOffset+m.depGraphPageSize())
+	}
+	return m, nil
+}
+
+func (m model) renderDepGraph(width int) string {
+	var b strings.Builder
+
+	b.WriteString(m.renderTitleBar(width))
+	b.WriteString("\n")
+	b.WriteString(titleStyle.Render(padRight(truncateToWidth(fmt.Sprintf("Dependencies of %s", m.projectLabel(m.graphProject)), width-2), width-2)))
+	b.WriteString("\n")
+
+	containers := m.projectContainers(m.graphProject)
+	b.WriteString(infoLabelStyle.Render(truncateToWidth(" Start order: "+startOrder(containers), width)))
+	b.WriteString("\n\n")
+
+	rows := m.depGraphPageSize()
+	lines := depGraphLines(docker.DependencyTree(containers))
+	offset := min(m.graphOffset, max(0, len(lines)-rows))
+	for i := offset; i < offset+rows; i++ {
+		if i < len(lines) {
+			b.WriteString(lines[i])
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.renderFilesFooter(width, [][2]string{{"↑↓", "scroll"}, {"Esc", "close"}}))
+	return b.String()
+}
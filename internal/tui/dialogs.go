@@ -0,0 +1,204 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// menuItem is one choice in a menu dialog, picked by pressing its key
+type menuItem struct {
+	key    string
+	label  string
+	action func(m *model) tea.Cmd
+}
+
+// confirm shows a y/n dialog and runs action on "y".
+// action gets the live model, the one confirm was called on is a stale copy by then.
+func (m *model) confirm(message string, action func(m *model) tea.Cmd) {
+	m.confirmMessage = message
+	m.pendingAction = action
+	if m.currentMode != modeConfirmation && m.currentMode != modeMenu && m.currentMode != modePrompt {
+		m.returnMode = m.currentMode
+	}
+	m.currentMode = modeConfirmation
+}
+
+// openMenu shows a small dialog listing items; esc closes it
+func (m *model) openMenu(title string, items []menuItem) {
+	m.menuTitle = title
+	m.menuItems = items
+	if m.currentMode != modeConfirmation && m.currentMode != modeMenu && m.currentMode != modePrompt {
+		m.returnMode = m.currentMode
+	}
+	m.currentMode = modeMenu
+}
+
+func (m model) updateMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "esc" || msg.String() == "q" {
+		m.currentMode = m.returnMode
+		m.menuItems = nil
+		m.statusMessage = "Cancelled"
+		return m, nil
+	}
+
+	for _, it := range m.menuItems {
+		if msg.String() != it.key {
+			continue
+		}
+		m.currentMode = m.returnMode
+		m.menuItems = nil
+		if it.action == nil {
+			return m, nil
+		}
+		// the action may open a follow-up dialog (e.g. a confirmation)
+		cmd := it.action(&m)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// prompt asks for one line of text; enter hands it to onSubmit, esc cancels
+func (m *model) prompt(title, placeholder string, onSubmit func(m *model, value string) tea.Cmd) tea.Cmd {
+	input := textinput.New()
+	input.Placeholder = placeholder
+	input.CharLimit = 256
+	input.Width = 50
+	input.Focus()
+
+	m.promptTitle = title
+	m.promptInput = input
+	m.promptSubmit = onSubmit
+	if m.currentMode != modeConfirmation && m.currentMode != modeMenu && m.currentMode != modePrompt {
+		m.returnMode = m.currentMode
+	}
+	m.currentMode = modePrompt
+	return textinput.Blink
+}
+
+func (m model) updatePrompt(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "esc":
+			m.currentMode = m.returnMode
+			m.promptSubmit = nil
+			m.statusMessage = "Cancelled"
+			return m, nil
+		case "enter":
+			m.currentMode = m.returnMode
+			submit := m.promptSubmit
+			m.promptSubmit = nil
+			if submit == nil {
+				return m, nil
+			}
+			// may open a follow-up dialog
+			cmd := submit(&m, strings.TrimSpace(m.promptInput.Value()))
+			return m, cmd
+		}
+	}
+
+	var cmd tea.Cmd
+	m.promptInput, cmd = m.promptInput.Update(msg)
+	return m, cmd
+}
+
+func (m model) renderPrompt(width int) string {
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(m.promptTitle))
+	content.WriteString("\n\n")
+	content.WriteString(m.promptInput.View())
+	content.WriteString("\n\n")
+	content.WriteString(infoLabelStyle.Render("[Enter] ok  [Esc] cancel"))
+
+	dialog := lipgloss.NewStyle().
+		Width(60).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(accent).
+		Padding(1, 2).
+		Render(content.String())
+
+	return centerDialog(dialog, width, m.terminalHeight)
+}
+
+func (m model) renderMenu(width int) string {
+	dialogWidth := 60
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(m.menuTitle))
+	content.WriteString("\n\n")
+	for _, it := range m.menuItems {
+		content.WriteString(fmt.Sprintf("%s%s%s %s\n",
+			meterBracketStyle.Render("["),
+			footerKeyStyle.Render(it.key),
+			meterBracketStyle.Render("]"),
+			footerDescStyle.Render(it.label)))
+	}
+	content.WriteString("\n")
+	content.WriteString(infoLabelStyle.Render("[Esc] cancel"))
+
+	dialog := lipgloss.NewStyle().
+		Width(dialogWidth).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(accent).
+		Padding(1, 2).
+		Render(content.String())
+
+	return centerDialog(dialog, width, m.terminalHeight)
+}
+
+// centerDialog places a rendered dialog in the middle of the screen
+func centerDialog(dialog string, width, height int) string {
+	lines := strings.Split(dialog, "\n")
+
+	padTop := (height - len(lines)) / 2
+	if padTop < 0 {
+		padTop = 0
+	}
+	padLeft := (width - lipgloss.Width(dialog)) / 2
+	if padLeft < 0 {
+		padLeft = 0
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Repeat("\n", padTop))
+	for _, line := range lines {
+		b.WriteString(strings.Repeat(" ", padLeft) + line + "\n")
+	}
+	return b.String()
+}
+
+// openPruneMenu offers the bulk cleanup actions, each behind a confirmation
+func (m *model) openPruneMenu() {
+	prune := func(kind, what string) func(m *model) tea.Cmd {
+		return func(m *model) tea.Cmd {
+			m.confirm(fmt.Sprintf("ARE YOU SURE you want to remove all %s?", what), func(m *model) tea.Cmd {
+				return pruneCmd(kind, what)
+			})
+			return nil
+		}
+	}
+
+	m.openMenu("Cleanup", []menuItem{
+		{"c", "Remove all stopped containers (exited or never started)", prune("container", "stopped containers")},
+		{"i", "Remove dangling images", prune("image", "dangling images")},
+		{"v", "Remove unused volumes", prune("volume", "unused volumes")},
+	})
+}
+
+func pruneCmd(kind, what string) tea.Cmd {
+	return func() tea.Msg {
+		res, err := docker.Prune(kind)
+		if err != nil {
+			return actionDoneMsg{err: err}
+		}
+		msg := fmt.Sprintf("Removed %d %s", res.Deleted, what)
+		if res.Reclaimed != "" {
+			msg += fmt.Sprintf(", reclaimed %s", res.Reclaimed)
+		}
+		return actionDoneMsg{msg: msg}
+	}
+}
@@ -0,0 +1,191 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// the discovery screen lists the compose projects under compose.dirs that
+// aren't running, so they can be brought up without leaving DockMate. The
+// list and compose view only know the projects that have containers.
+
+type projectsDiscoveredMsg struct {
+	projects []docker.DefinedProject
+	err      error
+}
+
+func discoverProjectsCmd(dirs []string, depth int) tea.Cmd {
+	return func() tea.Msg {
+		expanded := make([]string, len(dirs))
+		for i, dir := range dirs {
+			expanded[i] = expandHome(dir)
+		}
+		projects, err := docker.DiscoverComposeProjects(expanded, depth)
+		return projectsDiscoveredMsg{projects: projects, err: err}
+	}
+}
+
+func (m *model) openDiscover() tea.Cmd {
+	if len(m.settings.ComposeDirs) == 0 {
+		m.statusMessage = "Set compose.dirs in the config to look for compose projects on disk"
+		return nil
+	}
+	m.discovered = nil
+	m.discoverErr = nil
+	m.discoverCursor = 0
+	m.discoverLoading = true
+	m.returnMode = m.currentMode
+	m.currentMode = modeDiscover
+	m.statusMessage = ""
+	return tea.Batch(discoverProjectsCmd(m.settings.ComposeDirs, m.settings.ComposeDepth), m.composeCheckCmd())
+}
+
+func (m *model) handleProjectsDiscovered(msg projectsDiscoveredMsg) {
+	m.discoverLoading = false
+	m.discovered = msg.projects
+	m.discoverErr = msg.err
+}
+
+// definedContainers counts the listed containers of a project found on disk,
+// matched by name or by the directory compose ran in
+func (m model) definedContainers(p docker.DefinedProject) (running, total int) {
+	seen := make(map[string]bool)
+	count := func(c docker.Container) {
+		if seen[c.ID] || (c.ComposeProject != p.Name && c.ComposeDirectory != p.Dir) {
+			return
+		}
+		seen[c.ID] = true
+		total++
+		if strings.ToLower(c.State) == "running" {
+			running++
+		}
+	}
+	for _, c := range m.containers {
+		count(c)
+	}
+	for _, proj := range m.projects {
+		for _, c := range proj.Containers {
+			count(c)
+		}
+	}
+	return running, total
+}
+
+// stoppedProjects are the discovered projects without a running container.
+// They're filtered on every look, a project started elsewhere drops out with
+// the next refresh.
+func (m model) stoppedProjects() []docker.DefinedProject {
+	var out []docker.DefinedProject
+	for _, p := range m.discovered {
+		if running, _ := m.definedContainers(p); running == 0 {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func (m model) updateDiscover(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	projects := m.stoppedProjects()
+	m.discoverCursor = min(m.discoverCursor, max(0, len(projects)-1))
+	switch msg.String() {
+	case "esc", "q", "ctrl+o":
+		m.currentMode = m.returnMode
+		m.discovered = nil
+		m.statusMessage = "Discovery closed"
+	case "up", "k":
+		m.discoverCursor = max(0, m.discoverCursor-1)
+	case "down", "j":
+		m.discoverCursor = min(max(0, len(projects)-1), m.discoverCursor+1)
+	case "f5":
+		if !m.discoverLoading {
+			m.discoverLoading = true
+			m.discoverErr = nil
+			return m, discoverProjectsCmd(m.settings.ComposeDirs, m.settings.ComposeDepth)
+		}
+	case "enter", "u", "U":
+		if m.refuseReadOnly() || m.refuseNoCompose() {
+			return m, nil
+		}
+		if len(projects) == 0 {
+			return m, nil
+		}
+		p := projects[m.discoverCursor]
+		m.currentMode = m.returnMode
+		m.discovered = nil
+		m.statusMessage = fmt.Sprintf("Starting project %s...", m.projectLabel(p.Name))
+		m.trackProjectUp(p.Name)
+		return m, composeActionCmd("up", p.Name, p.Dir)
+	}
+	return m, nil
+}
+
+// homeRelative shortens a path under the home directory to ~/...
+func homeRelative(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(home, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.Join("~", rel)
+	}
+	return path
+}
+
+func (m model) renderDiscover(width int) string {
+	var b strings.Builder
+
+	b.WriteString(m.renderTitleBar(width))
+	b.WriteString("\n")
+	b.WriteString(titleStyle.Render(padRight(truncateToWidth("Compose projects on disk that aren't running", width-2), width-2)))
+	b.WriteString("\n")
+	b.WriteString(infoLabelStyle.Render(truncateToWidth(" Searching "+strings.Join(m.settings.ComposeDirs, ", "), width)))
+	b.WriteString("\n\n")
+
+	// title bar, title, dirs, blank line and footer
+	rows := max(1, m.terminalHeight-6)
+	var lines []string
+	projects := m.stoppedProjects()
+	switch {
+	case m.discoverLoading:
+		lines = append(lines, normalStyle.Render("  Looking for compose files..."))
+	case len(projects) == 0:
+		lines = append(lines, normalStyle.Render("  Every compose project found is running"))
+	default:
+		cursor := min(m.discoverCursor, len(projects)-1)
+		offset := max(0, cursor-rows+1)
+		for i := offset; i < min(offset+rows, len(projects)); i++ {
+			p := projects[i]
+			services := fmt.Sprintf("%d services", len(p.Services))
+			if len(p.Services) == 1 {
+				services = "1 service"
+			}
+			if _, total := m.definedContainers(p); total > 0 {
+				services += fmt.Sprintf(", %d stopped", total)
+			}
+			line := fmt.Sprintf(" %s %s  %s", padRight(truncateToWidth(m.projectLabel(p.Name), 24), 24), padRight(services, 24), homeRelative(p.File))
+			line = padRight(truncateToWidth(line, width), width)
+			if i == cursor {
+				lines = append(lines, selectedStyle.Render(line))
+			} else {
+				lines = append(lines, normalStyle.Render(line))
+			}
+		}
+	}
+	if m.discoverErr != nil && len(lines) < rows {
+		lines = append(lines, messageStyle.Render(truncateToWidth("  "+m.discoverErr.Error(), width)))
+	}
+	for i := 0; i < rows; i++ {
+		if i < len(lines) {
+			b.WriteString(lines[i])
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.renderFilesFooter(width, [][2]string{{"Enter", "up"}, {"F5", "rescan"}, {"Esc", "close"}}))
+	return b.String()
+}
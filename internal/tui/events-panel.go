@@ -0,0 +1,202 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// the events panel tails `docker events` below the list, in place of the
+// logs/info panel. The task panel still wins while it's open.
+
+const (
+	maxEvents   = 500
+	eventsSince = 15 * time.Minute // history shown when the panel opens
+)
+
+var (
+	eventBadStyle  = lipgloss.NewStyle().Foreground(meterRed)
+	eventWarnStyle = lipgloss.NewStyle().Foreground(yellowColor)
+	eventGoodStyle = lipgloss.NewStyle().Foreground(meterGreen)
+)
+
+// both carry their stream's channel, so a stream closed a moment ago can't
+// feed the one opened after it
+type eventMsg struct {
+	ch chan tea.Msg
+	ev docker.Event
+}
+
+type eventsEndedMsg struct {
+	ch  chan tea.Msg
+	err error
+}
+
+func waitForEvent(ch chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+func (m *model) openEvents() tea.Cmd {
+	if m.logsVisible || m.infoVisible {
+		m.statusMessage = "Close the logs or info panel first"
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan tea.Msg, 64)
+	m.eventsCh = ch
+	m.eventsCancel = cancel
+	m.events = nil
+	m.eventsErr = nil
+	m.eventsVisible = true
+	m.statusMessage = "Following container events"
+	m.updateViewport()
+
+	go func() {
+		err := docker.StreamEvents(ctx, eventsSince, func(ev docker.Event) {
+			select {
+			case ch <- eventMsg{ch: ch, ev: ev}:
+			case <-ctx.Done():
+			}
+		})
+		select {
+		case ch <- eventsEndedMsg{ch: ch, err: err}:
+		case <-ctx.Done():
+		}
+	}()
+	return waitForEvent(ch)
+}
+
+func (m *model) closeEvents() {
+	if m.eventsCancel != nil {
+		m.eventsCancel()
+	}
+	m.eventsCancel = nil
+	m.eventsCh = nil
+	m.events = nil
+	m.eventsVisible = false
+	m.updateViewport()
+	m.statusMessage = "Events closed"
+}
+
+func (m *model) handleEvent(msg eventMsg) tea.Cmd {
+	if msg.ch != m.eventsCh {
+		return nil
+	}
+	m.events = append(m.events, msg.ev)
+	if len(m.events) > maxEvents {
+		m.events = m.events[len(m.events)-maxEvents:]
+	}
+	return waitForEvent(m.eventsCh)
+}
+
+func (m *model) handleEventsEnded(msg eventsEndedMsg) {
+	if msg.ch != m.eventsCh {
+		return
+	}
+	m.eventsErr = msg.err
+	if m.eventsErr == nil {
+		m.eventsErr = fmt.Errorf("the event stream ended, press H twice to reopen it")
+	}
+}
+
+// openEventsFilter narrows the panel to one container, empty shows them all again
+func (m *model) openEventsFilter() tea.Cmd {
+	initial := m.eventsFilter
+	if c := m.selectedContainer(); c != nil && initial == "" {
+		initial = docker.ContainerName(*c)
+	}
+	cmd := m.prompt("Show the events of container", "name or ID, empty for all", func(m *model, value string) tea.Cmd {
+		m.eventsFilter = value
+		if value == "" {
+			m.statusMessage = "Showing the events of all containers"
+		} else {
+			m.statusMessage = fmt.Sprintf("Showing the events of %s", value)
+		}
+		return nil
+	})
+	m.promptInput.SetValue(initial)
+	return cmd
+}
+
+// visibleEvents applies the filter set with /, by name or ID prefix
+func (m model) visibleEvents() []docker.Event {
+	if m.eventsFilter == "" {
+		return m.events
+	}
+	f := strings.ToLower(m.eventsFilter)
+	var out []docker.Event
+	for _, ev := range m.events {
+		if strings.Contains(strings.ToLower(ev.Name), f) || strings.HasPrefix(ev.ID, f) {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+func eventStyle(action string) lipgloss.Style {
+	switch {
+	case action == "oom", action == "die", action == "kill", action == "health_status: unhealthy":
+		return eventBadStyle
+	case strings.HasPrefix(action, "health_status"), action == "restart", action == "pause":
+		return eventWarnStyle
+	case action == "start", action == "unpause":
+		return eventGoodStyle
+	}
+	return normalStyle
+}
+
+func (m model) renderEventsPanel(width int) string {
+	var b strings.Builder
+
+	b.WriteString(dividerStyle.Render(strings.Repeat("─", width)))
+	b.WriteString("\n")
+
+	events := m.visibleEvents()
+	title := "Container events (/ filter, Esc to close)"
+	if m.eventsFilter != "" {
+		title = fmt.Sprintf("Container events of %s (/ filter, Esc to close)", m.eventsFilter)
+	}
+	b.WriteString(titleStyle.Render(padRight(truncateToWidth(title, width-2), width-2)))
+	b.WriteString("\n")
+
+	maxLines := max(1, m.logPanelHeight-2)
+	var lines []string
+	if len(events) == 0 && m.eventsErr == nil {
+		lines = append(lines, normalStyle.Render(fmt.Sprintf("  No events in the last %d minutes", int(eventsSince.Minutes()))))
+	}
+	for _, ev := range events {
+		action := ev.Action
+		if ev.ExitCode != "" {
+			action += fmt.Sprintf(" (exit %s)", ev.ExitCode)
+		}
+		name := ev.Name
+		if name == "" {
+			name = shortID(ev.ID)
+		}
+		line := fmt.Sprintf("  %s  %-24s %s", ev.Time.Local().Format("15:04:05"), truncateToWidth(name, 24), action)
+		lines = append(lines, eventStyle(ev.Action).Render(truncateToWidth(line, width)))
+	}
+	if m.eventsErr != nil {
+		lines = append(lines, messageStyle.Render(truncateToWidth("  "+m.eventsErr.Error(), width)))
+	}
+
+	// newest at the bottom, like the logs
+	start := max(0, len(lines)-maxLines)
+	for i := start; i < len(lines); i++ {
+		b.WriteString(lines[i])
+		b.WriteString("\n")
+	}
+	for i := len(lines) - start; i < maxLines; i++ {
+		b.WriteString(normalStyle.Render(strings.Repeat(" ", width)))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
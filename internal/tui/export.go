@@ -0,0 +1,212 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+type runCommandMsg struct {
+	container string
+	command   string
+	err       error
+}
+
+func runCommandCmd(c docker.Container) tea.Cmd {
+	return func() tea.Msg {
+		command, err := docker.RunCommand(c.ID)
+		return runCommandMsg{container: docker.ContainerName(c), command: command, err: err}
+	}
+}
+
+type composeFileMsg struct {
+	container string
+	service   string
+	yaml      []byte
+	err       error
+}
+
+func composeFileCmd(c docker.Container) tea.Cmd {
+	return func() tea.Msg {
+		service, out, err := docker.ComposeFile(c.ID)
+		return composeFileMsg{container: docker.ContainerName(c), service: service, yaml: out, err: err}
+	}
+}
+
+// openExportMenu offers the ways to get a container's setup out of DockMate
+func (m *model) openExportMenu(c docker.Container) {
+	m.openMenu(fmt.Sprintf("Export %s", docker.ContainerName(c)), []menuItem{
+		{key: "r", label: "Show the run command", action: func(m *model) tea.Cmd {
+			m.statusMessage = fmt.Sprintf("Reading the setup of %s...", docker.ContainerName(c))
+			return runCommandCmd(c)
+		}},
+		{key: "c", label: "Compose file (standalone containers)", action: func(m *model) tea.Cmd {
+			return m.exportCompose(c)
+		}},
+		{key: "i", label: "Commit to a new image", action: func(m *model) tea.Cmd {
+			// commit pauses the container and creates an image
+			if m.refuseReadOnly() {
+				return nil
+			}
+			return m.openCommitPrompt(c)
+		}},
+		{key: "t", label: "Export the filesystem to a tarball", action: func(m *model) tea.Cmd {
+			return m.openExportPrompt(c)
+		}},
+	})
+}
+
+// snapshotNames are the defaults offered for a commit ("web-snapshot:20240601-1530")
+// and a tarball ("web-snapshot-20240601-1530.tar")
+func snapshotNames(c docker.Container) (image, tarball string) {
+	base := strings.ToLower(docker.ContainerName(c)) + "-snapshot"
+	stamp := time.Now().Format("20060102-1504")
+	return base + ":" + stamp, base + "-" + stamp + ".tar"
+}
+
+func (m *model) openCommitPrompt(c docker.Container) tea.Cmd {
+	name := docker.ContainerName(c)
+	cmd := m.prompt(fmt.Sprintf("Commit %s as image", name), "repo:tag", func(m *model, image string) tea.Cmd {
+		if image == "" {
+			m.statusMessage = "Cancelled"
+			return nil
+		}
+		m.statusMessage = fmt.Sprintf("Committing %s to %s...", name, image)
+		return func() tea.Msg {
+			id, err := docker.CommitContainer(c.ID, image)
+			if err != nil {
+				return actionDoneMsg{err: err}
+			}
+			return actionDoneMsg{msg: fmt.Sprintf("Committed %s to %s (%s)", name, image, shortID(id))}
+		}
+	})
+	image, _ := snapshotNames(c)
+	m.promptInput.SetValue(image)
+	return cmd
+}
+
+func (m *model) openExportPrompt(c docker.Container) tea.Cmd {
+	name := docker.ContainerName(c)
+	cmd := m.prompt(fmt.Sprintf("Export the filesystem of %s to", name), "path", func(m *model, path string) tea.Cmd {
+		if path == "" {
+			m.statusMessage = "Cancelled"
+			return nil
+		}
+		path = expandHome(path)
+		export := func(m *model) tea.Cmd {
+			// can take a while for big containers, the task panel shows it's still going
+			return m.startTask(fmt.Sprintf("Export %s", name), func(onLine func(string)) (string, error) {
+				onLine(fmt.Sprintf("Writing %s...", path))
+				if err := docker.ExportContainer(c.ID, path); err != nil {
+					return "", err
+				}
+				size := ""
+				if info, err := os.Stat(path); err == nil {
+					size = fmt.Sprintf(" (%s)", docker.FormatBytes(info.Size()))
+				}
+				return fmt.Sprintf("Exported %s to %s%s", name, path, size), nil
+			})
+		}
+		if _, err := os.Stat(path); err == nil {
+			m.confirm(fmt.Sprintf("%s exists. Overwrite it?", path), export)
+			return nil
+		}
+		return export(m)
+	})
+	_, tarball := snapshotNames(c)
+	m.promptInput.SetValue(tarball)
+	return cmd
+}
+
+// shortID cuts an image ID to the 12 characters docker shows
+func shortID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	return id[:min(len(id), 12)]
+}
+
+// expandHome turns a leading ~/ into the home directory
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+func (m *model) handleRunCommand(msg runCommandMsg) {
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Can't rebuild the run command of %s: %v", msg.container, msg.err)
+		return
+	}
+	m.statusMessage = ""
+	m.openMenu(msg.command, []menuItem{
+		{key: "c", label: "Copy to the clipboard", action: func(m *model) tea.Cmd {
+			m.copyToClipboard(msg.command, "run command")
+			return nil
+		}},
+	})
+}
+
+// exportCompose turns a standalone container into a compose file
+func (m *model) exportCompose(c docker.Container) tea.Cmd {
+	if c.ComposeProject != "" {
+		m.statusMessage = fmt.Sprintf("%s already belongs to compose project %s", docker.ContainerName(c), m.projectLabel(c.ComposeProject))
+		return nil
+	}
+	m.statusMessage = fmt.Sprintf("Generating a compose file for %s...", docker.ContainerName(c))
+	return composeFileCmd(c)
+}
+
+func (m *model) handleComposeFile(msg composeFileMsg) {
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Can't generate a compose file for %s: %v", msg.container, msg.err)
+		return
+	}
+	m.statusMessage = ""
+
+	lines := strings.Count(string(msg.yaml), "\n")
+	m.openMenu(fmt.Sprintf("Compose file for %s (%d lines)", msg.container, lines), []menuItem{
+		{key: "w", label: "Write to a file", action: func(m *model) tea.Cmd {
+			cmd := m.prompt("Write compose file to", "path", func(m *model, path string) tea.Cmd {
+				return m.writeComposeFile(path, msg.yaml)
+			})
+			m.promptInput.SetValue(msg.service + "-compose.yml")
+			return cmd
+		}},
+		{key: "c", label: "Copy to the clipboard", action: func(m *model) tea.Cmd {
+			m.copyToClipboard(string(msg.yaml), "compose file")
+			return nil
+		}},
+	})
+}
+
+// writeComposeFile asks before replacing an existing file
+func (m *model) writeComposeFile(path string, data []byte) tea.Cmd {
+	if path == "" {
+		m.statusMessage = "Cancelled"
+		return nil
+	}
+	path = expandHome(path)
+
+	write := func(m *model) tea.Cmd {
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			m.statusMessage = fmt.Sprintf("Error: %v", err)
+			return nil
+		}
+		abs, _ := filepath.Abs(path)
+		m.statusMessage = fmt.Sprintf("Wrote %s", abs)
+		return nil
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		m.confirm(fmt.Sprintf("%s exists. Overwrite it?", path), write)
+		return nil
+	}
+	return write(m)
+}
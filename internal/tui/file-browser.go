@@ -0,0 +1,310 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+const previewLimit = 256 * 1024
+
+type filesListMsg struct {
+	path    string
+	entries []docker.FileEntry
+	err     error
+	// open the path as a file if it turns out not to be a directory (symlinks)
+	previewOnError bool
+}
+
+type filePreviewMsg struct {
+	path      string
+	lines     []string
+	truncated bool
+	err       error
+}
+
+func listDirCmd(containerID, dir string, previewOnError bool) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := docker.ListDir(containerID, dir)
+		return filesListMsg{path: dir, entries: entries, err: err, previewOnError: previewOnError}
+	}
+}
+
+func previewFileCmd(containerID, filePath string) tea.Cmd {
+	return func() tea.Msg {
+		data, truncated, err := docker.ReadFile(containerID, filePath, previewLimit)
+		if err != nil {
+			return filePreviewMsg{path: filePath, err: err}
+		}
+		if bytes.IndexByte(data, 0) >= 0 {
+			return filePreviewMsg{path: filePath, lines: []string{"(binary file, press D to download it)"}}
+		}
+		text := strings.ReplaceAll(string(data), "\t", "    ")
+		return filePreviewMsg{path: filePath, lines: strings.Split(text, "\n"), truncated: truncated}
+	}
+}
+
+func downloadCmd(containerID, srcPath string) tea.Cmd {
+	return func() tea.Msg {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return actionDoneMsg{err: err}
+		}
+		dest, err := docker.DownloadPath(containerID, srcPath, cwd)
+		if err != nil {
+			return actionDoneMsg{err: err}
+		}
+		return actionDoneMsg{msg: fmt.Sprintf("Saved %s to %s", srcPath, dest)}
+	}
+}
+
+// openFileBrowser switches to the file browser for the selected container
+func (m *model) openFileBrowser(c docker.Container) tea.Cmd {
+	if strings.ToLower(c.State) != "running" {
+		m.statusMessage = "The file browser needs a running container"
+		return nil
+	}
+	m.filesContainerID = c.ID
+	m.filesContainerName = docker.ContainerName(c)
+	m.filesPath = "/"
+	m.filesEntries = nil
+	m.filesCursor = 0
+	m.filesPreview = nil
+	m.filesErr = nil
+	m.returnMode = m.currentMode
+	m.currentMode = modeFiles
+	m.statusMessage = ""
+	return listDirCmd(c.ID, "/", false)
+}
+
+func (m *model) handleFilesList(msg filesListMsg) tea.Cmd {
+	if msg.err != nil {
+		if msg.previewOnError {
+			return previewFileCmd(m.filesContainerID, msg.path)
+		}
+		m.filesErr = msg.err
+		return nil
+	}
+
+	// coming back up, keep the cursor on the directory we left
+	prev := m.filesPath
+	m.filesPath = msg.path
+	m.filesEntries = msg.entries
+	m.filesErr = nil
+	m.filesCursor = 0
+	for i, e := range m.filesEntries {
+		if path.Join(m.filesPath, e.Name) == prev {
+			m.filesCursor = i
+			break
+		}
+	}
+	return nil
+}
+
+func (m *model) handleFilePreview(msg filePreviewMsg) {
+	if msg.err != nil {
+		m.filesErr = msg.err
+		return
+	}
+	m.filesPreview = msg.lines
+	if msg.truncated {
+		m.filesPreview = append(m.filesPreview, fmt.Sprintf("... (only the first %s shown)", docker.FormatBytes(previewLimit)))
+	}
+	m.filesPreviewPath = msg.path
+	m.filesPreviewScroll = 0
+	m.filesErr = nil
+}
+
+func (m model) selectedFilePath() (string, *docker.FileEntry) {
+	if m.filesCursor < 0 || m.filesCursor >= len(m.filesEntries) {
+		return "", nil
+	}
+	e := &m.filesEntries[m.filesCursor]
+	return path.Join(m.filesPath, e.Name), e
+}
+
+func (m model) filesPageSize() int {
+	// title, path, header, footer, status
+	n := m.terminalHeight - 6
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+func (m model) updateFiles(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.filesPreview != nil {
+		switch msg.String() {
+		case "esc", "q", "left", "h", "backspace":
+			m.filesPreview = nil
+		case "up", "k":
+			if m.filesPreviewScroll > 0 {
+				m.filesPreviewScroll--
+			}
+		case "down", "j":
+			if m.filesPreviewScroll < len(m.filesPreview)-1 {
+				m.filesPreviewScroll++
+			}
+		case "pgup":
+			m.filesPreviewScroll = max(0, m.filesPreviewScroll-m.filesPageSize())
+		case "pgdown", " ":
+			m.filesPreviewScroll = min(max(0, len(m.filesPreview)-1), m.filesPreviewScroll+m.filesPageSize())
+		case "d", "D":
+			m.statusMessage = fmt.Sprintf("Downloading %s...", m.filesPreviewPath)
+			return m, downloadCmd(m.filesContainerID, m.filesPreviewPath)
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		m.currentMode = m.returnMode
+		m.filesEntries = nil
+		m.statusMessage = "File browser closed"
+		return m, nil
+
+	case "up", "k":
+		if m.filesCursor > 0 {
+			m.filesCursor--
+		}
+
+	case "down", "j":
+		if m.filesCursor < len(m.filesEntries)-1 {
+			m.filesCursor++
+		}
+
+	case "pgup":
+		m.filesCursor = max(0, m.filesCursor-m.filesPageSize())
+
+	case "pgdown":
+		m.filesCursor = max(0, min(len(m.filesEntries)-1, m.filesCursor+m.filesPageSize()))
+
+	case "left", "h", "backspace":
+		if m.filesPath != "/" {
+			return m, listDirCmd(m.filesContainerID, path.Dir(m.filesPath), false)
+		}
+
+	case "enter", "right", "l":
+		p, e := m.selectedFilePath()
+		if e == nil {
+			return m, nil
+		}
+		switch {
+		case e.IsDir():
+			return m, listDirCmd(m.filesContainerID, p, false)
+		case e.IsLink():
+			return m, listDirCmd(m.filesContainerID, p, true)
+		default:
+			return m, previewFileCmd(m.filesContainerID, p)
+		}
+
+	case "d", "D":
+		if p, e := m.selectedFilePath(); e != nil {
+			m.statusMessage = fmt.Sprintf("Downloading %s...", p)
+			return m, downloadCmd(m.filesContainerID, p)
+		}
+
+	case "f5":
+		return m, listDirCmd(m.filesContainerID, m.filesPath, false)
+	}
+	return m, nil
+}
+
+func (m model) renderFileBrowser(width int) string {
+	var b strings.Builder
+
+	b.WriteString(m.renderTitleBar(width))
+	b.WriteString("\n")
+
+	rows := m.filesPageSize()
+
+	if m.filesPreview != nil {
+		b.WriteString(titleStyle.Render(padRight(fmt.Sprintf("%s:%s", m.filesContainerName, m.filesPreviewPath), width-2)))
+		b.WriteString("\n")
+		b.WriteString(dividerStyle.Render(strings.Repeat("─", width)))
+		b.WriteString("\n")
+
+		end := min(len(m.filesPreview), m.filesPreviewScroll+rows)
+		shown := 0
+		for _, line := range m.filesPreview[m.filesPreviewScroll:end] {
+			b.WriteString(normalStyle.Render(truncateToWidth(line, width)))
+			b.WriteString("\n")
+			shown++
+		}
+		for ; shown < rows; shown++ {
+			b.WriteString("\n")
+		}
+		b.WriteString(m.renderFilesFooter(width, [][2]string{{"↑↓", "scroll"}, {"D", "download"}, {"Esc", "back"}}))
+		return b.String()
+	}
+
+	b.WriteString(titleStyle.Render(padRight(fmt.Sprintf("%s:%s", m.filesContainerName, m.filesPath), width-2)))
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(padRight(fmt.Sprintf(" %-11s %10s  %-13s %s", "MODE", "SIZE", "MODIFIED", "NAME"), width)))
+	b.WriteString("\n")
+
+	shown := 0
+	if m.filesErr != nil {
+		b.WriteString(messageStyle.Render(truncateToWidth("  "+m.filesErr.Error(), width)))
+		b.WriteString("\n")
+		shown++
+	} else if len(m.filesEntries) == 0 {
+		b.WriteString(normalStyle.Render("  (empty)"))
+		b.WriteString("\n")
+		shown++
+	}
+
+	start := 0
+	if m.filesCursor >= rows-shown {
+		start = m.filesCursor - (rows - shown) + 1
+	}
+	for i := start; i < len(m.filesEntries) && shown < rows; i++ {
+		e := m.filesEntries[i]
+		name := e.Name
+		switch {
+		case e.IsDir():
+			name += "/"
+		case e.IsLink():
+			name += " -> " + e.LinkTarget
+		}
+		size := "─"
+		if e.Size >= 0 && !e.IsDir() {
+			size = docker.FormatBytes(e.Size)
+		}
+		line := padRight(truncateToWidth(fmt.Sprintf(" %-11s %10s  %-13s %s", e.Mode, size, e.Modified, name), width), width)
+
+		switch {
+		case i == m.filesCursor:
+			b.WriteString(selectedStyle.Render(line))
+		case e.IsDir():
+			b.WriteString(titleStyle.UnsetPadding().Render(line))
+		default:
+			b.WriteString(normalStyle.Render(line))
+		}
+		b.WriteString("\n")
+		shown++
+	}
+	for ; shown < rows; shown++ {
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.renderFilesFooter(width, [][2]string{{"↑↓", "move"}, {"Enter", "open"}, {"⌫", "up"}, {"D", "download"}, {"Esc", "close"}}))
+	return b.String()
+}
+
+func (m model) renderFilesFooter(width int, keys [][2]string) string {
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, footerKeyStyle.Render(k[0])+" "+footerDescStyle.Render(k[1]))
+	}
+	footer := strings.Join(parts, "  ")
+	if m.statusMessage != "" {
+		footer += "  " + messageStyle.Render(m.statusMessage)
+	}
+	return footer + "\n"
+}
@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+type gpuUsageMsg struct {
+	usage map[string]docker.GPUUsage
+	err   error
+}
+
+// gpuUsageCmd asks nvidia-smi about the containers that requested GPUs, it
+// follows each inspect so there's only work to do on GPU hosts
+func (m model) gpuUsageCmd() tea.Cmd {
+	if m.noNvidiaSMI {
+		return nil
+	}
+	requests := make(map[string]string)
+	for id, info := range m.inspectInfo {
+		if info.GPUs != "" {
+			requests[id] = info.GPUs
+		}
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		usage, err := docker.GPUUsageByContainer(requests)
+		return gpuUsageMsg{usage: usage, err: err}
+	}
+}
+
+func (m *model) handleGPUUsage(msg gpuUsageMsg) {
+	if errors.Is(msg.err, docker.ErrNoNvidiaSMI) {
+		// the GPUs stay listed as requested, without usage
+		m.noNvidiaSMI = true
+		return
+	}
+	if msg.err != nil {
+		return
+	}
+	m.gpuUsage = msg.usage
+}
+
+// gpuText is the GPU column, e.g. "87% 3.1GB"
+func (m model) gpuText(c docker.Container) string {
+	if m.inspectInfo[c.ID].GPUs == "" {
+		return "─"
+	}
+	usage, ok := m.gpuUsage[c.ID]
+	if !ok || strings.ToLower(c.State) != "running" {
+		return "GPU"
+	}
+	return fmt.Sprintf("%d%% %s", usage.Utilization, docker.FormatBytes(usage.MemUsed))
+}
+
+// gpuLess sorts by utilization, then memory, containers without GPUs first
+func (m model) gpuLess(a, b docker.Container) bool {
+	ua, ub := m.gpuUsage[a.ID], m.gpuUsage[b.ID]
+	if ua.Utilization != ub.Utilization {
+		return ua.Utilization < ub.Utilization
+	}
+	return ua.MemUsed < ub.MemUsed
+}
+
+// gpuDescription is the info panel's GPU line
+func (m model) gpuDescription(c docker.Container) string {
+	request := m.inspectInfo[c.ID].GPUs
+	if request == "" {
+		return ""
+	}
+	usage, ok := m.gpuUsage[c.ID]
+	switch {
+	case m.noNvidiaSMI:
+		return fmt.Sprintf("%s requested, install nvidia-smi to see the usage", request)
+	case !ok || strings.ToLower(c.State) != "running":
+		return fmt.Sprintf("%s requested", request)
+	}
+	indexes := make([]string, 0, len(usage.GPUs))
+	for _, i := range usage.GPUs {
+		indexes = append(indexes, strconv.Itoa(i))
+	}
+	return fmt.Sprintf("GPU %s: %d%% busy, %s of %s memory used by the container (%s requested)",
+		strings.Join(indexes, ","), usage.Utilization, docker.FormatBytes(usage.MemUsed), docker.FormatBytes(usage.MemTotal), request)
+}
@@ -13,30 +13,95 @@ func (m model) renderHelp(width int) string {
 }
 
 func getHelpItems(m model) []list.Item {
-	return []list.Item{
+	items := []list.Item{
 		item{"↑ / ↓", "Move cursor up/down"},
-		item{"← / →", "Navigate between pages"},
+		item{"← / →", "Scroll a screen up/down (also PgUp / PgDn)"},
+		item{"Ctrl+U / Ctrl+D", "Scroll half a screen up/down"},
 		item{"Tab", "Toggle column selection mode"},
 		item{"Enter", "Sort by selected column (in column mode)"},
+		item{"+", "Secondary sort by the selected column, for ties (in column mode)"},
+		item{"Enter", "Compose: expand/collapse the project under the cursor"},
 		item{"S", "Start selected container"},
 		item{"X", "Stop selected container"},
 		item{"R", "Restart selected container"},
-		item{"D", "Remove selected container"},
-		item{"E", fmt.Sprintf("Open interactive shell (%s)", m.settings.Shell)},
+		item{"D", "Remove selected container (asks, with --force and --volumes options)"},
+		item{"e", fmt.Sprintf("Open interactive shell (%s)", m.settings.Shell)},
+		item{"E", "Quick commands: ps, env, df, ss/netstat, os-release, without a shell"},
+		item{"Ctrl+A", "Start or stop all containers in view, or in the project under the cursor"},
+		item{"Ctrl+O", "Compose projects under compose.dirs that aren't running, to bring up"},
+		item{"Ctrl+G", "Compose: the project's depends_on tree and start order"},
+		item{"Ctrl+P", "Podman auto-update: dry run or update the io.containers.autoupdate containers"},
+		item{"Ctrl+E", "Podman: status and journal of the container's systemd unit, with start/stop/restart"},
+		item{"$", "Secrets: swarm secrets and configs, or podman secrets, what uses them, create and remove"},
+		item{"Ctrl+X", "Switch to another profile from config.yml (restarts)"},
+		item{"#", "Diff: files the container added, changed or deleted on top of its image"},
+		item{"Ctrl+Z", "Undo the last stop (start it again) or remove (recreate it), for 10s"},
+		item{"Ctrl+W", "Cancel actions waiting in the queue"},
+		item{"Ctrl+N", "Run a new container: image, name, ports, env, volumes, restart policy"},
 		item{"L", "View/Toggle logs (container or compose project)"},
+		item{"Space", "Mark container, L with 2+ marked tails their logs together"},
+		item{"W", "Logs: toggle wrapping of long lines"},
+		item{"{", "Logs: show JSON lines as time, level, message and fields"},
+		item{"Ctrl+S", "Logs: save the loaded lines to a file"},
+		item{"/", "Logs: show only or hide lines matching a regex, saved per container"},
+		item{"@", "Logs: toggle timestamps"},
+		item{"<", "Logs: show the last 5m, 15m, 1h, 6h or 24h"},
+		item{"Ctrl+F", "Follow logs full-screen (a tmux pane or window with exec.tmux)"},
 		item{"I", "View/Toggle container info"},
-		item{"U", "Compose: up / start project"},
+		item{"*", "Pin/unpin container (pinned sort first and alert on exit)"},
+		item{"G", "Pull latest image and recreate container"},
+		item{"B", "Browse the container's files (preview, download)"},
+		item{"y", "Copy name/ID/image, the info, or the logs on screen, all or matching"},
+		item{"Y", "Copy the whole row"},
+		item{"F", "Scan the image for vulnerabilities (trivy or grype)"},
+		item{"Z", "Export: run command, compose file, commit to an image, tarball"},
+		item{"M", "Image: start/stop/restart every container using it, history (tag, push)"},
+		item{"V", "Open the commit the image was built from (OCI labels)"},
+		item{"T", "Attach a note to the container, shown next to its name"},
+		item{"U", "Compose: up / start project, with the profiles to pick when it has any"},
+		item{"I", "Compose: files, env file, profiles and effective config of the project"},
 		item{"D", "Compose: down / stop project"},
 		item{"R", "Compose: restart project"},
 		item{"P", "Compose: pause/unpause project"},
 		item{"X", "Compose: stop all containers in project"},
+		item{"A", "Compose: set a display alias for the project"},
 		item{"C", "Toggle compose/normal view"},
 		item{"F2", "Open settings"},
+		item{"F3", "Browse the tags of a registry repository and pull one"},
+		item{"F4", "Registry logins: where you're logged in, log in to more"},
+		item{"O", "Port lookup: which container owns a host port?"},
+		item{"F6", "Check registries for image updates"},
+		item{"F8", "Cleanup: prune stopped containers, dangling images, unused volumes"},
+		item{"F9", "Swarm: services, stacks, nodes; scale, update, rollback"},
+		item{"!", "Daemon info: versions, storage driver, cgroups, mirrors (copy for bug reports)"},
+		item{"H", "Events panel: recent container events, / filters by container"},
+		item{"N", "Only show the containers on a network (Esc shows all again)"},
+		item{"1 / 2 / 3", "Only show running / exited / unhealthy containers (again shows all)"},
+		item{"4", "Only show the project under the cursor (again shows all)"},
+		item{"J", "Networks: connect the container to a network or disconnect it"},
+		item{"K", "Bind mounts: open a host path in the file manager, or copy them"},
+		item{"%", "Change the container's CPU and memory limits (docker update)"},
+		item{"=", "Change the container's restart policy"},
+		item{"Ctrl+R", "Pause/resume auto-refresh (F5 still refreshes)"},
 		item{"F1", "Show this help"},
 		item{"q", "Quit application"},
 		item{"Esc", "Back/Cancel"},
 	}
 
+	// user commands from the config
+	for _, cc := range m.settings.Commands {
+		desc := cc.Name
+		if desc == "" {
+			desc = cc.Command
+		}
+		items = append(items, item{normalizeKey(cc.Key), "Custom: " + desc})
+	}
+	for _, t := range m.settings.Templates {
+		if t.Key != "" {
+			items = append(items, item{normalizeKey(t.Key), fmt.Sprintf("Template: run %s (%s)", t.Name, t.Image)})
+		}
+	}
+	return items
 }
 
 type item struct {
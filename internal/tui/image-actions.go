@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// containersWithImage returns the listed containers created from image
+func (m model) containersWithImage(image string) []docker.Container {
+	var out []docker.Container
+	for _, c := range m.containers {
+		if c.Image == image {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// openImageActions offers start/stop/restart for every container using the
+// selected container's image, e.g. after pulling a patched tag, and the image's history
+func (m *model) openImageActions() {
+	c := m.selectedContainer()
+	if c == nil || c.Image == "" {
+		m.statusMessage = "Select a container to act on everything using its image"
+		return
+	}
+
+	selected := *c
+	image := c.Image
+	all := m.containersWithImage(image)
+
+	var running, stopped []docker.Container
+	for _, c := range all {
+		switch strings.ToLower(c.State) {
+		case "running", "paused", "restarting":
+			running = append(running, c)
+		default:
+			stopped = append(stopped, c)
+		}
+	}
+
+	label := fmt.Sprintf("image %s", image)
+	m.openMenu(fmt.Sprintf("%d container(s) using %s (%d running)", len(all), image, len(running)), []menuItem{
+		{key: "s", label: fmt.Sprintf("Start the %d stopped", len(stopped)), action: func(m *model) tea.Cmd {
+			for _, c := range stopped {
+				m.trackStarted(c.ID)
+			}
+			return m.runOnAll("start", label, stopped)
+		}},
+		{key: "x", label: fmt.Sprintf("Stop the %d running", len(running)), action: func(m *model) tea.Cmd {
+			return m.stopInOrder(label, running)
+		}},
+		{key: "r", label: fmt.Sprintf("Restart the %d running", len(running)), action: func(m *model) tea.Cmd {
+			return m.runOnAll("restart", label, running)
+		}},
+		{key: "h", label: "History of the image (layers, tag, push)", action: func(m *model) tea.Cmd {
+			return m.openImageHistory(selected)
+		}},
+	})
+}
+
+// runOnAll runs a container action on cs in parallel and reports once all are done
+func (m *model) runOnAll(action, label string, cs []docker.Container) tea.Cmd {
+	if len(cs) == 0 {
+		m.statusMessage = fmt.Sprintf("Nothing to %s for %s", action, label)
+		return nil
+	}
+	m.statusMessage = fmt.Sprintf("Running %s on %d container(s) using %s...", action, len(cs), label)
+
+	return func() tea.Msg {
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		var failed []string
+
+		for _, c := range cs {
+			wg.Add(1)
+			go func(c docker.Container) {
+				defer wg.Done()
+				if err := docker.ContainerAction(action, c); err != nil {
+					mu.Lock()
+					failed = append(failed, docker.ContainerName(c))
+					mu.Unlock()
+				}
+			}(c)
+		}
+		wg.Wait()
+
+		if len(failed) > 0 {
+			return actionDoneMsg{err: fmt.Errorf("%s failed for %s", action, strings.Join(failed, ", "))}
+		}
+		return actionDoneMsg{msg: fmt.Sprintf("Ran %s on %d container(s) using %s", action, len(cs), label)}
+	}
+}
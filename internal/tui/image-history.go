@@ -0,0 +1,186 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// how many of the largest layers get highlighted
+const biggestLayerCount = 3
+
+var bigLayerStyle = lipgloss.NewStyle().Foreground(yellowColor).Bold(true)
+
+type imageHistoryMsg struct {
+	image  string
+	layers []docker.ImageLayer
+	err    error
+}
+
+func imageHistoryCmd(image string) tea.Cmd {
+	return func() tea.Msg {
+		layers, err := docker.ImageHistory(image)
+		return imageHistoryMsg{image: image, layers: layers, err: err}
+	}
+}
+
+// openImageHistory shows the layers of the selected container's image
+func (m *model) openImageHistory(c docker.Container) tea.Cmd {
+	if c.Image == "" {
+		m.statusMessage = "This container has no image to inspect"
+		return nil
+	}
+	m.historyImage = c.Image
+	m.historyLayers = nil
+	m.historyErr = nil
+	m.historyCursor = 0
+	m.historyLoading = true
+	m.returnMode = m.currentMode
+	m.currentMode = modeHistory
+	m.statusMessage = ""
+	return imageHistoryCmd(c.Image)
+}
+
+func (m *model) handleImageHistory(msg imageHistoryMsg) {
+	if msg.image != m.historyImage {
+		return
+	}
+	m.historyLoading = false
+	m.historyErr = msg.err
+	m.historyLayers = msg.layers
+	m.historyBiggest = docker.BiggestLayers(msg.layers, biggestLayerCount)
+}
+
+func (m model) historyPageSize() int {
+	// title, image line, header, divider, 3 lines of command, footer
+	return max(1, m.terminalHeight-9)
+}
+
+func (m model) updateHistory(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.closeHistory()
+		m.statusMessage = "Image history closed"
+	case "t", "T":
+		if m.refuseReadOnly() {
+			return m, nil
+		}
+		// back to the list first, so the dialogs return there and the push shows in the task panel
+		m.closeHistory()
+		return m, m.openTagPrompt(m.historyImage)
+	case "p", "P":
+		if m.refuseReadOnly() {
+			return m, nil
+		}
+		m.closeHistory()
+		m.confirmPush(m.historyImage)
+	case "up", "k":
+		if m.historyCursor > 0 {
+			m.historyCursor--
+		}
+	case "down", "j":
+		if m.historyCursor < len(m.historyLayers)-1 {
+			m.historyCursor++
+		}
+	case "pgup":
+		m.historyCursor = max(0, m.historyCursor-m.historyPageSize())
+	case "pgdown":
+		m.historyCursor = max(0, min(len(m.historyLayers)-1, m.historyCursor+m.historyPageSize()))
+	case "f5":
+		m.historyLoading = true
+		return m, imageHistoryCmd(m.historyImage)
+	}
+	return m, nil
+}
+
+func (m *model) closeHistory() {
+	m.currentMode = m.returnMode
+	m.historyLayers = nil
+}
+
+func (m model) renderImageHistory(width int) string {
+	var b strings.Builder
+
+	b.WriteString(m.renderTitleBar(width))
+	b.WriteString("\n")
+
+	total := docker.TotalSize(m.historyLayers)
+	b.WriteString(titleStyle.Render(padRight(fmt.Sprintf("%s  (%d layers, %s)", m.historyImage, len(m.historyLayers), docker.FormatBytes(total)), width-2)))
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(padRight(fmt.Sprintf(" %9s %5s  %-8s %s", "SIZE", "%", "CREATED", "CREATED BY"), width)))
+	b.WriteString("\n")
+
+	rows := m.historyPageSize()
+	shown := 0
+	switch {
+	case m.historyLoading:
+		b.WriteString(normalStyle.Render("  Loading history..."))
+		b.WriteString("\n")
+		shown++
+	case m.historyErr != nil:
+		b.WriteString(messageStyle.Render(truncateToWidth("  "+m.historyErr.Error(), width)))
+		b.WriteString("\n")
+		shown++
+	}
+
+	start := 0
+	if m.historyCursor >= rows {
+		start = m.historyCursor - rows + 1
+	}
+	for i := start; i < len(m.historyLayers) && shown < rows && !m.historyLoading; i++ {
+		l := m.historyLayers[i]
+		size, share := "─", ""
+		if l.Size >= 0 {
+			size = docker.FormatBytes(l.Size)
+			if total > 0 {
+				share = fmt.Sprintf("%.0f%%", float64(l.Size)*100/float64(total))
+			}
+		}
+		created := "─"
+		if !l.Created.IsZero() {
+			created = docker.FormatAge(time.Since(l.Created))
+		}
+		line := padRight(truncateToWidth(fmt.Sprintf(" %9s %5s  %-8s %s", size, share, created, l.CreatedBy), width), width)
+
+		switch {
+		case i == m.historyCursor:
+			b.WriteString(selectedStyle.Render(line))
+		case m.historyBiggest[i]:
+			b.WriteString(bigLayerStyle.Render(line))
+		default:
+			b.WriteString(normalStyle.Render(line))
+		}
+		b.WriteString("\n")
+		shown++
+	}
+	for ; shown < rows; shown++ {
+		b.WriteString("\n")
+	}
+
+	// the full command of the selected layer, it rarely fits on its row
+	b.WriteString(dividerStyle.Render(strings.Repeat("─", width)))
+	b.WriteString("\n")
+	var detail []string
+	if m.historyCursor < len(m.historyLayers) {
+		l := m.historyLayers[m.historyCursor]
+		detail = wrapText(l.CreatedBy, width-2)
+		if l.Comment != "" {
+			detail = append(detail, "# "+l.Comment)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		line := ""
+		if i < len(detail) {
+			line = detail[i]
+		}
+		b.WriteString(infoValueStyle.Render(" " + truncateToWidth(line, width-1)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.renderFilesFooter(width, [][2]string{{"↑↓", "move"}, {"T", "tag"}, {"P", "push"}, {"F5", "reload"}, {"Esc", "close"}}))
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+type imageTaggedMsg struct {
+	source string
+	target string
+	err    error
+}
+
+func tagImageCmd(source, target string) tea.Cmd {
+	return func() tea.Msg {
+		err := docker.TagImage(source, target)
+		return imageTaggedMsg{source: source, target: target, err: err}
+	}
+}
+
+// openTagPrompt asks for the new repo:tag, starting from the current name
+func (m *model) openTagPrompt(image string) tea.Cmd {
+	cmd := m.prompt(fmt.Sprintf("Tag %s as", image), "registry/repo:tag", func(m *model, target string) tea.Cmd {
+		if target == "" || target == image {
+			m.statusMessage = "Not tagged"
+			return nil
+		}
+		m.statusMessage = fmt.Sprintf("Tagging %s as %s...", image, target)
+		return tagImageCmd(image, target)
+	})
+	m.promptInput.SetValue(image)
+	return cmd
+}
+
+// handleImageTagged offers to push the new tag right away, usually the point of tagging
+func (m *model) handleImageTagged(msg imageTaggedMsg) {
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error: %v", msg.err)
+		return
+	}
+	m.statusMessage = fmt.Sprintf("Tagged %s as %s", msg.source, msg.target)
+	m.confirmPush(msg.target)
+}
+
+// confirmPush names the registry before pushing, an image without one goes to Docker Hub
+func (m *model) confirmPush(image string) {
+	registry := "its registry"
+	if ref, err := docker.ParseImageRef(image); err == nil {
+		registry = ref.Registry
+	}
+	m.confirm(fmt.Sprintf("Push %s to %s?", image, registry), func(m *model) tea.Cmd {
+		return m.pushImage(image)
+	})
+}
+
+// pushImage streams the push progress into the task panel
+func (m *model) pushImage(image string) tea.Cmd {
+	return m.startTask(fmt.Sprintf("Push %s", image), func(onLine func(string)) (string, error) {
+		if err := docker.PushImage(image, onLine); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Pushed %s", image), nil
+	})
+}
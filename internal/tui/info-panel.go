@@ -13,35 +13,7 @@ func (m model) renderInfoPanel(width int) string {
 	b.WriteString(dividerStyle.Render(strings.Repeat("─", width)))
 	b.WriteString("\n")
 
-	id := m.infoContainerID
-	if id == "" && m.infoContainer != nil {
-		id = m.infoContainer.ID
-	}
-
-	var container *docker.Container
-
-	if id != "" {
-
-		for _, p := range m.projects {
-			for i := range p.Containers {
-				if p.Containers[i].ID == id {
-					container = &p.Containers[i]
-					break
-				}
-			}
-			if container != nil {
-				break
-			}
-		}
-		if container == nil {
-			for i := range m.containers {
-				if m.containers[i].ID == id {
-					container = &m.containers[i]
-					break
-				}
-			}
-		}
-	}
+	container := m.infoTarget()
 
 	containerName := ""
 	if container != nil && len(container.Names) > 0 {
@@ -65,56 +37,8 @@ func (m model) renderInfoPanel(width int) string {
 		return b.String()
 	}
 
-	// Display container information fields
-	infoFields := []struct {
-		label string
-		value string
-	}{
-		{"Container ID", container.ID},
-		{"Name", containerName},
-		{"Image", container.Image},
-		{"Status", container.Status},
-		{"State", container.State},
-		{"CPU Usage", container.CPU},
-		{"Memory Usage", container.Memory},
-		{"Network I/O", container.NetIO},
-		{"Block I/O", container.BlockIO},
-		{"Ports", container.Ports},
-		// {"Compose Project", container.ComposeProject},
-		// {"Compose File Directory", container.ComposeFileDirectory},
-		// {"Compose Directory", container.ComposeDirectory},
-		// {"Compose Service", container.ComposeService},
-	}
-
-	// Add compose-specific fields if available
-	if container.ComposeProject != "" {
-		infoFields = append(infoFields, struct {
-			label string
-			value string
-		}{"Compose Project", container.ComposeProject})
-	}
-	if container.ComposeDirectory != "" {
-		infoFields = append(infoFields, struct {
-			label string
-			value string
-		}{"Compose Directory", container.ComposeDirectory})
-	}
-	if container.ComposeFileDirectory != "" {
-		infoFields = append(infoFields, struct {
-			label string
-			value string
-		}{"Compose File Directory", container.ComposeFileDirectory})
-	}
-	if container.ComposeService != "" {
-		infoFields = append(infoFields, struct {
-			label string
-			value string
-		}{"Compose Service", container.ComposeService})
-	}
-	panelHeight := m.infoPanelHeight
-	if container != nil && container.ComposeFileDirectory == "" {
-		panelHeight -= 4
-	}
+	infoFields := m.infoFields(container)
+	panelHeight := m.infoPanelHeightFor(container)
 
 	maxInfoLines := panelHeight - 2 // account for divider and title
 	if maxInfoLines < 1 {
@@ -202,6 +126,145 @@ func (m model) renderInfoPanel(width int) string {
 	return b.String()
 }
 
+type infoField struct {
+	label string
+	value string
+}
+
+// infoTarget looks up the container shown in the info panel from the latest fetched data
+func (m model) infoTarget() *docker.Container {
+	id := m.infoContainerID
+	if id == "" && m.infoContainer != nil {
+		id = m.infoContainer.ID
+	}
+
+	var container *docker.Container
+
+	if id != "" {
+
+		for _, p := range m.projects {
+			for i := range p.Containers {
+				if p.Containers[i].ID == id {
+					container = &p.Containers[i]
+					break
+				}
+			}
+			if container != nil {
+				break
+			}
+		}
+		if container == nil {
+			for i := range m.containers {
+				if m.containers[i].ID == id {
+					container = &m.containers[i]
+					break
+				}
+			}
+		}
+	}
+	return container
+}
+
+// infoFields lists the label/value rows shown for a container
+func (m model) infoFields(container *docker.Container) []infoField {
+	if container == nil {
+		return nil
+	}
+
+	containerName := ""
+	if len(container.Names) > 0 {
+		containerName = container.Names[0]
+	}
+
+	// Display container information fields
+	fields := []infoField{
+		{"Container ID", container.ID},
+		{"Name", containerName},
+	}
+	if note := m.containerNote(*container); note != "" {
+		fields = append(fields, infoField{"Note", note})
+	}
+	fields = append(fields, []infoField{
+		{"Image", container.Image},
+		{"Status", container.Status},
+		{"State", container.State},
+		{"CPU Usage", m.cpuUsageText(*container)},
+		{"Memory Usage", m.memoryUsageText(*container)},
+		{"Network I/O", container.NetIO},
+		{"Block I/O", container.BlockIO},
+		{"Ports", container.Ports},
+	}...)
+	fields = append(fields, m.networkFields(*container)...)
+	fields = append(fields, m.mountFields(*container)...)
+
+	// Add compose-specific fields if available
+	if container.ComposeProject != "" {
+		project := container.ComposeProject
+		if alias := m.projectLabel(project); alias != project {
+			project = fmt.Sprintf("%s (%s)", alias, project)
+		}
+		fields = append(fields, infoField{"Compose Project", project})
+	}
+	if container.ComposeDirectory != "" {
+		fields = append(fields, infoField{"Compose Directory", container.ComposeDirectory})
+	}
+	if container.ComposeFileDirectory != "" {
+		fields = append(fields, infoField{"Compose File Directory", container.ComposeFileDirectory})
+	}
+	if container.ComposeService != "" {
+		fields = append(fields, infoField{"Compose Service", container.ComposeService})
+	}
+	if profiles := docker.ServiceProfiles(*container); len(profiles) > 0 {
+		fields = append(fields, infoField{"Compose Profiles", strings.Join(profiles, ", ")})
+	}
+	if envFile := container.Labels[docker.EnvFileLabel]; envFile != "" {
+		fields = append(fields, infoField{"Compose Env File", envFile})
+	}
+	if unit := docker.SystemdUnit(*container); unit != "" {
+		fields = append(fields, infoField{"Systemd Unit", unit})
+	}
+
+	if ttl := m.ttlDescription(*container); ttl != "" {
+		fields = append(fields, infoField{"TTL", ttl})
+	}
+	if gpu := m.gpuDescription(*container); gpu != "" {
+		fields = append(fields, infoField{"GPU", gpu})
+	}
+	if policy := m.restartPolicyText(*container); policy != "" {
+		fields = append(fields, infoField{"Restart Policy", policy})
+	}
+	if crash := m.crashDescription(*container); crash != "" {
+		fields = append(fields, infoField{"Crashes", crash})
+	}
+	if upd := m.imageUpdateDescription(*container); upd != "" {
+		fields = append(fields, infoField{"Image Update", upd})
+	}
+	if auto := m.autoUpdateDescription(*container); auto != "" {
+		fields = append(fields, infoField{"Auto-Update", auto})
+	}
+	fields = append(fields, provenanceFields(*container)...)
+
+	return fields
+}
+
+// infoPanelHeightFor sizes the panel to its fields (plus divider and title),
+// capped at INFO_PANEL_HEIGHT or half the screen, whichever is bigger
+func (m model) infoPanelHeightFor(container *docker.Container) int {
+	height := len(m.infoFields(container)) + 2
+	if container == nil {
+		height = 3
+	}
+
+	limit := m.infoPanelHeight
+	if half := m.terminalHeight / 2; half > limit {
+		limit = half
+	}
+	if height > limit {
+		height = limit
+	}
+	return height
+}
+
 // wrapText performs hard wrapping on a string.
 func wrapText(text string, maxWidth int) []string {
 	var lines []string
@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// inspectInfoMsg carries what inspect adds to the listed containers, by container ID
+type inspectInfoMsg struct {
+	infos map[string]docker.InspectInfo
+	at    time.Time
+	err   error
+}
+
+// inspectInfoCmd inspects the containers after every list refresh, the list
+// itself has no restart count, OOM flag, start time or IPs
+func inspectInfoCmd(containers []docker.Container) tea.Cmd {
+	ids := make([]string, 0, len(containers))
+	for _, c := range containers {
+		ids = append(ids, c.ID)
+	}
+	return func() tea.Msg {
+		infos, err := docker.InspectContainers(ids)
+		return inspectInfoMsg{infos: infos, at: time.Now(), err: err}
+	}
+}
+
+func (m *model) handleInspectInfo(msg inspectInfoMsg) tea.Cmd {
+	if msg.err != nil {
+		// keep the last known state, the next refresh tries again
+		return nil
+	}
+	m.inspectInfo = msg.infos
+	ids := make([]string, 0, len(msg.infos))
+	for id, info := range msg.infos {
+		m.restartTracker.Observe(id, info.RestartCount, msg.at)
+		ids = append(ids, id)
+	}
+	m.restartTracker.Keep(ids)
+	if m.sortBy == sortByUptime || m.sortBy == sortByNetworks || m.sortBy == sortByIP {
+		// the list arrived before the inspect data it's sorted by
+		m.sortContainers()
+	}
+	return m.gpuUsageCmd()
+}
+
+// uptime is how long a running container has been up, by inspect's start
+// time. Zero for stopped containers and before the first inspect.
+func (m model) uptime(c docker.Container) time.Duration {
+	started := m.inspectInfo[c.ID].StartedAt
+	if strings.ToLower(c.State) != "running" || started.IsZero() {
+		return 0
+	}
+	return time.Since(started)
+}
+
+func (m model) uptimeText(c docker.Container) string {
+	if d := m.uptime(c); d > 0 {
+		return docker.FormatAge(d)
+	}
+	return "─"
+}
+
+func createdText(c docker.Container) string {
+	if c.CreatedAt.IsZero() {
+		return "─"
+	}
+	return docker.FormatAge(time.Since(c.CreatedAt)) + " ago"
+}
+
+func (m model) networksText(c docker.Container) string {
+	if names := m.inspectInfo[c.ID].NetworkNames(); len(names) > 0 {
+		return strings.Join(names, ", ")
+	}
+	return "─"
+}
+
+func (m model) ipText(c docker.Container) string {
+	if ips := m.inspectInfo[c.ID].IPs(); len(ips) > 0 {
+		return strings.Join(ips, ", ")
+	}
+	return "─"
+}
+
+// ipLess sorts by the first IP as an address, so .10 comes after .9.
+// Containers without one go last.
+func (m model) ipLess(a, b docker.Container) bool {
+	first := func(c docker.Container) (netip.Addr, bool) {
+		ips := m.inspectInfo[c.ID].IPs()
+		if len(ips) == 0 {
+			return netip.Addr{}, false
+		}
+		addr, err := netip.ParseAddr(ips[0])
+		return addr, err == nil
+	}
+	ia, okA := first(a)
+	ib, okB := first(b)
+	if okA != okB {
+		return okA
+	}
+	return ia.Less(ib)
+}
+
+// networkFields are the info panel's Networks line, with each network's addresses
+func (m model) networkFields(c docker.Container) []infoField {
+	info, ok := m.inspectInfo[c.ID]
+	if !ok || len(info.Networks) == 0 {
+		return nil
+	}
+	parts := make([]string, 0, len(info.Networks))
+	for _, n := range info.Networks {
+		var addrs []string
+		for _, a := range []string{n.IP, n.IPv6} {
+			if a != "" {
+				addrs = append(addrs, a)
+			}
+		}
+		if len(addrs) > 0 {
+			parts = append(parts, fmt.Sprintf("%s (%s)", n.Name, strings.Join(addrs, ", ")))
+		} else {
+			parts = append(parts, n.Name)
+		}
+	}
+	return []infoField{{"Networks", strings.Join(parts, ", ")}}
+}
+
+// onNetwork is the network filter's test. Containers not inspected yet,
+// created since the last refresh, show up with the next one.
+func (m model) onNetwork(c docker.Container) bool {
+	for _, name := range m.inspectInfo[c.ID].NetworkNames() {
+		if name == m.networkFilter {
+			return true
+		}
+	}
+	return false
+}
+
+// openNetworkFilter asks for a network to narrow the list to, empty shows all again
+func (m *model) openNetworkFilter() tea.Cmd {
+	initial := m.networkFilter
+	if c := m.selectedContainer(); c != nil && initial == "" {
+		if names := m.inspectInfo[c.ID].NetworkNames(); len(names) > 0 {
+			initial = names[0]
+		}
+	}
+	cmd := m.prompt("Show the containers on network", "network name, empty for all", func(m *model, value string) tea.Cmd {
+		m.networkFilter = value
+		if value == "" {
+			m.statusMessage = "Showing the containers of all networks"
+		} else {
+			m.statusMessage = fmt.Sprintf("Showing the containers on %s", value)
+		}
+		return tea.Batch(fetchContainers(), fetchComposeProjects())
+	})
+	m.promptInput.SetValue(initial)
+	return cmd
+}
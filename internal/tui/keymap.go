@@ -23,6 +23,8 @@ type keyMap struct {
 	NextPage       key.Binding
 	PrevPage       key.Binding
 	PageDown       key.Binding
+	HalfPageUp     key.Binding
+	HalfPageDown   key.Binding
 	Quit           key.Binding
 	Help           key.Binding
 	ComposeUp      key.Binding
@@ -30,6 +32,54 @@ type keyMap struct {
 	ComposeRestart key.Binding
 	ComposePause   key.Binding
 	ComposeStop    key.Binding
+	Pin            key.Binding
+	Prune          key.Binding
+	CheckUpdates   key.Binding
+	PullRecreate   key.Binding
+	Files          key.Binding
+	WrapLogs       key.Binding
+	PortLookup     key.Binding
+	Alias          key.Binding
+	ImageActions   key.Binding
+	OpenCommit     key.Binding
+	Note           key.Binding
+	Copy           key.Binding
+	CopyRow        key.Binding
+	ScanImage      key.Binding
+	Registry       key.Binding
+	Logins         key.Binding
+	Export         key.Binding
+	Swarm          key.Binding
+	DaemonInfo     key.Binding
+	Events         key.Binding
+	EventsFilter   key.Binding
+	NetworkFilter  key.Binding
+	Networks       key.Binding
+	Mounts         key.Binding
+	Limits         key.Binding
+	RestartPolicy  key.Binding
+	PauseRefresh   key.Binding
+	ThenSort       key.Binding
+	StateFilter    key.Binding
+	ProjectFilter  key.Binding
+	JSONLogs       key.Binding
+	SaveLogs       key.Binding
+	LogFilter      key.Binding
+	LogTimestamps  key.Binding
+	LogsSince      key.Binding
+	FollowLogs     key.Binding
+	QuickCommands  key.Binding
+	RunContainer   key.Binding
+	AllActions     key.Binding
+	ActionQueue    key.Binding
+	Undo           key.Binding
+	Diff           key.Binding
+	DepGraph       key.Binding
+	Discover       key.Binding
+	Unit           key.Binding
+	Secrets        key.Binding
+	Profiles       key.Binding
+	AutoUpdate     key.Binding
 }
 
 var Keys = keyMap{
@@ -39,7 +89,7 @@ var Keys = keyMap{
 	Stop:           key.NewBinding(key.WithKeys("x", "X")),
 	Logs:           key.NewBinding(key.WithKeys("l")),
 	Info:           key.NewBinding(key.WithKeys("i", "I")),
-	Exec:           key.NewBinding(key.WithKeys("e", "E")),
+	Exec:           key.NewBinding(key.WithKeys("e")),
 	Restart:        key.NewBinding(key.WithKeys("r", "R")),
 	Remove:         key.NewBinding(key.WithKeys("d", "D")),
 	Refresh:        key.NewBinding(key.WithKeys("f5")),
@@ -47,6 +97,8 @@ var Keys = keyMap{
 	NextPage:       key.NewBinding(key.WithKeys("n", "pagedown")),
 	PrevPage:       key.NewBinding(key.WithKeys("p", "pageup")),
 	PageDown:       key.NewBinding(key.WithKeys("pgdown", "right")),
+	HalfPageUp:     key.NewBinding(key.WithKeys("ctrl+u")),
+	HalfPageDown:   key.NewBinding(key.WithKeys("ctrl+d")),
 	Quit:           key.NewBinding(key.WithKeys("q", "Q", "ctrl+c", "f10")),
 	Help:           key.NewBinding(key.WithKeys("f1", "?")),
 	ComposeUp:      key.NewBinding(key.WithKeys("u", "U")),
@@ -54,4 +106,52 @@ var Keys = keyMap{
 	ComposeRestart: key.NewBinding(key.WithKeys("r", "R")),
 	ComposePause:   key.NewBinding(key.WithKeys("p", "P")),
 	ComposeStop:    key.NewBinding(key.WithKeys("x", "X")),
+	Pin:            key.NewBinding(key.WithKeys("*")),
+	Prune:          key.NewBinding(key.WithKeys("f8")),
+	CheckUpdates:   key.NewBinding(key.WithKeys("f6")),
+	PullRecreate:   key.NewBinding(key.WithKeys("g", "G")),
+	Files:          key.NewBinding(key.WithKeys("b", "B")),
+	WrapLogs:       key.NewBinding(key.WithKeys("w", "W")),
+	PortLookup:     key.NewBinding(key.WithKeys("o", "O")),
+	Alias:          key.NewBinding(key.WithKeys("a", "A")),
+	ImageActions:   key.NewBinding(key.WithKeys("m", "M")),
+	OpenCommit:     key.NewBinding(key.WithKeys("v", "V")),
+	Note:           key.NewBinding(key.WithKeys("t", "T")),
+	Copy:           key.NewBinding(key.WithKeys("y")),
+	CopyRow:        key.NewBinding(key.WithKeys("Y")),
+	ScanImage:      key.NewBinding(key.WithKeys("f", "F")),
+	Registry:       key.NewBinding(key.WithKeys("f3")),
+	Logins:         key.NewBinding(key.WithKeys("f4")),
+	Export:         key.NewBinding(key.WithKeys("z", "Z")),
+	Swarm:          key.NewBinding(key.WithKeys("f9")),
+	DaemonInfo:     key.NewBinding(key.WithKeys("!")),
+	Events:         key.NewBinding(key.WithKeys("H")),
+	EventsFilter:   key.NewBinding(key.WithKeys("/")),
+	NetworkFilter:  key.NewBinding(key.WithKeys("N")),
+	Networks:       key.NewBinding(key.WithKeys("J")),
+	Mounts:         key.NewBinding(key.WithKeys("K")),
+	Limits:         key.NewBinding(key.WithKeys("%")),
+	RestartPolicy:  key.NewBinding(key.WithKeys("=")),
+	PauseRefresh:   key.NewBinding(key.WithKeys("ctrl+r")),
+	ThenSort:       key.NewBinding(key.WithKeys("+")),
+	StateFilter:    key.NewBinding(key.WithKeys("1", "2", "3")),
+	ProjectFilter:  key.NewBinding(key.WithKeys("4")),
+	JSONLogs:       key.NewBinding(key.WithKeys("{")),
+	SaveLogs:       key.NewBinding(key.WithKeys("ctrl+s")),
+	LogFilter:      key.NewBinding(key.WithKeys("/")),
+	LogTimestamps:  key.NewBinding(key.WithKeys("@")),
+	LogsSince:      key.NewBinding(key.WithKeys("<")),
+	FollowLogs:     key.NewBinding(key.WithKeys("ctrl+f")),
+	QuickCommands:  key.NewBinding(key.WithKeys("E")),
+	RunContainer:   key.NewBinding(key.WithKeys("ctrl+n")),
+	AllActions:     key.NewBinding(key.WithKeys("ctrl+a")),
+	ActionQueue:    key.NewBinding(key.WithKeys("ctrl+w")),
+	Undo:           key.NewBinding(key.WithKeys("ctrl+z")),
+	Diff:           key.NewBinding(key.WithKeys("#")),
+	DepGraph:       key.NewBinding(key.WithKeys("ctrl+g")),
+	Discover:       key.NewBinding(key.WithKeys("ctrl+o")),
+	Unit:           key.NewBinding(key.WithKeys("ctrl+e")),
+	Secrets:        key.NewBinding(key.WithKeys("$")),
+	Profiles:       key.NewBinding(key.WithKeys("ctrl+x")),
+	AutoUpdate:     key.NewBinding(key.WithKeys("ctrl+p")),
 }
@@ -0,0 +1,192 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// the limits form changes a container's CPU and memory limits in place with
+// `docker update`, no recreate needed
+
+const (
+	limitsCPUs = iota
+	limitsMemory
+)
+
+func (m *model) openLimits(c docker.Container) tea.Cmd {
+	info, ok := m.inspectInfo[c.ID]
+	if !ok {
+		m.statusMessage = fmt.Sprintf("Limits of %s aren't known yet, try again after the next refresh", docker.ContainerName(c))
+		return nil
+	}
+
+	cpus := textinput.New()
+	cpus.Placeholder = "no limit"
+	cpus.CharLimit = 8
+	cpus.Width = 12
+	if info.CPULimit > 0 {
+		cpus.SetValue(strconv.FormatFloat(info.CPULimit, 'f', -1, 64))
+	}
+	memory := textinput.New()
+	memory.Placeholder = "no limit"
+	memory.CharLimit = 12
+	memory.Width = 12
+	if info.MemoryLimit > 0 {
+		memory.SetValue(docker.FormatMemory(info.MemoryLimit))
+	}
+	cpus.Focus()
+
+	m.limitsTarget = c
+	m.limitsInputs = []textinput.Model{cpus, memory}
+	m.limitsFocus = limitsCPUs
+	m.limitsErr = ""
+	m.returnMode = m.currentMode
+	m.currentMode = modeLimits
+	return textinput.Blink
+}
+
+func (m model) updateLimits(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.currentMode = m.returnMode
+		m.limitsInputs = nil
+		m.statusMessage = "Cancelled"
+		return m, nil
+	case "tab", "shift+tab", "up", "down":
+		m.limitsInputs[m.limitsFocus].Blur()
+		m.limitsFocus = (m.limitsFocus + 1) % len(m.limitsInputs)
+		return m, m.limitsInputs[m.limitsFocus].Focus()
+	case "enter":
+		cpus, memory, err := m.limitsValues()
+		if err != nil {
+			m.limitsErr = err.Error()
+			return m, nil
+		}
+		c := m.limitsTarget
+		m.currentMode = m.returnMode
+		m.limitsInputs = nil
+		m.statusMessage = fmt.Sprintf("Updating the limits of %s...", docker.ContainerName(c))
+		return m, func() tea.Msg {
+			if err := docker.UpdateLimits(c.ID, cpus, memory); err != nil {
+				return actionDoneMsg{err: err}
+			}
+			return actionDoneMsg{msg: fmt.Sprintf("Updated the limits of %s", docker.ContainerName(c))}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.limitsInputs[m.limitsFocus], cmd = m.limitsInputs[m.limitsFocus].Update(msg)
+	return m, cmd
+}
+
+// limitsValues reads the form. An empty CPUs field removes the CPU limit, an
+// empty memory field keeps the memory limit, which can't be removed.
+func (m model) limitsValues() (float64, int64, error) {
+	var cpus float64
+	if s := strings.TrimSpace(m.limitsInputs[limitsCPUs].Value()); s != "" {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil || v < 0 {
+			return 0, 0, fmt.Errorf("%q is not a number of CPUs like 0.5 or 2", s)
+		}
+		cpus = v
+	}
+	var memory int64
+	if s := strings.TrimSpace(m.limitsInputs[limitsMemory].Value()); s != "" {
+		v, err := docker.ParseMemory(s)
+		if err != nil {
+			return 0, 0, err
+		}
+		if v == 0 {
+			return 0, 0, fmt.Errorf("a memory limit can't be removed once set, only changed")
+		}
+		if v < 6<<20 {
+			return 0, 0, fmt.Errorf("the memory limit has to be at least 6m")
+		}
+		memory = v
+	}
+	return cpus, memory, nil
+}
+
+func (m model) renderLimits(width int) string {
+	c := m.limitsTarget
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(fmt.Sprintf("Limits of %s", docker.ContainerName(c))))
+	content.WriteString("\n\n")
+
+	labels := []string{"CPUs", "Memory"}
+	hints := []string{"e.g. 0.5 or 2, empty for no limit", "e.g. 512m or 2g, empty keeps it"}
+	for i, input := range m.limitsInputs {
+		content.WriteString(fmt.Sprintf("%s %s  %s\n",
+			infoLabelStyle.Render(padRight(labels[i], 7)),
+			input.View(),
+			footerDescStyle.Render(hints[i])))
+	}
+	content.WriteString("\n")
+	content.WriteString(infoValueStyle.Render(m.usageText(c)))
+	content.WriteString("\n")
+	if m.limitsErr != "" {
+		content.WriteString(messageStyle.Render(m.limitsErr))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+	content.WriteString(infoLabelStyle.Render("[Tab] next field  [Enter] apply  [Esc] cancel"))
+
+	dialog := lipgloss.NewStyle().
+		Width(64).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(accent).
+		Padding(1, 2).
+		Render(content.String())
+
+	return centerDialog(dialog, width, m.terminalHeight)
+}
+
+// usageText is the current usage against the limits, for the form
+func (m model) usageText(c docker.Container) string {
+	if strings.ToLower(c.State) != "running" {
+		return "Not running, the limits apply from the next start"
+	}
+	return fmt.Sprintf("Now: CPU %s, memory %s", m.cpuUsageText(c), m.memoryUsageText(c))
+}
+
+// cpuUsageText puts the CPU limit next to the usage, so 150% reads as 1.5 of 2 CPUs
+func (m model) cpuUsageText(c docker.Container) string {
+	usage := c.CPU
+	if usage == "" {
+		usage = "─"
+	}
+	info, ok := m.inspectInfo[c.ID]
+	if !ok {
+		return usage
+	}
+	if info.CPULimit > 0 {
+		return fmt.Sprintf("%s (limit %s CPUs)", usage, strconv.FormatFloat(info.CPULimit, 'f', -1, 64))
+	}
+	return usage + " (no limit)"
+}
+
+// memoryUsageText says what the memory percentage is a share of: the limit,
+// or all of the host's memory without one
+func (m model) memoryUsageText(c docker.Container) string {
+	usage := c.Memory
+	if usage == "" {
+		usage = "─"
+	}
+	if c.MemUsage != "" {
+		usage = fmt.Sprintf("%s (%s)", usage, c.MemUsage)
+	}
+	info, ok := m.inspectInfo[c.ID]
+	if !ok {
+		return usage
+	}
+	if info.MemoryLimit > 0 {
+		return fmt.Sprintf("%s of the %s limit", usage, docker.FormatMemory(info.MemoryLimit))
+	}
+	return usage + " of the host, no limit"
+}
@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// logsDir is where saved logs go unless another path is given
+const logsDir = "~/dockmate-logs"
+
+// plainLogLines are the loaded log lines from index from on, without colors,
+// with the time and name in front when several containers are interleaved
+func (m model) plainLogLines(from int) []string {
+	lines := make([]string, 0, len(m.logsLines)-from)
+	for i := from; i < len(m.logsLines); i++ {
+		l := m.logsLines[i]
+		if i < len(m.logsPrefixes) {
+			l = m.logsPrefixes[i] + l
+		}
+		lines = append(lines, ansi.Strip(l))
+	}
+	return lines
+}
+
+// logsName names the logs in the panel for a file name: the container,
+// the compose project or "N-containers"
+func (m model) logsName() string {
+	if len(m.logsMulti) > 0 {
+		return fmt.Sprintf("%d-containers", len(m.logsMulti))
+	}
+	if !m.logsIsProject {
+		for _, c := range m.statsContainers() {
+			if c.ID == m.logsContainer {
+				return docker.ContainerName(c)
+			}
+		}
+	}
+	return m.logsContainer
+}
+
+func (m *model) openCopyLogsMenu() {
+	if len(m.logsLines) == 0 {
+		m.statusMessage = "No logs to copy"
+		return
+	}
+	width := m.terminalWidth
+	if width <= 0 {
+		width = 80
+	}
+	_, first := m.logRows(width-4, max(1, m.logPanelHeight-2))
+	visible := len(m.logsLines) - first
+
+	m.openMenu("Copy logs", []menuItem{
+		{key: "v", label: fmt.Sprintf("The %d lines on screen", visible), action: func(m *model) tea.Cmd {
+			m.copyLogLines(m.plainLogLines(first))
+			return nil
+		}},
+		{key: "a", label: fmt.Sprintf("All %d loaded lines", len(m.logsLines)), action: func(m *model) tea.Cmd {
+			m.copyLogLines(m.plainLogLines(0))
+			return nil
+		}},
+		{key: "m", label: "Lines containing...", action: func(m *model) tea.Cmd {
+			return m.prompt("Copy the log lines containing", "text", func(m *model, text string) tea.Cmd {
+				if text == "" {
+					m.statusMessage = "Cancelled"
+					return nil
+				}
+				var lines []string
+				for _, l := range m.plainLogLines(0) {
+					if strings.Contains(strings.ToLower(l), strings.ToLower(text)) {
+						lines = append(lines, l)
+					}
+				}
+				if len(lines) == 0 {
+					m.statusMessage = fmt.Sprintf("No log lines contain %q", text)
+					return nil
+				}
+				m.copyLogLines(lines)
+				return nil
+			})
+		}},
+	})
+}
+
+func (m *model) copyLogLines(lines []string) {
+	m.copyToClipboard(strings.Join(lines, "\n")+"\n", fmt.Sprintf("%d log lines", len(lines)))
+}
+
+// saveLogs asks where to write the loaded logs, by default a new file in ~/dockmate-logs
+func (m *model) saveLogs() tea.Cmd {
+	if len(m.logsLines) == 0 {
+		m.statusMessage = "No logs to save"
+		return nil
+	}
+	lines := m.plainLogLines(0)
+	name := strings.NewReplacer("/", "_", " ", "_", ":", "_").Replace(m.logsName())
+	cmd := m.prompt("Save logs to", "path", func(m *model, path string) tea.Cmd {
+		if path == "" {
+			m.statusMessage = "Cancelled"
+			return nil
+		}
+		path = expandHome(path)
+		write := func(m *model) tea.Cmd {
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				m.statusMessage = fmt.Sprintf("Error: %v", err)
+				return nil
+			}
+			if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+				m.statusMessage = fmt.Sprintf("Error: %v", err)
+				return nil
+			}
+			abs, _ := filepath.Abs(path)
+			m.statusMessage = fmt.Sprintf("Saved %d log lines to %s", len(lines), abs)
+			return nil
+		}
+		if _, err := os.Stat(path); err == nil {
+			m.confirm(fmt.Sprintf("%s exists. Overwrite it?", path), write)
+			return nil
+		}
+		return write(m)
+	})
+	m.promptInput.SetValue(fmt.Sprintf("%s/%s-%s.log", logsDir, name, time.Now().Format("20060102-150405")))
+	return cmd
+}
@@ -0,0 +1,203 @@
+package tui
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shubh-io/dockmate/internal/config"
+)
+
+// the logs panel can be narrowed per container (or compose project) with an
+// include and any number of exclude regexes, kept in logs.filters in the
+// config. Every fetch keeps the lines as they came in logsRaw and the filter
+// picks logsLines from them, so a change shows at once and the followed
+// lines go through it too.
+
+type logFilter struct {
+	include *regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// compileLogFilter skips patterns that don't compile, `dockmate config
+// validate` reports them
+func compileLogFilter(f config.LogFilter) logFilter {
+	var lf logFilter
+	if f.Include != "" {
+		lf.include, _ = regexp.Compile(f.Include)
+	}
+	for _, p := range f.Exclude {
+		if re, err := regexp.Compile(p); err == nil {
+			lf.exclude = append(lf.exclude, re)
+		}
+	}
+	return lf
+}
+
+func (f logFilter) keep(line string) bool {
+	line = ansi.Strip(line)
+	if f.include != nil && !f.include.MatchString(line) {
+		return false
+	}
+	for _, re := range f.exclude {
+		if re.MatchString(line) {
+			return false
+		}
+	}
+	return true
+}
+
+func logFilterActive(f config.LogFilter) bool {
+	return f.Include != "" || len(f.Exclude) > 0
+}
+
+// setLogs takes freshly fetched lines. prefixes and sources are only set for
+// interleaved logs, sources being the container name of each line.
+func (m *model) setLogs(lines, prefixes, sources []string) {
+	m.logsRaw = lines
+	m.logsRawPrefixes = prefixes
+	m.logsSources = sources
+	m.filterLogs()
+}
+
+// filterLogs picks the lines the filters let through into logsLines
+func (m *model) filterLogs() {
+	filters := make(map[string]logFilter)
+	filterFor := func(name string) logFilter {
+		f, ok := filters[name]
+		if !ok {
+			f = compileLogFilter(m.settings.LogFilters[name])
+			filters[name] = f
+		}
+		return f
+	}
+
+	m.logsLines = nil
+	m.logsPrefixes = nil
+	name := m.logsName()
+	for i, line := range m.logsRaw {
+		source := name
+		if i < len(m.logsSources) {
+			source = m.logsSources[i]
+		}
+		if !filterFor(source).keep(line) {
+			continue
+		}
+		m.logsLines = append(m.logsLines, line)
+		if i < len(m.logsRawPrefixes) {
+			m.logsPrefixes = append(m.logsPrefixes, m.logsRawPrefixes[i])
+		}
+	}
+}
+
+// logFilterTargets are the names whose filters apply to the panel, every
+// container when their logs are interleaved
+func (m model) logFilterTargets() []string {
+	if len(m.logsMulti) > 0 {
+		return slices.Sorted(maps.Values(m.logsNames))
+	}
+	return []string{m.logsName()}
+}
+
+// logsFiltered says whether a filter applies to the panel, for its title
+func (m model) logsFiltered() bool {
+	for _, name := range m.logFilterTargets() {
+		if logFilterActive(m.settings.LogFilters[name]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *model) openLogFilterMenu() {
+	targets := m.logFilterTargets()
+	current := m.settings.LogFilters[targets[0]]
+	what := targets[0]
+	if len(targets) > 1 {
+		what = fmt.Sprintf("%d containers", len(targets))
+	}
+
+	items := []menuItem{
+		{key: "i", label: "Show only lines matching...", action: func(m *model) tea.Cmd {
+			cmd := m.prompt("Show only log lines matching (regex, empty for all)", "e.g. ERROR|WARN", func(m *model, value string) tea.Cmd {
+				if _, err := regexp.Compile(value); err != nil {
+					m.statusMessage = fmt.Sprintf("Invalid regex: %v", err)
+					return nil
+				}
+				m.updateLogFilters(targets, func(f *config.LogFilter) { f.Include = value })
+				return nil
+			})
+			m.promptInput.SetValue(current.Include)
+			return cmd
+		}},
+		{key: "x", label: "Hide lines matching...", action: func(m *model) tea.Cmd {
+			return m.prompt("Hide log lines matching (regex)", "e.g. GET /health", func(m *model, value string) tea.Cmd {
+				if value == "" {
+					m.statusMessage = "Cancelled"
+					return nil
+				}
+				if _, err := regexp.Compile(value); err != nil {
+					m.statusMessage = fmt.Sprintf("Invalid regex: %v", err)
+					return nil
+				}
+				m.updateLogFilters(targets, func(f *config.LogFilter) {
+					if !slices.Contains(f.Exclude, value) {
+						f.Exclude = append(f.Exclude, value)
+					}
+				})
+				return nil
+			})
+		}},
+	}
+	if m.logsFiltered() {
+		items = append(items, menuItem{key: "c", label: "Clear the filter", action: func(m *model) tea.Cmd {
+			m.updateLogFilters(targets, func(f *config.LogFilter) { *f = config.LogFilter{} })
+			return nil
+		}})
+	}
+
+	title := "Filter the logs of " + what
+	if len(targets) == 1 && logFilterActive(current) {
+		var parts []string
+		if current.Include != "" {
+			parts = append(parts, fmt.Sprintf("only %q", current.Include))
+		}
+		for _, p := range current.Exclude {
+			parts = append(parts, fmt.Sprintf("not %q", p))
+		}
+		title += " (" + strings.Join(parts, ", ") + ")"
+	}
+	m.openMenu(title, items)
+}
+
+// updateLogFilters changes the filters of names, saves them and filters the
+// panel again
+func (m *model) updateLogFilters(names []string, change func(f *config.LogFilter)) {
+	filters := make(map[string]config.LogFilter, len(m.settings.LogFilters)+len(names))
+	for k, v := range m.settings.LogFilters {
+		filters[k] = v
+	}
+	for _, name := range names {
+		f := filters[name]
+		f.Exclude = slices.Clone(f.Exclude)
+		change(&f)
+		if logFilterActive(f) {
+			filters[name] = f
+		} else {
+			delete(filters, name)
+		}
+	}
+	m.settings.LogFilters = filters
+	m.filterLogs()
+	m.statusMessage = fmt.Sprintf("Showing %d of %d log lines", len(m.logsLines), len(m.logsRaw))
+
+	cfg, _ := config.Load()
+	cfg.Logs.Filters = filters
+	if err := cfg.Save(); err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to save the log filter: %v", err)
+	}
+}
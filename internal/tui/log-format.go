@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// structured logs: with { on, a JSON log line is shown as
+// "time LEVEL message key=value ...", anything before the object (a compose
+// service prefix) is kept
+
+// the field names of the usual loggers, first match wins
+var (
+	jsonTimeKeys    = []string{"ts", "time", "timestamp", "@timestamp", "t"}
+	jsonLevelKeys   = []string{"level", "lvl", "severity", "log.level"}
+	jsonMessageKeys = []string{"msg", "message", "@message"}
+)
+
+// formatJSONLogLine flattens a JSON log line, other lines come back unchanged
+func formatJSONLogLine(s string) string {
+	start := strings.IndexByte(s, '{')
+	if start < 0 || !strings.HasSuffix(strings.TrimSpace(s), "}") {
+		return s
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(s[start:]), &fields); err != nil {
+		return s
+	}
+
+	take := func(keys []string) any {
+		for _, k := range keys {
+			if v, ok := fields[k]; ok {
+				delete(fields, k)
+				return v
+			}
+		}
+		return nil
+	}
+	ts, level, msg := take(jsonTimeKeys), take(jsonLevelKeys), take(jsonMessageKeys)
+
+	parts := []string{}
+	if prefix := strings.TrimSpace(s[:start]); prefix != "" {
+		parts = append(parts, prefix)
+	}
+	if ts != nil {
+		parts = append(parts, jsonLogTime(ts))
+	}
+	if level != nil {
+		parts = append(parts, strings.ToUpper(jsonLogValue(level)))
+	}
+	if msg != nil {
+		parts = append(parts, jsonLogValue(msg))
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", k, jsonLogValue(fields[k])))
+	}
+	return strings.Join(parts, " ")
+}
+
+// jsonLogValue shows strings bare and everything else as JSON
+func jsonLogValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// jsonLogTime shows epoch timestamps (zap's default) as local time, strings
+// are left as the app wrote them
+func jsonLogTime(v any) string {
+	f, ok := v.(float64)
+	if !ok {
+		return jsonLogValue(v)
+	}
+	sec, frac := math.Modf(f)
+	// milliseconds, as some loggers write them
+	if sec > 1e11 {
+		sec, frac = math.Modf(f / 1000)
+	}
+	return time.Unix(int64(sec), int64(math.Round(frac*1e3))*1e6).Local().Format("2006-01-02 15:04:05.000")
+}
@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// logsSinceChoices are the windows offered by the since menu
+var logsSinceChoices = []struct {
+	key   string
+	since time.Duration
+}{
+	{"1", 5 * time.Minute},
+	{"2", 15 * time.Minute},
+	{"3", time.Hour},
+	{"4", 6 * time.Hour},
+	{"5", 24 * time.Hour},
+}
+
+func (m model) logOptions() docker.LogOptions {
+	return docker.LogOptions{Timestamps: m.logsTimestamps, Since: m.logsSince}
+}
+
+// fetchOpenLogsCmd fetches the logs the panel shows again, with the current options
+func (m model) fetchOpenLogsCmd() tea.Cmd {
+	switch {
+	case !m.logsVisible:
+		return nil
+	case len(m.logsMulti) > 0:
+		return fetchMultiLogsCmd(m.logsMulti, m.logOptions())
+	case m.logsIsProject:
+		return fetchComposeLogsCmd(m.logsContainer, m.logsWorkingDir, m.logOptions())
+	case m.logsContainer != "":
+		return fetchLogsCmd(m.logsContainer, m.logOptions())
+	}
+	return nil
+}
+
+func (m *model) toggleLogTimestamps() tea.Cmd {
+	m.logsTimestamps = !m.logsTimestamps
+	if m.logsTimestamps {
+		m.statusMessage = "Log lines start with their timestamp"
+	} else {
+		m.statusMessage = "Log timestamps hidden"
+	}
+	return m.fetchOpenLogsCmd()
+}
+
+// openLogsSinceMenu offers windows to look back into instead of the last lines
+func (m *model) openLogsSinceMenu() {
+	var items []menuItem
+	for _, c := range logsSinceChoices {
+		since := c.since
+		items = append(items, menuItem{key: c.key, label: "Last " + formatSince(since), action: func(m *model) tea.Cmd {
+			m.logsSince = since
+			m.statusMessage = fmt.Sprintf("Logs of the last %s", formatSince(since))
+			return m.fetchOpenLogsCmd()
+		}})
+	}
+	items = append(items, menuItem{key: "a", label: "The last lines, any age", action: func(m *model) tea.Cmd {
+		m.logsSince = 0
+		m.statusMessage = "Logs of the last lines"
+		return m.fetchOpenLogsCmd()
+	}})
+	m.openMenu("Show logs from", items)
+}
+
+// formatSince is a window as people say it, 5m, 1h or 24h
+func formatSince(d time.Duration) string {
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
@@ -3,6 +3,8 @@ package tui
 import (
 	"fmt"
 	"strings"
+
+	"github.com/charmbracelet/x/ansi"
 )
 
 func (m model) renderLogsPanel(width int) string {
@@ -12,6 +14,21 @@ func (m model) renderLogsPanel(width int) string {
 	b.WriteString("\n")
 
 	logsTitle := fmt.Sprintf("Logs: %s ", m.logsContainer)
+	if m.logsWrap {
+		logsTitle += "[wrap] "
+	}
+	if m.logsJSON {
+		logsTitle += "[json] "
+	}
+	if m.logsSince > 0 {
+		logsTitle += fmt.Sprintf("[last %s] ", formatSince(m.logsSince))
+	}
+	if m.logsTimestamps && len(m.logsMulti) == 0 {
+		logsTitle += "[time] "
+	}
+	if m.logsFiltered() {
+		logsTitle += fmt.Sprintf("[filter %d/%d] ", len(m.logsLines), len(m.logsRaw))
+	}
 	if len(logsTitle) < width {
 		logsTitle += strings.Repeat(" ", width-len(logsTitle))
 	}
@@ -23,21 +40,15 @@ func (m model) renderLogsPanel(width int) string {
 		maxLogLines = 1
 	}
 
-	startLog := 0
-	if len(m.logsLines) > maxLogLines {
-		startLog = len(m.logsLines) - maxLogLines
-	}
+	rows, _ := m.logRows(width-4, maxLogLines)
 
-	for i := startLog; i < len(m.logsLines); i++ {
-		logLine := m.logsLines[i]
-		if len(logLine) > width-4 {
-			logLine = logLine[:width-7] + "..."
-		}
-		b.WriteString(normalStyle.Render("  " + logLine))
+	for _, row := range rows {
+		// reset so an app's color doesn't run into the next row
+		b.WriteString(normalStyle.Render("  " + row + ansiReset))
 		b.WriteString("\n")
 	}
 
-	renderedLines := len(m.logsLines) - startLog
+	renderedLines := len(rows)
 	for i := renderedLines; i < maxLogLines; i++ {
 		b.WriteString(normalStyle.Render(strings.Repeat(" ", width)))
 		b.WriteString("\n")
@@ -45,3 +56,49 @@ func (m model) renderLogsPanel(width int) string {
 
 	return b.String()
 }
+
+// logLine is the i-th loaded log line as the panel shows it
+func (m model) logLine(i int) string {
+	line := m.logsLines[i]
+	if m.logsJSON {
+		line = formatJSONLogLine(line)
+	}
+	if m.settings.LogsLevelColors {
+		line = colorLogLevel(line)
+	}
+	if i < len(m.logsPrefixes) {
+		line = m.logsPrefixes[i] + line
+	}
+	return line
+}
+
+// logRows are the last height rows of the logs at width, and the index of
+// the first loaded line they show (part of)
+func (m model) logRows(width, height int) ([]string, int) {
+	var rows []string
+	first := len(m.logsLines)
+	// only the tail fits, so just look at that many source lines
+	for i := len(m.logsLines) - 1; i >= 0 && len(rows) < height; i-- {
+		line := m.logLine(i)
+		if m.logsWrap {
+			rows = append(wrapLine(line, width), rows...)
+		} else {
+			rows = append([]string{ansi.Truncate(line, width, "...")}, rows...)
+		}
+		first = i
+	}
+	if len(rows) > height {
+		rows = rows[len(rows)-height:]
+	}
+	return rows, first
+}
+
+const ansiReset = "\x1b[0m"
+
+// wrapLine breaks a line into rows of at most width cells, keeping colors intact
+func wrapLine(s string, width int) []string {
+	if width < 1 || ansi.StringWidth(s) <= width {
+		return []string{s}
+	}
+	return strings.Split(ansi.Hardwrap(s, width, true), "\n")
+}
@@ -1,12 +1,10 @@
 package tui
 
 import (
+	"cmp"
 	"fmt"
 	"os"
-	"os/exec"
-	"path/filepath"
 	"slices"
-	"sort"
 	"strings"
 	"time"
 
@@ -16,6 +14,8 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/shubh-io/dockmate/internal/config"
 	"github.com/shubh-io/dockmate/internal/docker"
+	"github.com/shubh-io/dockmate/internal/logging"
+	"github.com/shubh-io/dockmate/internal/metrics"
 )
 
 // layout sizing constants
@@ -27,31 +27,22 @@ const (
 )
 
 func InitialModel() model {
-	// Load configuration from file
-	cfg, _ := config.Load()
-
-	columnPercents := []int{
-		cfg.Layout.ContainerId,
-		cfg.Layout.ContainerNameWidth,
-		cfg.Layout.MemoryWidth,
-		cfg.Layout.CPUWidth,
-		cfg.Layout.NetIOWidth,
-		cfg.Layout.DiskIOWidth,
-		cfg.Layout.ImageWidth,
-		cfg.Layout.StatusWidth,
-		cfg.Layout.PortWidth,
-	}
-	VisibleColumns := []bool{
-		cfg.Layout.ContainerIdVisible,
-		cfg.Layout.ContainerNameVisible,
-		cfg.Layout.MemoryVisible,
-		cfg.Layout.CPUVisible,
-		cfg.Layout.NetIOVisible,
-		cfg.Layout.DiskIOVisible,
-		cfg.Layout.ImageVisible,
-		cfg.Layout.StatusVisible,
-		cfg.Layout.PortVisible,
+	// Load configuration from file, with the repo's .dockmate.yml merged on top
+	cwd, _ := os.Getwd()
+	cfg, overlay, overlayErr := config.LoadWithProject(cwd)
+
+	statusMessage := ""
+	if problems, _ := config.Validate(); len(problems) > 0 {
+		// Load falls back to defaults quietly, at least say so
+		statusMessage = fmt.Sprintf("Config has %d problem(s), run: dockmate config validate", len(problems))
+	} else if overlayErr != nil {
+		statusMessage = fmt.Sprintf("Ignoring project config: %v", overlayErr)
+	} else if overlay.Path != "" {
+		statusMessage = fmt.Sprintf("Using project config %s", overlay.Path)
+	} else if webURL != "" {
+		statusMessage = fmt.Sprintf("Read-only web view at %s", webURL)
 	}
+
 	helpList := list.New(nil, list.NewDefaultDelegate(), 0, 0)
 	helpList.Title = "Help"
 	helpList.SetShowHelp(true)
@@ -60,111 +51,215 @@ func InitialModel() model {
 	helpList.SetShowFilter(false)
 	helpList.SetFilteringEnabled(false)
 
-	return model{
-		loading:              true,
-		startTime:            time.Now(),
-		page:                 0,
-		maxContainersPerPage: 12,
-		terminalWidth:        0,
-		terminalHeight:       0,
-		projects:             make(map[string]*docker.ComposeProject),
-		expandedProjects:     make(map[string]bool),
-		flatList:             []treeRow{},
-		logsVisible:          false, // logs hidden by default
-		logPanelHeight:       LOG_PANEL_HEIGHT,
-		infoVisible:          false,
-		infoPanelHeight:      INFO_PANEL_HEIGHT,
-		infoContainer:        nil,
-		infoContainerID:      "",
-		sortBy:               sortByStatus,
-		sortAsc:              false, // descending
-		columnMode:           false,
-		selectedColumn:       7,
-		currentMode:          modeNormal,
-		helpList:             helpList,
+	m := model{
+		loading:          true,
+		startTime:        time.Now(),
+		offset:           0,
+		listHeight:       12,
+		terminalWidth:    0,
+		terminalHeight:   0,
+		projects:         make(map[string]*docker.ComposeProject),
+		expandedProjects: make(map[string]bool),
+		flatList:         []treeRow{},
+		logsVisible:      false, // logs hidden by default
+		logPanelHeight:   LOG_PANEL_HEIGHT,
+		infoVisible:      false,
+		infoPanelHeight:  INFO_PANEL_HEIGHT,
+		infoContainer:    nil,
+		infoContainerID:  "",
+		sortBy:           sortByStatus,
+		sortAsc:          false, // descending
+		thenSortBy:       sortByNone,
+		columnMode:       false,
+		selectedColumn:   7,
+		currentMode:      modeNormal,
+		helpList:         helpList,
 
 		// Load settings from config file
-		settings: Settings{
-			ColumnPercents:  columnPercents,
-			RefreshInterval: cfg.Performance.PollRate,
-			Runtime:         ContainerRuntime(cfg.Runtime.Type),
-			Shell:           cfg.Exec.Shell,
-			VisibleColumns:  VisibleColumns,
-		},
+		settings:         settingsFromConfig(cfg),
 		suspendRefresh:   false,
 		settingsSelected: 0,
+		ttlStopped:       make(map[string]bool),
+		lastStates:       make(map[string]string),
+		restartTracker:   docker.NewRestartTracker(),
+		projectFilter:    os.Getenv("COMPOSE_PROJECT_NAME"),
+		projectPins:      overlay.Pinned,
+		shellCache:       config.LoadShellCache(),
+		detectedRuntime:  docker.RuntimeBinary(),
+		statusMessage:    statusMessage,
+	}
+	m.precheckRunning = precheck != nil
+	m.applyViewState(config.LoadViewState())
+	// Init checks compose when the compose view is restored
+	m.composeChecked = m.composeViewMode && composeCheck != nil
+	m.composeChecking = m.composeChecked
+	if cfg.Record.File != "" {
+		rec, err := docker.NewStatsRecorder(expandHome(cfg.Record.File), cfg.Record.Format, int64(cfg.Record.MaxSizeMB)<<20, cfg.Record.MaxFiles)
+		if err != nil {
+			m.statusMessage = fmt.Sprintf("Not recording stats: %v", err)
+		} else {
+			m.recorder = rec
+		}
+	}
+	if cfg.Metrics.Sink != "" {
+		sink, err := metrics.New(cfg.Metrics.Sink, cfg.Metrics.URL, os.Getenv(cfg.Metrics.TokenEnv))
+		if err != nil {
+			m.statusMessage = fmt.Sprintf("Not pushing metrics: %v", err)
+		} else {
+			m.metricsSink = sink
+		}
 	}
+	if keys := m.shadowedCommandKeys(); len(keys) > 0 {
+		m.statusMessage = fmt.Sprintf("Custom command or template key(s) %s already used by DockMate, pick others", strings.Join(keys, ", "))
+	}
+	return m
 }
 
 // called once at startup
 // kicks off container fetch and timer
 func (m model) Init() tea.Cmd {
 
-	return tea.Batch(fetchContainers(), tickCmd(time.Duration(m.settings.RefreshInterval)*time.Second))
+	cmds := []tea.Cmd{fetchContainers(), tickCmd(time.Duration(m.settings.RefreshInterval) * time.Second), waitForConfigChange(), precheckCmd()}
+	if m.composeViewMode {
+		// restored into compose view
+		cmds = append(cmds, fetchComposeProjects(), runComposeCheck())
+	}
+	return tea.Batch(cmds...)
 }
 
-// sort containers by current column and direction
-func (m *model) sortContainers() {
-	lessContainer := func(a, b docker.Container) bool {
+// sortableColumns maps the columns, in table order, to what they sort by
+var sortableColumns = []struct {
+	name string
+	col  sortColumn
+}{
+	{"ID", sortByID},
+	{"Name", sortByName},
+	{"Memory", sortByMemory},
+	{"CPU", sortByCPU},
+	{"Net I/O", sortByNetIO},
+	{"Disk I/O", sortByBlockIO},
+	{"Image", sortByImage},
+	{"Status", sortByStatus},
+	{"Ports", sortByPorts},
+	{"Uptime", sortByUptime},
+	{"Created", sortByCreated},
+	{"Networks", sortByNetworks},
+	{"IP", sortByIP},
+	{"GPU", sortByGPU},
+}
 
-		switch m.sortBy {
-		case sortByID:
-			return a.ID < b.ID
+// selectedSortColumn is the column highlighted in column mode
+func (m model) selectedSortColumn() (string, sortColumn, bool) {
+	// m.selectedColumn matches the VISUAL order of TUI
+	i := 0
+	for id, c := range sortableColumns {
+		if !m.settings.VisibleColumns[id] {
+			continue
+		}
+		if i == m.selectedColumn {
+			return c.name, c.col, true
+		}
+		i++
+	}
+	return "", 0, false
+}
 
-		case sortByName:
-			ai, aj := "", ""
+// compareContainers orders a and b by one column, ascending
+func (m model) compareContainers(col sortColumn, a, b docker.Container) int {
+	// for the columns that only have a less func
+	byLess := func(less func(a, b docker.Container) bool) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+		return 0
+	}
 
-			if len(a.Names) > 0 {
-				ai = a.Names[0]
-			}
+	switch col {
+	case sortByID:
+		return cmp.Compare(a.ID, b.ID)
 
-			if len(b.Names) > 0 {
-				aj = b.Names[0]
-			}
-			return strings.ToLower(ai) < strings.ToLower(aj)
+	case sortByName:
+		ai, aj := "", ""
+
+		if len(a.Names) > 0 {
+			ai = a.Names[0]
+		}
+
+		if len(b.Names) > 0 {
+			aj = b.Names[0]
+		}
+		return cmp.Compare(strings.ToLower(ai), strings.ToLower(aj))
 
-		case sortByMemory:
-			return parsePercent(a.Memory) < parsePercent(b.Memory)
+	case sortByMemory:
+		return cmp.Compare(parsePercent(a.Memory), parsePercent(b.Memory))
 
-		case sortByCPU:
-			return parsePercent(a.CPU) < parsePercent(b.CPU)
-		case sortByImage:
-			return strings.ToLower(a.Image) < strings.ToLower(b.Image)
+	case sortByCPU:
+		return cmp.Compare(parsePercent(a.CPU), parsePercent(b.CPU))
+	case sortByImage:
+		return cmp.Compare(strings.ToLower(a.Image), strings.ToLower(b.Image))
 
-		case sortByStatus:
-			return strings.ToLower(a.Status) < strings.ToLower(b.Status)
+	case sortByStatus:
+		return cmp.Compare(strings.ToLower(a.Status), strings.ToLower(b.Status))
 
-		case sortByPorts:
-			return strings.ToLower(a.Ports) < strings.ToLower(b.Ports)
+	case sortByPorts:
+		return cmp.Compare(strings.ToLower(a.Ports), strings.ToLower(b.Ports))
 
-		case sortByNetIO:
+	case sortByNetIO:
+		return cmp.Compare(parseNetIO(a.NetIO), parseNetIO(b.NetIO))
 
-			return parseNetIO(a.NetIO) < parseNetIO(b.NetIO)
+	case sortByBlockIO:
+		return cmp.Compare(parseNetIO(a.BlockIO), parseNetIO(b.BlockIO))
+
+	case sortByUptime:
+		return cmp.Compare(m.uptime(a), m.uptime(b))
+
+	case sortByCreated:
+		return a.CreatedAt.Compare(b.CreatedAt)
+
+	case sortByNetworks:
+		return cmp.Compare(m.networksText(a), m.networksText(b))
+
+	case sortByIP:
+		return byLess(m.ipLess)
+
+	case sortByGPU:
+		return byLess(m.gpuLess)
+	}
+	return 0
+}
 
-		case sortByBlockIO:
-			return parseNetIO(a.BlockIO) < parseNetIO(b.BlockIO)
-		default:
-			return a.ID < b.ID
+// sort containers by current column and direction, then by the secondary
+// column. The sort is stable, rows that tie on both keep the runtime's order
+// instead of shuffling with every refresh.
+func (m *model) sortContainers() {
+	// pinned containers always go first, then the selected columns decide
+	compare := func(a, b docker.Container) int {
+		if pa, pb := m.isPinned(a), m.isPinned(b); pa != pb {
+			if pa {
+				return -1
+			}
+			return 1
+		}
+		c := m.compareContainers(m.sortBy, a, b)
+		if !m.sortAsc {
+			c = -c
+		}
+		if c == 0 && m.thenSortBy != sortByNone {
+			c = m.compareContainers(m.thenSortBy, a, b)
 		}
+		return c
 	}
 
 	// sort main container slice
-	sort.Slice(m.containers, func(i, j int) bool {
-		if m.sortAsc {
-			return lessContainer(m.containers[i], m.containers[j])
-		}
-		return !lessContainer(m.containers[i], m.containers[j])
-	})
+	slices.SortStableFunc(m.containers, compare)
 
 	// also sort containers inside each compose project so compose view  matches column sorting
 	if len(m.projects) > 0 {
 		for _, p := range m.projects {
-			sort.Slice(p.Containers, func(i, j int) bool {
-				if m.sortAsc {
-					return lessContainer(p.Containers[i], p.Containers[j])
-				}
-				return !lessContainer(p.Containers[i], p.Containers[j])
-			})
+			slices.SortStableFunc(p.Containers, compare)
 		}
 		if m.composeViewMode {
 			m.buildFlatList()
@@ -174,16 +269,15 @@ func (m *model) sortContainers() {
 
 // calculateMaxContainers determines how many containers fit on screen given current layout state
 func (m *model) calculateMaxContainers() int {
-	availableHeight := m.terminalHeight - HEADER_HEIGHT
-	if m.logsVisible {
+	availableHeight := m.terminalHeight - HEADER_HEIGHT - m.actionRows()
+	if m.taskVisible || m.eventsVisible {
 		availableHeight -= m.logPanelHeight
-	}
-	if m.infoVisible {
-		// if compose file dir visible:
-		if m.infoContainer != nil && m.infoContainer.ComposeFileDirectory != "" {
-			availableHeight -= INFO_PANEL_HEIGHT
-		} else {
-			availableHeight -= (INFO_PANEL_HEIGHT - 4)
+	} else {
+		if m.logsVisible {
+			availableHeight -= m.logPanelHeight
+		}
+		if m.infoVisible {
+			availableHeight -= m.infoPanelHeightFor(m.infoTarget())
 		}
 	}
 	maxContainers := availableHeight / CONTAINER_ROW_HEIGHT
@@ -193,56 +287,18 @@ func (m *model) calculateMaxContainers() int {
 	return maxContainers
 }
 
-// updatePagination recalculates page sizing and keeps cursor/page within bounds
-func (m *model) updatePagination() {
-	m.maxContainersPerPage = m.calculateMaxContainers()
-	if m.maxContainersPerPage < 1 {
-		m.maxContainersPerPage = 1
-	}
-
-	itemCount := len(m.containers)
+// selectedContainer returns the container under the cursor in either view, nil on a project row
+func (m *model) selectedContainer() *docker.Container {
 	if m.composeViewMode {
-		itemCount = len(m.flatList)
-	}
-
-	if itemCount == 0 {
-		m.cursor = 0
-		m.page = 0
-		return
-	}
-
-	if m.cursor >= itemCount {
-		m.cursor = itemCount - 1
-	}
-	if m.cursor < 0 {
-		m.cursor = 0
-	}
-
-	maxPage := (itemCount - 1) / m.maxContainersPerPage
-	if maxPage < 0 {
-		maxPage = 0
-	}
-	if m.page > maxPage {
-		m.page = maxPage
-	}
-
-	if m.cursor < m.page*m.maxContainersPerPage {
-		m.page = m.cursor / m.maxContainersPerPage
-	}
-	if m.cursor >= (m.page+1)*m.maxContainersPerPage {
-		m.page = m.cursor / m.maxContainersPerPage
-	}
-
-	// keep persistent page indicator up-to-date
-	if m.maxContainersPerPage > 0 {
-		maxPage = (itemCount - 1) / m.maxContainersPerPage
-		if maxPage < 0 {
-			maxPage = 0
+		if m.cursor < len(m.flatList) && !m.flatList[m.cursor].isProject {
+			return m.flatList[m.cursor].container
 		}
-		m.message = fmt.Sprintf("Page %d/%d", m.page+1, maxPage+1)
-	} else {
-		m.message = fmt.Sprintf("Page %d/%d", m.page+1, 1)
+		return nil
 	}
+	if m.cursor >= 0 && m.cursor < len(m.containers) {
+		return &m.containers[m.cursor]
+	}
+	return nil
 }
 
 // ============================================================================
@@ -259,32 +315,59 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.terminalWidth = msg.Width
 		m.terminalHeight = msg.Height
 		m.helpList.SetSize(msg.Width, msg.Height-2)
-		m.updatePagination()
+		m.updateViewport()
 		return m, nil
 
 	case docker.ContainersMsg:
 		// got container list
 		m.loading = false
+		var connCmd tea.Cmd
 		if msg.Err != nil {
 			m.err = msg.Err
+			connCmd = m.connectionLost(msg.Err)
 		} else {
-			m.containers = msg.Containers
+			connCmd = m.reconnected()
+			containers := m.filterContainers(msg.Containers)
+			m.checkPinnedAlerts(containers)
+			m.containers = containers
+			m.applyStats()
 			m.err = nil
+			m.lastRefresh = time.Now()
+			m.lastFetch = msg.Took
 			// sort with current settings
 			m.sortContainers()
+			m.publishWebView()
 			// If in compose view, just rebuild!!
 			if m.currentMode == modeComposeView {
 				m.buildFlatList()
 			}
 		}
 
-		// keep cursor in bounds
-		if m.cursor >= len(m.containers) {
-			m.cursor = max(0, len(m.containers)-1)
+		if msg.Err == nil && !m.composeViewMode {
+			m.restoreCursor()
 		}
 		m.refreshInfoContainer()
 
-		m.updatePagination()
+		m.updateViewport()
+		var inspectCmd tea.Cmd
+		if msg.Err == nil {
+			// all of them, the network filter needs the networks of the hidden ones
+			inspectCmd = inspectInfoCmd(msg.Containers)
+		}
+		var statsCmd tea.Cmd
+		if msg.Err == nil {
+			statsCmd = m.statsCmd(false)
+		}
+		return m, tea.Batch(m.stopExpiredContainers(), connCmd, inspectCmd, statsCmd)
+
+	case inspectInfoMsg:
+		return m, m.handleInspectInfo(msg)
+
+	case gpuUsageMsg:
+		m.handleGPUUsage(msg)
+		if m.sortBy == sortByGPU {
+			m.sortContainers()
+		}
 		return m, nil
 
 	case composeProjectsMsg:
@@ -292,9 +375,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		if msg.Err != nil {
 			m.err = msg.Err
-			m.statusMessage = fmt.Sprintf("Error fetching compose projects: %v", msg.Err)
+			if !m.disconnected {
+				m.statusMessage = fmt.Sprintf("Error fetching compose projects: %v", msg.Err)
+			}
 		} else {
-			m.projects = msg.Projects
+			m.projects = m.filterProjects(msg.Projects)
+			m.applyStats()
+			m.lastRefresh = time.Now()
+			m.lastFetch = msg.Took
 			if m.expandedProjects == nil {
 				m.expandedProjects = make(map[string]bool)
 			}
@@ -314,23 +402,41 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.cursor >= len(m.flatList) {
 				m.cursor = max(0, len(m.flatList)-1)
 			}
+			m.restoreCursor()
+		}
+
+		m.refreshInfoContainer()
+		// keep the cursor on screen
+		m.updateViewport()
+		if msg.Err != nil {
+			return m, nil
 		}
+		return m, m.statsCmd(false)
 
+	case statsMsg:
+		cmd := m.handleStats(msg)
+		m.publishWebView()
 		m.refreshInfoContainer()
-		// just update pagination
-		m.updatePagination()
+		return m, cmd
+
+	case metricsPushedMsg:
+		m.handleMetricsPushed(msg)
+		return m, nil
+
+	case multiLogsMsg:
+		m.handleMultiLogs(msg)
 		return m, nil
 
 	case docker.LogsMsg:
 		// got logs
+		m.logsMulti = nil
 		if msg.Err != nil {
 			m.statusMessage = fmt.Sprintf("Logs error: %v", msg.Err)
-			m.logsLines = nil
+			m.setLogs(nil, nil, nil)
 			m.logsVisible = false
 			m.logsIsProject = false
 			m.logsWorkingDir = ""
 		} else {
-			m.logsLines = msg.Lines
 			m.logsContainer = msg.ID
 			m.logsVisible = true
 
@@ -339,30 +445,172 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else {
 				m.logsIsProject = false
 			}
+			m.setLogs(cleanLogLines(msg.Lines, m.settings.LogsStripANSI), nil, nil)
 		}
-		m.updatePagination()
+		m.updateViewport()
 		return m, nil
 
 	case actionDoneMsg:
 		// docker action finished
 		if msg.err != nil {
+			logging.Errorf("%v", msg.err)
 			m.statusMessage = fmt.Sprintf("Error: %v", msg.err)
+		} else if msg.msg != "" {
+			m.statusMessage = msg.msg
 		} else {
 			m.statusMessage = "Action completed successfully"
 		}
 
 		return m, fetchContainers()
 
+	case autoUpdateMsg:
+		m.handleAutoUpdate(msg)
+		return m, nil
+
+	case unitLoadedMsg:
+		m.handleUnitLoaded(msg)
+		return m, nil
+
+	case unitActionMsg:
+		return m, m.handleUnitAction(msg)
+
+	case precheckMsg:
+		return m, m.handlePrecheck(msg)
+
+	case composeCheckMsg:
+		m.handleComposeCheck(msg)
+		return m, nil
+
+	case secretsMsg:
+		m.handleSecrets(msg)
+		return m, nil
+
+	case secretActionMsg:
+		return m, m.handleSecretAction(msg)
+
+	case composeConfigMsg:
+		m.handleComposeConfig(msg)
+		return m, nil
+
+	case projectsDiscoveredMsg:
+		m.handleProjectsDiscovered(msg)
+		return m, nil
+
+	case actionFinishedMsg:
+		return m, m.handleActionFinished(msg)
+
+	case containerDiffMsg:
+		m.handleContainerDiff(msg)
+		return m, nil
+
+	case undoExpiredMsg:
+		m.handleUndoExpired(msg)
+		return m, nil
+
+	case actionTickMsg:
+		return m, m.handleActionTick()
+
+	case shellDoneMsg:
+		return m, m.handleShellDone(msg)
+
+	case batchStageMsg:
+		return m, m.handleBatchStage(msg)
+
+	case filesListMsg:
+		return m, m.handleFilesList(msg)
+
+	case filePreviewMsg:
+		m.handleFilePreview(msg)
+		return m, nil
+
+	case taskLineMsg:
+		return m, m.handleTaskLine(msg)
+
+	case taskDoneMsg:
+		return m, m.handleTaskDone(msg)
+
+	case eventMsg:
+		return m, m.handleEvent(msg)
+
+	case eventsEndedMsg:
+		m.handleEventsEnded(msg)
+		return m, nil
+
+	case imageUpdatesMsg:
+		m.handleImageUpdates(msg)
+		return m, nil
+
+	case shellsDetectedMsg:
+		return m, m.handleShellsDetected(msg)
+
+	case imageHistoryMsg:
+		m.handleImageHistory(msg)
+		return m, nil
+
+	case imagesListedMsg:
+		return m, m.handleImagesListed(msg)
+
+	case containerRunMsg:
+		return m, m.handleContainerRun(msg)
+
+	case imageTaggedMsg:
+		m.handleImageTagged(msg)
+		return m, nil
+
+	case swarmStateMsg:
+		m.handleSwarmState(msg)
+		return m, nil
+
+	case swarmTasksMsg:
+		m.handleSwarmTasks(msg)
+		return m, nil
+
+	case runCommandMsg:
+		m.handleRunCommand(msg)
+		return m, nil
+
+	case composeFileMsg:
+		m.handleComposeFile(msg)
+		return m, nil
+
+	case networksMsg:
+		m.handleNetworks(msg)
+		return m, nil
+
+	case registryLoginsMsg:
+		m.handleRegistryLogins(msg)
+		return m, nil
+
+	case remoteTagsMsg:
+		m.handleRemoteTags(msg)
+		return m, nil
+
+	case imageScanMsg:
+		m.handleImageScan(msg)
+		return m, nil
+
+	case daemonInfoMsg:
+		m.handleDaemonInfo(msg)
+		return m, nil
+
+	case configChangedMsg:
+		m.reloadConfig()
+		return m, waitForConfigChange()
+
+	case reconnectMsg:
+		return m, m.handleReconnect()
+
 	case tickMsg:
 
-		if m.suspendRefresh {
+		if m.suspendRefresh || m.disconnected || m.refreshPaused {
+			// while disconnected only the reconnect attempts talk to the daemon
 			return m, tickCmd(time.Duration(m.settings.RefreshInterval) * time.Second)
 		}
-		if m.logsVisible && m.logsContainer != "" {
-			if m.logsIsProject {
-				return m, tea.Batch(fetchContainers(), tickCmd(time.Duration(m.settings.RefreshInterval)*time.Second), fetchComposeLogsCmd(m.logsContainer, m.logsWorkingDir))
-			}
-			return m, tea.Batch(fetchContainers(), tickCmd(time.Duration(m.settings.RefreshInterval)*time.Second), fetchLogsCmd(m.logsContainer))
+		if m.currentMode == modeSwarm {
+			return m, tea.Batch(m.refreshSwarm(), tickCmd(time.Duration(m.settings.RefreshInterval)*time.Second))
+		}
+		if logs := m.fetchOpenLogsCmd(); logs != nil {
+			return m, tea.Batch(fetchContainers(), tickCmd(time.Duration(m.settings.RefreshInterval)*time.Second), logs)
 		}
 		if m.composeViewMode {
 			// in compose view , refresh both compose projects and containers as per refresh interval
@@ -373,14 +621,132 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		// keyboard input
 		m.statusMessage = ""
-		if msg.String() == "ctrl+c" || msg.String() == "q" {
-			if !(m.currentMode == modeHelp) {
-				return m, tea.Quit
 
+		// dialogs take every key before the global shortcuts below
+		if m.currentMode == modeConfirmation {
+			switch msg.String() {
+			case "y", "Y":
+				m.currentMode = m.returnMode
+				m.suspendRefresh = false
+				m.statusMessage = "Action confirmed"
+				if m.pendingAction != nil {
+					cmd := m.pendingAction(&m)
+					m.pendingAction = nil
+					return m, cmd
+				}
+				return m, nil
+			case "n", "N", "esc", "q":
+				m.currentMode = m.returnMode
+				m.suspendRefresh = false
+				m.statusMessage = "Action cancelled"
+				m.pendingAction = nil
+				return m, nil
 			}
+			return m, nil
+		}
+
+		if m.currentMode == modeMenu {
+			return m.updateMenu(msg)
+		}
+
+		if m.currentMode == modePrompt {
+			return m.updatePrompt(msg)
+		}
+
+		if m.currentMode == modeLimits {
+			return m.updateLimits(msg)
+		}
+
+		if m.currentMode == modeFiles && msg.String() != "ctrl+c" {
+			return m.updateFiles(msg)
+		}
+
+		if m.currentMode == modeHistory && msg.String() != "ctrl+c" {
+			return m.updateHistory(msg)
+		}
+
+		if m.currentMode == modeScan && msg.String() != "ctrl+c" {
+			return m.updateScan(msg)
+		}
+
+		if m.currentMode == modeRegistry && msg.String() != "ctrl+c" {
+			return m.updateRegistry(msg)
+		}
+
+		if m.currentMode == modeSwarm && msg.String() != "ctrl+c" {
+			return m.updateSwarm(msg)
+		}
+
+		if m.currentMode == modeDaemonInfo && msg.String() != "ctrl+c" {
+			return m.updateDaemonInfo(msg)
+		}
+
+		if m.currentMode == modeDiff && msg.String() != "ctrl+c" {
+			return m.updateDiff(msg)
+		}
+
+		if m.currentMode == modeDepGraph && msg.String() != "ctrl+c" {
+			return m.updateDepGraph(msg)
+		}
+
+		if m.currentMode == modeDiscover && msg.String() != "ctrl+c" {
+			return m.updateDiscover(msg)
+		}
+
+		if m.currentMode == modeComposeConfig && msg.String() != "ctrl+c" {
+			return m.updateComposeConfig(msg)
+		}
+
+		if m.currentMode == modeUnit && msg.String() != "ctrl+c" {
+			return m.updateUnit(msg)
+		}
+
+		if m.currentMode == modeSecrets && msg.String() != "ctrl+c" {
+			return m.updateSecrets(msg)
+		}
+
+		if m.currentMode == modePrecheck && msg.String() != "ctrl+c" {
+			return m.updatePrecheck(msg)
+		}
+
+		// ctrl+c always gets out, q goes through the session quit hook
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if msg.String() == "q" && m.currentMode != modeHelp {
+			return m.quit()
 		}
 
 		if msg.String() == "esc" {
+			if m.taskVisible {
+				m.taskVisible = false
+				m.updateViewport()
+				return m, nil
+			}
+			if m.eventsVisible {
+				m.closeEvents()
+				return m, nil
+			}
+			if m.networkFilter != "" && !m.columnMode && !m.logsVisible && !m.infoVisible {
+				m.statusMessage = fmt.Sprintf("Showing all networks (was %s)", m.networkFilter)
+				m.networkFilter = ""
+				return m, tea.Batch(fetchContainers(), fetchComposeProjects())
+			}
+			if m.stateFilter != "" && !m.columnMode && !m.logsVisible && !m.infoVisible {
+				m.statusMessage = fmt.Sprintf("Showing containers in every state (was %s)", m.stateFilter)
+				m.stateFilter = ""
+				return m, tea.Batch(fetchContainers(), fetchComposeProjects())
+			}
+			if m.projectFilter != "" && !m.columnMode && !m.logsVisible && !m.infoVisible {
+				m.statusMessage = fmt.Sprintf("Showing all projects (was %s)", m.projectFilter)
+				m.projectFilter = ""
+				return m, tea.Batch(fetchContainers(), fetchComposeProjects())
+			}
+			if len(m.marked) > 0 && !m.columnMode && !m.logsVisible && !m.infoVisible {
+				m.statusMessage = fmt.Sprintf("Unmarked %d containers", len(m.marked))
+				m.marked = nil
+				return m, nil
+			}
 			if m.columnMode {
 				m.columnMode = false
 				m.currentMode = modeNormal
@@ -390,7 +756,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.logsVisible {
 				m.logsVisible = false
 				m.currentMode = modeNormal
-				m.updatePagination()
+				m.updateViewport()
 				m.statusMessage = "Logs closed"
 				m.logsIsProject = false
 				m.logsWorkingDir = ""
@@ -400,7 +766,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.infoVisible = false
 				m.infoContainer = nil
 				m.currentMode = modeNormal
-				m.updatePagination()
+				m.updateViewport()
 				m.statusMessage = "Info panel closed"
 				return m, nil
 			}
@@ -409,19 +775,29 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 
 		case "`":
-			debugLogger.Printf(
-				"STATE SNAPSHOT: width=%d height=%d page=%d cursor=%d perPage=%d selectedColumn=%d",
-				m.terminalWidth, m.terminalHeight, m.page, m.cursor, m.maxContainersPerPage, m.selectedColumn,
+			if !logging.Enabled(logging.LevelDebug) {
+				m.statusMessage = "Debug snapshots need --log-level debug"
+				return m, nil
+			}
+			logging.Debugf(
+				"STATE SNAPSHOT: width=%d height=%d offset=%d cursor=%d listHeight=%d selectedColumn=%d",
+				m.terminalWidth, m.terminalHeight, m.offset, m.cursor, m.listHeight, m.selectedColumn,
 			)
-			m.statusMessage = "Dumped debug snapshot"
+			m.statusMessage = fmt.Sprintf("Dumped debug snapshot to %s", logging.Path())
 			return m, nil
 		case " ":
+			if m.currentMode != modeSettings {
+				m.toggleMark()
+				return m, nil
+			}
 			// toggle visibility for column when selected
-			if m.settings.VisibleColumns == nil || len(m.settings.VisibleColumns) != 9 {
-				m.settings.VisibleColumns = []bool{true, true, true, true, true, true, true, true, true}
+			if len(m.settings.VisibleColumns) != numColumns {
+				m.settings.VisibleColumns = defaultVisibleColumns()
 			}
-			if m.settingsSelected >= 0 && m.settingsSelected <= 8 {
+			if m.settingsSelected >= 0 && m.settingsSelected < numColumns {
 				m.settings.VisibleColumns[m.settingsSelected] = !m.settings.VisibleColumns[m.settingsSelected]
+			} else if m.settingsSelected == settingsRowProjectColors {
+				m.settings.ProjectColors = !m.settings.ProjectColors
 			}
 			return m, nil
 
@@ -459,7 +835,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					total += p
 				}
 				if total == 0 {
-					m.settings.ColumnPercents = []int{8, 14, 6, 6, 10, 12, 18, 13, 13}
+					m.settings.ColumnPercents = defaultColumnPercents()
 				} else if total != 100 {
 					// normalize proportionally
 					newp := make([]int, len(m.settings.ColumnPercents))
@@ -479,6 +855,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.currentMode = modeSettings
 			m.suspendRefresh = true
+			m.podmanSockets = docker.PodmanSockets()
 			m.statusMessage = "Settings: adjust column % and refresh interval"
 			return m, nil
 
@@ -499,7 +876,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "l", "L":
 
-			if m.infoVisible {
+			if m.infoVisible || m.eventsVisible {
 				return m, nil
 			}
 
@@ -507,26 +884,31 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.logsVisible {
 				m.logsVisible = false
 				m.currentMode = modeNormal
-				m.updatePagination()
+				m.updateViewport()
 				m.statusMessage = "Logs closed"
 				m.logsIsProject = false
 				m.logsWorkingDir = ""
 				return m, nil
 			}
 
+			if marked := m.markedContainers(); len(marked) > 1 {
+				return m, m.openMultiLogs(marked)
+			}
+			m.logsMulti = nil
+
 			var containerID string
 			if m.composeViewMode && m.cursor < len(m.flatList) {
 				row := m.flatList[m.cursor]
 				if row.isProject {
 					proj, dir := m.getSelectedProject()
 					if proj != "" {
-						m.statusMessage = fmt.Sprintf("Fetching logs for project %s...", proj)
+						m.statusMessage = fmt.Sprintf("Fetching logs for project %s...", m.projectLabel(proj))
 						m.logsVisible = true
 						m.logsIsProject = true
 						m.logsWorkingDir = dir
 						m.currentMode = modeLogs
-						m.updatePagination()
-						return m, fetchComposeLogsCmd(proj, dir)
+						m.updateViewport()
+						return m, fetchComposeLogsCmd(proj, dir, m.logOptions())
 					}
 				}
 
@@ -543,8 +925,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.logsVisible = true
 				m.currentMode = modeLogs
 				m.statusMessage = "Fetching logs..."
-				m.updatePagination()
-				return m, fetchLogsCmd(containerID)
+				m.updateViewport()
+				return m, fetchLogsCmd(containerID, m.logOptions())
 			}
 
 			return m, nil
@@ -552,63 +934,27 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "enter":
 
 			if m.columnMode {
-				var col sortColumn
-				var canSort bool = true
-
-				type ColumnDef struct {
-					Name     string
-					SortFunc sortColumn
-					ID       int
-				}
-
-				var masterColumns = []ColumnDef{
-					{"ID", sortByID, 0},
-					{"Name", sortByName, 1},
-					{"Memory", sortByMemory, 2},
-					{"CPU", sortByCPU, 3},
-					{"Net I/O", sortByNetIO, 4},
-					{"Disk I/O", sortByBlockIO, 5},
-					{"Image", sortByImage, 6},
-					{"Status", sortByStatus, 7},
-					{"Ports", sortByPorts, 8},
-				}
-
-				var activeCols []ColumnDef
-				for _, colDef := range masterColumns {
-					if m.settings.VisibleColumns[colDef.ID] {
-						activeCols = append(activeCols, colDef)
-					}
-				}
-
-				// m.selectedColumn matches the VISUAL order of TUI
-				if m.selectedColumn >= 0 && m.selectedColumn < len(activeCols) {
-					col = activeCols[m.selectedColumn].SortFunc
-					canSort = true
-				} else {
-					canSort = false
-				}
-
-				if canSort {
-
+				if name, col, ok := m.selectedSortColumn(); ok {
 					if m.sortBy == col {
 						m.sortAsc = !m.sortAsc
 					} else {
 						m.sortBy = col
 						m.sortAsc = true
 					}
+					if m.thenSortBy == col {
+						m.thenSortBy = sortByNone
+					}
 					m.sortContainers()
+					m.saveViewState()
 
 					dir := "asc"
 					if !m.sortAsc {
 						dir = "desc"
 					}
-
-					if m.selectedColumn >= 0 && m.selectedColumn < len(activeCols) {
-						m.statusMessage = fmt.Sprintf("Sorted by %s (%s)", activeCols[m.selectedColumn].Name, dir)
-					} else {
-						m.statusMessage = fmt.Sprintf("Sorted (%s)", dir)
-					}
+					m.statusMessage = fmt.Sprintf("Sorted by %s (%s)", name, dir)
 				}
+			} else if m.composeViewMode {
+				m.toggleProjectExpanded()
 			}
 			return m, nil
 
@@ -642,28 +988,6 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		if m.currentMode == modeConfirmation {
-			switch msg.String() {
-			case "y", "Y":
-				m.currentMode = modeComposeView
-				m.suspendRefresh = false
-				m.statusMessage = "Action confirmed"
-				if m.pendingAction != nil {
-					cmd := m.pendingAction()
-					m.pendingAction = nil
-					return m, cmd
-				}
-				return m, nil
-			case "n", "N", "esc", "q":
-				m.currentMode = modeComposeView
-				m.suspendRefresh = false
-				m.statusMessage = "Action cancelled"
-				m.pendingAction = nil
-				return m, nil
-			}
-			return m, nil
-		}
-
 		if m.currentMode == modeHelp {
 			switch msg.String() {
 			case "esc", "f1", "q":
@@ -685,55 +1009,66 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, nil
 			case "down", "j":
-				if m.settingsSelected < 11 {
+				if m.settingsSelected < settingsRowRemote {
 					m.settingsSelected++
 				}
 				return m, nil
 			case "left", "h", "-":
-				if m.settings.ColumnPercents == nil || len(m.settings.ColumnPercents) != 9 {
-					m.settings.ColumnPercents = []int{8, 14, 6, 6, 10, 12, 18, 13, 13}
+				if len(m.settings.ColumnPercents) != numColumns {
+					m.settings.ColumnPercents = defaultColumnPercents()
 				}
-				if m.settingsSelected >= 0 && m.settingsSelected <= 8 {
+				if m.settingsSelected >= 0 && m.settingsSelected < numColumns {
 					if m.settings.ColumnPercents[m.settingsSelected] > 1 {
 						m.settings.ColumnPercents[m.settingsSelected]--
 					}
-				} else if m.settingsSelected == 9 {
+				} else if m.settingsSelected == settingsRowRefresh {
 					if m.settings.RefreshInterval > 1 {
 						m.settings.RefreshInterval--
 					}
-				} else if m.settingsSelected == 10 {
-					// toggle runtime option btwn docker and podman
-					if m.settings.Runtime == RuntimeDocker {
-						m.settings.Runtime = RuntimePodman
-					} else {
-						m.settings.Runtime = RuntimeDocker
+				} else if m.settingsSelected == settingsRowStats {
+					if m.settings.StatsInterval > 0 {
+						m.settings.StatsInterval--
 					}
-				} else if m.settingsSelected == 11 {
+				} else if m.settingsSelected == settingsRowRuntime {
+					// cycle runtime options backward
+					idx := slices.Index(RuntimeOptions, m.settings.Runtime)
+					m.settings.Runtime = RuntimeOptions[(idx-1+len(RuntimeOptions))%len(RuntimeOptions)]
+				} else if m.settingsSelected == settingsRowShell {
 					// cycle shell options backward
 					idx := slices.Index(ShellOptions, m.settings.Shell)
 					m.settings.Shell = ShellOptions[(idx-1+len(ShellOptions))%len(ShellOptions)]
+				} else if m.settingsSelected == settingsRowPodman {
+					m.cyclePodmanSocket(-1)
+				} else if m.settingsSelected == settingsRowRemote {
+					m.cycleRemote(-1)
 				}
 				return m, nil
 			case "right", "l", "+":
-				if m.settings.ColumnPercents == nil || len(m.settings.ColumnPercents) != 9 {
-					m.settings.ColumnPercents = []int{8, 14, 6, 6, 10, 12, 18, 13, 13}
+				if len(m.settings.ColumnPercents) != numColumns {
+					m.settings.ColumnPercents = defaultColumnPercents()
 				}
-				if m.settingsSelected >= 0 && m.settingsSelected <= 8 {
+				if m.settingsSelected >= 0 && m.settingsSelected < numColumns {
 					m.settings.ColumnPercents[m.settingsSelected]++
-				} else if m.settingsSelected == 9 {
+				} else if m.settingsSelected == settingsRowRefresh {
 					if m.settings.RefreshInterval < 300 {
 						m.settings.RefreshInterval++
 					}
-				} else if m.settingsSelected == 10 {
-					if m.settings.Runtime == RuntimeDocker {
-						m.settings.Runtime = RuntimePodman
-					} else {
-						m.settings.Runtime = RuntimeDocker
+				} else if m.settingsSelected == settingsRowStats {
+					if m.settings.StatsInterval < 300 {
+						m.settings.StatsInterval++
 					}
-				} else if m.settingsSelected == 11 {
+				} else if m.settingsSelected == settingsRowRuntime {
+					// cycle runtime options forward
+					idx := slices.Index(RuntimeOptions, m.settings.Runtime)
+					m.settings.Runtime = RuntimeOptions[(idx+1)%len(RuntimeOptions)]
+				} else if m.settingsSelected == settingsRowShell {
 					// cycle shell options forward
 					idx := slices.Index(ShellOptions, m.settings.Shell)
 					m.settings.Shell = ShellOptions[(idx+1)%len(ShellOptions)]
+				} else if m.settingsSelected == settingsRowPodman {
+					m.cyclePodmanSocket(1)
+				} else if m.settingsSelected == settingsRowRemote {
+					m.cycleRemote(1)
 				}
 				return m, nil
 			case "s", "S":
@@ -741,39 +1076,51 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				currentCfg, _ := config.Load()
 				// check if runtime is changed
 				runtimeChanged := string(m.settings.Runtime) != currentCfg.Runtime.Type
-				// Create .yaml config from current settings
-				cfg := &config.Config{
-					Layout: config.LayoutConfig{
-						ContainerId:        m.settings.ColumnPercents[0],
-						ContainerNameWidth: m.settings.ColumnPercents[1],
-						MemoryWidth:        m.settings.ColumnPercents[2],
-						CPUWidth:           m.settings.ColumnPercents[3],
-						NetIOWidth:         m.settings.ColumnPercents[4],
-						DiskIOWidth:        m.settings.ColumnPercents[5],
-						ImageWidth:         m.settings.ColumnPercents[6],
-						StatusWidth:        m.settings.ColumnPercents[7],
-						PortWidth:          m.settings.ColumnPercents[8],
-
-						ContainerIdVisible:   m.settings.VisibleColumns[0],
-						ContainerNameVisible: m.settings.VisibleColumns[1],
-						MemoryVisible:        m.settings.VisibleColumns[2],
-						CPUVisible:           m.settings.VisibleColumns[3],
-						NetIOVisible:         m.settings.VisibleColumns[4],
-						DiskIOVisible:        m.settings.VisibleColumns[5],
-						ImageVisible:         m.settings.VisibleColumns[6],
-						StatusVisible:        m.settings.VisibleColumns[7],
-						PortVisible:          m.settings.VisibleColumns[8],
-					},
-					Performance: config.PerformanceConfig{
-						PollRate: m.settings.RefreshInterval,
-					},
-					Runtime: config.RuntimeConfig{
-						Type: string(m.settings.Runtime),
-					},
-					Exec: config.ExecConfig{
-						Shell: m.settings.Shell,
-					},
+				// so is the socket, it's only read on start
+				runtimeChanged = runtimeChanged || m.settings.Socket != currentCfg.Runtime.Socket
+				runtimeChanged = runtimeChanged || m.settings.Remote != currentCfg.Runtime.Remote
+				// Apply current settings on top of the loaded config so sections
+				// without a settings row (ttl, socket...) survive the save
+				cfg := currentCfg
+				cfg.Layout = config.LayoutConfig{
+					ContainerId:        m.settings.ColumnPercents[0],
+					ContainerNameWidth: m.settings.ColumnPercents[1],
+					MemoryWidth:        m.settings.ColumnPercents[2],
+					CPUWidth:           m.settings.ColumnPercents[3],
+					NetIOWidth:         m.settings.ColumnPercents[4],
+					DiskIOWidth:        m.settings.ColumnPercents[5],
+					ImageWidth:         m.settings.ColumnPercents[6],
+					StatusWidth:        m.settings.ColumnPercents[7],
+					PortWidth:          m.settings.ColumnPercents[8],
+					UptimeWidth:        m.settings.ColumnPercents[9],
+					CreatedWidth:       m.settings.ColumnPercents[10],
+					NetworksWidth:      m.settings.ColumnPercents[11],
+					IPWidth:            m.settings.ColumnPercents[12],
+					GPUWidth:           m.settings.ColumnPercents[13],
+
+					ContainerIdVisible:   m.settings.VisibleColumns[0],
+					ContainerNameVisible: m.settings.VisibleColumns[1],
+					MemoryVisible:        m.settings.VisibleColumns[2],
+					CPUVisible:           m.settings.VisibleColumns[3],
+					NetIOVisible:         m.settings.VisibleColumns[4],
+					DiskIOVisible:        m.settings.VisibleColumns[5],
+					ImageVisible:         m.settings.VisibleColumns[6],
+					StatusVisible:        m.settings.VisibleColumns[7],
+					PortVisible:          m.settings.VisibleColumns[8],
+					UptimeVisible:        m.settings.VisibleColumns[9],
+					CreatedVisible:       m.settings.VisibleColumns[10],
+					NetworksVisible:      m.settings.VisibleColumns[11],
+					IPVisible:            m.settings.VisibleColumns[12],
+					GPUVisible:           m.settings.VisibleColumns[13],
+
+					ProjectColors: m.settings.ProjectColors,
 				}
+				cfg.Performance.PollRate = m.settings.RefreshInterval
+				cfg.Performance.StatsRate = m.settings.StatsInterval
+				cfg.Runtime.Type = string(m.settings.Runtime)
+				cfg.Runtime.Socket = m.settings.Socket
+				cfg.Runtime.Remote = m.settings.Remote
+				cfg.Exec.Shell = m.settings.Shell
 
 				// Save to config
 				if err := cfg.Save(); err != nil {
@@ -782,26 +1129,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if runtimeChanged {
 						m.statusMessage = "Settings saved! Restarting app..."
 
-						cfg.Runtime.RunPreChecks = true
-						if err := cfg.Save(); err != nil {
-							// log but don't fail prechecks
-							fmt.Fprintf(os.Stderr, "Warning: failed to save config after prechecks: %v\n", err)
-						}
-
-						// Create restart marker file so our app can detect and restart
-						markerPath := filepath.Join(os.TempDir(), ".dockmate_restart")
-						os.WriteFile(markerPath, []byte{}, 0644)
 						// Exit app to restart with new settings
-						return m, tea.Sequence(
-							tea.Tick(800*time.Millisecond, func(t time.Time) tea.Msg { return tea.QuitMsg{} }),
-						)
+						return m, restartCmd()
 					}
 					total := 0
 					for _, p := range m.settings.ColumnPercents {
 						total += p
 					}
-					if total == 0 {
-						m.settings.ColumnPercents = []int{8, 14, 6, 6, 10, 12, 18, 13, 13}
+					if total == 0 || len(m.settings.ColumnPercents) != numColumns {
+						m.settings.ColumnPercents = defaultColumnPercents()
 					} else if total != 100 {
 						newp := make([]int, len(m.settings.ColumnPercents))
 						acc := 0
@@ -829,44 +1165,48 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		if m.currentMode == modeComposeView || m.currentMode == modeNormal || m.currentMode == modeLogs || m.currentMode == modeInfo {
+			if readOnly && m.changesSomething(msg) {
+				m.refuseReadOnly()
+				return m, nil
+			}
+			if m.isComposeAction(msg) && m.refuseNoCompose() {
+				return m, nil
+			}
+
 			// Handle key bindings
 			switch {
 			case key.Matches(msg, Keys.Quit):
-				return m, tea.Quit
+				return m.quit()
 
 			case key.Matches(msg, Keys.ComposeUp) && m.isProjectSelected():
 				proj, dir := m.getSelectedProject()
 				if proj != "" {
-					m.confirmMessage = fmt.Sprintf("ARE YOU SURE you want to START compose project %q?", proj)
-					m.pendingAction = func() tea.Cmd {
-						m.statusMessage = fmt.Sprintf("Starting project %s...", proj)
-						return composeActionCmd("up", proj, dir)
-					}
-					m.currentMode = modeConfirmation
+					m.openComposeUp(proj, dir)
 					return m, nil
 				}
 
+			case key.Matches(msg, Keys.Info) && m.isProjectSelected():
+				if proj, dir := m.getSelectedProject(); proj != "" {
+					return m, m.openComposeConfig(proj, dir)
+				}
+
 			case key.Matches(msg, Keys.ComposeDown) && m.isProjectSelected():
 				proj, dir := m.getSelectedProject()
 				if proj != "" {
-					m.confirmMessage = fmt.Sprintf("ARE YOU SURE you want to BRING DOWN compose project %q?", proj)
-					m.pendingAction = func() tea.Cmd {
-						m.statusMessage = fmt.Sprintf("Stopping project %s...", proj)
+					m.confirm(fmt.Sprintf("ARE YOU SURE you want to BRING DOWN compose project %q?", m.projectLabel(proj)), func(m *model) tea.Cmd {
+						m.statusMessage = fmt.Sprintf("Stopping project %s...", m.projectLabel(proj))
 						return composeActionCmd("down", proj, dir)
-					}
-					m.currentMode = modeConfirmation
+					})
 					return m, nil
 				}
 
 			case key.Matches(msg, Keys.ComposeRestart) && m.isProjectSelected():
 				proj, dir := m.getSelectedProject()
 				if proj != "" {
-					m.confirmMessage = fmt.Sprintf("ARE YOU SURE you want to RESTART compose project %q?", proj)
-					m.pendingAction = func() tea.Cmd {
-						m.statusMessage = fmt.Sprintf("Restarting project %s...", proj)
+					m.confirm(fmt.Sprintf("ARE YOU SURE you want to RESTART compose project %q?", m.projectLabel(proj)), func(m *model) tea.Cmd {
+						m.statusMessage = fmt.Sprintf("Restarting project %s...", m.projectLabel(proj))
 						return composeActionCmd("restart", proj, dir)
-					}
-					m.currentMode = modeConfirmation
+					})
 					return m, nil
 				}
 
@@ -882,25 +1222,23 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							}
 						}
 					}
-					m.confirmMessage = fmt.Sprintf("ARE YOU SURE you want to %s compose project %q?", strings.ToUpper(action), proj)
-					m.pendingAction = func() tea.Cmd {
-						m.statusMessage = fmt.Sprintf("%s project %s...", strings.Title(action), proj)
+					m.confirm(fmt.Sprintf("ARE YOU SURE you want to %s compose project %q?", strings.ToUpper(action), m.projectLabel(proj)), func(m *model) tea.Cmd {
+						m.statusMessage = fmt.Sprintf("%s project %s...", strings.Title(action), m.projectLabel(proj))
 						return composeActionCmd(action, proj, dir)
-					}
-					m.currentMode = modeConfirmation
+					})
 					return m, nil
 				}
 
 			case key.Matches(msg, Keys.Logs) && m.isProjectSelected():
 				proj, dir := m.getSelectedProject()
 				if proj != "" {
-					m.statusMessage = fmt.Sprintf("Fetching logs for project %s...", proj)
+					m.statusMessage = fmt.Sprintf("Fetching logs for project %s...", m.projectLabel(proj))
 					m.logsVisible = true
 					m.logsIsProject = true
 					m.logsWorkingDir = dir
 					m.currentMode = modeLogs
-					m.updatePagination()
-					return m, fetchComposeLogsCmd(proj, dir)
+					m.updateViewport()
+					return m, fetchComposeLogsCmd(proj, dir, m.logOptions())
 
 				}
 
@@ -909,144 +1247,47 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case key.Matches(msg, Keys.ComposeStop) && m.isProjectSelected():
 				proj, dir := m.getSelectedProject()
 				if proj != "" {
-					m.confirmMessage = fmt.Sprintf("ARE YOU SURE you want to stop all containers in compose project %q?", proj)
-					m.pendingAction = func() tea.Cmd {
-						m.statusMessage = fmt.Sprintf("Stopping project %s...", proj)
-						return composeActionCmd("stop", proj, dir)
-					}
-					m.currentMode = modeConfirmation
+					m.confirm(fmt.Sprintf("ARE YOU SURE you want to stop all containers in compose project %q?", m.projectLabel(proj)), func(m *model) tea.Cmd {
+						p, ok := m.projects[proj]
+						if !ok {
+							m.statusMessage = fmt.Sprintf("Stopping project %s...", m.projectLabel(proj))
+							return composeActionCmd("stop", proj, dir)
+						}
+						return m.stopInOrder("project "+m.projectLabel(proj), p.Containers)
+					})
 					return m, nil
 				}
 			case key.Matches(msg, Keys.Up):
 				if !m.columnMode {
 					if m.composeViewMode {
-						if len(m.flatList) > 0 {
-							m.moveCursorUpTree()
-						}
-					} else {
-						if m.cursor > 0 {
-							m.cursor--
-						}
-					}
-					if m.maxContainersPerPage > 0 && m.cursor < m.page*m.maxContainersPerPage {
-						m.page--
-						if m.page < 0 {
-							m.page = 0
-						}
-					}
-					if m.cursor < 0 {
-						m.cursor = 0
+						m.moveCursorUpTree()
+					} else if m.cursor > 0 {
+						m.cursor--
 					}
+					m.updateViewport()
 				}
 
 			case key.Matches(msg, Keys.Down):
 				if !m.columnMode {
 					if m.composeViewMode {
-						if m.cursor < len(m.flatList)-1 {
-							m.moveCursorDownTree()
-						}
-					} else {
-						maxItems := len(m.containers) - 1
-						if m.cursor < maxItems {
-							m.cursor++
-						}
-					}
-					if m.maxContainersPerPage > 0 && m.cursor >= (m.page+1)*m.maxContainersPerPage {
-						m.page++
+						m.moveCursorDownTree()
+					} else if m.cursor < len(m.containers)-1 {
+						m.cursor++
 					}
+					m.updateViewport()
 				}
 
 			case key.Matches(msg, Keys.PageUp):
-				if m.page > 0 {
-					m.page--
-					if m.maxContainersPerPage > 0 {
-						if m.composeViewMode {
-							pageStart := m.page * m.maxContainersPerPage
-							if pageStart < 0 {
-								pageStart = 0
-							}
-							pageEnd := pageStart + m.maxContainersPerPage
-							if pageEnd > len(m.flatList) {
-								pageEnd = len(m.flatList)
-							}
-							found := -1
-							for i := pageStart; i < pageEnd && i < len(m.flatList); i++ {
-								if !m.flatList[i].isProject {
-									found = i
-									break
-								}
-							}
-							if found != -1 {
-								m.cursor = found
-							} else if len(m.flatList) > 0 {
-								for i := pageStart - 1; i >= 0; i-- {
-									if !m.flatList[i].isProject {
-										m.cursor = i
-										break
-									}
-								}
-							}
-						} else {
-							m.cursor = m.page * m.maxContainersPerPage
-							if m.cursor >= len(m.containers) {
-								m.cursor = max(0, len(m.containers)-1)
-							}
-						}
-					}
-				}
-				m.updatePagination()
+				m.scrollBy(-m.listHeight)
 
 			case key.Matches(msg, Keys.PageDown):
-				// Go to next page (right arrow)
-				maxPage := 0
-				if m.maxContainersPerPage > 0 {
-					count := len(m.containers)
-					if m.composeViewMode {
-						count = len(m.flatList)
-					}
-					maxPage = (count - 1) / m.maxContainersPerPage
-				}
-				if maxPage < 0 {
-					maxPage = 0
-				}
-				if m.page < maxPage {
-					m.page++
-					if m.maxContainersPerPage > 0 {
-						if m.composeViewMode {
-							pageStart := m.page * m.maxContainersPerPage
-							if pageStart < 0 {
-								pageStart = 0
-							}
-							pageEnd := pageStart + m.maxContainersPerPage
-							if pageEnd > len(m.flatList) {
-								pageEnd = len(m.flatList)
-							}
-							found := -1
-							for i := pageStart; i < pageEnd && i < len(m.flatList); i++ {
-								if !m.flatList[i].isProject {
-									found = i
-									break
-								}
-							}
-							if found != -1 {
-								m.cursor = found
-							} else if len(m.flatList) > 0 {
-								for i := pageStart; i < len(m.flatList); i++ {
-									if !m.flatList[i].isProject {
-										m.cursor = i
-										break
-									}
-								}
-							}
-						} else {
-							m.cursor = m.page * m.maxContainersPerPage
-							if m.cursor >= len(m.containers) {
-								m.cursor = max(0, len(m.containers)-1)
-							}
-						}
-					}
-				}
-				m.updatePagination()
+				m.scrollBy(m.listHeight)
+
+			case key.Matches(msg, Keys.HalfPageUp):
+				m.scrollBy(-max(1, m.listHeight/2))
+
+			case key.Matches(msg, Keys.HalfPageDown):
+				m.scrollBy(max(1, m.listHeight/2))
 
 			case key.Matches(msg, Keys.Refresh):
 				// Manually refresh container list
@@ -1056,43 +1297,299 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.logsWorkingDir = ""
 				m.infoVisible = false
 				m.infoContainer = nil
-				m.updatePagination()
-				return m, fetchContainers()
+				m.updateViewport()
+				return m, tea.Batch(fetchContainers(), m.statsCmd(true))
 
 			case msg.String() == "c", msg.String() == "C":
 				m.composeViewMode = !m.composeViewMode
 				m.currentMode = modeComposeView
+				m.saveViewState()
 				if m.composeViewMode {
 					m.statusMessage = "Switched to Compose view "
-					m.expandedProjects = make(map[string]bool)
-					m.expandedProjects["Standalone Containers"] = true
 					m.cursor = 0
-					m.page = 0
+					m.offset = 0
 
 					// to save up performance and API calls
-					return m, tea.Batch(fetchComposeProjects(), tickCmd(time.Duration(m.settings.RefreshInterval)*time.Second))
+					return m, tea.Batch(fetchComposeProjects(), tickCmd(time.Duration(m.settings.RefreshInterval)*time.Second), m.composeCheckCmd())
 				}
 				// Exiting compose view  - back to normal
 				m.statusMessage = "Switched to Container View"
 				m.cursor = 0
-				m.page = 0
-				m.updatePagination()
+				m.offset = 0
+				m.updateViewport()
+				return m, nil
+
+			case key.Matches(msg, Keys.Prune):
+				m.openPruneMenu()
+				return m, nil
+
+			case key.Matches(msg, Keys.Pin):
+				if c := m.selectedContainer(); c != nil {
+					m.togglePin(*c)
+				}
+
+			case key.Matches(msg, Keys.Events):
+				if m.eventsVisible {
+					m.closeEvents()
+					return m, nil
+				}
+				return m, m.openEvents()
+
+			case key.Matches(msg, Keys.EventsFilter) && m.eventsVisible:
+				return m, m.openEventsFilter()
+
+			case key.Matches(msg, Keys.NetworkFilter):
+				return m, m.openNetworkFilter()
+
+			case key.Matches(msg, Keys.Networks):
+				return m, m.openNetworks()
+
+			case key.Matches(msg, Keys.Limits):
+				c := m.selectedContainer()
+				if m.infoVisible {
+					c = m.infoTarget()
+				}
+				if c != nil {
+					return m, m.openLimits(*c)
+				}
+				return m, nil
+
+			case key.Matches(msg, Keys.StateFilter):
+				return m, m.toggleStateFilter(msg.String())
+
+			case key.Matches(msg, Keys.ProjectFilter):
+				return m, m.toggleProjectFilter()
+
+			case key.Matches(msg, Keys.ThenSort) && m.columnMode:
+				// secondary sort, for the rows that tie on the sort column
+				name, col, ok := m.selectedSortColumn()
+				switch {
+				case !ok:
+				case col == m.thenSortBy:
+					m.thenSortBy = sortByNone
+					m.statusMessage = "Secondary sort cleared"
+				case col == m.sortBy:
+					m.statusMessage = fmt.Sprintf("Already sorted by %s", name)
+				default:
+					m.thenSortBy = col
+					m.statusMessage = fmt.Sprintf("Ties sorted by %s (asc)", name)
+				}
+				m.sortContainers()
+				m.saveViewState()
+				return m, nil
+
+			case key.Matches(msg, Keys.PauseRefresh):
+				m.refreshPaused = !m.refreshPaused
+				if m.refreshPaused {
+					m.statusMessage = "Auto-refresh paused, F5 still refreshes"
+					return m, nil
+				}
+				m.statusMessage = "Auto-refresh resumed"
+				if m.composeViewMode {
+					return m, fetchComposeProjects()
+				}
+				return m, fetchContainers()
+
+			case key.Matches(msg, Keys.RestartPolicy):
+				c := m.selectedContainer()
+				if m.infoVisible {
+					c = m.infoTarget()
+				}
+				if c != nil {
+					m.openRestartPolicy(*c)
+				}
+				return m, nil
+
+			case key.Matches(msg, Keys.Mounts):
+				c := m.selectedContainer()
+				if m.infoVisible {
+					c = m.infoTarget()
+				}
+				if c != nil {
+					m.openMounts(*c)
+				}
+				return m, nil
+
+			case key.Matches(msg, Keys.WrapLogs) && m.logsVisible:
+				m.logsWrap = !m.logsWrap
+				if m.logsWrap {
+					m.statusMessage = "Log wrapping on"
+				} else {
+					m.statusMessage = "Log wrapping off"
+				}
+				return m, nil
+
+			case key.Matches(msg, Keys.JSONLogs) && m.logsVisible:
+				m.logsJSON = !m.logsJSON
+				if m.logsJSON {
+					m.statusMessage = "JSON log lines flattened to time, level, message and fields"
+				} else {
+					m.statusMessage = "JSON log lines shown as is"
+				}
+				return m, nil
+
+			case key.Matches(msg, Keys.SaveLogs) && m.logsVisible:
+				return m, m.saveLogs()
+
+			case key.Matches(msg, Keys.LogFilter) && m.logsVisible:
+				m.openLogFilterMenu()
+				return m, nil
+
+			case key.Matches(msg, Keys.LogTimestamps) && m.logsVisible:
+				return m, m.toggleLogTimestamps()
+
+			case key.Matches(msg, Keys.LogsSince) && m.logsVisible:
+				m.openLogsSinceMenu()
+				return m, nil
+
+			case key.Matches(msg, Keys.FollowLogs):
+				if c := m.selectedContainer(); c != nil {
+					return m, m.followLogs(*c)
+				}
+
+			case key.Matches(msg, Keys.QuickCommands):
+				if c := m.selectedContainer(); c != nil {
+					m.openQuickCommands(*c)
+				}
+
+			case key.Matches(msg, Keys.RunContainer):
+				return m, m.openRunWizard()
+
+			case key.Matches(msg, Keys.AllActions):
+				m.openAllActions()
+
+			case key.Matches(msg, Keys.ActionQueue):
+				m.openActionQueue()
+
+			case key.Matches(msg, Keys.Undo):
+				return m, m.undoLast()
+
+			case key.Matches(msg, Keys.DepGraph):
+				m.openDepGraph()
+
+			case key.Matches(msg, Keys.Discover):
+				return m, m.openDiscover()
+
+			case key.Matches(msg, Keys.AutoUpdate):
+				m.openAutoUpdate()
+
+			case key.Matches(msg, Keys.Unit):
+				if c := m.selectedContainer(); c != nil {
+					return m, m.openUnit(*c)
+				}
+
+			case key.Matches(msg, Keys.Secrets):
+				return m, m.openSecrets()
+
+			case key.Matches(msg, Keys.Profiles):
+				m.openProfiles()
+
+			case key.Matches(msg, Keys.Diff):
+				if c := m.selectedContainer(); c != nil {
+					return m, m.openContainerDiff(*c)
+				}
+
+			case key.Matches(msg, Keys.Note):
+				c := m.selectedContainer()
+				if c == nil {
+					m.statusMessage = "Select a container to attach a note to it"
+					return m, nil
+				}
+				return m, m.openNotePrompt(*c)
+
+			case key.Matches(msg, Keys.Alias):
+				proj, _ := m.getSelectedProject()
+				if proj == "" {
+					if c := m.selectedContainer(); c != nil {
+						proj = c.ComposeProject
+					}
+				}
+				if proj == "" {
+					m.statusMessage = "Select a compose project to give it an alias"
+					return m, nil
+				}
+				return m, m.openAliasPrompt(proj)
+
+			case key.Matches(msg, Keys.PortLookup):
+				return m, m.openPortLookup()
+
+			case key.Matches(msg, Keys.ImageActions):
+				m.openImageActions()
 				return m, nil
 
+			case key.Matches(msg, Keys.OpenCommit):
+				c := m.selectedContainer()
+				if m.infoVisible {
+					c = m.infoTarget()
+				}
+				if c != nil {
+					return m, m.openCommit(*c)
+				}
+
+			case key.Matches(msg, Keys.Files):
+				if c := m.selectedContainer(); c != nil {
+					return m, m.openFileBrowser(*c)
+				}
+
+			case key.Matches(msg, Keys.Copy):
+				m.copySelection()
+				return m, nil
+
+			case key.Matches(msg, Keys.CopyRow):
+				if c := m.selectedContainer(); c != nil {
+					m.copyToClipboard(m.containerRow(*c), "row")
+				}
+				return m, nil
+
+			case key.Matches(msg, Keys.Export):
+				if c := m.selectedContainer(); c != nil {
+					m.openExportMenu(*c)
+				}
+
+			case key.Matches(msg, Keys.ScanImage):
+				if c := m.selectedContainer(); c != nil {
+					return m, m.openImageScan(*c)
+				}
+
+			case key.Matches(msg, Keys.CheckUpdates):
+				return m, m.startImageUpdateCheck()
+
+			case key.Matches(msg, Keys.Registry):
+				return m, m.openRegistryPrompt()
+
+			case key.Matches(msg, Keys.Logins):
+				return m, m.openRegistryLogins()
+
+			case key.Matches(msg, Keys.Swarm):
+				return m, m.openSwarm()
+
+			case key.Matches(msg, Keys.DaemonInfo):
+				return m, m.openDaemonInfo()
+
+			case key.Matches(msg, Keys.PullRecreate):
+				if c := m.selectedContainer(); c != nil {
+					target := *c
+					if docker.AutoUpdatePolicy(target) != "" {
+						m.statusMessage = fmt.Sprintf("podman auto-update updates %s through its unit, Ctrl+P to run it", docker.ContainerName(target))
+						return m, nil
+					}
+					m.confirm(fmt.Sprintf("Pull the latest %s and recreate %s?", target.Image, docker.ContainerName(target)), func(m *model) tea.Cmd {
+						return m.pullAndRecreate(target)
+					})
+					return m, nil
+				}
+
 			case key.Matches(msg, Keys.Start):
 				// Start selected container
 				if m.composeViewMode {
 					// In compose view mode, get container from flatList
 					if m.cursor < len(m.flatList) && !m.flatList[m.cursor].isProject {
-						container := m.flatList[m.cursor].container
-						m.statusMessage = "Starting container..."
-						return m, doAction("start", container.ID)
+						return m, m.containerAction("start", *m.flatList[m.cursor].container)
 					}
 				} else {
 					// Normal mode
 					if len(m.containers) > 0 {
-						m.statusMessage = "Starting container..."
-						return m, doAction("start", m.containers[m.cursor].ID)
+						return m, m.containerAction("start", m.containers[m.cursor])
 					}
 				}
 
@@ -1100,22 +1597,19 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Stop selected container
 				if m.composeViewMode {
 					if m.cursor < len(m.flatList) && !m.flatList[m.cursor].isProject {
-						container := m.flatList[m.cursor].container
-						m.statusMessage = "Stopping container..."
-						return m, doAction("stop", container.ID)
+						return m, m.containerAction("stop", *m.flatList[m.cursor].container)
 					}
 				} else {
 					// Normal mode
 					if len(m.containers) > 0 {
-						m.statusMessage = "Stopping container..."
-						return m, doAction("stop", m.containers[m.cursor].ID)
+						return m, m.containerAction("stop", m.containers[m.cursor])
 					}
 				}
 
 			case key.Matches(msg, Keys.Info):
 				// Toggle info panel for selected container
 				var selected *docker.Container
-				if m.logsVisible {
+				if m.logsVisible || m.eventsVisible {
 					return m, nil
 				}
 				if m.composeViewMode {
@@ -1141,7 +1635,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.currentMode = modeNormal
 						m.statusMessage = "Info panel closed"
 					}
-					m.updatePagination()
+					m.updateViewport()
 				}
 
 			case key.Matches(msg, Keys.Exec):
@@ -1157,22 +1651,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 				if container != nil && container.State == "running" {
-					containerID := container.ID
-					m.statusMessage = "Opening interactive shell..."
-					// Falls back to /bin/sh if configured shell is not available in container
-					shell := m.settings.Shell
-					shellCmd := fmt.Sprintf(
-						"echo '--- You are now in the interactive shell of %s ---'; "+
-							"if [ -x '%s' ]; then exec '%s'; else exec /bin/sh; fi",
-						containerID, shell, shell,
-					)
-					c := exec.Command(string(m.settings.Runtime), "exec", "-it", containerID, "sh", "-c", shellCmd)
-					return m, tea.ExecProcess(c, func(err error) tea.Msg {
-						if err != nil {
-							return actionDoneMsg{err: fmt.Errorf("shell error: %v", err)}
-						}
-						return actionDoneMsg{err: nil}
-					})
+					return m, m.openShell(*container)
 				}
 
 			case key.Matches(msg, Keys.Restart):
@@ -1180,32 +1659,27 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.composeViewMode {
 
 					if m.cursor < len(m.flatList) && !m.flatList[m.cursor].isProject {
-						container := m.flatList[m.cursor].container
-						m.statusMessage = "Restarting container..."
-						return m, doAction("restart", container.ID)
+						return m, m.containerAction("restart", *m.flatList[m.cursor].container)
 					}
 				} else {
 					// Normal mode
 					if len(m.containers) > 0 {
-						m.statusMessage = "Restarting container..."
-						return m, doAction("restart", m.containers[m.cursor].ID)
+						return m, m.containerAction("restart", m.containers[m.cursor])
 					}
 				}
 
 			case key.Matches(msg, Keys.Remove):
-				// Remove selected container
-				if m.composeViewMode {
-					if m.cursor < len(m.flatList) && !m.flatList[m.cursor].isProject {
-						container := m.flatList[m.cursor].container
-						m.statusMessage = "Removing container..."
-						return m, doAction("rm", container.ID)
-					}
-				} else {
-					// Normal mode
-					if len(m.containers) > 0 {
-						m.statusMessage = "Removing container..."
-						return m, doAction("rm", m.containers[m.cursor].ID)
-					}
+				// Remove selected container, after asking
+				if c := m.selectedContainer(); c != nil {
+					m.openRemove(*c)
+				}
+
+			default:
+				if cc, ok := m.customCommandFor(msg.String()); ok {
+					return m, m.runCustomCommand(cc)
+				}
+				if t, ok := m.templateFor(msg.String()); ok {
+					return m, m.runTemplate(t)
 				}
 			}
 		}
@@ -1235,6 +1709,70 @@ func (m model) View() string {
 		return m.renderConfirmation(m.terminalWidth)
 	}
 
+	if m.currentMode == modeMenu {
+		return m.renderMenu(m.terminalWidth)
+	}
+
+	if m.currentMode == modePrompt {
+		return m.renderPrompt(m.terminalWidth)
+	}
+
+	if m.currentMode == modeLimits {
+		return m.renderLimits(m.terminalWidth)
+	}
+
+	if m.currentMode == modeFiles {
+		return m.renderFileBrowser(max(m.terminalWidth, 80))
+	}
+
+	if m.currentMode == modeHistory {
+		return m.renderImageHistory(max(m.terminalWidth, 80))
+	}
+
+	if m.currentMode == modeScan {
+		return m.renderImageScan(max(m.terminalWidth, 80))
+	}
+
+	if m.currentMode == modeRegistry {
+		return m.renderRegistryBrowser(max(m.terminalWidth, 80))
+	}
+
+	if m.currentMode == modeSwarm {
+		return m.renderSwarm(max(m.terminalWidth, 80))
+	}
+
+	if m.currentMode == modeDaemonInfo {
+		return m.renderDaemonInfo(max(m.terminalWidth, 80))
+	}
+
+	if m.currentMode == modeDiff {
+		return m.renderContainerDiff(max(m.terminalWidth, 80))
+	}
+
+	if m.currentMode == modeDepGraph {
+		return m.renderDepGraph(max(m.terminalWidth, 80))
+	}
+
+	if m.currentMode == modeDiscover {
+		return m.renderDiscover(max(m.terminalWidth, 80))
+	}
+
+	if m.currentMode == modeComposeConfig {
+		return m.renderComposeConfig(max(m.terminalWidth, 80))
+	}
+
+	if m.currentMode == modeUnit {
+		return m.renderUnit(max(m.terminalWidth, 80))
+	}
+
+	if m.currentMode == modeSecrets {
+		return m.renderSecrets(max(m.terminalWidth, 80))
+	}
+
+	if m.currentMode == modePrecheck {
+		return m.renderPrecheck(max(m.terminalWidth, 80))
+	}
+
 	var b strings.Builder
 
 	// Ensure minimum width
@@ -1265,19 +1803,33 @@ func (m model) View() string {
 	b.WriteString(statsSection)
 	b.WriteString("\n")
 
-	usableWidth := width - 2
+	rowsToShow := m.listHeight
+	if rowsToShow < 1 {
+		rowsToShow = m.calculateMaxContainers()
+	}
+	if rowsToShow < 1 {
+		rowsToShow = 1
+	}
+
+	// the scrollbar takes the last column while the list doesn't fit
+	scrollbar := m.scrollbar()
+	listWidth := width
+	if scrollbar != nil {
+		listWidth--
+	}
+	usableWidth := listWidth - 2
 
-	mins := []int{13, 17, 8, 6, 10, 11, 11, 13, 15}
+	mins := []int{13, 17, 8, 6, 10, 11, 11, 13, 15, 9, 10, 12, 12, 10}
 
 	percents := m.settings.ColumnPercents
-	if len(percents) != 9 {
-		percents = []int{8, 14, 6, 6, 10, 12, 11, 13, 15}
+	if len(percents) != numColumns {
+		percents = defaultColumnPercents()
 	}
 
 	// visible columns
 	visible := m.settings.VisibleColumns
-	if visible == nil || len(visible) != 9 {
-		visible = []bool{true, true, true, true, true, true, true, true, true}
+	if len(visible) != numColumns {
+		visible = defaultVisibleColumns()
 		m.settings.VisibleColumns = visible
 	}
 
@@ -1334,6 +1886,11 @@ func (m model) View() string {
 	imageW := widths[6]
 	statusW := widths[7]
 	portsW := widths[8]
+	uptimeW := widths[9]
+	createdW := widths[10]
+	networksW := widths[11]
+	ipW := widths[12]
+	gpuW := widths[13]
 
 	sortIndicator := func(col sortColumn) string {
 		if m.sortBy == col {
@@ -1342,6 +1899,9 @@ func (m model) View() string {
 			}
 			return " ▼"
 		}
+		if m.thenSortBy == col {
+			return " ▵"
+		}
 		return ""
 	}
 
@@ -1386,6 +1946,11 @@ func (m model) View() string {
 		{6, "IMAGE", sortByImage, imageW - 1},
 		{7, "STATUS", sortByStatus, statusW},
 		{8, "PORTS", sortByPorts, portsW - 2},
+		{9, "UPTIME", sortByUptime, uptimeW - 1},
+		{10, "CREATED", sortByCreated, createdW - 1},
+		{11, "NETWORKS", sortByNetworks, networksW - 1},
+		{12, "IP", sortByIP, ipW - 1},
+		{13, "GPU", sortByGPU, gpuW - 1},
 	}
 
 	first := true
@@ -1409,60 +1974,40 @@ func (m model) View() string {
 	}
 	b.WriteString(hdr)
 	b.WriteString("\n")
-	// container list (paginated)
-
-	rowsToShow := m.maxContainersPerPage
-	if rowsToShow < 1 {
-		rowsToShow = m.calculateMaxContainers()
-	}
-	if rowsToShow < 1 {
-		rowsToShow = 1
-	}
 
-	// render rows
+	// container list, the rows from m.offset that fit
 	rowsRendered := 0
-
-	if m.composeViewMode {
-		// Compose view mode -- render from flatList
-		pageStart := m.page * rowsToShow
-		if pageStart > len(m.flatList) {
-			pageStart = 0
-			if len(m.flatList) > rowsToShow {
-				pageStart = len(m.flatList) - rowsToShow
-			}
+	start := m.offset
+	end := min(m.offset+rowsToShow, m.listLen())
+	if sticky := m.stickyProject(); sticky >= 0 {
+		row := m.renderTreeRow(m.flatList[sticky], false, idW, nameW, memoryW, cpuW, netIOW, blockIOW, imageW, statusW, portsW, uptimeW, createdW, networksW, ipW, gpuW, listWidth)
+		if m.disconnected {
+			row = staleRow(row)
 		}
-		pageEnd := pageStart + rowsToShow
-		if pageEnd > len(m.flatList) {
-			pageEnd = len(m.flatList)
+		if scrollbar != nil {
+			row += scrollbar[0]
 		}
-
-		for i := pageStart; i < pageEnd; i++ {
-			row := m.renderTreeRow(m.flatList[i], i == m.cursor, idW, nameW, memoryW, cpuW, netIOW, blockIOW, imageW, statusW, portsW, width)
-			b.WriteString(row)
-			b.WriteString("\n")
-			rowsRendered++
+		b.WriteString(row)
+		b.WriteString("\n")
+		rowsRendered++
+		start++
+	}
+	for i := start; i < end; i++ {
+		var row string
+		if m.composeViewMode {
+			row = m.renderTreeRow(m.flatList[i], i == m.cursor, idW, nameW, memoryW, cpuW, netIOW, blockIOW, imageW, statusW, portsW, uptimeW, createdW, networksW, ipW, gpuW, listWidth)
+		} else {
+			row = m.renderContainerRow(m.containers[i], i == m.cursor, idW, nameW, memoryW, cpuW, netIOW, blockIOW, imageW, statusW, portsW, uptimeW, createdW, networksW, ipW, gpuW, listWidth)
 		}
-	} else {
-		// Normal mode: render from containers
-		pageStart := m.page * rowsToShow
-		if pageStart > len(m.containers) {
-			pageStart = 0
-			if len(m.containers) > rowsToShow {
-				pageStart = len(m.containers) - rowsToShow
-			}
+		if m.disconnected {
+			row = staleRow(row)
 		}
-		pageEnd := pageStart + rowsToShow
-		if pageEnd > len(m.containers) {
-			pageEnd = len(m.containers)
-		}
-
-		for i := pageStart; i < pageEnd; i++ {
-			c := m.containers[i]
-			row := m.renderContainerRow(c, i == m.cursor, idW, nameW, memoryW, cpuW, netIOW, blockIOW, imageW, statusW, portsW, width)
-			b.WriteString(row)
-			b.WriteString("\n")
-			rowsRendered++
+		if scrollbar != nil {
+			row += scrollbar[rowsRendered]
 		}
+		b.WriteString(row)
+		b.WriteString("\n")
+		rowsRendered++
 	}
 
 	// If no rows were rendered and app isnt loading, show the message.
@@ -1473,6 +2018,15 @@ func (m model) View() string {
 		emptyNow = !m.loading && len(m.containers) == 0
 	}
 
+	// the first list hasn't come yet
+	if m.loading && rowsRendered == 0 && m.lastRefresh.IsZero() {
+		for _, row := range m.renderSkeleton(width, rowsToShow) {
+			b.WriteString(row)
+			b.WriteString("\n")
+			rowsRendered++
+		}
+	}
+
 	if emptyNow && rowsRendered == 0 {
 		text := "No containers to display"
 		pad := (width - visibleLen(text)) / 2
@@ -1493,21 +2047,28 @@ func (m model) View() string {
 		b.WriteString("\n")
 	}
 
-	if m.logsVisible && !m.infoVisible {
-		b.WriteString(m.renderLogsPanel(width))
-	}
-	if m.infoVisible && !m.logsVisible {
-		b.WriteString(m.renderInfoPanel(width))
+	if m.taskVisible {
+		b.WriteString(m.renderTaskPanel(width))
+	} else if m.eventsVisible {
+		b.WriteString(m.renderEventsPanel(width))
+	} else {
+		if m.logsVisible && !m.infoVisible {
+			b.WriteString(m.renderLogsPanel(width))
+		}
+		if m.infoVisible && !m.logsVisible {
+			b.WriteString(m.renderInfoPanel(width))
+		}
 	}
 
 	pageLine := m.message
-	if pageLine == "" {
-		pageLine = fmt.Sprintf("Page %d/%d", m.page+1, 1)
-	}
 	if len(pageLine) < width {
 		pageLine += strings.Repeat(" ", width-len(pageLine))
 	}
-	b.WriteString(messageStyle.Render(pageLine))
+	if m.disconnected {
+		b.WriteString(m.renderReconnectBanner(width))
+	} else {
+		b.WriteString(messageStyle.Render(pageLine))
+	}
 	b.WriteString("\n")
 
 	if m.statusMessage != "" {
@@ -1518,6 +2079,7 @@ func (m model) View() string {
 		b.WriteString(messageStyle.Render(sm))
 		b.WriteString("\n")
 	}
+	b.WriteString(m.renderActionQueue(width))
 
 	b.WriteString(normalStyle.Render(strings.Repeat(" ", width)))
 	b.WriteString("\n")
@@ -1573,15 +2135,55 @@ func (m model) renderStatsSection(running, stopped, total int, uptime time.Durat
 		meterBracketStyle.Render("]"),
 		infoValueStyle.Render(fmt.Sprintf("%d/%d", running, total)))
 
-	infoLine := fmt.Sprintf("%s %s  %s %s  %s %s %s %s",
+	usage := docker.Summarize(m.containers)
+
+	refresh := infoValueStyle.Render(fmt.Sprintf("%ds", m.settings.RefreshInterval))
+	if m.refreshPaused {
+		refresh = messageStyle.Render("⏸ paused")
+	}
+	if !m.lastRefresh.IsZero() {
+		refresh += infoValueStyle.Render(fmt.Sprintf(" (last %s, %.1fs)", m.lastRefresh.Format("15:04:05"), m.lastFetch.Seconds()))
+	}
+
+	infoLine := fmt.Sprintf("%s %s  %s %s  %s %s  %s %s  %s %s  %s %s",
+		infoLabelStyle.Render("CPU:"),
+		infoValueStyle.Render(fmt.Sprintf("%.1f%%", usage.CPUPercent)),
+		infoLabelStyle.Render("Mem:"),
+		infoValueStyle.Render(docker.FormatBytesBinary(usage.MemBytes)),
 		infoLabelStyle.Render("Total:"),
 		infoValueStyle.Render(fmt.Sprintf("%d", total)),
 		infoLabelStyle.Render("Session:"),
 		infoValueStyle.Render(formatDuration(uptime)),
 		infoLabelStyle.Render("Refresh:"),
-		infoValueStyle.Render(fmt.Sprintf("%ds", m.settings.RefreshInterval)),
+		refresh,
 		infoLabelStyle.Render("Runtime:"),
-		infoValueStyle.Render(string(m.settings.Runtime)))
+		infoValueStyle.Render(m.runtimeLabel()))
+	if m.stateFilter != "" {
+		infoLine = fmt.Sprintf("%s %s  %s", infoLabelStyle.Render("Only:"), infoValueStyle.Render(m.stateFilter), infoLine)
+	}
+	if m.networkFilter != "" {
+		infoLine = fmt.Sprintf("%s %s  %s", infoLabelStyle.Render("Network:"), infoValueStyle.Render(m.networkFilter), infoLine)
+	}
+	if m.projectFilter != "" {
+		infoLine = fmt.Sprintf("%s %s  %s", infoLabelStyle.Render("Project:"), infoValueStyle.Render(m.projectLabel(m.projectFilter)), infoLine)
+	}
+	if webView != nil {
+		infoLine = fmt.Sprintf("%s %s  %s", infoLabelStyle.Render("Web:"), infoValueStyle.Render("on"), infoLine)
+	}
+	if m.recorder != nil {
+		infoLine = fmt.Sprintf("%s  %s", messageStyle.Render("● REC"), infoLine)
+	}
+	if readOnly {
+		infoLine = fmt.Sprintf("%s  %s", messageStyle.Render("READ-ONLY"), infoLine)
+	}
+	if profile := config.Cached().AppliedProfile(); profile != "" {
+		infoLine = fmt.Sprintf("%s %s  %s", infoLabelStyle.Render("Profile:"), infoValueStyle.Render(profile), infoLine)
+	}
+	if remote := remoteIndicator(); remote != "" {
+		infoLine = fmt.Sprintf("%s  %s", remote, infoLine)
+	} else if host := os.Getenv("DOCKER_HOST"); host != "" {
+		infoLine = fmt.Sprintf("%s %s  %s", infoLabelStyle.Render("Host:"), infoValueStyle.Render(host), infoLine)
+	}
 
 	leftLen := visibleLen(runningLine)
 	rightLen := visibleLen(infoLine)
@@ -1705,8 +2307,8 @@ func truncateToWidth(s string, width int) string {
 }
 
 func countVisibleColumns(visible []bool) int {
-	if visible == nil || len(visible) != 9 {
-		return 9
+	if len(visible) != numColumns {
+		return numColumns
 	}
 	count := 0
 	for _, vis := range visible {
@@ -1719,12 +2321,13 @@ func countVisibleColumns(visible []bool) int {
 
 // render one container row
 // applies styles based on selection and state
-func (m model) renderContainerRow(c docker.Container, selected bool, idW, nameW, memoryW, cpuW, netIOW, blockIOW, imageW, statusW, portsW, totalWidth int) string {
+func (m model) renderContainerRow(c docker.Container, selected bool, idW, nameW, memoryW, cpuW, netIOW, blockIOW, imageW, statusW, portsW, uptimeW, createdW, networksW, ipW, gpuW, totalWidth int) string {
 	// get name from names array
 	name := ""
 	if len(c.Names) > 0 {
 		name = c.Names[0]
 	}
+	name = m.nameBadges(c) + name + m.noteSuffix(c)
 
 	// truncate fields to fit
 	id := c.ID
@@ -1792,15 +2395,15 @@ func (m model) renderContainerRow(c docker.Container, selected bool, idW, nameW,
 
 	// build colm for visible columns only
 	visible := m.settings.VisibleColumns
-	if visible == nil || len(visible) != 9 {
-		visible = []bool{true, true, true, true, true, true, true, true, true}
+	if len(visible) != numColumns {
+		visible = defaultVisibleColumns()
 	}
 
-	padWidths := []int{idW - 1, nameW - 1, memoryW - 2, cpuW - 2, netIOW - 1, blockIOW - 1, imageW - 1, statusW, portsW - 2}
-	values := []string{id, name, mem, cpu, netio, blockio, img, status, ports}
+	padWidths := []int{idW - 1, nameW - 1, memoryW - 2, cpuW - 2, netIOW - 1, blockIOW - 1, imageW - 1, statusW, portsW - 2, uptimeW - 1, createdW - 1, networksW - 1, ipW - 1, gpuW - 1}
+	values := []string{id, name, mem, cpu, netio, blockio, img, status, ports, m.uptimeText(c), createdText(c), m.networksText(c), m.ipText(c), m.gpuText(c)}
 
-	parts := make([]string, 0, 9)
-	for i := 0; i < 9; i++ {
+	parts := make([]string, 0, numColumns)
+	for i := 0; i < numColumns; i++ {
 		if !visible[i] || padWidths[i] <= 0 {
 			continue
 		}
@@ -1820,20 +2423,24 @@ func (m model) renderContainerRow(c docker.Container, selected bool, idW, nameW,
 	}
 
 	// Apply style based on selection and state
-	if selected {
-		return selectedStyle.Render(row)
-	}
-
+	style := normalStyle
 	switch strings.ToLower(c.State) {
 	case "running":
-		return runningStyle.Render(row)
+		style = runningStyle
 	case "paused":
-		return pausedStyle.Render(row)
+		style = pausedStyle
 	case "exited", "dead":
-		return stoppedStyle.Render(row)
-	default:
-		return normalStyle.Render(row)
+		style = stoppedStyle
+	}
+	if selected {
+		style = selectedStyle
+	}
+
+	// the project bar takes the row's leading space
+	if m.settings.ProjectColors && c.ComposeProject != "" && strings.HasPrefix(row, " ") {
+		return projectBar(c.ComposeProject) + style.Render(row[1:])
 	}
+	return style.Render(row)
 }
 
 func padRight(s string, width int) string {
@@ -1894,7 +2501,7 @@ func (m model) renderFooter(width int) string {
 			desc string
 		}{
 			{"↑↓", "Nav"},
-			{"←→", "Nav pages"},
+			{"←→", "Scroll"},
 			{"Tab", "Col Mode"},
 			{"c", "Compose View"},
 			{"f1", "Keyboard shortcuts"},
@@ -1907,7 +2514,7 @@ func (m model) renderFooter(width int) string {
 				desc string
 			}{
 				{"↑↓", "Nav"},
-				{"←→", "Nav pages"},
+				{"←→", "Scroll"},
 				{"Tab", "Col Mode"},
 
 				{"c", "Normal View"},
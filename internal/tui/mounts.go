@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// mountText is a mount as the info panel shows it, e.g.
+// "bind /srv/app → /app (ro)" or "volume pgdata → /var/lib/data (rw)"
+func mountText(mt docker.Mount) string {
+	source := mt.Source
+	if mt.Type == "volume" && mt.Name != "" {
+		source = mt.Name
+	}
+	mode := "ro"
+	if mt.RW {
+		mode = "rw"
+	}
+	if source == "" {
+		return fmt.Sprintf("%s %s (%s)", mt.Type, mt.Destination, mode)
+	}
+	return fmt.Sprintf("%s %s → %s (%s)", mt.Type, source, mt.Destination, mode)
+}
+
+// mountFields are the info panel's Mount lines, one per mount
+func (m model) mountFields(c docker.Container) []infoField {
+	var fields []infoField
+	for _, mt := range m.inspectInfo[c.ID].Mounts {
+		fields = append(fields, infoField{"Mount", mountText(mt)})
+	}
+	return fields
+}
+
+// openMounts offers to open the container's bind mounts in the file manager,
+// or to copy their host paths
+func (m *model) openMounts(c docker.Container) {
+	name := docker.ContainerName(c)
+	info, ok := m.inspectInfo[c.ID]
+	if !ok {
+		m.statusMessage = fmt.Sprintf("Mounts of %s aren't known yet, try again after the next refresh", name)
+		return
+	}
+	paths := info.BindSources()
+	if len(paths) == 0 {
+		m.statusMessage = fmt.Sprintf("%s has no bind mounts (%d mounts in total)", name, len(info.Mounts))
+		return
+	}
+
+	var items []menuItem
+	for i, path := range paths {
+		if i == 9 {
+			break
+		}
+		items = append(items, menuItem{
+			key:   strconv.Itoa(i + 1),
+			label: truncateToWidth("Open "+path, 50),
+			action: func(m *model) tea.Cmd {
+				return openFolder(path)
+			},
+		})
+	}
+	items = append(items, menuItem{key: "y", label: fmt.Sprintf("Copy the %d host path(s)", len(paths)), action: func(m *model) tea.Cmd {
+		m.copyToClipboard(strings.Join(paths, "\n")+"\n", "host paths")
+		return nil
+	}})
+
+	m.openMenu(fmt.Sprintf("Bind mounts of %s", name), items)
+}
+
+// openFolder shows a bind mount's host path in the desktop's file manager.
+// With a remote DOCKER_HOST the path is on the other machine.
+func openFolder(path string) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := os.Stat(path); err != nil {
+			return actionDoneMsg{err: fmt.Errorf("can't open %s here: %v", path, err)}
+		}
+		cmd := desktopOpen(path)
+		if err := cmd.Start(); err != nil {
+			return actionDoneMsg{err: fmt.Errorf("can't open a file manager (%v), the path is %s", err, path)}
+		}
+		go cmd.Wait()
+		return actionDoneMsg{msg: fmt.Sprintf("Opened %s", path)}
+	}
+}
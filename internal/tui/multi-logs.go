@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// containers marked with Space get their logs tailed together: `l` with two
+// or more marked puts their lines in one panel, ordered by time, each with
+// its time and the container's name in a color of its own
+
+// multiLogsTail is how many lines each container contributes
+const multiLogsTail = 100
+
+// maxLogPrefixName caps the name column of the multi-container logs
+const maxLogPrefixName = 20
+
+type multiLogsMsg struct {
+	ids   []string
+	lines []docker.LogLine
+	err   error
+}
+
+func fetchMultiLogsCmd(ids []string, opts docker.LogOptions) tea.Cmd {
+	return func() tea.Msg {
+		lines, err := docker.GetInterleavedLogs(ids, multiLogsTail, opts)
+		return multiLogsMsg{ids: ids, lines: lines, err: err}
+	}
+}
+
+// toggleMark marks or unmarks the selected container and moves down, so a
+// run of containers is marked by holding Space
+func (m *model) toggleMark() {
+	c := m.selectedContainer()
+	if c == nil {
+		return
+	}
+	if m.marked == nil {
+		m.marked = make(map[string]bool)
+	}
+	if m.marked[c.ID] {
+		delete(m.marked, c.ID)
+	} else {
+		m.marked[c.ID] = true
+	}
+	m.cursor++
+	m.updateViewport()
+	m.statusMessage = fmt.Sprintf("%d marked, l to tail their logs together", len(m.marked))
+}
+
+// markedContainers are the marked containers still around, in list order
+func (m *model) markedContainers() []docker.Container {
+	var out []docker.Container
+	seen := make(map[string]bool)
+	for _, c := range m.statsContainers() {
+		if m.marked[c.ID] && !seen[c.ID] {
+			seen[c.ID] = true
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func (m *model) openMultiLogs(cs []docker.Container) tea.Cmd {
+	m.logsMulti = make([]string, len(cs))
+	m.logsNames = make(map[string]string, len(cs))
+	for i, c := range cs {
+		m.logsMulti[i] = c.ID
+		m.logsNames[c.ID] = docker.ContainerName(c)
+	}
+	m.logsVisible = true
+	m.logsIsProject = false
+	m.logsWorkingDir = ""
+	m.currentMode = modeLogs
+	m.statusMessage = fmt.Sprintf("Fetching logs of %d containers...", len(cs))
+	m.updateViewport()
+	return fetchMultiLogsCmd(m.logsMulti, m.logOptions())
+}
+
+func (m *model) handleMultiLogs(msg multiLogsMsg) {
+	if len(m.logsMulti) == 0 || !m.logsVisible {
+		// closed, or a single container opened, while this was on its way
+		return
+	}
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Logs error: %v", msg.err)
+		m.setLogs(nil, nil, nil)
+		m.logsVisible = false
+		m.logsMulti = nil
+		return
+	}
+
+	width := 0
+	for _, name := range m.logsNames {
+		width = max(width, min(len(name), maxLogPrefixName))
+	}
+	prefix := make(map[string]string, len(m.logsNames))
+	for id, name := range m.logsNames {
+		prefix[id] = lipgloss.NewStyle().Foreground(projectColor(name)).Render(padRight(truncateToWidth(name, width), width))
+	}
+
+	texts := make([]string, len(msg.lines))
+	prefixes := make([]string, len(msg.lines))
+	sources := make([]string, len(msg.lines))
+	for i, l := range msg.lines {
+		texts[i] = l.Text
+		sources[i] = m.logsNames[l.Container]
+		stamp := "            "
+		if !l.Time.IsZero() {
+			stamp = l.Time.In(time.Local).Format("15:04:05.000")
+		}
+		prefixes[i] = infoLabelStyle.Render(stamp) + " " + prefix[l.Container] + " │ "
+	}
+	m.logsContainer = fmt.Sprintf("%d containers", len(m.logsMulti))
+	m.setLogs(cleanLogLines(texts, m.settings.LogsStripANSI), prefixes, sources)
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+type networksMsg struct {
+	container docker.Container
+	networks  []docker.Network
+	err       error
+}
+
+func networksCmd(c docker.Container) tea.Cmd {
+	return func() tea.Msg {
+		networks, err := docker.ListNetworks()
+		return networksMsg{container: c, networks: networks, err: err}
+	}
+}
+
+// openNetworks lists the networks for the selected container to join or leave
+func (m *model) openNetworks() tea.Cmd {
+	c := m.selectedContainer()
+	if c == nil {
+		m.statusMessage = "Select a container to connect to a network"
+		return nil
+	}
+	m.statusMessage = "Listing networks..."
+	return networksCmd(*c)
+}
+
+// handleNetworks shows the picker: an attached network is disconnected, any
+// other one connected. Networks past the ninth can be typed in.
+func (m *model) handleNetworks(msg networksMsg) {
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Can't list networks: %v", msg.err)
+		return
+	}
+	m.statusMessage = ""
+
+	c := msg.container
+	name := docker.ContainerName(c)
+	attached := m.inspectInfo[c.ID].NetworkNames()
+
+	var items []menuItem
+	for i, n := range msg.networks {
+		if i == 9 {
+			break
+		}
+		network := n.Name
+		if slices.Contains(attached, network) {
+			items = append(items, menuItem{
+				key:   strconv.Itoa(i + 1),
+				label: truncateToWidth(fmt.Sprintf("✓ %s (%s)  disconnect", network, n.Driver), 50),
+				action: func(m *model) tea.Cmd {
+					m.confirm(fmt.Sprintf("Disconnect %s from %s?", name, network), func(m *model) tea.Cmd {
+						return disconnectNetworkCmd(network, c)
+					})
+					return nil
+				},
+			})
+			continue
+		}
+		items = append(items, menuItem{
+			key:   strconv.Itoa(i + 1),
+			label: truncateToWidth(fmt.Sprintf("  %s (%s)  connect", network, n.Driver), 50),
+			action: func(m *model) tea.Cmd {
+				return connectNetworkCmd(network, c)
+			},
+		})
+	}
+	items = append(items, menuItem{key: "c", label: "Connect to a network by name", action: func(m *model) tea.Cmd {
+		return m.prompt(fmt.Sprintf("Connect %s to network", name), "network name", func(m *model, network string) tea.Cmd {
+			if network == "" {
+				m.statusMessage = "Cancelled"
+				return nil
+			}
+			return connectNetworkCmd(network, c)
+		})
+	}})
+
+	m.openMenu(fmt.Sprintf("Networks of %s (pick one to connect or disconnect)", name), items)
+}
+
+func connectNetworkCmd(network string, c docker.Container) tea.Cmd {
+	return func() tea.Msg {
+		if err := docker.ConnectNetwork(network, c.ID); err != nil {
+			return actionDoneMsg{err: err}
+		}
+		return actionDoneMsg{msg: fmt.Sprintf("Connected %s to %s", docker.ContainerName(c), network)}
+	}
+}
+
+func disconnectNetworkCmd(network string, c docker.Container) tea.Cmd {
+	return func() tea.Msg {
+		if err := docker.DisconnectNetwork(network, c.ID); err != nil {
+			return actionDoneMsg{err: err}
+		}
+		return actionDoneMsg{msg: fmt.Sprintf("Disconnected %s from %s", docker.ContainerName(c), network)}
+	}
+}
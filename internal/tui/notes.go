@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/config"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// containerNote is the note attached to c by name, "" if there is none
+func (m model) containerNote(c docker.Container) string {
+	return m.settings.ContainerNotes[docker.ContainerName(c)]
+}
+
+// noteSuffix is what goes after the name in the NAME column
+func (m model) noteSuffix(c docker.Container) string {
+	if note := m.containerNote(c); note != "" {
+		return " — " + note
+	}
+	return ""
+}
+
+// openNotePrompt asks for a short note on a container, e.g. "DO NOT STOP"; empty removes it
+func (m *model) openNotePrompt(c docker.Container) tea.Cmd {
+	name := docker.ContainerName(c)
+	cmd := m.prompt(fmt.Sprintf("Note for %s (empty to clear)", name), "e.g. DO NOT STOP", func(m *model, value string) tea.Cmd {
+		m.setContainerNote(name, value)
+		return nil
+	})
+	m.promptInput.SetValue(m.settings.ContainerNotes[name])
+	return cmd
+}
+
+func (m *model) setContainerNote(name, note string) {
+	note = strings.TrimSpace(note)
+
+	notes := make(map[string]string, len(m.settings.ContainerNotes)+1)
+	for k, v := range m.settings.ContainerNotes {
+		notes[k] = v
+	}
+	if note == "" {
+		delete(notes, name)
+		m.statusMessage = fmt.Sprintf("Removed note from %s", name)
+	} else {
+		notes[name] = note
+		m.statusMessage = fmt.Sprintf("Noted %s: %s", name, note)
+	}
+	m.settings.ContainerNotes = notes
+
+	cfg, _ := config.Load()
+	cfg.ContainerNotes = notes
+	if err := cfg.Save(); err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to save note: %v", err)
+	}
+}
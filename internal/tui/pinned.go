@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/shubh-io/dockmate/internal/config"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+func (m model) isPinned(c docker.Container) bool {
+	return slices.Contains(m.settings.Pinned, docker.ContainerName(c))
+}
+
+// togglePin pins/unpins a container by name and saves it straight to config
+func (m *model) togglePin(c docker.Container) {
+	name := docker.ContainerName(c)
+	if name == "" {
+		return
+	}
+
+	if slices.Contains(m.projectPins, name) {
+		m.statusMessage = fmt.Sprintf("%s is pinned by the project's %s", name, config.ProjectFileName)
+		return
+	}
+
+	if idx := slices.Index(m.settings.Pinned, name); idx >= 0 {
+		m.settings.Pinned = slices.Delete(slices.Clone(m.settings.Pinned), idx, idx+1)
+		m.statusMessage = fmt.Sprintf("Unpinned %s", name)
+	} else {
+		m.settings.Pinned = append(slices.Clone(m.settings.Pinned), name)
+		m.statusMessage = fmt.Sprintf("Pinned %s", name)
+	}
+
+	// only personal pins go to the global config
+	cfg, _ := config.Load()
+	cfg.Pinned = nil
+	for _, p := range m.settings.Pinned {
+		if !slices.Contains(m.projectPins, p) {
+			cfg.Pinned = append(cfg.Pinned, p)
+		}
+	}
+	if err := cfg.Save(); err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to save pinned containers: %v", err)
+	}
+
+	m.sortContainers()
+	if m.composeViewMode {
+		m.buildFlatList()
+	}
+}
+
+// containerHealth pulls the health part out of a status like "Up 2 hours (unhealthy)"
+func containerHealth(c docker.Container) string {
+	st := strings.ToLower(c.Status)
+	switch {
+	case strings.Contains(st, "(unhealthy)"):
+		return "unhealthy"
+	case strings.Contains(st, "(healthy)"):
+		return "healthy"
+	case strings.Contains(st, "(health: starting)"):
+		return "starting"
+	}
+	return ""
+}
+
+// checkPinnedAlerts compares the new container list with the previous one and
+// raises an alert when a pinned container exits or turns unhealthy
+func (m *model) checkPinnedAlerts(containers []docker.Container) {
+	if m.lastStates == nil {
+		m.lastStates = make(map[string]string)
+	}
+
+	var alerts []string
+	seen := make(map[string]bool, len(containers))
+	for _, c := range containers {
+		seen[c.ID] = true
+		state := strings.ToLower(c.State) + "/" + containerHealth(c)
+		prev, known := m.lastStates[c.ID]
+		m.lastStates[c.ID] = state
+
+		if !known || prev == state || !m.isPinned(c) {
+			continue
+		}
+		wasRunning := strings.HasPrefix(prev, "running/")
+		switch {
+		case wasRunning && strings.ToLower(c.State) != "running":
+			alerts = append(alerts, fmt.Sprintf("%s %s", docker.ContainerName(c), c.State))
+		case containerHealth(c) == "unhealthy" && !strings.HasSuffix(prev, "/unhealthy"):
+			alerts = append(alerts, fmt.Sprintf("%s unhealthy", docker.ContainerName(c)))
+		}
+	}
+
+	// pinned containers that vanished entirely are worth a mention too
+	for id, prev := range m.lastStates {
+		if seen[id] {
+			continue
+		}
+		delete(m.lastStates, id)
+		for _, c := range m.containers {
+			if c.ID == id && m.isPinned(c) && strings.HasPrefix(prev, "running/") {
+				alerts = append(alerts, fmt.Sprintf("%s removed", docker.ContainerName(c)))
+			}
+		}
+	}
+
+	if len(alerts) > 0 {
+		m.statusMessage = "⚠ Pinned: " + strings.Join(alerts, ", ")
+	}
+}
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// settings switches podman between its rootless and rootful sockets through
+// runtime.socket. Either one only shows its own containers, so the row says
+// which one DockMate is on and what the other one takes.
+
+// podmanSocketOptions are the sockets the row cycles through: none (the
+// local podman of whoever runs DockMate), rootless and rootful, and a
+// custom one from the config
+func (m model) podmanSocketOptions() []string {
+	options := []string{"", docker.RootlessPodmanSocket(), docker.RootfulPodmanSocket}
+	for _, o := range options {
+		if o == docker.SocketPath(m.settings.Socket) {
+			return options
+		}
+	}
+	return append(options, m.settings.Socket)
+}
+
+func (m *model) cyclePodmanSocket(step int) {
+	if m.settings.Runtime != RuntimePodman {
+		m.statusMessage = "The rootless/rootful switch is for the podman runtime"
+		return
+	}
+	options := m.podmanSocketOptions()
+	idx := 0
+	for i, o := range options {
+		if o == docker.SocketPath(m.settings.Socket) || o == m.settings.Socket {
+			idx = i
+		}
+	}
+	m.settings.Socket = options[(idx+step+len(options))%len(options)]
+	m.podmanSockets = docker.PodmanSockets()
+}
+
+// podmanSocketRow is the settings row and the note below it
+func (m model) podmanSocketRow() (string, string) {
+	if m.settings.Runtime != RuntimePodman {
+		return "Podman: -", "Rootless or rootful podman, with the podman runtime"
+	}
+	connected := fmt.Sprintf("Connected to %s podman", docker.PodmanMode())
+	path := docker.SocketPath(m.settings.Socket)
+	for _, s := range m.podmanSockets {
+		if s.Path != path {
+			continue
+		}
+		row := fmt.Sprintf("Podman: %s (%s)", s.Mode, s.Path)
+		if hint := s.Hint(); hint != "" {
+			return row, connected + ". The " + s.Mode + " socket is " + hint
+		}
+		return row, connected + ", saving switches to " + s.Mode + " and restarts"
+	}
+	if m.settings.Socket == "" {
+		return "Podman: local (no socket, as the user running DockMate)", connected
+	}
+	return fmt.Sprintf("Podman: %s", m.settings.Socket), connected
+}
@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// openPortLookup asks for a host port and jumps to the container publishing it
+func (m *model) openPortLookup() tea.Cmd {
+	return m.prompt("Who owns this port?", "host port, e.g. 8080", func(m *model, value string) tea.Cmd {
+		port, err := strconv.Atoi(strings.TrimPrefix(value, ":"))
+		if err != nil || port < 1 || port > 65535 {
+			m.statusMessage = fmt.Sprintf("Not a port number: %q", value)
+			return nil
+		}
+		m.lookupPort(port)
+		return nil
+	})
+}
+
+func (m *model) lookupPort(port int) {
+	var owners []string
+	firstID := ""
+	for _, c := range m.containers {
+		mappings := c.PublishedOn(port)
+		if len(mappings) == 0 {
+			continue
+		}
+		if firstID == "" {
+			firstID = c.ID
+		}
+		pm := mappings[0]
+		owners = append(owners, fmt.Sprintf("%s (%s:%d->%d/%s)", docker.ContainerName(c), pm.HostIP, pm.HostPort, pm.ContainerPort, pm.Protocol))
+	}
+
+	if firstID == "" {
+		m.statusMessage = fmt.Sprintf("No container publishes port %d, something else on the host has it", port)
+		return
+	}
+
+	m.selectContainerByID(firstID)
+	m.statusMessage = fmt.Sprintf("Port %d: %s", port, strings.Join(owners, ", "))
+}
+
+// selectContainerByID moves the cursor to a container, expanding its project in the tree view
+func (m *model) selectContainerByID(id string) {
+	if m.composeViewMode {
+		for name, p := range m.projects {
+			for _, c := range p.Containers {
+				if c.ID == id {
+					m.expandedProjects[name] = true
+				}
+			}
+		}
+		m.buildFlatList()
+		for i, row := range m.flatList {
+			if !row.isProject && row.container != nil && row.container.ID == id {
+				m.cursor = i
+				break
+			}
+		}
+	} else {
+		for i, c := range m.containers {
+			if c.ID == id {
+				m.cursor = i
+				break
+			}
+		}
+	}
+	m.updateViewport()
+}
@@ -0,0 +1,249 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// the startup checks run next to the first fetch instead of before the TUI,
+// a slow daemon shows placeholder rows rather than a blank terminal. When they
+// fail the dialog says what's wrong and can run them again. Compose gets the
+// same dialog, the first time the compose view needs it.
+
+// PrecheckResult is what the startup checks found, Message and Suggestion
+// are only set when they failed
+type PrecheckResult struct {
+	Passed     bool
+	Message    string
+	Suggestion string
+}
+
+// precheck is set once from main, nil with --no-precheck
+var precheck func() PrecheckResult
+
+// SetPrecheck makes the TUI run fn when it starts, and again on retry
+func SetPrecheck(fn func() PrecheckResult) {
+	precheck = fn
+}
+
+// skeletonRows are the placeholder rows shown until the first list arrives
+const skeletonRows = 6
+
+var precheckTitleStyle = lipgloss.NewStyle().Foreground(meterRed).Bold(true)
+
+// composeCheck is set once from main
+var composeCheck func() PrecheckResult
+
+// SetComposeCheck makes the TUI run fn the first time the compose view opens
+func SetComposeCheck(fn func() PrecheckResult) {
+	composeCheck = fn
+}
+
+// checkDialog is a failed check, shown until it passes or is dismissed
+type checkDialog struct {
+	title   string
+	result  PrecheckResult
+	compose bool // CheckCompose rather than the startup checks
+}
+
+type precheckMsg struct {
+	result PrecheckResult
+}
+
+func precheckCmd() tea.Cmd {
+	if precheck == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return precheckMsg{result: precheck()}
+	}
+}
+
+func (m *model) handlePrecheck(msg precheckMsg) tea.Cmd {
+	m.precheckRunning = false
+	if !msg.result.Passed {
+		m.precheckFailed = true
+		m.showCheck(checkDialog{title: fmt.Sprintf("DockMate can't use %s yet", docker.RuntimeBinary()), result: msg.result})
+		return nil
+	}
+	m.closeCheck(false)
+	if !m.precheckFailed {
+		return nil
+	}
+	m.precheckFailed = false
+	m.statusMessage = "Startup checks passed"
+	m.loading = true
+	return fetchContainers()
+}
+
+type composeCheckMsg struct {
+	result PrecheckResult
+}
+
+func runComposeCheck() tea.Cmd {
+	if composeCheck == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return composeCheckMsg{result: composeCheck()}
+	}
+}
+
+// composeCheckCmd checks compose the first time it's needed
+func (m *model) composeCheckCmd() tea.Cmd {
+	if composeCheck == nil || m.composeChecked {
+		return nil
+	}
+	m.composeChecked = true
+	m.composeChecking = true
+	return runComposeCheck()
+}
+
+func (m *model) handleComposeCheck(msg composeCheckMsg) {
+	m.composeChecking = false
+	if msg.result.Passed {
+		if m.composeProblem != nil {
+			m.statusMessage = "Compose is available"
+		}
+		m.composeProblem = nil
+		m.closeCheck(true)
+		return
+	}
+	m.composeProblem = &msg.result
+	m.showCheck(checkDialog{title: "Compose isn't available", result: msg.result, compose: true})
+}
+
+// refuseNoCompose shows why compose actions don't work, when they don't
+func (m *model) refuseNoCompose() bool {
+	if m.composeProblem == nil {
+		return false
+	}
+	m.showCheck(checkDialog{title: "Compose isn't available", result: *m.composeProblem, compose: true})
+	return true
+}
+
+// isComposeAction reports whether msg runs compose on the selected project
+func (m model) isComposeAction(msg tea.KeyMsg) bool {
+	return m.isProjectSelected() && key.Matches(msg, Keys.ComposeUp, Keys.ComposeDown, Keys.ComposeRestart, Keys.ComposePause, Keys.ComposeStop)
+}
+
+func (m *model) showCheck(d checkDialog) {
+	m.checkDialog = &d
+	if m.currentMode != modePrecheck {
+		m.checkReturnMode = m.currentMode
+		m.currentMode = modePrecheck
+	}
+}
+
+// closeCheck closes the dialog if it's the compose one (or not)
+func (m *model) closeCheck(compose bool) {
+	if m.checkDialog == nil || m.checkDialog.compose != compose {
+		return
+	}
+	m.checkDialog = nil
+	if m.currentMode == modePrecheck {
+		m.currentMode = m.checkReturnMode
+	}
+}
+
+func (m model) updatePrecheck(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	d := m.checkDialog
+	if d == nil {
+		m.currentMode = m.checkReturnMode
+		return m, nil
+	}
+	switch msg.String() {
+	case "r", "R", "enter":
+		if d.compose && !m.composeChecking {
+			m.composeChecking = true
+			return m, runComposeCheck()
+		}
+		if !d.compose && !m.precheckRunning {
+			m.precheckRunning = true
+			return m, precheckCmd()
+		}
+	case "q", "Q":
+		if !d.compose {
+			return m, tea.Quit
+		}
+		fallthrough
+	case "esc":
+		// carry on without, the list says what the daemon answers
+		m.checkDialog = nil
+		m.currentMode = m.checkReturnMode
+		m.statusMessage = d.title + ": " + strings.SplitN(strings.TrimSpace(d.result.Message), "\n", 2)[0]
+	}
+	return m, nil
+}
+
+func (m model) renderPrecheck(width int) string {
+	dialogWidth := min(76, width-4)
+	d := m.checkDialog
+	if d == nil {
+		d = &checkDialog{}
+	}
+
+	var content strings.Builder
+	content.WriteString(precheckTitleStyle.Render(d.title))
+	content.WriteString("\n\n")
+	content.WriteString(strings.TrimSpace(d.result.Message))
+	if s := strings.TrimSpace(d.result.Suggestion); s != "" {
+		content.WriteString("\n\n")
+		content.WriteString(s)
+	}
+	content.WriteString("\n\n")
+	if (d.compose && m.composeChecking) || (!d.compose && m.precheckRunning) {
+		content.WriteString("Checking again...")
+	} else if d.compose {
+		content.WriteString("r retry · Esc close")
+	} else {
+		content.WriteString("r retry · Esc continue anyway · q quit")
+	}
+
+	dialog := lipgloss.NewStyle().
+		Width(dialogWidth).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(meterRed).
+		Padding(1, 2).
+		Render(content.String())
+
+	var b strings.Builder
+	b.WriteString(m.renderTitleBar(width))
+	b.WriteString("\n")
+	lines := strings.Split(dialog, "\n")
+	padTop := max(0, (m.terminalHeight-1-len(lines))/2)
+	padLeft := max(0, (width-lipgloss.Width(dialog))/2)
+	for i := 0; i < padTop; i++ {
+		b.WriteString("\n")
+	}
+	for _, line := range lines {
+		b.WriteString(strings.Repeat(" ", padLeft) + line + "\n")
+	}
+	return b.String()
+}
+
+// renderSkeleton fills the list with placeholder rows until the first list
+// arrives, so a slow daemon doesn't look like a hang
+func (m model) renderSkeleton(width, rows int) []string {
+	widths := []int{12, 22, 9, 7, 16, 28}
+	var out []string
+	for i := 0; i < min(rows, skeletonRows); i++ {
+		var line strings.Builder
+		for j, w := range widths {
+			// ragged like real names, not a solid block
+			w -= (i*3 + j*5) % 5
+			line.WriteString(" " + strings.Repeat("░", w) + strings.Repeat(" ", (i*3+j*5)%5))
+		}
+		out = append(out, staleRowStyle.Render(padRight(truncateToWidth(line.String(), width), width)))
+	}
+	if len(out) > 0 {
+		msg := fmt.Sprintf("  Connecting to %s...", docker.RuntimeBinary())
+		out[len(out)/2] = messageStyle.Render(padRight(msg, width))
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/config"
+)
+
+// the profile switcher lists the profiles in config.yml; picking one saves
+// it as `profile:` and restarts on it, the runtime may well be another one
+
+// restartCmd quits with the restart marker in place, main starts DockMate
+// again on the saved config
+func restartCmd() tea.Cmd {
+	markerPath := filepath.Join(os.TempDir(), ".dockmate_restart")
+	os.WriteFile(markerPath, []byte{}, 0644)
+	return tea.Tick(800*time.Millisecond, func(t time.Time) tea.Msg { return tea.QuitMsg{} })
+}
+
+func (m *model) openProfiles() {
+	cfg, _ := config.Load()
+	names := cfg.ProfileNames()
+	if len(names) == 0 {
+		m.statusMessage = "No profiles in the config, add them under profiles: in config.yml"
+		return
+	}
+	current := cfg.AppliedProfile()
+
+	label := func(name, text string) string {
+		if name == current {
+			return text + " (current)"
+		}
+		return text
+	}
+	items := []menuItem{{key: "0", label: label("", "No profile, the settings at the top of config.yml"), action: switchProfile("")}}
+	for i, name := range names {
+		if i == 9 {
+			break
+		}
+		items = append(items, menuItem{key: fmt.Sprintf("%d", i+1), label: label(name, name), action: switchProfile(name)})
+	}
+	m.openMenu("Switch profile", items)
+}
+
+func switchProfile(name string) func(m *model) tea.Cmd {
+	return func(m *model) tea.Cmd {
+		cfg, _ := config.Load()
+		if name == cfg.AppliedProfile() {
+			m.statusMessage = "Already on that profile"
+			return nil
+		}
+		cfg.Profile = name
+		if err := cfg.Save(); err != nil {
+			m.statusMessage = fmt.Sprintf("Failed to save config: %v", err)
+			return nil
+		}
+		// dockmate --profile would otherwise win over the choice
+		if os.Getenv(config.ProfileEnv) != "" {
+			os.Setenv(config.ProfileEnv, name)
+		}
+		if name == "" {
+			m.statusMessage = "Switching off the profile, restarting..."
+		} else {
+			m.statusMessage = fmt.Sprintf("Switching to profile %s, restarting...", name)
+		}
+		return restartCmd()
+	}
+}
@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// the project filter comes from COMPOSE_PROJECT_NAME, set by direnv or by a
+// .dockmate.yml/.envrc marker, so DockMate opens on the repo's own project,
+// or from 4 on a project. The network filter is set with N and the state
+// filter with 1-3, both narrow the list further. They all last until Esc or
+// the end of the session.
+
+// state filters, by the key that toggles them
+var stateFilters = map[string]string{
+	"1": "running",
+	"2": "exited",
+	"3": "unhealthy",
+}
+
+func (m model) matchesStateFilter(c docker.Container) bool {
+	state := strings.ToLower(c.State)
+	switch m.stateFilter {
+	case "running":
+		return state == "running"
+	case "exited":
+		return state == "exited" || state == "dead"
+	case "unhealthy":
+		return containerHealth(c) == "unhealthy"
+	}
+	return true
+}
+
+func (m model) filterContainers(cs []docker.Container) []docker.Container {
+	if m.projectFilter == "" && m.networkFilter == "" && m.stateFilter == "" {
+		return cs
+	}
+	var out []docker.Container
+	for _, c := range cs {
+		if m.projectFilter != "" && c.ComposeProject != m.projectFilter {
+			continue
+		}
+		if m.networkFilter != "" && !m.onNetwork(c) {
+			continue
+		}
+		if !m.matchesStateFilter(c) {
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+func (m model) filterProjects(projects map[string]*docker.ComposeProject) map[string]*docker.ComposeProject {
+	if m.projectFilter == "" && m.networkFilter == "" && m.stateFilter == "" {
+		return projects
+	}
+	out := make(map[string]*docker.ComposeProject)
+	for name, p := range projects {
+		if m.projectFilter != "" && name != m.projectFilter {
+			continue
+		}
+		if m.networkFilter != "" || m.stateFilter != "" {
+			// a copy, the projects of the message aren't ours to change
+			filtered := *p
+			filtered.Containers = m.filterContainers(p.Containers)
+			if len(filtered.Containers) == 0 {
+				continue
+			}
+			p = &filtered
+		}
+		out[name] = p
+	}
+	return out
+}
+
+// toggleStateFilter switches to the filter of key, or back to every state
+// when it's the one already on
+func (m *model) toggleStateFilter(key string) tea.Cmd {
+	filter := stateFilters[key]
+	if m.stateFilter == filter {
+		m.stateFilter = ""
+		m.statusMessage = "Showing containers in every state"
+	} else {
+		m.stateFilter = filter
+		m.statusMessage = fmt.Sprintf("Showing %s containers only (%s again shows all)", filter, key)
+	}
+	m.cursor = 0
+	return tea.Batch(fetchContainers(), fetchComposeProjects())
+}
+
+// toggleProjectFilter narrows the list to the project under the cursor, or
+// shows every project again
+func (m *model) toggleProjectFilter() tea.Cmd {
+	if m.projectFilter != "" {
+		m.statusMessage = fmt.Sprintf("Showing all projects (was %s)", m.projectLabel(m.projectFilter))
+		m.projectFilter = ""
+		return tea.Batch(fetchContainers(), fetchComposeProjects())
+	}
+	project, _ := m.getSelectedProject()
+	if c := m.selectedContainer(); c != nil && project == "" {
+		project = c.ComposeProject
+	}
+	if project == "" {
+		m.statusMessage = "Not part of a compose project"
+		return nil
+	}
+	m.projectFilter = project
+	m.statusMessage = fmt.Sprintf("Showing project %s only (4 again shows all)", m.projectLabel(project))
+	m.cursor = 0
+	return tea.Batch(fetchContainers(), fetchComposeProjects())
+}
@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// provenanceFields adds the image's OCI build labels to the info panel
+func provenanceFields(c docker.Container) []infoField {
+	p := docker.ImageProvenance(c)
+
+	var fields []infoField
+	if p.Revision != "" {
+		fields = append(fields, infoField{"Revision", p.Revision})
+	}
+	if p.Source != "" {
+		fields = append(fields, infoField{"Source", p.Source})
+	}
+	if p.Version != "" {
+		fields = append(fields, infoField{"Version", p.Version})
+	}
+	if !p.Created.IsZero() {
+		fields = append(fields, infoField{"Built", p.Created.Local().Format("2006-01-02 15:04")})
+	}
+	if url := p.CommitURL(); url != "" && p.Revision != "" {
+		fields = append(fields, infoField{"Commit", url + "  [v] open"})
+	}
+	return fields
+}
+
+// openCommit opens the commit the container's image was built from
+func (m *model) openCommit(c docker.Container) tea.Cmd {
+	p := docker.ImageProvenance(c)
+	url := p.CommitURL()
+	switch {
+	case p.Empty():
+		m.statusMessage = fmt.Sprintf("%s has no org.opencontainers.image.* labels", docker.ContainerName(c))
+		return nil
+	case url == "":
+		m.statusMessage = fmt.Sprintf("Don't know how to link to source %q", p.Source)
+		return nil
+	}
+
+	m.statusMessage = fmt.Sprintf("Opening %s", url)
+	return openBrowser(url)
+}
+
+// desktopOpen is the command handing target, a URL or a path, to the
+// desktop's default application
+func desktopOpen(target string) *exec.Cmd {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", target)
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+	default:
+		return exec.Command("xdg-open", target)
+	}
+}
+
+// openBrowser hands url to the desktop's default browser
+func openBrowser(url string) tea.Cmd {
+	return func() tea.Msg {
+		cmd := desktopOpen(url)
+		if err := cmd.Start(); err != nil {
+			return actionDoneMsg{err: fmt.Errorf("can't open a browser (%v), the link is %s", err, url)}
+		}
+		go cmd.Wait()
+		return actionDoneMsg{msg: fmt.Sprintf("Opened %s", url)}
+	}
+}
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// quick commands are the usual first looks inside a container, run without
+// opening a shell. The output goes to the task panel.
+
+type quickCommand struct {
+	key    string
+	label  string
+	script string
+}
+
+// scripts fall back to what busybox and slim images have
+var quickCommands = []quickCommand{
+	{"p", "Processes (ps aux)", "ps aux 2>/dev/null || ps"},
+	{"e", "Environment (env)", "env | sort"},
+	{"d", "Disk usage (df -h)", "df -h"},
+	{"n", "Listening sockets (ss / netstat)",
+		"if command -v ss >/dev/null; then ss -tulpn; " +
+			"elif command -v netstat >/dev/null; then netstat -tulpn; " +
+			"else echo 'neither ss nor netstat is in the container' >&2; exit 127; fi"},
+	{"o", "OS release", "cat /etc/os-release"},
+}
+
+// openQuickCommands offers the quick commands for a running container
+func (m *model) openQuickCommands(c docker.Container) {
+	name := docker.ContainerName(c)
+	if strings.ToLower(c.State) != "running" {
+		m.statusMessage = fmt.Sprintf("%s isn't running", name)
+		return
+	}
+	items := make([]menuItem, 0, len(quickCommands))
+	for _, qc := range quickCommands {
+		items = append(items, menuItem{
+			key:   qc.key,
+			label: qc.label,
+			action: func(m *model) tea.Cmd {
+				return m.runQuickCommand(c.ID, name, qc)
+			},
+		})
+	}
+	m.openMenu(fmt.Sprintf("Run in %s", name), items)
+}
+
+func (m *model) runQuickCommand(id, name string, qc quickCommand) tea.Cmd {
+	return m.startTask(fmt.Sprintf("%s in %s", qc.label, name), func(onLine func(string)) (string, error) {
+		out, err := docker.RunInContainer(id, qc.script)
+		for _, line := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+			onLine(line)
+		}
+		if err != nil {
+			return "", fmt.Errorf("%s: %v", qc.label, err)
+		}
+		return fmt.Sprintf("Ran %s in %s", strings.ToLower(qc.label), name), nil
+	})
+}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// with --read-only DockMate only watches: every key that would change a
+// container, an image, a project or the daemon is refused. Logs, info,
+// stats, filters and the like work as usual, and so do DockMate's own
+// settings (pins, notes, aliases).
+
+// readOnly is set once from main
+var readOnly bool
+
+// SetReadOnly turns read-only mode on or off
+func SetReadOnly(on bool) {
+	readOnly = on
+}
+
+// changesSomething reports whether msg is a list key that changes
+// something, with the same conditions the list's own dispatch has
+func (m model) changesSomething(msg tea.KeyMsg) bool {
+	if m.isComposeAction(msg) {
+		return true
+	}
+	if key.Matches(msg,
+		Keys.Start, Keys.Stop, Keys.Restart, Keys.Remove, Keys.Exec,
+		Keys.Prune, Keys.PullRecreate, Keys.ImageActions, Keys.Networks,
+		Keys.Limits, Keys.RestartPolicy, Keys.QuickCommands, Keys.RunContainer,
+		Keys.AllActions, Keys.Undo, Keys.AutoUpdate,
+		// registry logins write credentials
+		Keys.Logins,
+	) {
+		return true
+	}
+	// custom commands and templates run whatever they're set up to
+	if _, ok := m.customCommandFor(msg.String()); ok {
+		return true
+	}
+	_, ok := m.templateFor(msg.String())
+	return ok
+}
+
+// refuseReadOnly says so and reports true when DockMate is read-only, for
+// the actions on the other screens
+func (m *model) refuseReadOnly() bool {
+	if !readOnly {
+		return false
+	}
+	m.statusMessage = "Read-only (--read-only): nothing can be changed"
+	return true
+}
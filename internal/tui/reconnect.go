@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shubh-io/dockmate/internal/docker"
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+const (
+	reconnectMinDelay = 1 * time.Second
+	reconnectMaxDelay = 30 * time.Second
+)
+
+var (
+	// last-known rows while the daemon is gone
+	staleRowStyle = lipgloss.NewStyle().Foreground(textMuted)
+
+	reconnectBannerStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("#000000")).
+				Background(yellowColor)
+)
+
+// reconnectMsg fires when it's time for the next reconnect attempt
+type reconnectMsg struct{}
+
+// connectionLost is called when listing containers fails. The last-known list
+// stays on screen (greyed out), regular refreshes pause, and the daemon is
+// retried with a growing delay until it answers again.
+func (m *model) connectionLost(err error) tea.Cmd {
+	if !m.disconnected {
+		logging.Errorf("lost connection to %s: %v", docker.RuntimeBinary(), err)
+	}
+	m.disconnected = true
+	m.disconnectErr = err
+	if m.reconnectPending {
+		// a retry is already scheduled, this error came from some other fetch
+		return nil
+	}
+
+	if m.reconnectDelay == 0 {
+		m.reconnectDelay = reconnectMinDelay
+	} else {
+		m.reconnectDelay = min(m.reconnectDelay*2, reconnectMaxDelay)
+	}
+	m.reconnectAttempt++
+	m.reconnectAt = time.Now().Add(m.reconnectDelay)
+	m.reconnectPending = true
+
+	return tea.Tick(m.reconnectDelay, func(time.Time) tea.Msg { return reconnectMsg{} })
+}
+
+func (m *model) handleReconnect() tea.Cmd {
+	m.reconnectPending = false
+	return fetchContainers()
+}
+
+// reconnected puts everything back to normal after the daemon answered again
+func (m *model) reconnected() tea.Cmd {
+	if !m.disconnected {
+		return nil
+	}
+	logging.Infof("reconnected to %s after %d attempt(s)", docker.RuntimeBinary(), m.reconnectAttempt)
+	m.disconnected = false
+	m.disconnectErr = nil
+	m.reconnectDelay = 0
+	m.reconnectAttempt = 0
+	m.statusMessage = fmt.Sprintf("Reconnected to %s", docker.RuntimeBinary())
+
+	if m.composeViewMode {
+		return fetchComposeProjects()
+	}
+	return nil
+}
+
+// renderReconnectBanner is shown instead of the page line while the daemon is gone
+func (m model) renderReconnectBanner(width int) string {
+	reason := ""
+	if m.disconnectErr != nil {
+		reason = strings.TrimSpace(strings.SplitN(m.disconnectErr.Error(), "\n", 2)[0])
+	}
+
+	next := "now"
+	if left := time.Until(m.reconnectAt).Round(time.Second); left > 0 {
+		next = "in " + left.String()
+	}
+	text := fmt.Sprintf(" ⚠ Lost connection to %s, retrying %s (attempt %d): %s", docker.RuntimeBinary(), next, m.reconnectAttempt, reason)
+	if visibleLen(text) > width {
+		text = truncateToWidth(text, width)
+	}
+	return reconnectBannerStyle.Render(padRight(text, width))
+}
+
+// staleRow greys out an already rendered row
+func staleRow(row string) string {
+	return staleRowStyle.Render(ansi.Strip(row))
+}
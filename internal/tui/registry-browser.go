@@ -0,0 +1,243 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+type remoteTagsMsg struct {
+	repo string
+	tags []docker.RemoteTag
+	err  error
+}
+
+func remoteTagsCmd(repo string) tea.Cmd {
+	return func() tea.Msg {
+		tags, err := docker.ListRemoteTags(repo)
+		return remoteTagsMsg{repo: repo, tags: tags, err: err}
+	}
+}
+
+// repoOf drops the tag or digest from an image name
+func repoOf(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
+
+// openRegistryPrompt asks which repository to browse, starting from the selected container's
+func (m *model) openRegistryPrompt() tea.Cmd {
+	initial := m.registryRepo
+	if c := m.selectedContainer(); c != nil && c.Image != "" && m.currentMode != modeRegistry {
+		initial = repoOf(c.Image)
+	}
+	cmd := m.prompt("Browse tags of repository", "nginx, user/app or registry.example.com/team/app", func(m *model, repo string) tea.Cmd {
+		if repo == "" {
+			m.statusMessage = "Cancelled"
+			return nil
+		}
+		return m.openRegistryBrowser(repoOf(repo))
+	})
+	m.promptInput.SetValue(initial)
+	return cmd
+}
+
+func (m *model) openRegistryBrowser(repo string) tea.Cmd {
+	m.registryRepo = repo
+	m.registryTags = nil
+	m.registryErr = nil
+	m.registryCursor = 0
+	m.registryFilter = ""
+	m.registryFiltering = false
+	m.registryLoading = true
+	if m.currentMode != modeRegistry {
+		m.registryReturnMode = m.currentMode
+	}
+	m.currentMode = modeRegistry
+	m.statusMessage = ""
+	return remoteTagsCmd(repo)
+}
+
+func (m *model) handleRemoteTags(msg remoteTagsMsg) {
+	if msg.repo != m.registryRepo {
+		return
+	}
+	m.registryLoading = false
+	m.registryErr = msg.err
+	m.registryTags = msg.tags
+}
+
+// visibleTags applies the filter typed with /
+func (m model) visibleTags() []docker.RemoteTag {
+	if m.registryFilter == "" {
+		return m.registryTags
+	}
+	var out []docker.RemoteTag
+	for _, t := range m.registryTags {
+		if strings.Contains(strings.ToLower(t.Name), strings.ToLower(m.registryFilter)) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func (m model) registryPageSize() int {
+	// title, repo line, header, filter line, footer
+	return max(1, m.terminalHeight-6)
+}
+
+func (m model) updateRegistry(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.registryFiltering {
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.registryFilter = ""
+			m.registryFiltering = false
+		case tea.KeyEnter:
+			m.registryFiltering = false
+		case tea.KeyBackspace:
+			if r := []rune(m.registryFilter); len(r) > 0 {
+				m.registryFilter = string(r[:len(r)-1])
+			}
+		case tea.KeyRunes:
+			m.registryFilter += string(msg.Runes)
+		}
+		m.registryCursor = 0
+		return m, nil
+	}
+
+	tags := m.visibleTags()
+	switch msg.String() {
+	case "esc", "q":
+		if m.registryFilter != "" {
+			m.registryFilter = ""
+			m.registryCursor = 0
+			return m, nil
+		}
+		m.currentMode = m.registryReturnMode
+		m.registryTags = nil
+		m.statusMessage = "Registry browser closed"
+	case "up", "k":
+		if m.registryCursor > 0 {
+			m.registryCursor--
+		}
+	case "down", "j":
+		if m.registryCursor < len(tags)-1 {
+			m.registryCursor++
+		}
+	case "pgup":
+		m.registryCursor = max(0, m.registryCursor-m.registryPageSize())
+	case "pgdown":
+		m.registryCursor = max(0, min(len(tags)-1, m.registryCursor+m.registryPageSize()))
+	case "/":
+		m.registryFiltering = true
+	case "s", "S":
+		return m, m.openRegistryPrompt()
+	case "enter", "p", "P":
+		if m.refuseReadOnly() {
+			return m, nil
+		}
+		if m.registryCursor < len(tags) {
+			image := m.registryRepo + ":" + tags[m.registryCursor].Name
+			// the pull streams into the task panel on the list
+			m.currentMode = m.registryReturnMode
+			return m, m.pullImage(image)
+		}
+	case "f5":
+		m.registryLoading = true
+		m.registryErr = nil
+		return m, remoteTagsCmd(m.registryRepo)
+	}
+	return m, nil
+}
+
+func (m *model) pullImage(image string) tea.Cmd {
+	return m.startTask(fmt.Sprintf("Pull %s", image), func(onLine func(string)) (string, error) {
+		if err := docker.PullImage(image, onLine); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Pulled %s", image), nil
+	})
+}
+
+func (m model) renderRegistryBrowser(width int) string {
+	var b strings.Builder
+
+	b.WriteString(m.renderTitleBar(width))
+	b.WriteString("\n")
+
+	tags := m.visibleTags()
+	title := fmt.Sprintf("%s  (%d tags)", m.registryRepo, len(m.registryTags))
+	if m.registryFilter != "" {
+		title = fmt.Sprintf("%s  (%d of %d tags)", m.registryRepo, len(tags), len(m.registryTags))
+	}
+	b.WriteString(titleStyle.Render(padRight(truncateToWidth(title, width-2), width-2)))
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(padRight(fmt.Sprintf(" %-30s %-19s %9s  %s", "TAG", "DIGEST", "SIZE", "PUSHED"), width)))
+	b.WriteString("\n")
+
+	rows := m.registryPageSize()
+	shown := 0
+	switch {
+	case m.registryLoading:
+		b.WriteString(normalStyle.Render("  Loading tags..."))
+		b.WriteString("\n")
+		shown++
+	case m.registryErr != nil:
+		b.WriteString(messageStyle.Render(truncateToWidth("  "+m.registryErr.Error(), width)))
+		b.WriteString("\n")
+		shown++
+	case len(tags) == 0:
+		b.WriteString(normalStyle.Render("  No tags"))
+		b.WriteString("\n")
+		shown++
+	}
+
+	start := 0
+	if m.registryCursor >= rows {
+		start = m.registryCursor - rows + 1
+	}
+	for i := start; i < len(tags) && shown < rows && !m.registryLoading; i++ {
+		t := tags[i]
+		digest, size, pushed := "─", "─", "─"
+		if t.Digest != "" {
+			// sha256: plus 12 hex characters, like image IDs
+			digest = t.Digest[:min(len(t.Digest), 19)]
+		}
+		if t.Size >= 0 {
+			size = docker.FormatBytes(t.Size)
+		}
+		if !t.Updated.IsZero() {
+			pushed = docker.FormatAge(time.Since(t.Updated)) + " ago"
+		}
+		line := padRight(truncateToWidth(fmt.Sprintf(" %-30s %-19s %9s  %s", truncateToWidth(t.Name, 30), digest, size, pushed), width), width)
+		if i == m.registryCursor {
+			b.WriteString(selectedStyle.Render(line))
+		} else {
+			b.WriteString(normalStyle.Render(line))
+		}
+		b.WriteString("\n")
+		shown++
+	}
+	for ; shown < rows; shown++ {
+		b.WriteString("\n")
+	}
+
+	filter := ""
+	if m.registryFiltering || m.registryFilter != "" {
+		filter = "/" + m.registryFilter
+		if m.registryFiltering {
+			filter += "█"
+		}
+	}
+	b.WriteString(infoValueStyle.Render(" " + truncateToWidth(filter, width-1)))
+	b.WriteString("\n")
+
+	b.WriteString(m.renderFilesFooter(width, [][2]string{{"↑↓", "move"}, {"/", "filter"}, {"Enter", "pull"}, {"S", "other repo"}, {"F5", "reload"}, {"Esc", "close"}}))
+	return b.String()
+}
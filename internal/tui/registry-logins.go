@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"fmt"
+	"os/exec"
+	"slices"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+type registryLoginsMsg struct {
+	logins []docker.RegistryLogin
+	err    error
+}
+
+func registryLoginsCmd() tea.Cmd {
+	return func() tea.Msg {
+		logins, err := docker.RegistryLogins()
+		return registryLoginsMsg{logins: logins, err: err}
+	}
+}
+
+func (m *model) openRegistryLogins() tea.Cmd {
+	m.statusMessage = "Reading registry logins..."
+	return registryLoginsCmd()
+}
+
+// handleRegistryLogins lists the registries the listed images come from and
+// the ones the user is logged into; picking one logs in (again)
+func (m *model) handleRegistryLogins(msg registryLoginsMsg) {
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Can't read registry logins: %v", msg.err)
+		return
+	}
+	m.statusMessage = ""
+
+	images := make([]string, 0, len(m.containers))
+	for _, c := range m.containers {
+		images = append(images, c.Image)
+	}
+	inUse := docker.RegistriesOf(images)
+
+	byRegistry := make(map[string]docker.RegistryLogin)
+	registries := slices.Clone(inUse)
+	for _, l := range msg.logins {
+		byRegistry[l.Registry] = l
+		if !slices.Contains(registries, l.Registry) {
+			registries = append(registries, l.Registry)
+		}
+	}
+
+	var items []menuItem
+	for i, reg := range registries {
+		if i == 9 {
+			break
+		}
+		label := "✗ " + reg + "  not logged in"
+		user := ""
+		if l, ok := byRegistry[reg]; ok {
+			user = l.User
+			who := l.User
+			if who == "" {
+				who = "logged in"
+			}
+			label = fmt.Sprintf("✓ %s  %s (%s)", reg, who, l.Source)
+		}
+		if slices.Contains(inUse, reg) {
+			label += ", in use"
+		}
+		items = append(items, menuItem{
+			key:   strconv.Itoa(i + 1),
+			label: truncateToWidth(label, 50),
+			action: func(m *model) tea.Cmd {
+				return m.openLoginPrompt(reg, user)
+			},
+		})
+	}
+	items = append(items, menuItem{key: "n", label: "Log in to another registry", action: func(m *model) tea.Cmd {
+		return m.prompt("Registry to log in to", "ghcr.io, registry.example.com:5000", func(m *model, host string) tea.Cmd {
+			if host == "" {
+				m.statusMessage = "Cancelled"
+				return nil
+			}
+			return m.openLoginPrompt(docker.NormalizeRegistry(host), "")
+		})
+	}})
+
+	m.openMenu("Registry logins (pick one to log in)", items)
+}
+
+// openLoginPrompt asks for the username; the password is asked by the runtime
+// itself on the terminal, so DockMate never sees it
+func (m *model) openLoginPrompt(registry, user string) tea.Cmd {
+	cmd := m.prompt(fmt.Sprintf("Username for %s", registry), "username", func(m *model, user string) tea.Cmd {
+		if user == "" {
+			m.statusMessage = "Cancelled"
+			return nil
+		}
+		c := exec.Command(docker.RuntimeBinary(), "login", "-u", user, registry)
+		return tea.ExecProcess(c, func(err error) tea.Msg {
+			if err != nil {
+				return actionDoneMsg{err: fmt.Errorf("login to %s failed: %v", registry, err)}
+			}
+			return actionDoneMsg{msg: fmt.Sprintf("Logged in to %s as %s", registry, user)}
+		})
+	})
+	m.promptInput.SetValue(user)
+	return cmd
+}
@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// settings picks which of the config's remotes DockMate connects to over
+// SSH, or the local daemon. Saving restarts, main opens the tunnel.
+
+// cycleRemote steps through local and the remotes
+func (m *model) cycleRemote(step int) {
+	if len(m.settings.Remotes) == 0 {
+		m.statusMessage = "No remotes in the config, add them under remotes: in config.yml"
+		return
+	}
+	names := []string{""}
+	for _, r := range m.settings.Remotes {
+		names = append(names, r.Name)
+	}
+	idx := 0
+	for i, n := range names {
+		if n == m.settings.Remote {
+			idx = i
+		}
+	}
+	m.settings.Remote = names[(idx+step+len(names))%len(names)]
+}
+
+// remoteRow is the settings row and the note below it
+func (m model) remoteRow() (string, string) {
+	note := "Local daemon"
+	if t := docker.ActiveRemote(); t != nil {
+		note = fmt.Sprintf("Connected to %s over SSH", t.Target())
+	}
+	if len(m.settings.Remotes) == 0 {
+		return "Remote: local", "Remote daemons over SSH go under remotes: in config.yml"
+	}
+	if m.settings.Remote == "" {
+		return "Remote: local", note
+	}
+	for _, r := range m.settings.Remotes {
+		if r.Name != m.settings.Remote {
+			continue
+		}
+		target := r.Host
+		if r.User != "" {
+			target = r.User + "@" + target
+		}
+		return fmt.Sprintf("Remote: %s (%s)", r.Name, target), note
+	}
+	return fmt.Sprintf("Remote: %s (not in remotes)", m.settings.Remote), note
+}
+
+// remoteIndicator is the title bar's note of the remote, red once the
+// tunnel is down
+func remoteIndicator() string {
+	t := docker.ActiveRemote()
+	if t == nil {
+		return ""
+	}
+	if t.Err() != nil {
+		return fmt.Sprintf("%s %s", infoLabelStyle.Render("Remote:"), stoppedStyle.Render(t.Profile.Name+" (tunnel down)"))
+	}
+	return fmt.Sprintf("%s %s", infoLabelStyle.Render("Remote:"), infoValueStyle.Render(t.Profile.Name))
+}
@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// openRemove asks before removing a container, with --force and --volumes to
+// toggle. A plain rm refuses a running container, so --force starts out on
+// for those.
+func (m *model) openRemove(c docker.Container) {
+	m.openRemoveOptions(c, strings.ToLower(c.State) == "running", false)
+}
+
+func (m *model) openRemoveOptions(c docker.Container, force, volumes bool) {
+	check := func(on bool) string {
+		if on {
+			return "[x]"
+		}
+		return "[ ]"
+	}
+	name := docker.ContainerName(c)
+	items := []menuItem{
+		{key: "f", label: check(force) + " --force: stop it first if it's running", action: func(m *model) tea.Cmd {
+			m.openRemoveOptions(c, !force, volumes)
+			return nil
+		}},
+		{key: "v", label: check(volumes) + " --volumes: also remove its anonymous volumes", action: func(m *model) tea.Cmd {
+			m.openRemoveOptions(c, force, !volumes)
+			return nil
+		}},
+		{key: "y", label: "Remove", action: func(m *model) tea.Cmd {
+			return m.removeContainer(c, force, volumes)
+		}},
+	}
+	title := fmt.Sprintf("Remove %s?", name)
+	if strings.ToLower(c.State) == "running" && !force {
+		title = fmt.Sprintf("Remove %s? It's running, rm refuses it without --force", name)
+	}
+	m.openMenu(title, items)
+}
+
+func (m *model) removeContainer(c docker.Container, force, volumes bool) tea.Cmd {
+	name := docker.ContainerName(c)
+	done := fmt.Sprintf("Removed %s", name)
+	if volumes {
+		done += " and its anonymous volumes"
+	}
+
+	// filled in by the action before it removes, read once it's done
+	var snapshot docker.Snapshot
+	undo := &undoAction{
+		prompt: done + ", Ctrl+Z to recreate it",
+		run: func(m *model) tea.Cmd {
+			if len(snapshot.Args) == 0 {
+				m.statusMessage = fmt.Sprintf("No snapshot of %s to recreate it from", name)
+				return nil
+			}
+			return m.queueAction("recreate "+name, "Recreated "+name, func() error {
+				return docker.RestoreSnapshot(snapshot)
+			}, nil)
+		},
+	}
+	return m.queueAction("remove "+name, done, func() error {
+		// without a snapshot the remove still goes ahead, just without undo
+		if s, err := docker.SnapshotContainer(c.ID); err == nil {
+			snapshot = s
+		}
+		return docker.RemoveContainer(c.ID, force, volumes)
+	}, undo)
+}
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+var restartPolicies = []struct {
+	name string
+	desc string
+}{
+	{"no", "stays down after a crash or reboot"},
+	{"on-failure", "restarts after a non-zero exit"},
+	{"unless-stopped", "restarts after crashes and reboots, unless stopped by hand"},
+	{"always", "restarts after crashes and reboots, even if stopped by hand"},
+}
+
+// restartPolicyText is the info panel's Restart Policy, with what the policy
+// does after a reboot since that's where it usually surprises
+func (m model) restartPolicyText(c docker.Container) string {
+	policy := m.inspectInfo[c.ID].RestartPolicy
+	if policy == "" {
+		return ""
+	}
+	name, _, _ := strings.Cut(policy, ":")
+	for _, p := range restartPolicies {
+		if p.name == name {
+			return fmt.Sprintf("%s (%s)", policy, p.desc)
+		}
+	}
+	return policy
+}
+
+// openRestartPolicy offers the policies for `docker update --restart`
+func (m *model) openRestartPolicy(c docker.Container) {
+	name := docker.ContainerName(c)
+	current, ok := m.inspectInfo[c.ID]
+	if !ok {
+		m.statusMessage = fmt.Sprintf("The restart policy of %s isn't known yet, try again after the next refresh", name)
+		return
+	}
+
+	var items []menuItem
+	for i, p := range restartPolicies {
+		policy := p.name
+		mark := "  "
+		if strings.HasPrefix(current.RestartPolicy, policy) {
+			mark = "✓ "
+		}
+		items = append(items, menuItem{
+			key:   strconv.Itoa(i + 1),
+			label: truncateToWidth(fmt.Sprintf("%s%s: %s", mark, policy, p.desc), 50),
+			action: func(m *model) tea.Cmd {
+				return restartPolicyCmd(c, policy)
+			},
+		})
+	}
+	items = append(items, menuItem{key: "5", label: "  on-failure with a retry limit", action: func(m *model) tea.Cmd {
+		return m.prompt(fmt.Sprintf("Restarts of %s on failure, at most", name), "e.g. 5", func(m *model, value string) tea.Cmd {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				m.statusMessage = fmt.Sprintf("%q is not a retry limit, use a number like 5", value)
+				return nil
+			}
+			return restartPolicyCmd(c, fmt.Sprintf("on-failure:%d", n))
+		})
+	}})
+
+	m.openMenu(fmt.Sprintf("Restart policy of %s (now %s)", name, current.RestartPolicy), items)
+}
+
+func restartPolicyCmd(c docker.Container, policy string) tea.Cmd {
+	return func() tea.Msg {
+		if err := docker.UpdateRestartPolicy(c.ID, policy); err != nil {
+			return actionDoneMsg{err: err}
+		}
+		return actionDoneMsg{msg: fmt.Sprintf("Restart policy of %s set to %s", docker.ContainerName(c), policy)}
+	}
+}
@@ -0,0 +1,176 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// the run wizard asks for a new container one step at a time: the image, a
+// name, ports, environment, volumes and the restart policy. Esc at any step
+// drops it. The last step shows the whole `run -d` command before running it.
+
+// maxWizardImages is how many local images the first step lists, newest first
+const maxWizardImages = 9
+
+type imagesListedMsg struct {
+	images []string
+	err    error
+}
+
+type containerRunMsg struct {
+	id    string
+	name  string
+	image string
+	err   error
+}
+
+func listImagesCmd() tea.Cmd {
+	return func() tea.Msg {
+		images, err := docker.ListImages()
+		return imagesListedMsg{images: images, err: err}
+	}
+}
+
+// openRunWizard starts with the local images, the first step picks one of them
+func (m *model) openRunWizard() tea.Cmd {
+	m.statusMessage = "Listing images..."
+	return listImagesCmd()
+}
+
+func (m *model) handleImagesListed(msg imagesListedMsg) tea.Cmd {
+	// the templates and a typed image still work without the list
+	m.statusMessage = ""
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error: %v", msg.err)
+	}
+
+	items := m.templateItems()
+	items = append(items, menuItem{key: "t", label: "Type an image, pulled if it isn't here", action: func(m *model) tea.Cmd {
+		return m.prompt("Image to run", "e.g. nginx:latest", func(m *model, image string) tea.Cmd {
+			if image == "" {
+				m.statusMessage = "Cancelled"
+				return nil
+			}
+			return m.runWizardName(docker.RunSpec{Image: image})
+		})
+	}})
+	for i, image := range msg.images[:min(len(msg.images), maxWizardImages)] {
+		items = append(items, menuItem{
+			key:   strconv.Itoa(i + 1),
+			label: truncateToWidth(image, 50),
+			action: func(m *model) tea.Cmd {
+				return m.runWizardName(docker.RunSpec{Image: image})
+			},
+		})
+	}
+	m.openMenu("Run a new container: image", items)
+	return nil
+}
+
+func (m *model) runWizardName(spec docker.RunSpec) tea.Cmd {
+	return m.prompt(fmt.Sprintf("Name for the %s container", spec.Image), "empty for a random one", func(m *model, name string) tea.Cmd {
+		spec.Name = name
+		return m.runWizardPorts(spec)
+	})
+}
+
+func (m *model) runWizardPorts(spec docker.RunSpec) tea.Cmd {
+	return m.prompt("Ports to publish, host:container", "e.g. 8080:80, 8443:443", func(m *model, value string) tea.Cmd {
+		spec.Ports = splitList(value)
+		return m.runWizardEnv(spec)
+	})
+}
+
+func (m *model) runWizardEnv(spec docker.RunSpec) tea.Cmd {
+	return m.prompt("Environment variables, KEY=value", "e.g. TZ=UTC, DEBUG=1", func(m *model, value string) tea.Cmd {
+		spec.Env = splitList(value)
+		for _, e := range spec.Env {
+			if strings.HasPrefix(e, "=") {
+				m.statusMessage = fmt.Sprintf("%q has no variable name, not run", e)
+				return nil
+			}
+		}
+		return m.runWizardVolumes(spec)
+	})
+}
+
+func (m *model) runWizardVolumes(spec docker.RunSpec) tea.Cmd {
+	return m.prompt("Volumes, source:target", "e.g. ./data:/data, cache:/cache:ro", func(m *model, value string) tea.Cmd {
+		for _, v := range splitList(value) {
+			spec.Volumes = append(spec.Volumes, absVolume(v))
+		}
+		m.runWizardRestart(spec)
+		return nil
+	})
+}
+
+func (m *model) runWizardRestart(spec docker.RunSpec) {
+	var items []menuItem
+	for i, p := range restartPolicies {
+		policy := p.name
+		items = append(items, menuItem{
+			key:   strconv.Itoa(i + 1),
+			label: truncateToWidth(fmt.Sprintf("%s: %s", policy, p.desc), 50),
+			action: func(m *model) tea.Cmd {
+				spec.Restart = policy
+				m.confirm(fmt.Sprintf("Run %s?", spec.CommandLine()), func(m *model) tea.Cmd {
+					m.statusMessage = fmt.Sprintf("Starting %s...", spec.Image)
+					return runContainerCmd(spec)
+				})
+				return nil
+			},
+		})
+	}
+	m.openMenu("Restart policy of the new container", items)
+}
+
+func runContainerCmd(spec docker.RunSpec) tea.Cmd {
+	return func() tea.Msg {
+		id, err := docker.RunContainer(spec)
+		return containerRunMsg{id: id, name: spec.Name, image: spec.Image, err: err}
+	}
+}
+
+// handleContainerRun puts the cursor on the new container once the list has it
+func (m *model) handleContainerRun(msg containerRunMsg) tea.Cmd {
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error: %v", msg.err)
+		return nil
+	}
+	name := msg.name
+	if name == "" {
+		name = shortID(msg.id)
+	}
+	m.statusMessage = fmt.Sprintf("Started %s as %s", msg.image, name)
+	m.returnTo = msg.id
+	return tea.Batch(fetchContainers(), fetchComposeProjects())
+}
+
+// splitList splits a comma separated answer, dropping empty entries
+func splitList(value string) []string {
+	var out []string
+	for _, s := range strings.Split(value, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// absVolume makes a bind mount from ./ or ~/ absolute, -v takes a path
+// without a slash for a named volume
+func absVolume(v string) string {
+	source, rest, ok := strings.Cut(v, ":")
+	if !ok || !(strings.HasPrefix(source, ".") || strings.HasPrefix(source, "~/")) {
+		return v
+	}
+	if abs, err := filepath.Abs(expandHome(source)); err == nil {
+		source = abs
+	}
+	return source + ":" + rest
+}
@@ -57,6 +57,8 @@ func NewRuntimeSelectionModel() RuntimeSelectionModel {
 	items := []list.Item{
 		runtimeItem("Docker (default)"),
 		runtimeItem("Podman"),
+		runtimeItem("nerdctl"),
+		runtimeItem("Auto-detect"),
 	}
 
 	const defaultWidth = 20
@@ -93,6 +95,8 @@ func (m RuntimeSelectionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if ok {
 				if strings.Contains(string(i), "Docker") {
 					m.choice = "docker"
+				} else if strings.HasPrefix(string(i), "Auto") {
+					m.choice = "auto"
 				} else {
 					m.choice = strings.ToLower(string(i))
 				}
@@ -114,3 +118,11 @@ func (m RuntimeSelectionModel) View() string {
 func (m RuntimeSelectionModel) GetChoice() string {
 	return m.choice
 }
+
+// runtimeLabel is the runtime shown in the header, with what "auto" picked
+func (m model) runtimeLabel() string {
+	if m.settings.Runtime == RuntimeAuto {
+		return fmt.Sprintf("auto (%s)", m.detectedRuntime)
+	}
+	return string(m.settings.Runtime)
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// the container list scrolls: m.offset is the first row on screen and
+// m.listHeight the number of rows that fit. Moving the cursor off either edge
+// scrolls by just enough to bring it back. The column header is drawn above
+// the list, and in compose view the header of the project the first row
+// belongs to is pinned over it.
+
+var (
+	scrollTrackStyle = lipgloss.NewStyle().Foreground(textMuted)
+	scrollThumbStyle = lipgloss.NewStyle().Foreground(accent)
+)
+
+// listLen is the number of rows in the list, containers or the compose tree
+func (m model) listLen() int {
+	if m.composeViewMode {
+		return len(m.flatList)
+	}
+	return len(m.containers)
+}
+
+// updateViewport recalculates the list height and keeps the cursor and the
+// offset within bounds, with the cursor on screen
+func (m *model) updateViewport() {
+	m.listHeight = m.calculateMaxContainers()
+	if m.listHeight < 1 {
+		m.listHeight = 1
+	}
+
+	n := m.listLen()
+	if n == 0 {
+		m.cursor = 0
+		m.offset = 0
+		m.message = ""
+		return
+	}
+
+	m.cursor = min(max(m.cursor, 0), n-1)
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.listHeight {
+		m.offset = m.cursor - m.listHeight + 1
+	}
+	// no empty space below the last row while there's more above
+	m.offset = min(max(m.offset, 0), max(0, n-m.listHeight))
+	// the pinned project header covers the first row
+	if m.cursor == m.offset && m.stickyProject() >= 0 {
+		m.offset--
+	}
+
+	// persistent position indicator below the list
+	m.message = fmt.Sprintf("Rows %d-%d of %d", m.offset+1, min(m.offset+m.listHeight, n), n)
+}
+
+// stickyProject is the flatList index of the project header pinned at the
+// top of the compose view, -1 when the first row on screen is a header or
+// there's nothing to pin
+func (m model) stickyProject() int {
+	if !m.composeViewMode || m.listHeight < 2 || m.offset >= len(m.flatList) || m.flatList[m.offset].isProject {
+		return -1
+	}
+	for i := m.offset - 1; i >= 0; i-- {
+		if m.flatList[i].isProject {
+			return i
+		}
+	}
+	return -1
+}
+
+// scrollBy moves the cursor and the view together by delta rows, for the
+// page and half-page jumps, so the cursor keeps its place on screen
+func (m *model) scrollBy(delta int) {
+	m.cursor += delta
+	m.offset += delta
+	m.updateViewport()
+}
+
+// scrollbar is the column drawn right of the list, one cell per row, or nil
+// when every row fits
+func (m model) scrollbar() []string {
+	n, h := m.listLen(), m.listHeight
+	if h < 1 || n <= h {
+		return nil
+	}
+	thumb := max(1, h*h/n)
+	top := (m.offset*(h-thumb) + (n-h)/2) / (n - h)
+	cells := make([]string, h)
+	for i := range cells {
+		if i >= top && i < top+thumb {
+			cells[i] = scrollThumbStyle.Render("┃")
+		} else {
+			cells[i] = scrollTrackStyle.Render("│")
+		}
+	}
+	return cells
+}
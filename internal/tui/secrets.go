@@ -0,0 +1,229 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// the secrets screen lists swarm secrets and configs, or podman secrets, with
+// what uses them. Their contents can't be read back, so there's create from a
+// file and remove, nothing to view or edit.
+
+type secretsMsg struct {
+	supported bool
+	reason    string
+	secrets   []docker.Secret
+	err       error
+}
+
+func secretsCmd() tea.Cmd {
+	return func() tea.Msg {
+		ok, reason := docker.SecretsSupported()
+		if !ok {
+			return secretsMsg{reason: reason}
+		}
+		secrets, err := docker.ListSecrets()
+		return secretsMsg{supported: true, secrets: secrets, err: err}
+	}
+}
+
+type secretActionMsg struct {
+	msg string
+	err error
+}
+
+func secretActionCmd(done string, fn func() error) tea.Cmd {
+	return func() tea.Msg {
+		return secretActionMsg{msg: done, err: fn()}
+	}
+}
+
+// openSecrets asks the runtime first, the screen opens once it's known to
+// have secrets
+func (m *model) openSecrets() tea.Cmd {
+	if m.secretsOpening {
+		return nil
+	}
+	m.secretsOpening = true
+	m.statusMessage = "Loading secrets..."
+	return secretsCmd()
+}
+
+func (m *model) handleSecrets(msg secretsMsg) {
+	m.secretsLoading = false
+	if !msg.supported {
+		if m.secretsOpening {
+			m.statusMessage = msg.reason
+		}
+		m.secretsOpening = false
+		return
+	}
+	if m.secretsOpening {
+		m.secretsOpening = false
+		m.secretsCursor = 0
+		m.secretsReturnMode = m.currentMode
+		m.currentMode = modeSecrets
+		m.statusMessage = ""
+	}
+	m.secretsErr = msg.err
+	if msg.err == nil {
+		m.secrets = msg.secrets
+	}
+	m.secretsCursor = max(0, min(m.secretsCursor, len(m.secrets)-1))
+}
+
+func (m *model) handleSecretAction(msg secretActionMsg) tea.Cmd {
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error: %v", msg.err)
+	} else {
+		m.statusMessage = msg.msg
+	}
+	if m.currentMode != modeSecrets {
+		return nil
+	}
+	m.secretsLoading = true
+	return secretsCmd()
+}
+
+// createSecret asks for the name and then the file the contents come from
+func (m *model) createSecret(kind string) tea.Cmd {
+	return m.prompt(fmt.Sprintf("New %s name", kind), "db_password", func(m *model, name string) tea.Cmd {
+		if name == "" {
+			m.statusMessage = "Cancelled"
+			return nil
+		}
+		return m.prompt(fmt.Sprintf("File with the contents of %s", name), "~/secrets/db_password.txt", func(m *model, file string) tea.Cmd {
+			if file == "" {
+				m.statusMessage = "Cancelled"
+				return nil
+			}
+			file = expandHome(file)
+			m.statusMessage = fmt.Sprintf("Creating %s %s...", kind, name)
+			return secretActionCmd(fmt.Sprintf("Created %s %s", kind, name), func() error {
+				return docker.CreateSecret(kind, name, file)
+			})
+		})
+	})
+}
+
+func (m model) secretsPageSize() int {
+	// title bar, title, header, error line and footer
+	return max(1, m.terminalHeight-5)
+}
+
+func (m model) updateSecrets(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "$":
+		m.currentMode = m.secretsReturnMode
+		m.secrets = nil
+		m.statusMessage = "Secrets closed"
+	case "up", "k":
+		if m.secretsCursor > 0 {
+			m.secretsCursor--
+		}
+	case "down", "j":
+		if m.secretsCursor < len(m.secrets)-1 {
+			m.secretsCursor++
+		}
+	case "pgup":
+		m.secretsCursor = max(0, m.secretsCursor-m.secretsPageSize())
+	case "pgdown":
+		m.secretsCursor = max(0, min(len(m.secrets)-1, m.secretsCursor+m.secretsPageSize()))
+	case "c", "C":
+		if m.refuseReadOnly() {
+			return m, nil
+		}
+		if docker.RuntimeBinary() != "docker" {
+			return m, m.createSecret("secret")
+		}
+		m.openMenu("Create from a file", []menuItem{
+			{key: "s", label: "Secret", action: func(m *model) tea.Cmd { return m.createSecret("secret") }},
+			{key: "c", label: "Config", action: func(m *model) tea.Cmd { return m.createSecret("config") }},
+		})
+	case "d", "D", "delete":
+		if m.refuseReadOnly() {
+			return m, nil
+		}
+		if m.secretsCursor >= len(m.secrets) {
+			return m, nil
+		}
+		s := m.secrets[m.secretsCursor]
+		question := fmt.Sprintf("Remove %s %s?", s.Kind, s.Name)
+		if len(s.UsedBy) > 0 {
+			question = fmt.Sprintf("Remove %s %s? It's used by %s", s.Kind, s.Name, strings.Join(s.UsedBy, ", "))
+		}
+		m.confirm(question, func(m *model) tea.Cmd {
+			m.statusMessage = fmt.Sprintf("Removing %s %s...", s.Kind, s.Name)
+			return secretActionCmd(fmt.Sprintf("Removed %s %s", s.Kind, s.Name), func() error {
+				return docker.RemoveSecret(s.Kind, s.Name)
+			})
+		})
+	case "f5":
+		if !m.secretsLoading {
+			m.secretsLoading = true
+			return m, secretsCmd()
+		}
+	}
+	return m, nil
+}
+
+func (m model) renderSecrets(width int) string {
+	var b strings.Builder
+
+	b.WriteString(m.renderTitleBar(width))
+	b.WriteString("\n")
+	title := "Secrets"
+	if docker.RuntimeBinary() == "docker" {
+		title = "Secrets and configs"
+	}
+	b.WriteString(titleStyle.Render(padRight(truncateToWidth(fmt.Sprintf("%s (%d)", title, len(m.secrets)), width-2), width-2)))
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(padRight(fmt.Sprintf(" %-7s %-30s %-10s %-16s %s", "KIND", "NAME", "DRIVER", "CREATED", "USED BY"), width)))
+	b.WriteString("\n")
+
+	rows := m.secretsPageSize()
+	start := 0
+	if m.secretsCursor >= rows {
+		start = m.secretsCursor - rows + 1
+	}
+	var lines []string
+	if len(m.secrets) == 0 {
+		lines = append(lines, normalStyle.Render("  No secrets yet, c creates one from a file"))
+	}
+	for i := start; i < len(m.secrets) && i < start+rows; i++ {
+		s := m.secrets[i]
+		driver := s.Driver
+		if driver == "" {
+			driver = "-"
+		}
+		usedBy := strings.Join(s.UsedBy, ", ")
+		if usedBy == "" {
+			usedBy = "-"
+		}
+		line := truncateToWidth(fmt.Sprintf(" %-7s %-30s %-10s %-16s %s", s.Kind, truncateToWidth(s.Name, 30), truncateToWidth(driver, 10), truncateToWidth(s.Created, 16), usedBy), width)
+		if i == m.secretsCursor {
+			lines = append(lines, selectedStyle.Render(padRight(line, width)))
+		} else {
+			lines = append(lines, normalStyle.Render(line))
+		}
+	}
+	for i := 0; i < rows; i++ {
+		if i < len(lines) {
+			b.WriteString(lines[i])
+		}
+		b.WriteString("\n")
+	}
+
+	errLine := ""
+	if m.secretsErr != nil {
+		errLine = m.secretsErr.Error()
+	}
+	b.WriteString(messageStyle.Render(" " + truncateToWidth(errLine, width-1)))
+	b.WriteString("\n")
+
+	b.WriteString(m.renderFilesFooter(width, [][2]string{{"↑↓", "move"}, {"c", "create from file"}, {"d", "remove"}, {"F5", "reload"}, {"Esc", "close"}}))
+	return b.String()
+}
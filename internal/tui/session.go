@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// trackStarted remembers a container started from DockMate, for the quit hook
+func (m *model) trackStarted(id string) {
+	if m.sessionStarted == nil {
+		m.sessionStarted = make(map[string]bool)
+	}
+	m.sessionStarted[id] = true
+}
+
+// trackProjectUp remembers a compose project brought up from DockMate.
+// tracked by name because `up` may recreate containers with new IDs.
+func (m *model) trackProjectUp(project string) {
+	if m.sessionProjects == nil {
+		m.sessionProjects = make(map[string]bool)
+	}
+	m.sessionProjects[project] = true
+}
+
+// sessionContainers returns the containers started this session that are still running
+func (m model) sessionContainers() []docker.Container {
+	var out []docker.Container
+	for _, c := range m.containers {
+		if strings.ToLower(c.State) != "running" {
+			continue
+		}
+		if m.sessionStarted[c.ID] || (c.ComposeProject != "" && m.sessionProjects[c.ComposeProject]) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// quit runs the session.stop_on_quit hook before leaving
+func (m model) quit() (tea.Model, tea.Cmd) {
+	started := m.sessionContainers()
+	if len(started) == 0 || m.settings.StopOnQuit == "never" || len(m.batchStages) > 0 {
+		return m, tea.Quit
+	}
+
+	stopAndQuit := func(m *model) tea.Cmd {
+		m.quitAfterBatch = true
+		return m.stopInOrder("session containers", started)
+	}
+
+	if m.settings.StopOnQuit == "always" {
+		return m, stopAndQuit(&m)
+	}
+
+	names := make([]string, len(started))
+	for i, c := range started {
+		names[i] = docker.ContainerName(c)
+	}
+	m.openMenu(fmt.Sprintf("Stop %d container(s) started this session? (%s)", len(started), strings.Join(names, ", ")), []menuItem{
+		{"y", "Stop them, then quit", stopAndQuit},
+		{"n", "Quit and leave them running", func(m *model) tea.Cmd { return tea.Quit }},
+	})
+	return m, nil
+}
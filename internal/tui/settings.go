@@ -3,8 +3,102 @@ package tui
 import (
 	"fmt"
 	"strings"
+
+	"github.com/shubh-io/dockmate/internal/config"
 )
 
+// settingsFromConfig maps a loaded config onto what the TUI works with
+func settingsFromConfig(cfg *config.Config) Settings {
+	columnPercents := []int{
+		cfg.Layout.ContainerId,
+		cfg.Layout.ContainerNameWidth,
+		cfg.Layout.MemoryWidth,
+		cfg.Layout.CPUWidth,
+		cfg.Layout.NetIOWidth,
+		cfg.Layout.DiskIOWidth,
+		cfg.Layout.ImageWidth,
+		cfg.Layout.StatusWidth,
+		cfg.Layout.PortWidth,
+		cfg.Layout.UptimeWidth,
+		cfg.Layout.CreatedWidth,
+		cfg.Layout.NetworksWidth,
+		cfg.Layout.IPWidth,
+		cfg.Layout.GPUWidth,
+	}
+	visibleColumns := []bool{
+		cfg.Layout.ContainerIdVisible,
+		cfg.Layout.ContainerNameVisible,
+		cfg.Layout.MemoryVisible,
+		cfg.Layout.CPUVisible,
+		cfg.Layout.NetIOVisible,
+		cfg.Layout.DiskIOVisible,
+		cfg.Layout.ImageVisible,
+		cfg.Layout.StatusVisible,
+		cfg.Layout.PortVisible,
+		cfg.Layout.UptimeVisible,
+		cfg.Layout.CreatedVisible,
+		cfg.Layout.NetworksVisible,
+		cfg.Layout.IPVisible,
+		cfg.Layout.GPUVisible,
+	}
+	return Settings{
+		ColumnPercents:  columnPercents,
+		RefreshInterval: cfg.Performance.PollRate,
+		StatsInterval:   cfg.Performance.StatsRate,
+		StatsOverrides:  cfg.Performance.ContainerStatsRates,
+		ParallelActions: cfg.Performance.ParallelActions,
+		Runtime:         ContainerRuntime(cfg.Runtime.Type),
+		Socket:          cfg.Runtime.Socket,
+		Remote:          cfg.Runtime.Remote,
+		Remotes:         cfg.Remotes,
+		Shell:           cfg.Exec.Shell,
+		Tmux:            cfg.Exec.Tmux,
+		DetachKeys:      cfg.Exec.DetachKeys,
+		VisibleColumns:  visibleColumns,
+		ProjectColors:   cfg.Layout.ProjectColors,
+		TTLAutoStop:     cfg.TTL.AutoStop,
+		TTLOverrides:    cfg.TTL.Containers,
+		Pinned:          cfg.Pinned,
+		StopOnQuit:      cfg.Session.StopOnQuit,
+		LogsStripANSI:   cfg.Logs.StripANSI,
+		LogsLevelColors: cfg.Logs.LevelColors,
+		LogFilters:      cfg.Logs.Filters,
+		ProjectAliases:  cfg.ProjectAliases,
+		ContainerNotes:  cfg.ContainerNotes,
+		Commands:        cfg.Commands,
+		Templates:       cfg.Templates,
+		ComposeDirs:     cfg.Compose.Dirs,
+		ComposeDepth:    cfg.Compose.ScanDepth,
+
+		CrashLoopRestarts: cfg.Alerts.CrashLoopRestarts,
+		CrashLoopMinutes:  cfg.Alerts.CrashLoopMinutes,
+	}
+}
+
+// numColumns counts the list columns, CONTAINER ID to GPU
+const numColumns = 14
+
+// the settings rows after the columns
+const (
+	settingsRowRefresh = numColumns + iota
+	settingsRowStats
+	settingsRowProjectColors
+	settingsRowRuntime
+	settingsRowShell
+	settingsRowPodman
+	settingsRowRemote
+)
+
+// the fallbacks for settings that don't have every column: the widths of the
+// config defaults, with the original columns shown and the optional ones hidden
+func defaultColumnPercents() []int {
+	return []int{8, 14, 6, 6, 10, 12, 18, 13, 13, 8, 8, 12, 12, 10}
+}
+
+func defaultVisibleColumns() []bool {
+	return []bool{true, true, true, true, true, true, true, true, true, false, false, false, false, false}
+}
+
 func (m model) renderSettings(width int) string {
 	var b strings.Builder
 
@@ -21,9 +115,9 @@ func (m model) renderSettings(width int) string {
 	b.WriteString("\n")
 
 	// Column list
-	colNames := []string{"CONTAINER ID", "NAME", "MEMORY", "CPU", "NET I/O", "Disk I/O", "IMAGE", "STATUS", "PORTS"}
-	if m.settings.ColumnPercents == nil || len(m.settings.ColumnPercents) != 9 {
-		m.settings.ColumnPercents = []int{8, 14, 6, 6, 10, 12, 18, 13, 13}
+	colNames := []string{"CONTAINER ID", "NAME", "MEMORY", "CPU", "NET I/O", "Disk I/O", "IMAGE", "STATUS", "PORTS", "UPTIME", "CREATED", "NETWORKS", "IP", "GPU"}
+	if len(m.settings.ColumnPercents) != numColumns {
+		m.settings.ColumnPercents = defaultColumnPercents()
 	}
 
 	for i, name := range colNames {
@@ -46,20 +140,45 @@ func (m model) renderSettings(width int) string {
 		b.WriteString("\n")
 	}
 
-	// Refresh interval row (index 9)
+	// Refresh interval row
 	b.WriteString("\n")
 	refreshLine := fmt.Sprintf(" %2ds  Refresh Interval", m.settings.RefreshInterval)
-	if m.settingsSelected == 9 {
+	if m.settingsSelected == settingsRowRefresh {
 		b.WriteString(selectedStyle.Render(padRight(refreshLine, width)))
 	} else {
 		b.WriteString(normalStyle.Render(padRight(refreshLine, width)))
 	}
 	b.WriteString("\n")
 
-	// runtime row (index 10)
+	// stats interval row, 0 is every refresh
+	statsLine := fmt.Sprintf(" %2ds  Stats Interval", m.settings.StatsInterval)
+	if m.settings.StatsInterval == 0 {
+		statsLine = "  ─   Stats Interval (with every refresh)"
+	}
+	if m.settingsSelected == settingsRowStats {
+		b.WriteString(selectedStyle.Render(padRight(statsLine, width)))
+	} else {
+		b.WriteString(normalStyle.Render(padRight(statsLine, width)))
+	}
+	b.WriteString("\n")
+
+	// project colors row
+	checkMark := "[ ]"
+	if m.settings.ProjectColors {
+		checkMark = "[x]"
+	}
+	colorsLine := fmt.Sprintf(" %s  Project Colors (a colored bar per compose project)", checkMark)
+	if m.settingsSelected == settingsRowProjectColors {
+		b.WriteString(selectedStyle.Render(padRight(colorsLine, width)))
+	} else {
+		b.WriteString(normalStyle.Render(padRight(colorsLine, width)))
+	}
+	b.WriteString("\n")
+
+	// runtime row
 	b.WriteString("\n")
 	runtime := fmt.Sprintf("Runtime: %s", m.settings.Runtime)
-	if m.settingsSelected == 10 {
+	if m.settingsSelected == settingsRowRuntime {
 		b.WriteString(selectedStyle.Render(padRight(runtime, width)))
 	} else {
 		b.WriteString(normalStyle.Render(padRight(runtime, width)))
@@ -67,16 +186,38 @@ func (m model) renderSettings(width int) string {
 	b.WriteString("\n")
 	b.WriteString(normalStyle.Render("Changing the runtime will trigger a RESTART!"))
 
-	// shell row (index 11)
+	// shell row
 	b.WriteString("\n\n")
 	shellLine := fmt.Sprintf("Shell: %s", m.settings.Shell)
-	if m.settingsSelected == 11 {
+	if m.settingsSelected == settingsRowShell {
 		b.WriteString(selectedStyle.Render(padRight(shellLine, width)))
 	} else {
 		b.WriteString(normalStyle.Render(padRight(shellLine, width)))
 	}
 	b.WriteString("\n")
-	b.WriteString(normalStyle.Render("Shell used for container exec (fallback: /bin/sh)"))
+	b.WriteString(normalStyle.Render("Shell used for container exec (auto: best one found, ask: pick each time; fallback: /bin/sh)"))
+
+	// podman socket row
+	b.WriteString("\n\n")
+	podmanLine, podmanNote := m.podmanSocketRow()
+	if m.settingsSelected == settingsRowPodman {
+		b.WriteString(selectedStyle.Render(padRight(podmanLine, width)))
+	} else {
+		b.WriteString(normalStyle.Render(padRight(podmanLine, width)))
+	}
+	b.WriteString("\n")
+	b.WriteString(normalStyle.Render(truncateToWidth(podmanNote, width)))
+
+	// remote row
+	b.WriteString("\n\n")
+	remoteLine, remoteNote := m.remoteRow()
+	if m.settingsSelected == settingsRowRemote {
+		b.WriteString(selectedStyle.Render(padRight(remoteLine, width)))
+	} else {
+		b.WriteString(normalStyle.Render(padRight(remoteLine, width)))
+	}
+	b.WriteString("\n")
+	b.WriteString(normalStyle.Render(truncateToWidth(remoteNote, width)))
 
 	b.WriteString("\n")
 	instr := "[←/→] or [+/-] adjust  •  [space] toggle  •  [↑/↓] navigate • [s] save  •   [Esc] cancel"
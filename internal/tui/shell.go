@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+type shellsDetectedMsg struct {
+	containerID string
+	name        string
+	image       string
+	shells      []string
+	err         error
+}
+
+func detectShellsCmd(c docker.Container) tea.Cmd {
+	return func() tea.Msg {
+		shells, err := docker.DetectShells(c.ID)
+		return shellsDetectedMsg{containerID: c.ID, name: docker.ContainerName(c), image: c.Image, shells: shells, err: err}
+	}
+}
+
+// openShell execs into a running container with the configured shell.
+// "auto" uses the shell cached for the image, probing the container the first time;
+// "ask" always probes and lets the user pick.
+func (m *model) openShell(c docker.Container) tea.Cmd {
+	mode := m.settings.Shell
+	if docker.WindowsContainers() && mode != "ask" {
+		// configured shells are unix paths, Windows containers always go through detection
+		mode = "auto"
+	}
+
+	switch mode {
+	case "auto":
+		if shell := m.shellCache[c.Image]; shell != "" {
+			m.statusMessage = fmt.Sprintf("Opening %s...", shell)
+			return m.execShell(c.ID, docker.ContainerName(c), shell)
+		}
+		m.statusMessage = "Looking for a shell..."
+		return detectShellsCmd(c)
+	case "ask":
+		m.statusMessage = "Looking for shells..."
+		return detectShellsCmd(c)
+	}
+	m.statusMessage = "Opening interactive shell..."
+	return m.execShell(c.ID, docker.ContainerName(c), m.settings.Shell)
+}
+
+func (m *model) handleShellsDetected(msg shellsDetectedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Can't open a shell in %s: %v", msg.name, msg.err)
+		return nil
+	}
+
+	if m.settings.Shell == "ask" && len(msg.shells) > 1 {
+		items := make([]menuItem, 0, len(msg.shells))
+		for i, shell := range msg.shells {
+			items = append(items, menuItem{
+				key:   strconv.Itoa(i + 1),
+				label: shell,
+				action: func(m *model) tea.Cmd {
+					m.statusMessage = fmt.Sprintf("Opening %s...", shell)
+					return m.execShell(msg.containerID, msg.name, shell)
+				},
+			})
+		}
+		m.openMenu(fmt.Sprintf("Shell for %s", msg.name), items)
+		return nil
+	}
+
+	shell := msg.shells[0]
+	if m.settings.Shell != "ask" && msg.image != "" {
+		if m.shellCache[msg.image] != shell {
+			m.shellCache[msg.image] = shell
+			// only a cache, exec works fine without it
+			_ = m.shellCache.Save()
+		}
+	}
+	m.statusMessage = fmt.Sprintf("Opening %s...", shell)
+	return m.execShell(msg.containerID, msg.name, shell)
+}
+
+// execShell hands the terminal to `exec -it` until the shell exits or is detached from.
+// Falls back to /bin/sh if the shell is not in the container (e.g. a stale cache entry).
+// Windows shells are started directly, Windows containers have no sh to wrap them in.
+func (m model) execShell(containerID, name, shell string) tea.Cmd {
+	done := func(err error) tea.Msg {
+		return shellDoneMsg{containerID: containerID, name: name, err: err}
+	}
+	if docker.IsWindowsShell(shell) {
+		return m.runInTerminal("shell", m.execArgs(containerID, shell), done)
+	}
+
+	banner := fmt.Sprintf("--- You are now in the interactive shell of %s ---", name)
+	if keys := m.detachKeys(); keys != "" {
+		banner = fmt.Sprintf("--- You are now in the interactive shell of %s, %s detaches ---", name, keys)
+	}
+	shellCmd := fmt.Sprintf(
+		"echo '%s'; if [ -x '%s' ]; then exec '%s'; else exec /bin/sh; fi",
+		banner, shell, shell,
+	)
+	return m.runInTerminal("shell", m.execArgs(containerID, "sh", "-c", shellCmd), done)
+}
+
+// execArgs is the `exec -it` command line, with exec.detach_keys when set
+func (m model) execArgs(containerID string, cmd ...string) []string {
+	args := []string{docker.RuntimeBinary(), "exec", "-it"}
+	if m.settings.DetachKeys != "" && docker.RuntimeBinary() != "nerdctl" {
+		args = append(args, "--detach-keys", m.settings.DetachKeys)
+	}
+	return append(append(args, containerID), cmd...)
+}
+
+// detachKeys is the key sequence that leaves a shell running in the
+// background, "" when the runtime has none (nerdctl exec can't detach)
+func (m model) detachKeys() string {
+	if docker.RuntimeBinary() == "nerdctl" {
+		return ""
+	}
+	if m.settings.DetachKeys != "" {
+		return m.settings.DetachKeys
+	}
+	return "ctrl-p,ctrl-q"
+}
+
+type shellDoneMsg struct {
+	containerID string
+	name        string
+	err         error
+}
+
+// handleShellDone puts the cursor back on the container once the list is
+// fetched again, in case it moved or the view was rebuilt meanwhile. The
+// shell's own exit status is no DockMate error, only failing to run it is.
+func (m *model) handleShellDone(msg shellDoneMsg) tea.Cmd {
+	var exitErr *exec.ExitError
+	switch {
+	case msg.err == nil:
+		m.statusMessage = fmt.Sprintf("Back from the shell in %s", msg.name)
+	case errors.As(msg.err, &exitErr):
+		m.statusMessage = fmt.Sprintf("Back from the shell in %s (exit %d)", msg.name, exitErr.ExitCode())
+	default:
+		logging.Errorf("shell in %s: %v", msg.name, msg.err)
+		m.statusMessage = fmt.Sprintf("Error: shell error: %v", msg.err)
+	}
+	m.returnTo = msg.containerID
+	if m.composeViewMode {
+		return tea.Batch(fetchContainers(), fetchComposeProjects())
+	}
+	return fetchContainers()
+}
+
+// restoreCursor moves the cursor to m.returnTo, the container a shell was
+// opened on or one just run, once the list it's in has arrived
+func (m *model) restoreCursor() {
+	if m.returnTo == "" {
+		return
+	}
+	m.selectContainerByID(m.returnTo)
+	m.returnTo = ""
+}
@@ -0,0 +1,210 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+	"github.com/shubh-io/dockmate/internal/logging"
+)
+
+// the list is fetched without stats, `docker stats` is the slow part on big
+// hosts. After each list the containers whose stats are due get them in one
+// call, the rest keep showing their last ones.
+
+// statsSlack lets a stats call that lands a little early on a refresh count,
+// else a 10s interval with a 2s refresh would only come round every 12s
+const statsSlack = 500 * time.Millisecond
+
+type statsMsg struct {
+	stats map[string]docker.ContainerStats
+	err   error
+}
+
+// statsInterval is how often c's stats are fetched. 0 means only on demand,
+// with F5 or while it's in the info panel.
+func (m model) statsInterval(c docker.Container) time.Duration {
+	if secs, ok := m.settings.StatsOverrides[docker.ContainerName(c)]; ok {
+		return time.Duration(secs) * time.Second
+	}
+	secs := m.settings.StatsInterval
+	if secs == 0 {
+		secs = m.settings.RefreshInterval
+	}
+	return time.Duration(secs) * time.Second
+}
+
+// statsContainers are the containers on screen, the list or the compose tree
+func (m model) statsContainers() []docker.Container {
+	if !m.composeViewMode {
+		return m.containers
+	}
+	var out []docker.Container
+	for _, p := range m.projects {
+		out = append(out, p.Containers...)
+	}
+	return out
+}
+
+// statsCmd fetches the stats that are due, or of every running container when
+// forced. One call at a time, a slow one just delays the next.
+func (m *model) statsCmd(force bool) tea.Cmd {
+	if m.statsLoading {
+		return nil
+	}
+	if m.statsAt == nil {
+		m.statsAt = make(map[string]time.Time)
+	}
+
+	var infoID string
+	if m.infoVisible {
+		if c := m.infoTarget(); c != nil {
+			infoID = c.ID
+		}
+	}
+
+	now := time.Now()
+	var ids []string
+	for _, c := range m.statsContainers() {
+		if strings.ToLower(c.State) != "running" {
+			continue
+		}
+		due := force || c.ID == infoID
+		if !due {
+			interval := m.statsInterval(c)
+			due = interval > 0 && now.Sub(m.statsAt[c.ID]) >= interval-statsSlack
+		}
+		if due {
+			ids = append(ids, c.ID)
+			m.statsAt[c.ID] = now
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	m.statsLoading = true
+	return func() tea.Msg {
+		stats, err := docker.GetAllContainerStats(ids)
+		return statsMsg{stats: stats, err: err}
+	}
+}
+
+func (m *model) handleStats(msg statsMsg) tea.Cmd {
+	m.statsLoading = false
+	if msg.err != nil {
+		// the last stats stay up, the next call is due with the next interval
+		return nil
+	}
+	if m.stats == nil {
+		m.stats = make(map[string]docker.ContainerStats)
+	}
+	for id, s := range msg.stats {
+		m.stats[id] = s
+	}
+	m.applyStats()
+	samples := m.statsSamples(msg.stats)
+	m.recordStats(samples)
+	switch m.sortBy {
+	case sortByCPU, sortByMemory, sortByNetIO, sortByBlockIO:
+		m.sortContainers()
+	}
+	if m.composeViewMode {
+		m.buildFlatList()
+	}
+	return m.pushMetricsCmd(samples)
+}
+
+// applyStats puts the latest stats on the running containers, the list comes
+// without them. Containers that stopped or went away lose theirs.
+func (m *model) applyStats() {
+	seen := make(map[string]bool)
+	apply := func(cs []docker.Container) {
+		for i := range cs {
+			c := &cs[i]
+			if strings.ToLower(c.State) != "running" {
+				continue
+			}
+			seen[c.ID] = true
+			if s, ok := m.stats[c.ID]; ok {
+				c.CPU = s.CPU
+				c.Memory = s.Memory
+				c.MemUsage = s.MemUsage
+				c.NetIO = s.NetIO
+				c.BlockIO = s.BlockIO
+			}
+		}
+	}
+	apply(m.containers)
+	for _, p := range m.projects {
+		apply(p.Containers)
+	}
+
+	for id := range m.stats {
+		if !seen[id] {
+			delete(m.stats, id)
+		}
+	}
+	for id := range m.statsAt {
+		if !seen[id] {
+			delete(m.statsAt, id)
+		}
+	}
+}
+
+// statsSamples are the containers whose stats were just fetched, for the
+// recording and the metrics sink
+func (m model) statsSamples(fetched map[string]docker.ContainerStats) []docker.StatsSample {
+	now := time.Now()
+	var samples []docker.StatsSample
+	for _, c := range m.statsContainers() {
+		if _, ok := fetched[c.ID]; ok {
+			samples = append(samples, docker.NewStatsSample(c, now))
+		}
+	}
+	return samples
+}
+
+// recordStats appends the stats just fetched to record.file. A failed write
+// stops the recording rather than failing on every refresh.
+func (m *model) recordStats(samples []docker.StatsSample) {
+	if m.recorder == nil {
+		return
+	}
+	if err := m.recorder.Record(samples); err != nil {
+		m.statusMessage = fmt.Sprintf("Stopped recording stats to %s: %v", m.recorder.Path(), err)
+		m.recorder = nil
+	}
+}
+
+type metricsPushedMsg struct {
+	err error
+}
+
+// pushMetricsCmd sends the samples to the metrics sink. One push at a time, a
+// sink that's slower than the refresh misses samples instead of piling up.
+func (m *model) pushMetricsCmd(samples []docker.StatsSample) tea.Cmd {
+	if m.metricsSink == nil || m.metricsPushing || len(samples) == 0 {
+		return nil
+	}
+	m.metricsPushing = true
+	sink := m.metricsSink
+	return func() tea.Msg {
+		return metricsPushedMsg{err: sink.Push(samples)}
+	}
+}
+
+func (m *model) handleMetricsPushed(msg metricsPushedMsg) {
+	m.metricsPushing = false
+	if msg.err != nil {
+		if m.metricsErr == nil {
+			m.statusMessage = fmt.Sprintf("Metrics push to %s failed: %v", m.metricsSink.URL(), msg.err)
+		}
+		logging.Errorf("metrics push to %s: %v", m.metricsSink.URL(), msg.err)
+	} else if m.metricsErr != nil {
+		m.statusMessage = "Metrics push works again"
+	}
+	m.metricsErr = msg.err
+}
@@ -0,0 +1,541 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+var degradedStyle = lipgloss.NewStyle().Foreground(yellowColor)
+
+// the swarm screen's tabs, tab cycles through them
+const (
+	swarmViewServices = iota
+	swarmViewStacks
+	swarmViewNodes
+	swarmViewCount
+)
+
+var swarmViewNames = []string{"Services", "Stacks", "Nodes"}
+
+type swarmStateMsg struct {
+	active   bool
+	services []docker.SwarmService
+	stacks   []docker.SwarmStack
+	nodes    []docker.SwarmNode
+	err      error
+}
+
+type swarmTasksMsg struct {
+	service string
+	tasks   []docker.SwarmTask
+	err     error
+}
+
+// swarmStateCmd loads services, stacks and nodes; only managers can list them
+func swarmStateCmd() tea.Cmd {
+	return func() tea.Msg {
+		if !docker.SwarmActive() {
+			return swarmStateMsg{}
+		}
+		msg := swarmStateMsg{active: true}
+		msg.services, msg.err = docker.ListServices()
+		if msg.err != nil {
+			return msg
+		}
+		msg.stacks, msg.err = docker.ListStacks()
+		if msg.err != nil {
+			return msg
+		}
+		msg.nodes, msg.err = docker.ListNodes()
+		return msg
+	}
+}
+
+func swarmTasksCmd(service string) tea.Cmd {
+	return func() tea.Msg {
+		tasks, err := docker.ServiceTasks(service)
+		return swarmTasksMsg{service: service, tasks: tasks, err: err}
+	}
+}
+
+// openSwarm checks for swarm mode first, the screen only opens on a swarm node
+func (m *model) openSwarm() tea.Cmd {
+	m.statusMessage = "Looking for swarm services..."
+	m.swarmOpening = true
+	return swarmStateCmd()
+}
+
+// refreshSwarm reloads whatever the swarm screen shows, on every tick
+func (m model) refreshSwarm() tea.Cmd {
+	if m.swarmTasksFor != "" {
+		return swarmTasksCmd(m.swarmTasksFor)
+	}
+	return swarmStateCmd()
+}
+
+func (m *model) handleSwarmState(msg swarmStateMsg) {
+	if !msg.active {
+		if m.swarmOpening {
+			m.statusMessage = "This daemon is not in swarm mode (see docker swarm init)"
+		}
+		m.swarmOpening = false
+		return
+	}
+	if m.swarmOpening {
+		m.swarmOpening = false
+		m.swarmCursor = 0
+		m.swarmNodeCursor = 0
+		m.swarmTasksFor = ""
+		m.swarmReturnMode = m.currentMode
+		m.currentMode = modeSwarm
+		m.statusMessage = ""
+	}
+	m.swarmErr = msg.err
+	if msg.err == nil {
+		m.swarmServices = msg.services
+		m.swarmStacks = msg.stacks
+		m.swarmNodes = msg.nodes
+	}
+	m.swarmCursor = max(0, min(m.swarmCursor, len(m.swarmRows())-1))
+	m.swarmNodeCursor = max(0, min(m.swarmNodeCursor, len(m.swarmNodes)-1))
+}
+
+func (m *model) handleSwarmTasks(msg swarmTasksMsg) {
+	if msg.service != m.swarmTasksFor {
+		return
+	}
+	m.swarmErr = msg.err
+	if msg.err == nil {
+		m.swarmTasks = msg.tasks
+	}
+	m.swarmTaskCursor = max(0, min(m.swarmTaskCursor, len(m.swarmTasks)-1))
+}
+
+// swarmRow is a line of the services or stacks tab: a stack, or a service
+type swarmRow struct {
+	stack    string // set on stack rows, "" is the group of services outside any stack
+	services int
+	degraded int
+	service  *docker.SwarmService
+}
+
+// swarmRows lists the services, grouped under their stacks on the stacks tab
+// like compose projects. Stacks expand with enter.
+func (m model) swarmRows() []swarmRow {
+	var rows []swarmRow
+	if m.swarmView != swarmViewStacks {
+		for i := range m.swarmServices {
+			rows = append(rows, swarmRow{service: &m.swarmServices[i]})
+		}
+		return rows
+	}
+
+	byStack := make(map[string][]*docker.SwarmService)
+	for i := range m.swarmServices {
+		s := &m.swarmServices[i]
+		stack := docker.StackOf(s.Name, m.swarmStacks)
+		byStack[stack] = append(byStack[stack], s)
+	}
+
+	names := make([]string, 0, len(m.swarmStacks)+1)
+	for _, st := range m.swarmStacks {
+		names = append(names, st.Name)
+	}
+	if len(byStack[""]) > 0 {
+		names = append(names, "")
+	}
+	for _, name := range names {
+		row := swarmRow{stack: name, services: len(byStack[name])}
+		for _, s := range byStack[name] {
+			if s.Degraded() {
+				row.degraded++
+			}
+		}
+		rows = append(rows, row)
+		if m.swarmExpanded[name] {
+			for _, s := range byStack[name] {
+				rows = append(rows, swarmRow{service: s})
+			}
+		}
+	}
+	return rows
+}
+
+func (m model) swarmPageSize() int {
+	// title, tabs, header, error/detail line, footer
+	return max(1, m.terminalHeight-6)
+}
+
+func (m model) selectedService() *docker.SwarmService {
+	rows := m.swarmRows()
+	if m.swarmView != swarmViewNodes && m.swarmCursor < len(rows) {
+		return rows[m.swarmCursor].service
+	}
+	return nil
+}
+
+func (m model) updateSwarm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.swarmTasksFor != "" {
+		switch msg.String() {
+		case "esc", "q", "left", "backspace":
+			m.swarmTasksFor = ""
+			m.swarmTasks = nil
+			m.swarmErr = nil
+			return m, swarmStateCmd()
+		case "up", "k":
+			if m.swarmTaskCursor > 0 {
+				m.swarmTaskCursor--
+			}
+		case "down", "j":
+			if m.swarmTaskCursor < len(m.swarmTasks)-1 {
+				m.swarmTaskCursor++
+			}
+		case "f5":
+			return m, swarmTasksCmd(m.swarmTasksFor)
+		}
+		return m, nil
+	}
+
+	if m.swarmView == swarmViewNodes {
+		switch msg.String() {
+		case "up", "k":
+			if m.swarmNodeCursor > 0 {
+				m.swarmNodeCursor--
+			}
+			return m, nil
+		case "down", "j":
+			if m.swarmNodeCursor < len(m.swarmNodes)-1 {
+				m.swarmNodeCursor++
+			}
+			return m, nil
+		}
+	}
+
+	rows := m.swarmRows()
+	switch msg.String() {
+	case "esc", "q":
+		m.currentMode = m.swarmReturnMode
+		m.swarmServices = nil
+		m.statusMessage = "Swarm closed"
+	case "tab":
+		m.swarmView = (m.swarmView + 1) % swarmViewCount
+		m.swarmCursor = 0
+	case "up", "k":
+		if m.swarmCursor > 0 {
+			m.swarmCursor--
+		}
+	case "down", "j":
+		if m.swarmCursor < len(rows)-1 {
+			m.swarmCursor++
+		}
+	case "pgup":
+		m.swarmCursor = max(0, m.swarmCursor-m.swarmPageSize())
+	case "pgdown":
+		m.swarmCursor = max(0, min(len(rows)-1, m.swarmCursor+m.swarmPageSize()))
+	case "enter", "right":
+		if m.swarmView == swarmViewStacks && m.swarmCursor < len(rows) && rows[m.swarmCursor].service == nil {
+			stack := rows[m.swarmCursor].stack
+			if m.swarmExpanded == nil {
+				m.swarmExpanded = make(map[string]bool)
+			}
+			m.swarmExpanded[stack] = !m.swarmExpanded[stack]
+			return m, nil
+		}
+		if s := m.selectedService(); s != nil {
+			m.swarmTasksFor = s.Name
+			m.swarmTasks = nil
+			m.swarmTaskCursor = 0
+			m.swarmErr = nil
+			return m, swarmTasksCmd(s.Name)
+		}
+	case "s", "S":
+		if m.refuseReadOnly() {
+			return m, nil
+		}
+		if s := m.selectedService(); s != nil {
+			return m, m.openScalePrompt(*s)
+		}
+	case "u", "U":
+		if m.refuseReadOnly() {
+			return m, nil
+		}
+		if s := m.selectedService(); s != nil {
+			return m, m.openServiceUpdatePrompt(*s)
+		}
+	case "b", "B":
+		if m.refuseReadOnly() {
+			return m, nil
+		}
+		if s := m.selectedService(); s != nil {
+			name := s.Name
+			m.confirm(fmt.Sprintf("Roll %s back to its previous version?", name), func(m *model) tea.Cmd {
+				m.statusMessage = fmt.Sprintf("Rolling back %s...", name)
+				return swarmActionCmd(fmt.Sprintf("Rolled back %s", name), func() error {
+					return docker.RollbackService(name)
+				})
+			})
+		}
+	case "f5":
+		return m, swarmStateCmd()
+	}
+	return m, nil
+}
+
+func swarmActionCmd(done string, fn func() error) tea.Cmd {
+	return func() tea.Msg {
+		if err := fn(); err != nil {
+			return actionDoneMsg{err: err}
+		}
+		return actionDoneMsg{msg: done}
+	}
+}
+
+func (m *model) openScalePrompt(s docker.SwarmService) tea.Cmd {
+	if s.Mode == "global" {
+		m.statusMessage = fmt.Sprintf("%s is a global service, it runs once per node", s.Name)
+		return nil
+	}
+	name := s.Name
+	cmd := m.prompt(fmt.Sprintf("Replicas for %s", name), "number of replicas", func(m *model, value string) tea.Cmd {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			m.statusMessage = fmt.Sprintf("%q is not a number of replicas", value)
+			return nil
+		}
+		m.statusMessage = fmt.Sprintf("Scaling %s to %d...", name, n)
+		return swarmActionCmd(fmt.Sprintf("Scaled %s to %d", name, n), func() error {
+			return docker.ScaleService(name, n)
+		})
+	})
+	// "2/3 (max 1 per node)" starts from the desired 3
+	_, desired, _ := strings.Cut(s.Replicas, "/")
+	desired, _, _ = strings.Cut(desired, " ")
+	m.promptInput.SetValue(desired)
+	return cmd
+}
+
+func (m *model) openServiceUpdatePrompt(s docker.SwarmService) tea.Cmd {
+	name := s.Name
+	cmd := m.prompt(fmt.Sprintf("Update %s to image", name), "repo:tag", func(m *model, image string) tea.Cmd {
+		if image == "" {
+			m.statusMessage = "Cancelled"
+			return nil
+		}
+		m.statusMessage = fmt.Sprintf("Updating %s to %s...", name, image)
+		return swarmActionCmd(fmt.Sprintf("Updating %s to %s", name, image), func() error {
+			return docker.UpdateServiceImage(name, image)
+		})
+	})
+	// the pinned digest would keep the old image
+	image, _, _ := strings.Cut(s.Image, "@")
+	m.promptInput.SetValue(image)
+	return cmd
+}
+
+func (m model) renderSwarm(width int) string {
+	if m.swarmTasksFor != "" {
+		return m.renderSwarmTasks(width)
+	}
+
+	var b strings.Builder
+	b.WriteString(m.renderTitleBar(width))
+	b.WriteString("\n")
+
+	tabs := make([]string, 0, len(swarmViewNames))
+	for i, name := range swarmViewNames {
+		if i == m.swarmView {
+			tabs = append(tabs, selectedStyle.Render(" "+name+" "))
+		} else {
+			tabs = append(tabs, normalStyle.Render(" "+name+" "))
+		}
+	}
+	b.WriteString(" " + strings.Join(tabs, " "))
+	b.WriteString("\n")
+
+	var footer [][2]string
+	if m.swarmView == swarmViewNodes {
+		m.renderSwarmNodes(&b, width)
+		footer = [][2]string{{"↑↓", "move"}, {"Tab", "view"}, {"F5", "reload"}, {"Esc", "close"}}
+	} else {
+		m.renderSwarmServices(&b, width)
+		footer = [][2]string{{"Tab", "view"}, {"Enter", "tasks"}, {"S", "scale"}, {"U", "update image"}, {"B", "rollback"}, {"F5", "reload"}, {"Esc", "close"}}
+		if m.swarmView == swarmViewStacks {
+			footer[1][1] = "expand/tasks"
+		}
+	}
+
+	errLine := ""
+	if m.swarmErr != nil {
+		errLine = m.swarmErr.Error()
+	}
+	b.WriteString(messageStyle.Render(" " + truncateToWidth(errLine, width-1)))
+	b.WriteString("\n")
+
+	b.WriteString(m.renderFilesFooter(width, footer))
+	return b.String()
+}
+
+// renderSwarmServices draws the services tab, and the stacks tab with the
+// services of open stacks under them
+func (m model) renderSwarmServices(b *strings.Builder, width int) {
+	b.WriteString(headerStyle.Render(padRight(fmt.Sprintf(" %-24s %-11s %-9s %-30s %s", "SERVICE", "MODE", "REPLICAS", "IMAGE", "PORTS"), width)))
+	b.WriteString("\n")
+
+	rows := m.swarmRows()
+	page := m.swarmPageSize()
+	shown := 0
+	if len(rows) == 0 {
+		b.WriteString(normalStyle.Render("  No services"))
+		b.WriteString("\n")
+		shown++
+	}
+	start := 0
+	if m.swarmCursor >= page {
+		start = m.swarmCursor - page + 1
+	}
+	for i := start; i < len(rows) && shown < page; i++ {
+		row := rows[i]
+		var line string
+		degraded := false
+		if s := row.service; s != nil {
+			name := s.Name
+			if m.swarmView == swarmViewStacks {
+				name = "  " + name
+			}
+			image, _, _ := strings.Cut(s.Image, "@")
+			line = fmt.Sprintf(" %-24s %-11s %-9s %-30s %s",
+				truncateToWidth(name, 24), s.Mode, s.Replicas, truncateToWidth(image, 30), s.Ports)
+			degraded = s.Degraded()
+		} else {
+			arrow := "▸"
+			if m.swarmExpanded[row.stack] {
+				arrow = "▾"
+			}
+			name := row.stack
+			if name == "" {
+				name = "(no stack)"
+			}
+			summary := fmt.Sprintf("%d services", row.services)
+			if row.degraded > 0 {
+				summary += fmt.Sprintf(" (%d degraded)", row.degraded)
+			}
+			line = fmt.Sprintf(" %s %-22s %s", arrow, truncateToWidth(name, 22), summary)
+			degraded = row.degraded > 0
+		}
+		line = padRight(truncateToWidth(line, width), width)
+		switch {
+		case i == m.swarmCursor:
+			b.WriteString(selectedStyle.Render(line))
+		case degraded:
+			b.WriteString(degradedStyle.Render(line))
+		default:
+			b.WriteString(normalStyle.Render(line))
+		}
+		b.WriteString("\n")
+		shown++
+	}
+	for ; shown < page; shown++ {
+		b.WriteString("\n")
+	}
+}
+
+// renderSwarmNodes draws the nodes tab, down and drained nodes stand out
+func (m model) renderSwarmNodes(b *strings.Builder, width int) {
+	b.WriteString(headerStyle.Render(padRight(fmt.Sprintf(" %-26s %-8s %-13s %-12s %s", "HOSTNAME", "STATUS", "AVAILABILITY", "MANAGER", "ENGINE"), width)))
+	b.WriteString("\n")
+
+	page := m.swarmPageSize()
+	shown := 0
+	if len(m.swarmNodes) == 0 {
+		b.WriteString(normalStyle.Render("  No nodes, only managers can list them"))
+		b.WriteString("\n")
+		shown++
+	}
+	start := 0
+	if m.swarmNodeCursor >= page {
+		start = m.swarmNodeCursor - page + 1
+	}
+	for i := start; i < len(m.swarmNodes) && shown < page; i++ {
+		n := m.swarmNodes[i]
+		name := n.Hostname
+		if n.Self {
+			name += " *"
+		}
+		manager := n.ManagerStatus
+		if manager == "" {
+			manager = "worker"
+		}
+		line := padRight(truncateToWidth(fmt.Sprintf(" %-26s %-8s %-13s %-12s %s",
+			truncateToWidth(name, 26), n.Status, n.Availability, manager, n.EngineVersion), width), width)
+		switch {
+		case i == m.swarmNodeCursor:
+			b.WriteString(selectedStyle.Render(line))
+		case n.Status != "Ready" || n.Availability != "Active":
+			b.WriteString(degradedStyle.Render(line))
+		default:
+			b.WriteString(normalStyle.Render(line))
+		}
+		b.WriteString("\n")
+		shown++
+	}
+	for ; shown < page; shown++ {
+		b.WriteString("\n")
+	}
+}
+
+func (m model) renderSwarmTasks(width int) string {
+	var b strings.Builder
+	b.WriteString(m.renderTitleBar(width))
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(padRight(fmt.Sprintf(" %-20s %-18s %-10s %-26s %s", "TASK", "NODE", "DESIRED", "CURRENT", "IMAGE"), width)))
+	b.WriteString("\n")
+
+	rows := m.swarmPageSize() + 1 // no tabs line here
+	shown := 0
+	if len(m.swarmTasks) == 0 {
+		b.WriteString(normalStyle.Render("  No tasks for " + m.swarmTasksFor))
+		b.WriteString("\n")
+		shown++
+	}
+	start := 0
+	if m.swarmTaskCursor >= rows {
+		start = m.swarmTaskCursor - rows + 1
+	}
+	for i := start; i < len(m.swarmTasks) && shown < rows; i++ {
+		t := m.swarmTasks[i]
+		image, _, _ := strings.Cut(t.Image, "@")
+		line := padRight(truncateToWidth(fmt.Sprintf(" %-20s %-18s %-10s %-26s %s",
+			truncateToWidth(t.Name, 20), truncateToWidth(t.Node, 18), t.DesiredState, truncateToWidth(t.CurrentState, 26), image), width), width)
+		switch {
+		case i == m.swarmTaskCursor:
+			b.WriteString(selectedStyle.Render(line))
+		case t.Error != "":
+			b.WriteString(degradedStyle.Render(line))
+		default:
+			b.WriteString(normalStyle.Render(line))
+		}
+		b.WriteString("\n")
+		shown++
+	}
+	for ; shown < rows; shown++ {
+		b.WriteString("\n")
+	}
+
+	// the error of the selected task, it never fits in a column
+	detail := ""
+	if m.swarmErr != nil {
+		detail = m.swarmErr.Error()
+	} else if m.swarmTaskCursor < len(m.swarmTasks) {
+		detail = m.swarmTasks[m.swarmTaskCursor].Error
+	}
+	b.WriteString(messageStyle.Render(" " + truncateToWidth(detail, width-1)))
+	b.WriteString("\n")
+
+	b.WriteString(m.renderFilesFooter(width, [][2]string{{"↑↓", "move"}, {"F5", "reload"}, {"Esc", "services"}}))
+	return b.String()
+}
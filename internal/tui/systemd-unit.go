@@ -0,0 +1,176 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// the unit screen is for containers podman runs from a systemd unit, quadlets
+// mostly: the unit's status, the end of its journal, and systemctl start,
+// stop and restart. s, x and r in the list go through the unit as well.
+
+// unitJournalLines is how much of the journal the screen shows
+const unitJournalLines = 200
+
+type unitLoadedMsg struct {
+	unit    string
+	status  []string
+	journal []string
+	err     error
+}
+
+func unitLoadCmd(unit string) tea.Cmd {
+	return func() tea.Msg {
+		status, err := docker.UnitStatus(unit)
+		if err != nil {
+			return unitLoadedMsg{unit: unit, err: err}
+		}
+		journal, err := docker.UnitJournal(unit, unitJournalLines)
+		return unitLoadedMsg{unit: unit, status: status, journal: journal, err: err}
+	}
+}
+
+type unitActionMsg struct {
+	unit   string
+	action string
+	err    error
+}
+
+func unitActionCmd(action, unit string) tea.Cmd {
+	return func() tea.Msg {
+		return unitActionMsg{unit: unit, action: action, err: docker.UnitAction(action, unit)}
+	}
+}
+
+func (m *model) openUnit(c docker.Container) tea.Cmd {
+	unit := docker.SystemdUnit(c)
+	if unit == "" {
+		m.statusMessage = fmt.Sprintf("%s isn't run by a systemd unit", docker.ContainerName(c))
+		return nil
+	}
+	m.unitName = unit
+	m.unitStatus = nil
+	m.unitJournal = nil
+	m.unitErr = nil
+	m.unitOffset = 0
+	m.unitLoading = true
+	m.returnMode = m.currentMode
+	m.currentMode = modeUnit
+	m.statusMessage = ""
+	return unitLoadCmd(unit)
+}
+
+func (m *model) handleUnitLoaded(msg unitLoadedMsg) {
+	if msg.unit != m.unitName {
+		return
+	}
+	m.unitLoading = false
+	m.unitErr = msg.err
+	m.unitStatus = msg.status
+	m.unitJournal = msg.journal
+	// the journal opens at its end, the latest lines
+	m.unitOffset = max(0, len(m.unitJournal)-m.unitPageSize())
+}
+
+func (m *model) handleUnitAction(msg unitActionMsg) tea.Cmd {
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error: %v", msg.err)
+	} else {
+		done := map[string]string{"start": "Started", "stop": "Stopped", "restart": "Restarted"}[msg.action]
+		m.statusMessage = fmt.Sprintf("%s %s", done, msg.unit)
+	}
+	cmds := []tea.Cmd{fetchContainers()}
+	if m.currentMode == modeUnit && msg.unit == m.unitName {
+		m.unitLoading = true
+		cmds = append(cmds, unitLoadCmd(m.unitName))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m model) unitPageSize() int {
+	// title bar, title, status, blank line, journal heading and footer
+	return max(1, m.terminalHeight-6-len(m.unitStatus))
+}
+
+func (m model) updateUnit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	last := max(0, len(m.unitJournal)-m.unitPageSize())
+	switch msg.String() {
+	case "esc", "q", "ctrl+e":
+		m.currentMode = m.returnMode
+		m.unitJournal = nil
+		m.statusMessage = "Unit closed"
+	case "up", "k":
+		m.unitOffset = max(0, m.unitOffset-1)
+	case "down", "j":
+		m.unitOffset = min(last, m.unitOffset+1)
+	case "pgup", "ctrl+u":
+		m.unitOffset = max(0, m.unitOffset-m.unitPageSize())
+	case "pgdown", "ctrl+d":
+		m.unitOffset = min(last, m.unitOffset+m.unitPageSize())
+	case "home", "g":
+		m.unitOffset = 0
+	case "end", "G":
+		m.unitOffset = last
+	case "s", "S", "x", "X", "r", "R":
+		if m.refuseReadOnly() {
+			return m, nil
+		}
+		action := map[string]string{"s": "start", "x": "stop", "r": "restart"}[strings.ToLower(msg.String())]
+		m.statusMessage = fmt.Sprintf("systemctl %s %s...", action, m.unitName)
+		return m, unitActionCmd(action, m.unitName)
+	case "f5":
+		if !m.unitLoading {
+			m.unitLoading = true
+			return m, unitLoadCmd(m.unitName)
+		}
+	}
+	return m, nil
+}
+
+func (m model) renderUnit(width int) string {
+	var b strings.Builder
+
+	b.WriteString(m.renderTitleBar(width))
+	b.WriteString("\n")
+	b.WriteString(titleStyle.Render(padRight(truncateToWidth(fmt.Sprintf("Systemd unit %s", m.unitName), width-2), width-2)))
+	b.WriteString("\n")
+	for _, line := range m.unitStatus {
+		b.WriteString(normalStyle.Render(truncateToWidth(" "+line, width)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	rows := m.unitPageSize()
+	heading := " Journal"
+	if len(m.unitJournal) > rows {
+		heading += fmt.Sprintf("  (%d-%d of %d)", m.unitOffset+1, min(m.unitOffset+rows, len(m.unitJournal)), len(m.unitJournal))
+	}
+	b.WriteString(infoLabelStyle.Render(heading))
+	b.WriteString("\n")
+
+	var lines []string
+	switch {
+	case m.unitLoading && len(m.unitStatus) == 0:
+		lines = append(lines, normalStyle.Render("  Asking systemd..."))
+	case m.unitErr != nil:
+		lines = append(lines, messageStyle.Render(truncateToWidth("  "+m.unitErr.Error(), width)))
+	case len(m.unitJournal) == 0:
+		lines = append(lines, normalStyle.Render("  Nothing in the journal"))
+	default:
+		for _, l := range m.unitJournal[m.unitOffset:min(m.unitOffset+rows, len(m.unitJournal))] {
+			lines = append(lines, normalStyle.Render(truncateToWidth(" "+l, width)))
+		}
+	}
+	for i := 0; i < rows; i++ {
+		if i < len(lines) {
+			b.WriteString(lines[i])
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.renderFilesFooter(width, [][2]string{{"s/x/r", "start/stop/restart"}, {"F5", "reload"}, {"Esc", "close"}}))
+	return b.String()
+}
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// long running actions (pulls, recreates) stream their output into the task
+// panel, which takes the place of the logs/info panel while it's open
+
+const maxTaskLines = 500
+
+type taskLineMsg struct{ line string }
+
+type taskDoneMsg struct {
+	err error
+	msg string
+}
+
+func waitForTask(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+// startTask runs fn in the background; every line it reports shows up in the panel.
+// fn returns the status message shown once it's done.
+func (m *model) startTask(title string, fn func(onLine func(string)) (string, error)) tea.Cmd {
+	if m.taskRunning {
+		m.statusMessage = fmt.Sprintf("Still busy with: %s", m.taskTitle)
+		return nil
+	}
+
+	ch := make(chan tea.Msg, 64)
+	m.taskCh = ch
+	m.taskTitle = title
+	m.taskLines = nil
+	m.taskRunning = true
+	m.taskVisible = true
+	m.statusMessage = title + "..."
+	m.updateViewport()
+
+	go func() {
+		msg, err := fn(func(line string) {
+			ch <- taskLineMsg{line: line}
+		})
+		ch <- taskDoneMsg{err: err, msg: msg}
+	}()
+
+	return waitForTask(ch)
+}
+
+func (m *model) handleTaskLine(msg taskLineMsg) tea.Cmd {
+	m.taskLines = append(m.taskLines, msg.line)
+	if len(m.taskLines) > maxTaskLines {
+		m.taskLines = m.taskLines[len(m.taskLines)-maxTaskLines:]
+	}
+	return waitForTask(m.taskCh)
+}
+
+func (m *model) handleTaskDone(msg taskDoneMsg) tea.Cmd {
+	m.taskRunning = false
+	m.taskCh = nil
+	if msg.err != nil {
+		m.taskLines = append(m.taskLines, strings.Split(msg.err.Error(), "\n")...)
+		m.statusMessage = fmt.Sprintf("Error: %s", strings.SplitN(msg.err.Error(), "\n", 2)[0])
+	} else {
+		m.statusMessage = msg.msg
+	}
+	return fetchContainers()
+}
+
+func (m model) renderTaskPanel(width int) string {
+	var b strings.Builder
+
+	b.WriteString(dividerStyle.Render(strings.Repeat("─", width)))
+	b.WriteString("\n")
+
+	title := m.taskTitle
+	if m.taskRunning {
+		title += " (running, Esc to hide)"
+	} else {
+		title += " (done, Esc to close)"
+	}
+	title = padRight(title, width)
+	b.WriteString(titleStyle.Render(title))
+	b.WriteString("\n")
+
+	maxLines := m.logPanelHeight - 2
+	if maxLines < 1 {
+		maxLines = 1
+	}
+
+	start := 0
+	if len(m.taskLines) > maxLines {
+		start = len(m.taskLines) - maxLines
+	}
+	for i := start; i < len(m.taskLines); i++ {
+		line := truncateToWidth(m.taskLines[i], width-4)
+		b.WriteString(normalStyle.Render("  " + line))
+		b.WriteString("\n")
+	}
+	for i := len(m.taskLines) - start; i < maxLines; i++ {
+		b.WriteString(normalStyle.Render(strings.Repeat(" ", width)))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/config"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// templates are containers from the config that are run as they are, listed
+// first in the Ctrl+N menu or bound to a key of their own
+
+// templateMenuKeys are the Ctrl+N menu keys for templates, t and the digits
+// are taken by the rest of the menu and q closes it
+const templateMenuKeys = "abcdefghijklmnoprsuvwxyz"
+
+// templateFor returns the template bound to k
+func (m model) templateFor(k string) (config.RunTemplate, bool) {
+	for _, t := range m.settings.Templates {
+		if t.Key != "" && normalizeKey(t.Key) == k {
+			return t, true
+		}
+	}
+	return config.RunTemplate{}, false
+}
+
+// templateItems are the Ctrl+N menu entries of the templates
+func (m model) templateItems() []menuItem {
+	var items []menuItem
+	for i, t := range m.settings.Templates {
+		if i >= len(templateMenuKeys) {
+			break
+		}
+		items = append(items, menuItem{
+			key:   templateMenuKeys[i : i+1],
+			label: truncateToWidth(fmt.Sprintf("%s (%s)", t.Name, t.Image), 50),
+			action: func(m *model) tea.Cmd {
+				return m.runTemplate(t)
+			},
+		})
+	}
+	return items
+}
+
+// runTemplate fills in the template from the selected container and runs it,
+// in the background or, for interactive ones, in the terminal until it exits
+func (m *model) runTemplate(t config.RunTemplate) tea.Cmd {
+	data, selected := m.commandData()
+	spec := docker.RunSpec{Image: t.Image, Interactive: t.Interactive}
+	fill := func(texts []string) ([]string, error) {
+		var out []string
+		for _, text := range texts {
+			if !selected && strings.Contains(text, "{{") {
+				return nil, fmt.Errorf("select a container to run it for")
+			}
+			s, err := fillTemplate(t.Name, text, data)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, s)
+		}
+		return out, nil
+	}
+	var err error
+	if spec.Args, err = fill(t.Args); err == nil {
+		if spec.Env, err = fill(t.Env); err == nil {
+			spec.Command, err = fill(t.Command)
+		}
+	}
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Can't run template %s: %v", t.Name, err)
+		return nil
+	}
+
+	if !t.Interactive {
+		m.statusMessage = fmt.Sprintf("Starting %s...", t.Name)
+		return runContainerCmd(spec)
+	}
+	m.statusMessage = fmt.Sprintf("Running %s...", t.Name)
+	return m.runInTerminal(t.Name, spec.Argv(), func(err error) tea.Msg {
+		if err != nil {
+			return actionDoneMsg{err: fmt.Errorf("%s: %v", t.Name, err)}
+		}
+		return actionDoneMsg{msg: fmt.Sprintf("%s finished", t.Name)}
+	})
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// inside tmux, with exec.tmux set to pane or window, shells and log follows
+// get a tmux pane or window of their own and DockMate keeps running next to
+// them. Everywhere else they take over the terminal until they exit.
+
+// tmuxTarget is "pane" or "window" when commands go to tmux, "" otherwise
+func (m model) tmuxTarget() string {
+	if os.Getenv("TMUX") == "" {
+		return ""
+	}
+	switch m.settings.Tmux {
+	case "pane", "window":
+		return m.settings.Tmux
+	}
+	return ""
+}
+
+// runInTerminal runs an interactive command, named title, in a tmux pane or
+// window, or in place of the TUI. done gets the command's error when it ran
+// in place.
+func (m model) runInTerminal(title string, args []string, done tea.ExecCallback) tea.Cmd {
+	// the pane starts from the tmux server's environment, not DockMate's
+	var env []string
+	for _, name := range []string{"DOCKER_HOST", "CONTAINER_HOST", "CONTAINERD_ADDRESS", "DOCKER_CERT_PATH", "DOCKER_TLS_VERIFY", "DOCKER_TLS"} {
+		if v := os.Getenv(name); v != "" {
+			env = append(env, "-e", name+"="+v)
+		}
+	}
+	switch m.tmuxTarget() {
+	case "pane":
+		return tmuxCmd(title, "pane", slices.Concat([]string{"split-window"}, env, args))
+	case "window":
+		return tmuxCmd(title, "window", slices.Concat([]string{"new-window", "-n", title}, env, args))
+	}
+	return tea.ExecProcess(exec.Command(args[0], args[1:]...), done)
+}
+
+func tmuxCmd(title, target string, args []string) tea.Cmd {
+	return func() tea.Msg {
+		out, err := exec.Command("tmux", args...).CombinedOutput()
+		if err != nil {
+			return actionDoneMsg{err: fmt.Errorf("tmux: %v: %s", err, out)}
+		}
+		return actionDoneMsg{msg: fmt.Sprintf("Opened %s in a tmux %s", title, target)}
+	}
+}
+
+// followLogs follows a container's logs full-screen until Ctrl+C
+func (m *model) followLogs(c docker.Container) tea.Cmd {
+	name := docker.ContainerName(c)
+	script := fmt.Sprintf("echo '--- Following the logs of %s, Ctrl+C to go back ---'; exec %s logs -f --tail 100 %s",
+		c.ID, docker.RuntimeBinary(), c.ID)
+	m.statusMessage = fmt.Sprintf("Following the logs of %s...", name)
+	return m.runInTerminal("logs "+name, []string{"sh", "-c", script}, func(error) tea.Msg {
+		// Ctrl+C is how it ends, that's no error
+		return actionDoneMsg{msg: fmt.Sprintf("Stopped following the logs of %s", name)}
+	})
+}
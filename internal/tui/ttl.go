@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// ttlOverride returns the ttl configured locally for this container (ttl.containers), if any
+func (m model) ttlOverride(c docker.Container) string {
+	return m.settings.TTLOverrides[docker.ContainerName(c)]
+}
+
+func (m model) isExpired(c docker.Container) bool {
+	expires, ok := c.ExpiresAt(m.ttlOverride(c))
+	return ok && time.Now().After(expires)
+}
+
+// ttlDescription is the info panel text, empty when the container has no ttl
+func (m model) ttlDescription(c docker.Container) string {
+	override := m.ttlOverride(c)
+	ttl, ok := c.TTL(override)
+	if !ok {
+		return ""
+	}
+
+	expires, ok := c.ExpiresAt(override)
+	if !ok {
+		return fmt.Sprintf("%s (creation time unknown)", docker.FormatAge(ttl))
+	}
+
+	left := time.Until(expires)
+	if left <= 0 {
+		return fmt.Sprintf("%s - EXPIRED %s ago", docker.FormatAge(ttl), docker.FormatAge(-left))
+	}
+	return fmt.Sprintf("%s - expires in %s", docker.FormatAge(ttl), docker.FormatAge(left))
+}
+
+// nameBadges returns the markers shown in front of a container's name
+func (m model) nameBadges(c docker.Container) string {
+	badges := ""
+	if m.marked[c.ID] {
+		badges += "✓ "
+	}
+	if m.isPinned(c) {
+		badges += "★ "
+	}
+	if m.isExpired(c) {
+		badges += "⧗ "
+	}
+	if m.oomKilled(c) || m.crashLooping(c) {
+		badges += "⚠ "
+	}
+	if m.updateAvailable(c) {
+		badges += "⬆ "
+	}
+	if docker.AutoUpdatePolicy(c) != "" {
+		badges += "↻ "
+	}
+	return badges
+}
+
+// stopExpiredContainers stops running containers whose ttl ran out, when ttl.auto_stop is on.
+// each container is only stopped once per session so a manual restart sticks.
+func (m *model) stopExpiredContainers() tea.Cmd {
+	if !m.settings.TTLAutoStop || readOnly || len(m.batchStages) > 0 {
+		return nil
+	}
+	if m.ttlStopped == nil {
+		m.ttlStopped = make(map[string]bool)
+	}
+
+	var expired []docker.Container
+	for _, c := range m.containers {
+		if strings.ToLower(c.State) != "running" || m.ttlStopped[c.ID] || !m.isExpired(c) {
+			continue
+		}
+		m.ttlStopped[c.ID] = true
+		expired = append(expired, c)
+	}
+
+	if len(expired) == 0 {
+		return nil
+	}
+	return m.stopInOrder("expired containers", expired)
+}
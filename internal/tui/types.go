@@ -1,54 +1,278 @@
 package tui
 
 import (
+	"context"
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/config"
 	"github.com/shubh-io/dockmate/internal/docker"
+	"github.com/shubh-io/dockmate/internal/metrics"
 )
 
 type model struct {
-	containers           []docker.Container                // all containers (running + stopped)
-	projects             map[string]*docker.ComposeProject // compose projects
-	expandedProjects     map[string]bool                   // track which projects are expanded
-	flatList             []treeRow                         // flattened tree for rendering
-	cursor               int                               // selected container index
-	page                 int                               // current page
-	maxContainersPerPage int                               // containers per page (dynamic)
-	terminalWidth        int                               // terminal width
-	terminalHeight       int                               // terminal height
-	err                  error                             // last error
-	loading              bool                              // fetching data?
-	message              string                            // page indicator (persistent)
-	statusMessage        string                            // transient status message
-	startTime            time.Time                         // when app started
-	logsVisible          bool                              // logs panel visible?
-	logPanelHeight       int                               // height of logs panel
-	logsLines            []string                          // log lines
-	logsContainer        string                            // container id for logs
-	logsIsProject        bool                              // true if logsContainer refers to a compose project
-	logsWorkingDir       string                            // working directory for compose project logs
-	infoVisible          bool                              // info panel visible?
-	infoPanelHeight      int                               // height of info panel
-	infoContainer        *docker.Container                 // container for info display
-	infoContainerID      string                            // info container ID
-	sortBy               sortColumn                        // which column to sort by
-	sortAsc              bool                              // sort direction
-	columnMode           bool                              // column nav mode (vs row nav)
-	selectedColumn       int                               // selected column (0-8)
-	currentMode          appMode                           // current UI mode
-	helpList             list.Model
+	containers       []docker.Container                // all containers (running + stopped)
+	projects         map[string]*docker.ComposeProject // compose projects
+	expandedProjects map[string]bool                   // track which projects are expanded
+	flatList         []treeRow                         // flattened tree for rendering
+	cursor           int                               // selected container index
+	offset           int                               // first list row on screen
+	returnTo         string                            // container to put the cursor on once the list has it, after a shell or run
+	listHeight       int                               // list rows that fit on screen (dynamic)
+	terminalWidth    int                               // terminal width
+	terminalHeight   int                               // terminal height
+	err              error                             // last error
+	loading          bool                              // fetching data?
+	message          string                            // page indicator (persistent)
+	statusMessage    string                            // transient status message
+	startTime        time.Time                         // when app started
+	logsVisible      bool                              // logs panel visible?
+	logPanelHeight   int                               // height of logs panel
+	logsLines        []string                          // log lines
+	logsContainer    string                            // container id for logs
+	logsIsProject    bool                              // true if logsContainer refers to a compose project
+	logsWorkingDir   string                            // working directory for compose project logs
+	logsWrap         bool                              // wrap long log lines instead of cutting them
+	logsJSON         bool                              // flatten JSON log lines, see log-format.go
+	logsTimestamps   bool                              // ask for the logs with --timestamps
+	logsSince        time.Duration                     // only logs this recent, 0 for the last lines of any age
+	logsMulti        []string                          // ids of the containers whose logs are interleaved, see multi-logs.go
+	logsNames        map[string]string                 // names of the logsMulti containers by id
+	logsPrefixes     []string                          // time and name in front of each of the logsLines when interleaved
+	logsRaw          []string                          // fetched log lines before the filter, see log-filter.go
+	logsRawPrefixes  []string                          // logsPrefixes of logsRaw
+	logsSources      []string                          // container name of each of logsRaw when interleaved
+	marked           map[string]bool                   // ids of the containers marked with Space
+	infoVisible      bool                              // info panel visible?
+	infoPanelHeight  int                               // height of info panel
+	infoContainer    *docker.Container                 // container for info display
+	infoContainerID  string                            // info container ID
+	sortBy           sortColumn                        // which column to sort by
+	sortAsc          bool                              // sort direction
+	thenSortBy       sortColumn                        // secondary sort column, always ascending
+	columnMode       bool                              // column nav mode (vs row nav)
+	selectedColumn   int                               // selected column (0-8)
+	currentMode      appMode                           // current UI mode
+	helpList         list.Model
 
 	// settings
 	settings         Settings
 	composeViewMode  bool
 	suspendRefresh   bool
+	refreshPaused    bool // auto-refresh stopped by hand, unlike suspendRefresh which dialogs set
 	settingsSelected int
 
+	// last successful list fetch, for the header
+	lastRefresh time.Time
+	lastFetch   time.Duration
+
 	// confirmation
 	confirmMessage string
-	pendingAction  func() tea.Cmd
+	pendingAction  func(m *model) tea.Cmd
+	returnMode     appMode // mode to go back to once a dialog closes
+
+	// menu dialog
+	menuTitle string
+	menuItems []menuItem
+
+	// text prompt dialog
+	promptTitle  string
+	promptInput  textinput.Model
+	promptSubmit func(m *model, value string) tea.Cmd
+
+	// resource limits form
+	limitsTarget docker.Container
+	limitsInputs []textinput.Model // CPUs, memory
+	limitsFocus  int
+	limitsErr    string
+
+	ttlStopped map[string]bool   // containers already auto-stopped for an expired ttl
+	lastStates map[string]string // previous state per container, for pinned alerts
+
+	// ordered batch stop or start in progress
+	batchAction string               // stop or start
+	batchStages [][]docker.Container // stages still to go, in stop or start order
+	batchLabel  string
+	batchTotal  int
+	batchDone   int
+	batchFailed []string
+
+	imageUpdates    map[string]docker.ImageUpdate // registry check results by container ID
+	autoUpdates     map[string]docker.AutoUpdate  // podman auto-update dry run by container ID
+	podmanSockets   []docker.PodmanSocket         // looked at when settings open
+	checkingUpdates bool
+
+	// OOM kills, crash loops, uptime and networks
+	inspectInfo    map[string]docker.InspectInfo // by container ID, from the last inspect
+	restartTracker *docker.RestartTracker
+	recorder       *docker.StatsRecorder      // appends the stats to record.file, nil when not recording
+	metricsSink    *metrics.Sink              // pushes the stats to metrics.url, nil when not configured
+	metricsPushing bool                       // a push is on its way
+	metricsErr     error                      // how the last push failed, to report a failing sink once
+	gpuUsage       map[string]docker.GPUUsage // by container ID, for containers with GPUs
+	noNvidiaSMI    bool                       // stop asking for GPU usage
+
+	// stats are fetched apart from the list, see stats.go
+	stats        map[string]docker.ContainerStats // by container ID, the latest of each
+	statsAt      map[string]time.Time             // when each container's stats were last asked for
+	statsLoading bool
+
+	// session tracking for the quit hook
+	sessionStarted  map[string]bool // container IDs started from DockMate
+	sessionProjects map[string]bool // compose projects brought up from DockMate
+	quitAfterBatch  bool
+
+	// queued container actions, waiting ones after the running ones
+	actions       []*queuedAction
+	nextActionID  int
+	actionTicking bool // spinner tick scheduled
+
+	undo       *undoAction // what Ctrl+Z undoes, for a few seconds
+	nextUndoID int
+
+	// task panel, output of long running actions
+	taskVisible bool
+	taskRunning bool
+	taskTitle   string
+	taskLines   []string
+	taskCh      chan tea.Msg
+
+	// events panel, a tail of docker events
+	eventsVisible bool
+	events        []docker.Event
+	eventsErr     error
+	eventsFilter  string // container name or ID prefix, empty for all
+	eventsCh      chan tea.Msg
+	eventsCancel  context.CancelFunc
+
+	projectFilter string   // only show this compose project, empty for everything
+	stateFilter   string   // running, exited or unhealthy, empty for every state
+	networkFilter string   // only show containers attached to this network
+	projectPins   []string // pinned by the repo's .dockmate.yml, not saved globally
+
+	// file browser
+	filesContainerID   string
+	filesContainerName string
+	filesPath          string
+	filesEntries       []docker.FileEntry
+	filesCursor        int
+	filesErr           error
+	filesPreview       []string // lines of the file being previewed, nil when listing
+	filesPreviewPath   string
+	filesPreviewScroll int
+
+	// image history
+	historyImage   string
+	historyLayers  []docker.ImageLayer
+	historyBiggest map[int]bool // indexes of the largest layers, highlighted
+	historyErr     error
+	historyCursor  int
+	historyLoading bool
+
+	// vulnerability scan
+	scanImage    string
+	scanScanner  string // trivy or grype
+	scanResult   docker.ScanResult
+	scanErr      error
+	scanCursor   int
+	scanExpanded bool // details of the selected vulnerability shown
+	scanLoading  bool
+
+	// registry browser
+	registryRepo       string
+	registryTags       []docker.RemoteTag
+	registryErr        error
+	registryCursor     int
+	registryFilter     string
+	registryFiltering  bool // typing the filter
+	registryLoading    bool
+	registryReturnMode appMode // its own, the repository prompt can be opened from inside
+
+	// daemon info
+	daemonInfo        docker.DaemonInfo
+	daemonInfoErr     error
+	daemonInfoLoading bool
+
+	// container diff screen
+	diffID      string
+	diffName    string
+	diffChanges []docker.FileChange
+	diffErr     error
+	diffLoading bool
+	diffOffset  int // first change on screen
+
+	// compose dependency screen
+	graphProject string
+	graphOffset  int
+
+	// compose projects found on disk
+	discovered      []docker.DefinedProject
+	discoverErr     error
+	discoverLoading bool
+	discoverCursor  int // row among the ones not running
+
+	// compose project setup and effective config
+	configProject string
+	configDir     string
+	configSetup   docker.ComposeSetup
+	configLines   []string
+	configErr     error
+	configLoading bool
+	configOffset  int
+
+	// systemd unit screen
+	unitName    string
+	unitStatus  []string
+	unitJournal []string
+	unitErr     error
+	unitLoading bool
+	unitOffset  int // first journal line on screen
+
+	// swarm secrets and configs, podman secrets
+	secrets           []docker.Secret
+	secretsErr        error
+	secretsOpening    bool // waiting to hear whether the runtime has secrets
+	secretsLoading    bool
+	secretsCursor     int
+	secretsReturnMode appMode // its own, create and remove open dialogs from inside
+
+	// the startup checks, run once the TUI is up, and compose's
+	precheckRunning bool
+	precheckFailed  bool // a pass after a failure fetches the list again
+	composeChecked  bool // once per run, unless retried from the dialog
+	composeChecking bool
+	composeProblem  *PrecheckResult // nil while compose works (or isn't checked yet)
+	checkDialog     *checkDialog
+	checkReturnMode appMode
+
+	// swarm services, stacks and nodes
+	swarmOpening    bool // waiting to hear whether this is a swarm node
+	swarmView       int  // swarmViewServices, swarmViewStacks or swarmViewNodes
+	swarmServices   []docker.SwarmService
+	swarmStacks     []docker.SwarmStack
+	swarmNodes      []docker.SwarmNode
+	swarmExpanded   map[string]bool // stacks opened on the stacks tab
+	swarmCursor     int             // row of the services or stacks tab
+	swarmNodeCursor int
+	swarmErr        error
+	swarmTasksFor   string // service whose tasks are shown, empty for the services
+	swarmTasks      []docker.SwarmTask
+	swarmTaskCursor int
+	swarmReturnMode appMode // its own, prompts and confirmations open from inside
+
+	shellCache config.ShellCache // shell picked per image for exec
+
+	detectedRuntime string // what runtime "auto" resolved to, for the header
+
+	// daemon connection, see reconnect.go
+	disconnected     bool          // listing containers failed, showing the last-known list
+	disconnectErr    error         // why it failed
+	reconnectDelay   time.Duration // current backoff
+	reconnectAttempt int
+	reconnectAt      time.Time
+	reconnectPending bool // a reconnectMsg is on its way
 }
 
 // treeRow represents a row in the flattened tree
@@ -59,26 +283,58 @@ type treeRow struct {
 	indent      int
 	running     int
 	total       int
+	usage       docker.UsageTotals // project rows: the containers' stats added up
 }
 
 // runtime
 type ContainerRuntime string
 
 const (
-	RuntimeDocker ContainerRuntime = "docker"
-	RuntimePodman ContainerRuntime = "podman"
+	RuntimeDocker  ContainerRuntime = "docker"
+	RuntimePodman  ContainerRuntime = "podman"
+	RuntimeNerdctl ContainerRuntime = "nerdctl"
+	RuntimeAuto    ContainerRuntime = "auto" // first of runtime.auto_order that works
 )
 
-// available shell options for container exec
-var ShellOptions = []string{"/bin/sh", "/bin/bash", "/bin/zsh", "/bin/ash"}
+// runtime options cycled through in settings
+var RuntimeOptions = []ContainerRuntime{RuntimeAuto, RuntimeDocker, RuntimePodman, RuntimeNerdctl}
+
+// available shell options for container exec.
+// "auto" probes the container and uses the best shell, "ask" shows a picker.
+var ShellOptions = []string{"auto", "ask", "/bin/sh", "/bin/bash", "/bin/zsh", "/bin/ash"}
 
 // app settings
 type Settings struct {
 	ColumnPercents  []int
 	RefreshInterval int
+	StatsInterval   int            // seconds between stats calls, 0 is every refresh
+	StatsOverrides  map[string]int // container name -> stats interval, 0 only on demand
+	ParallelActions int            // container actions running at once, the rest queue
 	Runtime         ContainerRuntime
+	Socket          string // runtime.socket, settings switches podman's rootless/rootful
+	Remote          string // runtime.remote, the remotes entry connected to over SSH
+	Remotes         []config.RemoteProfile
 	Shell           string
+	Tmux            string // pane or window opens shells and log follows in tmux
+	DetachKeys      string // exec --detach-keys, empty for the runtime's own
 	VisibleColumns  []bool
+	ProjectColors   bool // a colored bar per compose project in the flat list
+	TTLAutoStop     bool
+	TTLOverrides    map[string]string           // container name -> ttl
+	Pinned          []string                    // pinned container names
+	StopOnQuit      string                      // ask, always or never
+	LogsStripANSI   bool                        // drop app colors from logs instead of showing them
+	LogsLevelColors bool                        // color the log level of uncolored lines
+	ProjectAliases  map[string]string           // compose project -> display name
+	ContainerNotes  map[string]string           // container name -> note
+	LogFilters      map[string]config.LogFilter // container or project name -> logs filter
+	Commands        []config.CustomCommand
+	Templates       []config.RunTemplate
+	ComposeDirs     []string // searched for compose projects that aren't running
+	ComposeDepth    int      // levels of subdirectories searched
+	// more than CrashLoopRestarts restarts in CrashLoopMinutes is a crash loop
+	CrashLoopRestarts int
+	CrashLoopMinutes  int
 }
 
 // which column to sort by
@@ -94,8 +350,16 @@ const (
 	sortByImage
 	sortByStatus
 	sortByPorts
+	sortByUptime
+	sortByCreated
+	sortByNetworks
+	sortByIP
+	sortByGPU
 )
 
+// sortByNone leaves the secondary sort column unset
+const sortByNone sortColumn = -1
+
 // which mode the TUI is in
 type appMode int
 
@@ -108,14 +372,32 @@ const (
 	modeComposeView
 	modeHelp
 	modeConfirmation
+	modeMenu
+	modeFiles
+	modeHistory
+	modeScan
+	modeRegistry
+	modeSwarm
+	modeDaemonInfo
+	modeDiff
+	modeDepGraph
+	modeDiscover
+	modeComposeConfig
+	modeUnit
+	modeSecrets
+	modePrecheck
+	modePrompt
+	modeLimits
 )
 
 type actionDoneMsg struct {
-	err error // nil if ok
+	err error  // nil if ok
+	msg string // optional status shown on success
 }
 type tickMsg time.Time
 
 type composeProjectsMsg struct {
 	Projects map[string]*docker.ComposeProject
 	Err      error
+	Took     time.Duration
 }
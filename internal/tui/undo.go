@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// a stop or remove can be undone for a few seconds after it's done, in case
+// the key hit the wrong row: a stopped container is started again, a removed
+// one is recreated from the snapshot taken just before it went
+
+const undoWindow = 10 * time.Second
+
+type undoAction struct {
+	id     int
+	prompt string // the status line while it can be undone
+	run    func(m *model) tea.Cmd
+}
+
+type undoExpiredMsg struct{ id int }
+
+// offerUndo makes u the action Ctrl+Z undoes, for undoWindow
+func (m *model) offerUndo(u *undoAction) tea.Cmd {
+	m.nextUndoID++
+	u.id = m.nextUndoID
+	m.undo = u
+	m.statusMessage = u.prompt
+	id := u.id
+	return tea.Tick(undoWindow, func(time.Time) tea.Msg { return undoExpiredMsg{id: id} })
+}
+
+func (m *model) handleUndoExpired(msg undoExpiredMsg) {
+	if m.undo == nil || m.undo.id != msg.id {
+		return
+	}
+	if m.statusMessage == m.undo.prompt {
+		m.statusMessage = ""
+	}
+	m.undo = nil
+}
+
+func (m *model) undoLast() tea.Cmd {
+	if m.undo == nil {
+		m.statusMessage = "Nothing to undo"
+		return nil
+	}
+	u := m.undo
+	m.undo = nil
+	return u.run(m)
+}
@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// imageUpdatesMsg carries the result of a registry check, keyed by container ID
+type imageUpdatesMsg struct {
+	updates map[string]docker.ImageUpdate
+	err     error
+}
+
+func checkImageUpdatesCmd(containers []docker.Container) tea.Cmd {
+	// copy, the model's slice gets replaced while we wait on registries
+	cs := append([]docker.Container(nil), containers...)
+	return func() tea.Msg {
+		updates, err := docker.CheckImageUpdates(cs)
+		return imageUpdatesMsg{updates: updates, err: err}
+	}
+}
+
+func (m *model) startImageUpdateCheck() tea.Cmd {
+	if m.checkingUpdates {
+		m.statusMessage = "Already checking for image updates..."
+		return nil
+	}
+	m.checkingUpdates = true
+	m.statusMessage = "Checking registries for image updates..."
+	return checkImageUpdatesCmd(m.containers)
+}
+
+func (m *model) handleImageUpdates(msg imageUpdatesMsg) {
+	m.checkingUpdates = false
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Update check failed: %v", msg.err)
+		return
+	}
+
+	m.imageUpdates = msg.updates
+	available, failed := 0, 0
+	for _, u := range msg.updates {
+		if u.Err != nil {
+			failed++
+		} else if u.Available {
+			available++
+		}
+	}
+
+	m.statusMessage = fmt.Sprintf("Image updates available for %d of %d containers", available, len(msg.updates))
+	if failed > 0 {
+		m.statusMessage += fmt.Sprintf(" (%d could not be checked)", failed)
+	}
+}
+
+func (m model) updateAvailable(c docker.Container) bool {
+	u, ok := m.imageUpdates[c.ID]
+	return ok && u.Available || m.autoUpdates[c.ID].Pending()
+}
+
+// imageUpdateDescription is the info panel text, empty until a check ran
+func (m model) imageUpdateDescription(c docker.Container) string {
+	u, ok := m.imageUpdates[c.ID]
+	switch {
+	case !ok:
+		return ""
+	case u.Err != nil:
+		return fmt.Sprintf("check failed: %v", u.Err)
+	case u.Available && docker.AutoUpdatePolicy(c) != "":
+		return "update available (podman auto-update, Ctrl+P)"
+	case u.Available:
+		return "update available (g to pull & recreate)"
+	default:
+		return "up to date"
+	}
+}
+
+// pullAndRecreate pulls the container's image and recreates the container,
+// through compose for compose services, streaming progress into the task panel
+func (m *model) pullAndRecreate(c docker.Container) tea.Cmd {
+	name := docker.ContainerName(c)
+	return m.startTask(fmt.Sprintf("Pull & recreate %s", name), func(onLine func(string)) (string, error) {
+		var err error
+		if c.ComposeProject != "" && c.ComposeService != "" {
+			err = docker.RecreateComposeService(c.ComposeProject, c.ComposeDirectory, c.ComposeService, onLine)
+		} else {
+			err = docker.Recreate(c.ID, onLine)
+		}
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Recreated %s with the latest %s", name, c.Image), nil
+	})
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/shubh-io/dockmate/internal/config"
+)
+
+// names the sort column is saved under, stable across releases unlike the consts
+var sortColumnNames = map[sortColumn]string{
+	sortByID:       "id",
+	sortByName:     "name",
+	sortByMemory:   "memory",
+	sortByCPU:      "cpu",
+	sortByNetIO:    "net_io",
+	sortByBlockIO:  "disk_io",
+	sortByImage:    "image",
+	sortByStatus:   "status",
+	sortByPorts:    "ports",
+	sortByUptime:   "uptime",
+	sortByCreated:  "created",
+	sortByNetworks: "networks",
+	sortByIP:       "ip",
+	sortByGPU:      "gpu",
+}
+
+// applyViewState restores how the TUI was left last time
+func (m *model) applyViewState(state config.ViewState) {
+	for col, name := range sortColumnNames {
+		if name == state.SortBy {
+			m.sortBy = col
+			m.sortAsc = state.SortAsc
+		}
+		if name == state.ThenSortBy {
+			m.thenSortBy = col
+		}
+	}
+	for name, expanded := range state.ExpandedProjects {
+		m.expandedProjects[name] = expanded
+	}
+	if state.ComposeView {
+		m.composeViewMode = true
+		m.currentMode = modeComposeView
+	}
+}
+
+// saveViewState remembers sorting, view mode and expanded projects for the next start
+func (m model) saveViewState() {
+	state := config.ViewState{
+		SortBy:           sortColumnNames[m.sortBy],
+		SortAsc:          m.sortAsc,
+		ThenSortBy:       sortColumnNames[m.thenSortBy],
+		ComposeView:      m.composeViewMode,
+		ExpandedProjects: m.expandedProjects,
+	}
+	// only a convenience, the TUI works the same without it
+	_ = state.Save()
+}
+
+// toggleProjectExpanded folds or unfolds the compose project under the cursor
+func (m *model) toggleProjectExpanded() {
+	if m.cursor >= len(m.flatList) || !m.flatList[m.cursor].isProject {
+		return
+	}
+	name := m.flatList[m.cursor].projectName
+	m.expandedProjects[name] = !m.expandedProjects[name]
+	if m.expandedProjects[name] {
+		m.statusMessage = fmt.Sprintf("Expanded %s", m.projectLabel(name))
+	} else {
+		m.statusMessage = fmt.Sprintf("Collapsed %s", m.projectLabel(name))
+	}
+
+	m.buildFlatList()
+	m.updateViewport()
+	m.saveViewState()
+}
@@ -0,0 +1,225 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+// lines of the expanded details of the selected vulnerability
+const scanDetailLines = 4
+
+var severityStyles = map[string]lipgloss.Style{
+	"CRITICAL": lipgloss.NewStyle().Foreground(meterRed).Bold(true),
+	"HIGH":     lipgloss.NewStyle().Foreground(meterRed),
+	"MEDIUM":   lipgloss.NewStyle().Foreground(yellowColor),
+	"LOW":      lipgloss.NewStyle().Foreground(textSecondary),
+	"UNKNOWN":  lipgloss.NewStyle().Foreground(textMuted),
+}
+
+type imageScanMsg struct {
+	image  string
+	result docker.ScanResult
+	err    error
+}
+
+func imageScanCmd(image string) tea.Cmd {
+	return func() tea.Msg {
+		result, err := docker.ScanImage(image)
+		return imageScanMsg{image: image, result: result, err: err}
+	}
+}
+
+// openImageScan scans the selected container's image with trivy or grype,
+// whichever is installed
+func (m *model) openImageScan(c docker.Container) tea.Cmd {
+	if c.Image == "" {
+		m.statusMessage = "This container has no image to scan"
+		return nil
+	}
+	scanner, err := docker.FindScanner()
+	if err != nil {
+		m.statusMessage = "Install trivy or grype to scan images for vulnerabilities"
+		return nil
+	}
+	m.scanImage = c.Image
+	m.scanScanner = scanner
+	m.scanResult = docker.ScanResult{}
+	m.scanErr = nil
+	m.scanCursor = 0
+	m.scanExpanded = false
+	m.scanLoading = true
+	m.returnMode = m.currentMode
+	m.currentMode = modeScan
+	m.statusMessage = ""
+	return imageScanCmd(c.Image)
+}
+
+func (m *model) handleImageScan(msg imageScanMsg) {
+	if msg.image != m.scanImage {
+		return
+	}
+	m.scanLoading = false
+	m.scanErr = msg.err
+	m.scanResult = msg.result
+	if m.currentMode != modeScan {
+		// closed while the scan was running
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("Scan of %s failed: %v", msg.image, msg.err)
+		} else {
+			m.statusMessage = fmt.Sprintf("Scan of %s: %s", msg.image, severitySummary(msg.result))
+		}
+	}
+}
+
+// severitySummary reads like "2 critical, 5 high, 0 medium, 1 low"
+func severitySummary(r docker.ScanResult) string {
+	if len(r.Vulnerabilities) == 0 {
+		return "no known vulnerabilities"
+	}
+	counts := r.Counts()
+	var parts []string
+	for _, sev := range docker.SeverityOrder {
+		if sev == "UNKNOWN" && counts[sev] == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", counts[sev], strings.ToLower(sev)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (m model) scanPageSize() int {
+	// title, summary, header, footer, plus the details when expanded
+	rows := m.terminalHeight - 6
+	if m.scanExpanded {
+		rows -= scanDetailLines + 1
+	}
+	return max(1, rows)
+}
+
+func (m model) updateScan(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	vulns := m.scanResult.Vulnerabilities
+	switch msg.String() {
+	case "esc", "q":
+		m.currentMode = m.returnMode
+		if m.scanLoading {
+			m.statusMessage = fmt.Sprintf("Still scanning %s in the background...", m.scanImage)
+		} else {
+			m.scanResult = docker.ScanResult{}
+			m.statusMessage = "Vulnerability scan closed"
+		}
+	case "up", "k":
+		if m.scanCursor > 0 {
+			m.scanCursor--
+		}
+	case "down", "j":
+		if m.scanCursor < len(vulns)-1 {
+			m.scanCursor++
+		}
+	case "pgup":
+		m.scanCursor = max(0, m.scanCursor-m.scanPageSize())
+	case "pgdown":
+		m.scanCursor = max(0, min(len(vulns)-1, m.scanCursor+m.scanPageSize()))
+	case "enter", " ":
+		m.scanExpanded = !m.scanExpanded
+	case "f5":
+		if !m.scanLoading {
+			m.scanLoading = true
+			m.scanErr = nil
+			return m, imageScanCmd(m.scanImage)
+		}
+	}
+	return m, nil
+}
+
+func (m model) renderImageScan(width int) string {
+	var b strings.Builder
+
+	b.WriteString(m.renderTitleBar(width))
+	b.WriteString("\n")
+
+	title := fmt.Sprintf("%s  (scanned with %s)", m.scanImage, m.scanScanner)
+	b.WriteString(titleStyle.Render(padRight(truncateToWidth(title, width-2), width-2)))
+	b.WriteString("\n")
+
+	switch {
+	case m.scanLoading:
+		b.WriteString(normalStyle.Render(fmt.Sprintf("  Scanning with %s, the first run downloads its vulnerability database...", m.scanScanner)))
+	case m.scanErr != nil:
+		b.WriteString(messageStyle.Render(truncateToWidth("  "+m.scanErr.Error(), width)))
+	default:
+		counts := m.scanResult.Counts()
+		var parts []string
+		for _, sev := range docker.SeverityOrder {
+			parts = append(parts, severityStyles[sev].Render(fmt.Sprintf("%s %d", sev, counts[sev])))
+		}
+		b.WriteString("  " + strings.Join(parts, "   "))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(headerStyle.Render(padRight(fmt.Sprintf(" %-9s %-20s %-22s %-16s %s", "SEVERITY", "ID", "PACKAGE", "INSTALLED", "FIXED IN"), width)))
+	b.WriteString("\n")
+
+	vulns := m.scanResult.Vulnerabilities
+	rows := m.scanPageSize()
+	shown := 0
+	if !m.scanLoading && m.scanErr == nil && len(vulns) == 0 {
+		b.WriteString(normalStyle.Render("  No known vulnerabilities"))
+		b.WriteString("\n")
+		shown++
+	}
+
+	start := 0
+	if m.scanCursor >= rows {
+		start = m.scanCursor - rows + 1
+	}
+	for i := start; i < len(vulns) && shown < rows && !m.scanLoading; i++ {
+		v := vulns[i]
+		fixed := v.FixedIn
+		if fixed == "" {
+			fixed = "─"
+		}
+		line := padRight(truncateToWidth(fmt.Sprintf(" %-9s %-20s %-22s %-16s %s",
+			v.Severity, truncateToWidth(v.ID, 20), truncateToWidth(v.Package, 22), truncateToWidth(v.Installed, 16), fixed), width), width)
+
+		if i == m.scanCursor {
+			b.WriteString(selectedStyle.Render(line))
+		} else {
+			b.WriteString(severityStyles[v.Severity].Render(line))
+		}
+		b.WriteString("\n")
+		shown++
+	}
+	for ; shown < rows; shown++ {
+		b.WriteString("\n")
+	}
+
+	if m.scanExpanded {
+		b.WriteString(dividerStyle.Render(strings.Repeat("─", width)))
+		b.WriteString("\n")
+		var detail []string
+		if m.scanCursor < len(vulns) {
+			v := vulns[m.scanCursor]
+			fix := "no fix available yet"
+			if v.FixedIn != "" {
+				fix = "fixed in " + v.FixedIn
+			}
+			detail = append(detail, fmt.Sprintf("%s  %s %s, %s", v.ID, v.Package, v.Installed, fix))
+			detail = append(detail, wrapText(v.Title, width-2)...)
+		}
+		for i := 0; i < scanDetailLines; i++ {
+			line := ""
+			if i < len(detail) {
+				line = detail[i]
+			}
+			b.WriteString(infoValueStyle.Render(" " + truncateToWidth(line, width-1)))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString(m.renderFilesFooter(width, [][2]string{{"↑↓", "move"}, {"Enter", "details"}, {"F5", "rescan"}, {"Esc", "close"}}))
+	return b.String()
+}
@@ -0,0 +1,23 @@
+package tui
+
+import "github.com/shubh-io/dockmate/internal/web"
+
+// read-only web companion, set once from main when started with --web
+var (
+	webView *web.Server
+	webURL  string
+)
+
+// EnableWebView makes the TUI publish every container refresh to s;
+// url (with the token) is shown on startup so it can be shared
+func EnableWebView(s *web.Server, url string) {
+	webView = s
+	webURL = url
+}
+
+// publishWebView pushes what the TUI is showing to connected browsers
+func (m model) publishWebView() {
+	if webView != nil {
+		webView.Publish(m.containers)
+	}
+}
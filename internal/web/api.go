@@ -0,0 +1,172 @@
+package web
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+//go:embed serve.html
+var serveHTML []byte
+
+// API answers the read-only JSON endpoints of `dockmate serve` straight from
+// the runtime. Containers come trimmed to the web view's Container, labels
+// left out:
+//
+//	GET /             a page with the container table and logs, ?token=
+//	GET /containers   []Container, with stats
+//	GET /compose      Project by name
+//	GET /stats        docker.StatsSummary, host totals and per project
+//	GET /logs/{id}    the container's last log lines, ?since=5m&timestamps=true
+type API struct {
+	token string
+
+	// the runtime calls, tests swap them out
+	listContainers  func() ([]docker.Container, error)
+	composeProjects func() (map[string]*docker.ComposeProject, error)
+	logs            func(id string, opts docker.LogOptions) ([]string, error)
+}
+
+// NewAPI creates an API that only answers requests carrying token, a random
+// one when empty
+func NewAPI(token string) (*API, error) {
+	token, err := newToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return &API{
+		token:           token,
+		listContainers:  docker.ListContainers,
+		composeProjects: docker.FetchComposeProjects,
+		logs:            docker.GetLogs,
+	}, nil
+}
+
+// Token returns the access token clients send as a bearer token or ?token=
+func (a *API) Token() string {
+	return a.token
+}
+
+// ListenAndServe serves on addr (e.g. ":8080") until it fails
+func (a *API) ListenAndServe(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: a.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	return srv.Serve(ln)
+}
+
+// Handler serves the endpoints; GET only
+func (a *API) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/{$}", a.serveIndex)
+	mux.HandleFunc("/containers", a.serveContainers)
+	mux.HandleFunc("/compose", a.serveCompose)
+	mux.HandleFunc("/stats", a.serveStats)
+	mux.HandleFunc("/logs/{id}", a.serveLogs)
+	return readOnly(a.token, mux)
+}
+
+func (a *API) serveIndex(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(serveHTML)
+}
+
+func (a *API) serveContainers(w http.ResponseWriter, r *http.Request) {
+	containers, err := a.listContainers()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, toContainers(containers))
+}
+
+func (a *API) serveCompose(w http.ResponseWriter, r *http.Request) {
+	projects, err := a.composeProjects()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	out := make(map[string]Project, len(projects))
+	for name, p := range projects {
+		out[name] = Project{
+			Name:       p.Name,
+			ConfigFile: p.ConfigFile,
+			WorkingDir: p.WorkingDir,
+			Containers: toContainers(p.Containers),
+		}
+	}
+	writeJSON(w, out)
+}
+
+func (a *API) serveStats(w http.ResponseWriter, r *http.Request) {
+	containers, err := a.listContainers()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, docker.Summarize(containers))
+}
+
+func (a *API) serveLogs(w http.ResponseWriter, r *http.Request) {
+	var opts docker.LogOptions
+	q := r.URL.Query()
+	if s := q.Get("since"); s != "" {
+		since, err := time.ParseDuration(s)
+		if err != nil || since < 0 {
+			http.Error(w, "since should be a duration like 5m or 1h", http.StatusBadRequest)
+			return
+		}
+		opts.Since = since
+	}
+	if s := q.Get("timestamps"); s != "" {
+		opts.Timestamps, _ = strconv.ParseBool(s)
+	}
+
+	// the id ends up in the runtime's argv, so only one of the listed
+	// containers gets there, never something like --follow
+	id := r.PathValue("id")
+	containers, err := a.listContainers()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	i := slices.IndexFunc(containers, func(c docker.Container) bool {
+		return !strings.HasPrefix(id, "-") && (strings.HasPrefix(c.ID, id) || slices.Contains(c.Names, id))
+	})
+	if id == "" || i < 0 {
+		http.Error(w, "no such container", http.StatusNotFound)
+		return
+	}
+
+	lines, err := a.logs(containers[i].ID, opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if lines == nil {
+		lines = []string{}
+	}
+	writeJSON(w, lines)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError passes a runtime failure on as a 502, the runtime is the
+// server behind this one
+func writeError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadGateway)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
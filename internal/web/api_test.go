@@ -0,0 +1,109 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/docker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAPI(t *testing.T) (*API, *httptest.Server) {
+	t.Helper()
+	a, err := NewAPI("secret")
+	require.NoError(t, err)
+	a.listContainers = func() ([]docker.Container, error) {
+		return []docker.Container{
+			{ID: "abc", Names: []string{"web"}, State: "running", CPU: "10%", ComposeProject: "shop", Labels: map[string]string{"secret": "hunter2"}},
+			{ID: "def", Names: []string{"db"}, State: "exited"},
+		}, nil
+	}
+	a.composeProjects = func() (map[string]*docker.ComposeProject, error) {
+		return nil, errors.New("compose not installed")
+	}
+	ts := httptest.NewServer(a.Handler())
+	t.Cleanup(ts.Close)
+	return a, ts
+}
+
+func getJSON(t *testing.T, url string, v any) int {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if v != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	}
+	return resp.StatusCode
+}
+
+func TestAPI(t *testing.T) {
+	a, ts := testAPI(t)
+
+	resp, err := http.Get(ts.URL + "/containers")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	var containers []map[string]any
+	assert.Equal(t, http.StatusOK, getJSON(t, ts.URL+"/containers", &containers))
+	require.Len(t, containers, 2)
+	assert.Equal(t, "web", containers[0]["name"])
+	assert.Equal(t, "shop", containers[0]["project"])
+	assert.NotContains(t, containers[0], "labels")
+	assert.NotContains(t, containers[0], "Labels")
+
+	var sum docker.StatsSummary
+	assert.Equal(t, http.StatusOK, getJSON(t, ts.URL+"/stats", &sum))
+	assert.Equal(t, 1, sum.Running)
+	assert.Equal(t, 10.0, sum.CPUPercent)
+
+	var failed map[string]string
+	assert.Equal(t, http.StatusBadGateway, getJSON(t, ts.URL+"/compose", &failed))
+	assert.Equal(t, "compose not installed", failed["error"])
+
+	var gotID string
+	var gotOpts docker.LogOptions
+	a.logs = func(id string, opts docker.LogOptions) ([]string, error) {
+		gotID, gotOpts = id, opts
+		return []string{"listening on :80"}, nil
+	}
+	var lines []string
+	assert.Equal(t, http.StatusOK, getJSON(t, ts.URL+"/logs/abc?since=5m&timestamps=true", &lines))
+	assert.Equal(t, []string{"listening on :80"}, lines)
+	assert.Equal(t, "abc", gotID)
+	assert.Equal(t, docker.LogOptions{Timestamps: true, Since: 5 * time.Minute}, gotOpts)
+
+	assert.Equal(t, http.StatusBadRequest, getJSON(t, ts.URL+"/logs/abc?since=yesterday", nil))
+
+	// by name too, and only ever a listed container's id reaches the runtime
+	assert.Equal(t, http.StatusOK, getJSON(t, ts.URL+"/logs/db", nil))
+	assert.Equal(t, "def", gotID)
+	gotID = ""
+	for _, id := range []string{"--follow", "-f", "nope"} {
+		assert.Equal(t, http.StatusNotFound, getJSON(t, ts.URL+"/logs/"+id, nil), id)
+	}
+	assert.Empty(t, gotID)
+}
+
+func TestAPIPage(t *testing.T) {
+	_, ts := testAPI(t)
+
+	resp, err := http.Get(ts.URL + "/?token=secret")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/html")
+
+	resp, err = http.Get(ts.URL + "/nope?token=secret")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
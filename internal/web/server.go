@@ -0,0 +1,229 @@
+// Package web serves a read-only browser view of what the TUI is showing,
+// pushed to the page over server-sent events.
+package web
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/docker"
+)
+
+//go:embed index.html
+var indexHTML []byte
+
+// Container is the part of docker.Container the web view shows.
+// Labels are left out on purpose, they often carry more than people expect.
+type Container struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Image   string `json:"image"`
+	State   string `json:"state"`
+	Status  string `json:"status"`
+	CPU     string `json:"cpu"`
+	Memory  string `json:"memory"`
+	Ports   string `json:"ports"`
+	Project string `json:"project"`
+}
+
+// Project is a compose project with its containers, trimmed the same way
+type Project struct {
+	Name       string      `json:"name"`
+	ConfigFile string      `json:"config_file"`
+	WorkingDir string      `json:"working_dir"`
+	Containers []Container `json:"containers"`
+}
+
+// toContainers trims the runtime's containers down to Container, for the web
+// view and the API alike
+func toContainers(containers []docker.Container) []Container {
+	out := make([]Container, 0, len(containers))
+	for _, c := range containers {
+		out = append(out, Container{
+			ID:      c.ID,
+			Name:    docker.ContainerName(c),
+			Image:   c.Image,
+			State:   c.State,
+			Status:  c.Status,
+			CPU:     c.CPU,
+			Memory:  c.Memory,
+			Ports:   c.Ports,
+			Project: c.ComposeProject,
+		})
+	}
+	return out
+}
+
+// Snapshot is one update sent to the browser
+type Snapshot struct {
+	Time       time.Time   `json:"time"`
+	Containers []Container `json:"containers"`
+}
+
+// Server holds the latest snapshot and fans it out to connected browsers
+type Server struct {
+	token string
+
+	mu          sync.Mutex
+	latest      []byte
+	subscribers map[chan []byte]struct{}
+}
+
+// NewServer creates a server that only answers requests carrying token.
+// An empty token gets a random one, see Token.
+func NewServer(token string) (*Server, error) {
+	token, err := newToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{token: token, subscribers: make(map[chan []byte]struct{})}, nil
+}
+
+// newToken is token, or a random one when it's empty
+func newToken(token string) (string, error) {
+	if token != "" {
+		return token, nil
+	}
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generating token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// Token returns the access token browsers have to pass as ?token=
+func (s *Server) Token() string {
+	return s.token
+}
+
+// Start listens on addr (e.g. ":8080") and serves in the background.
+// Listen errors are returned right away so a taken port is reported before the TUI starts.
+func (s *Server) Start(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go srv.Serve(ln)
+	return nil
+}
+
+// Publish sends the current container list to every connected browser
+func (s *Server) Publish(containers []docker.Container) {
+	snap := Snapshot{Time: time.Now(), Containers: toContainers(containers)}
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latest = data
+	for ch := range s.subscribers {
+		// slow browsers skip an update instead of blocking the TUI
+		select {
+		case ch <- data:
+		default:
+		}
+	}
+}
+
+// Handler serves the page, the event stream and a JSON snapshot; GET only
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.serveIndex)
+	mux.HandleFunc("/events", s.serveEvents)
+	mux.HandleFunc("/api/containers", s.serveSnapshot)
+	return readOnly(s.token, mux)
+}
+
+// readOnly lets GET and HEAD requests carrying token through to h
+func readOnly(token string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "read-only", http.StatusMethodNotAllowed)
+			return
+		}
+		if !authorized(r, token) {
+			http.Error(w, "missing or wrong token", http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// authorized accepts the token as ?token= (EventSource can't set headers) or as a bearer token
+func authorized(r *http.Request, token string) bool {
+	got := r.URL.Query().Get("token")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		got = strings.TrimPrefix(auth, "Bearer ")
+	}
+	return subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
+
+func (s *Server) serveIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(indexHTML)
+}
+
+func (s *Server) serveSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	data := s.latest
+	s.mu.Unlock()
+
+	if data == nil {
+		data = []byte(`{"containers":[]}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func (s *Server) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	ch := make(chan []byte, 1)
+	s.mu.Lock()
+	s.subscribers[ch] = struct{}{}
+	latest := s.latest
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.subscribers, ch)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	if latest != nil {
+		fmt.Fprintf(w, "data: %s\n\n", latest)
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case data := <-ch:
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
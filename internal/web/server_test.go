@@ -0,0 +1,108 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shubh-io/dockmate/internal/docker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerRequiresToken(t *testing.T) {
+	s, err := NewServer("secret")
+	require.NoError(t, err)
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/containers")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, err = http.Get(ts.URL + "/api/containers?token=wrong")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestServerIsReadOnly(t *testing.T) {
+	s, err := NewServer("secret")
+	require.NoError(t, err)
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/api/containers?token=secret", "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestServerGeneratesToken(t *testing.T) {
+	a, err := NewServer("")
+	require.NoError(t, err)
+	b, err := NewServer("")
+	require.NoError(t, err)
+
+	assert.Len(t, a.Token(), 32)
+	assert.NotEqual(t, a.Token(), b.Token())
+}
+
+func TestServerPublish(t *testing.T) {
+	s, err := NewServer("secret")
+	require.NoError(t, err)
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+
+	s.Publish([]docker.Container{{
+		ID:     "abc123",
+		Names:  []string{"/web"},
+		State:  "running",
+		Labels: map[string]string{"secret": "hunter2"},
+	}})
+
+	resp, err := http.Get(ts.URL + "/api/containers?token=secret")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var snap Snapshot
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&snap))
+	require.Len(t, snap.Containers, 1)
+	assert.Equal(t, "web", snap.Containers[0].Name)
+	assert.Equal(t, "running", snap.Containers[0].State)
+
+	// live updates arrive on the event stream
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/events?token=secret", nil)
+	stream, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer stream.Body.Close()
+
+	reader := bufio.NewReader(stream.Body)
+	first, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Contains(t, first, `"name":"web"`)
+	assert.NotContains(t, first, "hunter2")
+
+	s.Publish([]docker.Container{{ID: "def456", Names: []string{"db"}, State: "exited"}})
+	var next string
+	for !strings.HasPrefix(next, "data:") {
+		next, err = reader.ReadString('\n')
+		require.NoError(t, err)
+	}
+	assert.Contains(t, next, `"name":"db"`)
+}
@@ -1,16 +1,24 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/shubh-io/dockmate/internal/check"
+	"github.com/shubh-io/dockmate/internal/cli"
 	"github.com/shubh-io/dockmate/internal/config"
+	"github.com/shubh-io/dockmate/internal/docker"
+	"github.com/shubh-io/dockmate/internal/logging"
 	"github.com/shubh-io/dockmate/internal/tui"
 	"github.com/shubh-io/dockmate/internal/update"
+	"github.com/shubh-io/dockmate/internal/web"
 	"github.com/shubh-io/dockmate/pkg/version"
 )
 
@@ -22,12 +30,176 @@ const restartMarkerFile = ".dockmate_restart"
 // ============================================================================
 
 func main() {
+	opts, command, err := parseOptions(os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "dockmate: %v\n", err)
+		exit(2)
+	}
+	if opts.version {
+		fmt.Printf("DockMate version: %s\n", version.Dockmate_Version)
+		return
+	}
+	if err := opts.apply(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		exit(1)
+	}
+
+	setupLogging(opts.level())
+	startWebView(opts.web)
+
 	// Restart loop for settings changes
 	for {
-		if !runApp() {
+		if !runApp(opts, &command) {
 			break
 		}
 	}
+	exit(0)
+}
+
+// exit closes the SSH tunnel and the log file before exiting, os.Exit skips
+// deferred calls and would leave `ssh -N` running
+func exit(code int) {
+	docker.CloseRemote()
+	logging.Close()
+	os.Exit(code)
+}
+
+// applyProjectMarker lets a .dockmate.yml or .envrc in the working directory
+// pick the engine (DOCKER_HOST) and the compose project to show
+func applyProjectMarker() {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	marker, err := config.FindProjectMarker(cwd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	if marker != nil {
+		marker.Apply()
+	}
+}
+
+// startWebView starts the read-only web companion when run with `--web :8080`.
+// The token comes from DOCKMATE_WEB_TOKEN, or a random one is generated.
+func startWebView(addr string) {
+	if addr == "" {
+		return
+	}
+
+	server, err := web.NewServer(os.Getenv("DOCKMATE_WEB_TOKEN"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Web view failed: %v\n", err)
+		exit(1)
+	}
+	if err := server.Start(addr); err != nil {
+		fmt.Fprintf(os.Stderr, "Web view failed: %v\n", err)
+		exit(1)
+	}
+
+	host, port, _ := net.SplitHostPort(addr)
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	url := fmt.Sprintf("http://%s/?token=%s", net.JoinHostPort(host, port), server.Token())
+	fmt.Printf("Read-only web view at %s\n", url)
+	tui.EnableWebView(server, url)
+}
+
+// setupLogging applies logging.level from the config, or the level
+// `--log-level` or `--debug` asks for, which wins
+func setupLogging(levelName string) {
+	cfg, _ := config.Load()
+	if levelName == "" {
+		levelName = cfg.Logging.Level
+	}
+
+	level, err := logging.ParseLevel(levelName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if err := logging.Setup(level, cfg.Logging.File); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: can't set up logging: %v\n", err)
+	}
+	logging.Infof("DockMate %s starting, log level %s", version.Dockmate_Version, level)
+}
+
+// applyRuntimeSocket points the runtime at runtime.socket, when one is
+// configured, with runtime.tls's files
+func applyRuntimeSocket() {
+	cfg, _ := config.Load()
+	docker.ApplySocket(cfg.Runtime)
+	if err := docker.ApplyTLS(cfg.Runtime); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: can't set up runtime.tls: %v\n", err)
+	}
+}
+
+// connectRemote opens the SSH tunnel to runtime.remote, when one is
+// configured. Only what talks to the runtime calls it, so `config edit`,
+// `version` and the like still work with an unreachable remote, and `doctor`
+// opens it itself to report a failure.
+func connectRemote() {
+	cfg, _ := config.Load()
+	if err := docker.UseRemote(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Can't connect to remote %s: %v\n\n", cfg.Runtime.Remote, err)
+		fmt.Fprintf(os.Stderr, "Fix it under remotes: in %s (dockmate config edit), or clear runtime.remote to use the local daemon\n", configPathHint())
+		exit(1)
+	}
+}
+
+func configPathHint() string {
+	if path, err := config.GetConfigPath(); err == nil {
+		return path
+	}
+	return "~/.config/dockmate/config.yml"
+}
+
+// runtimeCommand implements `dockmate runtime [docker|podman|nerdctl|auto]`:
+// saves the given runtime, or asks with the selection TUI when none is given
+func runtimeCommand(args []string) error {
+	selectedRuntime := ""
+	if len(args) > 0 {
+		selectedRuntime = strings.TrimSpace(strings.ToLower(args[0]))
+		if selectedRuntime != "auto" && !slices.Contains(docker.KnownRuntimes, selectedRuntime) {
+			return fmt.Errorf("unknown runtime %q, use one of: %s, auto", args[0], strings.Join(docker.KnownRuntimes, ", "))
+		}
+	} else {
+		runtimeSelector := tui.NewRuntimeSelectionModel()
+		program := tea.NewProgram(runtimeSelector, tea.WithAltScreen())
+
+		finalModel, err := program.Run()
+		if err != nil {
+			return err
+		}
+
+		rsModel, ok := finalModel.(tui.RuntimeSelectionModel)
+		if !ok {
+			return fmt.Errorf("invalid model type returned")
+		}
+
+		selectedRuntime = strings.TrimSpace(rsModel.GetChoice())
+		if selectedRuntime == "" {
+			return fmt.Errorf("no runtime selected")
+		}
+	}
+
+	// load current config and update runtime
+	cfg, _ := config.Load()
+	cfg.Runtime.Type = selectedRuntime
+
+	// Save updated config (if you dont know, config location is ~/.config/dockmate/config.yml or $XDG_CONFIG_HOME/dockmate/config.yml)
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("saving config: %v", err)
+	}
+
+	fmt.Printf("Runtime set to %s.\n\n", selectedRuntime)
+	fmt.Printf("To run the application: run 'dockmate'\n")
+	fmt.Printf("To change runtime later: 'dockmate runtime' (interactive) or 'dockmate runtime docker|podman|nerdctl|auto'.\n")
+	return nil
 }
 
 func getRestartMarkerPath() string {
@@ -35,69 +207,121 @@ func getRestartMarkerPath() string {
 	return filepath.Join(tmpDir, restartMarkerFile)
 }
 
-func runApp() bool {
-	if len(os.Args) > 1 {
-		switch os.Args[1] {
-		case "version", "--version", "-v":
+// runApp runs the command, or the TUI when there's none. A command that
+// opens the TUI afterwards is cleared, so a restart doesn't run it again.
+func runApp(opts options, command *[]string) bool {
+	applyProjectMarker()
+	applyRuntimeSocket()
+
+	if args := *command; len(args) > 0 {
+		switch args[0] {
+		case "version":
 			fmt.Printf("DockMate version: %s\n", version.Dockmate_Version)
 			return false
 		case "update":
 			update.UpdateCommand()
 			return false
-		case "--runtime":
-			runtimeSelector := tui.NewRuntimeSelectionModel()
-			program := tea.NewProgram(runtimeSelector, tea.WithAltScreen())
-
-			finalModel, err := program.Run()
+		case "stats":
+			connectRemote()
+			if err := cli.StatsCommand(args[1:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Stats failed: %v\n", err)
+				exit(1)
+			}
+			return false
+		case "start", "stop", "restart":
+			if opts.readOnly {
+				fmt.Fprintf(os.Stderr, "%s refused, DockMate is running with --read-only\n", args[0])
+				exit(1)
+			}
+			connectRemote()
+			openTUI, err := cli.ActionCommand(args[0], args[1:])
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Runtime selection failed: %v\n", err)
-				os.Exit(1)
+				fmt.Fprintf(os.Stderr, "%s failed: %v\n", args[0], err)
+				exit(1)
 			}
-
-			rsModel, ok := finalModel.(tui.RuntimeSelectionModel)
-			if !ok {
-				fmt.Fprintf(os.Stderr, "Invalid model type returned\n")
-				os.Exit(1)
+			if !openTUI {
+				return false
 			}
-
-			selectedRuntime := strings.TrimSpace(rsModel.GetChoice())
-			if selectedRuntime == "" {
-				fmt.Fprintf(os.Stderr, "No runtime selected\n")
-				os.Exit(1)
+			// don't run the action again when the TUI restarts itself
+			*command = nil
+		case "doctor":
+			if err := cli.DoctorCommand(args[1:]); err != nil {
+				fmt.Fprintf(os.Stderr, "\nDoctor: %v\n", err)
+				exit(1)
 			}
-
-			// load current config and update runtime
-			cfg, _ := config.Load()
-			cfg.Runtime.Type = selectedRuntime
-
-			// Save updated config (if you dont know, config location is ~/.config/dockmate/config.yml or $XDG_CONFIG_HOME/dockmate/config.yml)
-			if err := cfg.Save(); err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to save runtime selection: %v\n", err)
-				os.Exit(1)
+			return false
+		case "config":
+			if err := cli.ConfigCommand(args[1:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Config: %v\n", err)
+				exit(1)
+			}
+			return false
+		case "serve":
+			connectRemote()
+			if err := cli.ServeCommand(args[1:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Serve failed: %v\n", err)
+				exit(1)
+			}
+			return false
+		case "report":
+			connectRemote()
+			if err := cli.ReportCommand(args[1:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Report failed: %v\n", err)
+				exit(1)
 			}
-
-			fmt.Printf("Runtime set to %s.\n\n", selectedRuntime)
-			fmt.Printf("To run the application: run 'dockmate'\n")
-			fmt.Printf("To change runtime interactively later: 'dockmate --runtime'.\n")
 			return false
+		case "runtime":
+			if err := runtimeCommand(args[1:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Runtime selection failed: %v\n", err)
+				exit(1)
+			}
+			return false
+		default:
+			fmt.Fprintf(os.Stderr, "dockmate: unknown command %q, see dockmate --help\n", args[0])
+			exit(2)
 		}
 	}
 
-	result := check.RunPreChecks()
+	connectRemote()
 
-	if !result.Passed {
-		fmt.Fprintf(os.Stderr, "%s\n\n%s\n", result.ErrorMessage, result.SuggestedAction)
-		os.Stderr.Sync()
-		os.Exit(1)
+	// --no-precheck trusts the setup, a broken one shows up as errors in the TUI
+	if !opts.noPrecheck {
+		// the first start may need the runtime selector, which needs the
+		// terminal to itself. The checks proper run inside the TUI, next to
+		// the first fetch.
+		if result := check.EnsureRuntime(); !result.Passed {
+			fmt.Fprintf(os.Stderr, "%s\n\n%s\n", result.ErrorMessage, result.SuggestedAction)
+			os.Stderr.Sync()
+			exit(1)
+		}
+		tui.SetPrecheck(func() tui.PrecheckResult {
+			result := check.RunPreChecks()
+			return tui.PrecheckResult{Passed: result.Passed, Message: result.ErrorMessage, Suggestion: result.SuggestedAction}
+		})
 	}
+	tui.SetComposeCheck(func() tui.PrecheckResult {
+		result := check.CheckCompose()
+		return tui.PrecheckResult{Passed: result.Passed, Message: result.ErrorMessage, Suggestion: result.SuggestedAction}
+	})
+	// the runtime may have just been picked, and a restart means the settings changed
+	docker.ReloadRuntimeConfig()
 
 	// start the TUI with alternate screen mode
 	// (alternate screen = your terminal history stays clean)
 
-	p := tea.NewProgram(tui.InitialModel(), tea.WithAltScreen())
+	p := tea.NewProgram(tui.WithCrashReports(tui.InitialModel()), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
+		if errors.Is(err, tea.ErrProgramPanic) {
+			// bubbletea has restored the terminal and printed the stack by now
+			fmt.Fprintf(os.Stderr, "\nSorry, DockMate crashed.\n")
+			if f := tui.CrashFile(); f != "" {
+				fmt.Fprintf(os.Stderr, "A crash report was saved to %s\n", f)
+			}
+			fmt.Fprintf(os.Stderr, "Please attach it to an issue at https://github.com/shubh-io/DockMate/issues\n")
+			exit(2)
+		}
 		fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// check if a restart was requested (if temp marker file exists)